@@ -6,10 +6,15 @@ base_url = "http://localhost:8000"
 // "json".
 log_format = "standard"
 
+// admins are the email addresses of users who can administer Hermes (e.g.,
+// rename and merge tags).
+admins = []
+
 // algolia configures Hermes to work with Algolia.
 algolia {
   application_id            = ""
   docs_index_name           = "docs"
+  doc_sections_index_name   = "doc_sections"
   drafts_index_name         = "drafts"
   internal_index_name       = "internal"
   links_index_name          = "links"
@@ -19,6 +24,37 @@ algolia {
   write_api_key             = ""
 }
 
+// analytics configures document view analytics.
+analytics {
+  // view_deduplication_window is the duration in which repeated views of a
+  // document by the same user are only counted once. Defaults to "30m".
+  view_deduplication_window = "30m"
+}
+
+// api_tokens configures Hermes-issued API tokens (personal access tokens and
+// service account tokens) for machine access. Tokens are managed using the
+// /api/v2/me/tokens endpoint and sent using the "Authorization: Bearer <token>"
+// header.
+api_tokens {
+  // default_ttl is the lifetime of tokens created without an explicit
+  // expiration. Defaults to "720h" (30 days).
+  default_ttl = "720h"
+
+  // disabled disables authentication using API tokens.
+  disabled = false
+
+  // max_ttl is the maximum lifetime of tokens. Defaults to "2160h" (90 days).
+  max_ttl = "2160h"
+
+  // service_account defines a service account that tokens can be issued for.
+  // Requests authenticated with a service account token act as the service
+  // account's email address. Only owners can issue and revoke its tokens.
+  // service_account "release-bot" {
+  //   email  = "release-bot@example.com"
+  //   owners = ["owner@example.com"]
+  // }
+}
+
 // datadog configures Hermes to send metrics to Datadog.
 datadog {
   enabled = false
@@ -54,6 +90,23 @@ document_types {
       name = "Target Version"
       type = "string"
     }
+
+    // numbering configures the numbering scheme of documents of this type.
+    // Documents are numbered per product and document type (e.g., "TF-123")
+    // if not configured. Run "hermes operator renumber" after changing it.
+    // numbering {
+    //   // format is the format of document numbers, with the placeholders
+    //   // {product}, {doc_type}, {year}, and {number}. Numbers restart each
+    //   // year if the format contains {year}.
+    //   format = "{product}-{year}-{number}"
+    //
+    //   // padding is the minimum number of digits of the number.
+    //   padding = 3
+    //
+    //   // sequence is the name of the sequence of numbers. Document types with
+    //   // the same sequence share numbers within a product.
+    //   sequence = "RFC"
+    // }
   }
 
   document_type "PRD" {
@@ -85,6 +138,17 @@ document_types {
   // }
 }
 
+// drafts_trash configures moving deleted drafts to a trash folder, where they
+// can be restored by their owners until they are permanently deleted. Drafts
+// are deleted immediately if this block is removed.
+drafts_trash {
+  // folder is the Google Drive folder that deleted drafts are moved to.
+  folder = "my-drafts-trash-folder-id"
+
+  // retention_period is the duration that deleted drafts can be restored.
+  retention_period = "720h"
+}
+
 // email configures Hermes to send email notifications.
 email {
   // enabled enables sending email notifications.
@@ -94,6 +158,28 @@ email {
   from_address = "hermes@yourorganization.com"
 }
 
+// embeddings configures semantic search using embeddings of document content,
+// which requires the pgvector PostgreSQL extension.
+embeddings {
+  // enabled enables computing embeddings in the indexer, finding similar
+  // documents, and hybrid search.
+  enabled = false
+
+  // provider is the embeddings provider: "local" (a CPU-only model that runs in
+  // Hermes) or "openai" (an OpenAI-compatible embeddings API, which can be
+  // served locally).
+  provider = "local"
+
+  // max_distance is the maximum cosine distance of documents returned by
+  // semantic search.
+  max_distance = 0.5
+
+  // The following are used by the "openai" provider.
+  // url     = "http://localhost:11434/v1"
+  // model   = "nomic-embed-text"
+  // api_key = ""
+}
+
 // FeatureFlags contain available feature flags.
 feature_flags {
   // api_v2 enables v2 of the API.
@@ -159,6 +245,15 @@ google_workspace {
     client_id    = ""
     hd           = "hashicorp.com"
     redirect_uri = "http://localhost:8000/torii/redirect.html"
+
+    // token_cache configures caching of Google access token validation, so
+    // Google isn't called on every authenticated request.
+    token_cache {
+      disabled     = false
+      max_entries  = 10000
+      max_ttl      = "5m"
+      negative_ttl = "30s"
+    }
   }
 }
 
@@ -168,6 +263,10 @@ indexer {
   // simultaneously indexed.
   max_parallel_docs = 5
 
+  // max_lag is the maximum duration since the last completed full index before
+  // the indexer is reported as unhealthy by the /health/indexer endpoint.
+  max_lag = "1h"
+
   // update_doc_headers enables the indexer to automatically update document
   // headers for changed documents based on Hermes metadata.
   update_doc_headers = true
@@ -196,6 +295,34 @@ jira {
   user = ""
 }
 
+// oidc configures Hermes to authenticate users using a generic OpenID Connect
+// provider (e.g., Keycloak, Azure AD, or Okta). Only one of okta or oidc can be
+// enabled. The provider must allow "<base_url>/auth/oidc/callback" as a
+// redirect URI.
+oidc {
+  // audience is the expected audience of bearer tokens. Defaults to client_id.
+  // audience = ""
+
+  // client_id is the OIDC client ID.
+  client_id = ""
+
+  // client_secret is the OIDC client secret.
+  client_secret = ""
+
+  // disabled disables OIDC authentication.
+  disabled = true
+
+  // email_claim is the token claim that contains the user's email address.
+  email_claim = "email"
+
+  // issuer_url is the URL of the OIDC provider.
+  issuer_url = ""
+
+  // jwks_cache_duration is the duration that the provider's signing keys are
+  // cached.
+  jwks_cache_duration = "1h"
+}
+
 // okta configures Hermes to authenticate users using an AWS Application Load
 // Balancer and Okta instead of using Google OAuth.
 okta {
@@ -238,8 +365,104 @@ products {
   }
 }
 
+// rate_limit configures rate limiting of authenticated requests per user and
+// per route.
+rate_limit {
+  disabled = false
+
+  // store is where request counters are kept: "memory" (per server replica)
+  // or "postgres" (shared across server replicas). Defaults to "memory".
+  store = "memory"
+
+  // default is the budget for routes without a route budget. If not set, only
+  // routes with a route budget are rate limited.
+  default {
+    requests = 600
+    period   = "1m"
+  }
+
+  // route is the budget for a route. The label must match the pattern of an
+  // authenticated route, or the server fails to start.
+  route "/1/indexes/" {
+    requests = 300
+    period   = "1m"
+  }
+  route "/api/v2/groups" {
+    requests = 60
+    period   = "1m"
+  }
+  route "/api/v2/people" {
+    requests = 60
+    period   = "1m"
+  }
+}
+
+// retention configures retention policies, which archive or flag documents that
+// haven't been modified for a while. Document owners are notified before a
+// policy applies, and can opt their documents out.
+// retention {
+//   // archive_folder is the Google Drive folder that archived documents are
+//   // moved to.
+//   archive_folder = "my-archive-folder-id"
+//
+//   // notice_period is the duration before a policy applies that document
+//   // owners are notified.
+//   notice_period = "336h"
+//
+//   // policy defines a retention policy.
+//   policy "archive-obsolete" {
+//     // action is "archive" or "flag".
+//     action = "archive"
+//
+//     // age is the duration since a document was last modified after which the
+//     // policy applies.
+//     age = "17520h"
+//
+//     // document_types, products, and statuses filter the documents that the
+//     // policy applies to.
+//     statuses = ["Obsolete"]
+//   }
+//
+//   policy "flag-stale-drafts" {
+//     action   = "flag"
+//     age      = "4320h"
+//     statuses = ["WIP"]
+//   }
+// }
+
 // server contains the configuration for the server.
 server {
   // addr is the address to bind to for listening.
   addr = "127.0.0.1:8000"
+
+  // health_check_timeout is the timeout for each dependency check performed by
+  // the /health/ready endpoint.
+  health_check_timeout = "5s"
 }
+
+// short_links configures short links (e.g., "/l/rfc/lab-001").
+short_links {
+  // static_redirect is a short link that redirects to a fixed URL instead of a
+  // document.
+  static_redirect {
+    path = "/rfc"
+    url  = "https://drive.google.com/drive/folders/0AJA7q1x_uaLUUk9PVA"
+  }
+  static_redirect {
+    path = "/prd"
+    url  = "https://drive.google.com/drive/folders/0AJvQodV_kfUeUk9PVA"
+  }
+}
+
+// stale_drafts configures emailing the owners of drafts that haven't been
+// modified for a while, to keep, publish, or delete them, and emailing admins a
+// report of stale drafts per product. Requires email to be enabled.
+// stale_drafts {
+//   // nudge_interval is the duration between emails about the same stale draft,
+//   // and between reports to admins.
+//   nudge_interval = "720h"
+//
+//   // threshold is the duration since a draft was last modified after which it
+//   // is stale.
+//   threshold = "2160h"
+// }
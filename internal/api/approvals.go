@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -196,6 +197,16 @@ func ApprovalHandler(
 				return
 			}
 
+			// Update the document's records in the doc sections index.
+			if err := aw.UpdateDocSections(
+				context.Background(), docObj); err != nil {
+				l.Error("error updating doc sections in Algolia",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"doc_id", docID)
+			}
+
 			// Replace the doc header.
 			if err := doc.ReplaceHeader(cfg.BaseURL, false, s); err != nil {
 				l.Error("error replacing doc header",
@@ -455,6 +466,16 @@ func ApprovalHandler(
 				return
 			}
 
+			// Update the document's records in the doc sections index.
+			if err := aw.UpdateDocSections(
+				context.Background(), docObj); err != nil {
+				l.Error("error updating doc sections in Algolia",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"doc_id", docID)
+			}
+
 			// Replace the doc header.
 			err = doc.ReplaceHeader(cfg.BaseURL, false, s)
 			if err != nil {
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -531,6 +532,16 @@ func DocumentHandler(
 				return
 			}
 
+			// Update the document's records in the doc sections index.
+			if err := aw.UpdateDocSections(
+				context.Background(), docObj); err != nil {
+				l.Error("error updating doc sections in Algolia",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"doc_id", docID)
+			}
+
 			// Send emails to new approvers.
 			if cfg.Email != nil && cfg.Email.Enabled {
 				if len(approversToEmail) > 0 {
@@ -199,6 +199,11 @@ func compareAlgoliaAndDatabaseDocument(
 			// we do when assigning a document number when a doc review is requested.
 			dbDocNumber = fmt.Sprintf(
 				"%s-%03d", dbDoc.Product.Abbreviation, dbDoc.DocumentNumber)
+			// Documents numbered with a numbering scheme store their formatted
+			// document number.
+			if dbDoc.DocumentNumber != 0 && dbDoc.FormattedDocumentNumber != "" {
+				dbDocNumber = dbDoc.FormattedDocumentNumber
+			}
 		}
 		if algoDocNumber != dbDocNumber {
 			// Some legacy documents may not have the three digit number padding so
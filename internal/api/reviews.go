@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/email"
 	"github.com/hashicorp-forge/hermes/pkg/algolia"
+	"github.com/hashicorp-forge/hermes/pkg/docnumber"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
 	hcd "github.com/hashicorp-forge/hermes/pkg/hashicorpdocs"
@@ -104,11 +106,10 @@ func ReviewHandler(
 				return
 			}
 
-			// Get latest product number.
-			latestNum, err := models.GetLatestProductNumber(
-				db, doc.DocType, doc.Product)
+			// Get numbering scheme of the document type.
+			scheme, err := cfg.NumberingScheme(doc.DocType)
 			if err != nil {
-				l.Error("error getting product document number",
+				l.Error("error getting document numbering scheme",
 					"error", err,
 					"doc_id", docID,
 					"method", r.Method,
@@ -136,10 +137,20 @@ func ReviewHandler(
 			}
 
 			// Set the document number.
-			nextDocNum := latestNum + 1
-			doc.DocNumber = fmt.Sprintf("%s-%03d",
-				product.Abbreviation,
-				nextDocNum)
+			nextDocNum, err := docnumber.Next(db, scheme, doc.Product, time.Now())
+			if err != nil {
+				l.Error("error getting next document number",
+					"error", err,
+					"doc_id", docID,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				http.Error(w, "Error creating review",
+					http.StatusInternalServerError)
+				return
+			}
+			doc.DocNumber = scheme.String(
+				product.Abbreviation, doc.DocType, nextDocNum)
 
 			// Change document status to "In-Review".
 			doc.Status = "In-Review"
@@ -152,7 +163,7 @@ func ReviewHandler(
 					http.StatusInternalServerError)
 
 				if err := revertReviewCreation(
-					*doc, product.Abbreviation, "", nil, cfg, aw, s,
+					*doc, product.Abbreviation, "", nil, cfg, aw, s, db,
 				); err != nil {
 					l.Error("error reverting review creation",
 						"error", err,
@@ -208,7 +219,7 @@ func ReviewHandler(
 					http.StatusInternalServerError)
 
 				if err := revertReviewCreation(
-					*doc, product.Abbreviation, "", nil, cfg, aw, s,
+					*doc, product.Abbreviation, "", nil, cfg, aw, s, db,
 				); err != nil {
 					l.Error("error reverting review creation",
 						"error", err,
@@ -232,7 +243,7 @@ func ReviewHandler(
 					http.StatusInternalServerError)
 
 				if err := revertReviewCreation(
-					*doc, product.Abbreviation, "", nil, cfg, aw, s,
+					*doc, product.Abbreviation, "", nil, cfg, aw, s, db,
 				); err != nil {
 					l.Error("error reverting review creation",
 						"error", err,
@@ -295,7 +306,7 @@ func ReviewHandler(
 					http.StatusInternalServerError)
 
 				if err := revertReviewCreation(
-					*doc, product.Abbreviation, latestRev.Id, nil, cfg, aw, s,
+					*doc, product.Abbreviation, latestRev.Id, nil, cfg, aw, s, db,
 				); err != nil {
 					l.Error("error reverting review creation",
 						"error", err,
@@ -313,7 +324,7 @@ func ReviewHandler(
 					http.StatusInternalServerError)
 
 				if err := revertReviewCreation(
-					*doc, product.Abbreviation, latestRev.Id, nil, cfg, aw, s,
+					*doc, product.Abbreviation, latestRev.Id, nil, cfg, aw, s, db,
 				); err != nil {
 					l.Error("error reverting review creation",
 						"error", err,
@@ -336,7 +347,7 @@ func ReviewHandler(
 					http.StatusInternalServerError)
 
 				if err := revertReviewCreation(
-					*doc, product.Abbreviation, latestRev.Id, nil, cfg, aw, s,
+					*doc, product.Abbreviation, latestRev.Id, nil, cfg, aw, s, db,
 				); err != nil {
 					l.Error("error reverting review creation",
 						"error", err,
@@ -364,7 +375,7 @@ func ReviewHandler(
 					http.StatusInternalServerError)
 
 				if err := revertReviewCreation(
-					*doc, product.Abbreviation, latestRev.Id, shortcut, cfg, aw, s,
+					*doc, product.Abbreviation, latestRev.Id, shortcut, cfg, aw, s, db,
 				); err != nil {
 					l.Error("error reverting review creation",
 						"error", err,
@@ -381,8 +392,8 @@ func ReviewHandler(
 			)
 
 			// Create go-link.
-			if err := links.SaveDocumentRedirectDetails(
-				aw, docID, doc.DocType, doc.DocNumber); err != nil {
+			if err := links.SaveDocumentShortLink(
+				db, docID, doc.DocType, doc.DocNumber); err != nil {
 				l.Error("error saving redirect details",
 					"error", err,
 					"doc_id", docID,
@@ -392,7 +403,7 @@ func ReviewHandler(
 					http.StatusInternalServerError)
 
 				if err := revertReviewCreation(
-					*doc, product.Abbreviation, latestRev.Id, shortcut, cfg, aw, s,
+					*doc, product.Abbreviation, latestRev.Id, shortcut, cfg, aw, s, db,
 				); err != nil {
 					l.Error("error reverting review creation",
 						"error", err,
@@ -422,7 +433,7 @@ func ReviewHandler(
 					http.StatusInternalServerError)
 
 				if err := revertReviewCreation(
-					*doc, product.Abbreviation, latestRev.Id, shortcut, cfg, aw, s,
+					*doc, product.Abbreviation, latestRev.Id, shortcut, cfg, aw, s, db,
 				); err != nil {
 					l.Error("error reverting review creation",
 						"error", err,
@@ -433,7 +444,10 @@ func ReviewHandler(
 				return
 			}
 			d.Status = models.InReviewDocumentStatus
-			d.DocumentNumber = nextDocNum
+			d.DocumentNumber = nextDocNum.Number
+			d.DocumentNumberYear = nextDocNum.Year
+			d.DocumentNumberSequence = scheme.Sequence()
+			d.FormattedDocumentNumber = doc.DocNumber
 			d.DocumentModifiedAt = modifiedTime
 			if err := d.Upsert(db); err != nil {
 				l.Error("error upserting document in database",
@@ -445,7 +459,7 @@ func ReviewHandler(
 					http.StatusInternalServerError)
 
 				if err := revertReviewCreation(
-					*doc, product.Abbreviation, latestRev.Id, shortcut, cfg, aw, s,
+					*doc, product.Abbreviation, latestRev.Id, shortcut, cfg, aw, s, db,
 				); err != nil {
 					l.Error("error reverting review creation",
 						"error", err,
@@ -706,14 +720,15 @@ func revertReviewCreation(
 	shortcut *drive.File,
 	cfg *config.Config,
 	a *algolia.Client,
-	s *gw.Service) error {
+	s *gw.Service,
+	db *gorm.DB) error {
 
 	// Use go-multierror so we can return all cleanup errors.
 	var result error
 
 	// Delete go-link if it exists.
-	if err := links.DeleteDocumentRedirectDetails(
-		a, doc.ObjectID, doc.DocType, doc.DocNumber,
+	if err := links.DeleteDocumentShortLink(
+		db, doc.ObjectID, doc.DocType, doc.DocNumber,
 	); err != nil {
 		result = multierror.Append(
 			result, fmt.Errorf("error deleting go-link: %w", err))
@@ -783,6 +798,10 @@ func revertReviewCreation(
 		result = multierror.Append(
 			result, fmt.Errorf("error deleting doc in Algolia: %w", err))
 	}
+	if err := a.DeleteDocSections(context.Background(), doc.ObjectID); err != nil {
+		result = multierror.Append(
+			result, fmt.Errorf("error deleting doc sections in Algolia: %w", err))
+	}
 
 	return result
 }
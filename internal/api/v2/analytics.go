@@ -2,9 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
 )
 
 type AnalyticsRequest struct {
@@ -19,9 +23,12 @@ type AnalyticsResponse struct {
 // Analytics handles user events for analytics
 func AnalyticsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		// Only allow POST requests.
 		if r.Method != http.MethodPost {
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 
@@ -29,8 +36,8 @@ func AnalyticsHandler(srv server.Server) http.Handler {
 		var req AnalyticsRequest
 		if err := decoder.Decode(&req); err != nil {
 			srv.Logger.Error("error decoding analytics request", "error", err)
-			http.Error(w, "Error decoding analytics request",
-				http.StatusBadRequest)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Error decoding analytics request")
 			return
 		}
 
@@ -40,14 +47,61 @@ func AnalyticsHandler(srv server.Server) http.Handler {
 
 		// Check if document id is set, product name is optional
 		if req.DocumentID != "" {
+			userEmail := r.Context().Value("userEmail").(string)
+
+			dedupWindow, err := srv.Config.ViewDeduplicationWindow()
+			if err != nil {
+				srv.Logger.Error("error getting view deduplication window",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error recording analytics event")
+				return
+			}
+
+			// Record document view in the database.
+			v := models.DocumentView{
+				Document: models.Document{
+					GoogleFileID: req.DocumentID,
+				},
+				User: models.User{
+					EmailAddress: userEmail,
+				},
+			}
+			recorded, err := v.Record(srv.DB, dedupWindow)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					srv.Logger.Warn("document for view event not found",
+						"method", r.Method,
+						"path", r.URL.Path,
+						"document_id", req.DocumentID,
+					)
+					writeError(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+						"Document not found")
+					return
+				}
+				srv.Logger.Error("error recording document view",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"document_id", req.DocumentID,
+				)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error recording analytics event")
+				return
+			}
+
 			srv.Logger.Info(
 				"document view event",
 				"method", r.Method,
 				"path", r.URL.Path,
 				"document_id", req.DocumentID,
 				"product_name", req.ProductName,
+				"deduplicated", !recorded,
 			)
-			response.Recorded = true
+			response.Recorded = recorded
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -57,9 +111,119 @@ func AnalyticsHandler(srv server.Server) http.Handler {
 		err := enc.Encode(response)
 		if err != nil {
 			srv.Logger.Error("error encoding analytics response", "error", err)
-			http.Error(w, "Error encoding analytics response",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error encoding analytics response")
 			return
 		}
 	})
 }
+
+// documentAnalyticsResponse is the response for a document's analytics.
+type documentAnalyticsResponse struct {
+	// Views is the total number of document views.
+	Views int64 `json:"views"`
+
+	// UniqueViewers is the number of distinct users that viewed the document.
+	UniqueViewers int64 `json:"uniqueViewers"`
+
+	// ViewsLastWeek is the number of document views in the last 7 days.
+	ViewsLastWeek int64 `json:"viewsLastWeek"`
+
+	// UniqueViewersLastWeek is the number of distinct users that viewed the
+	// document in the last 7 days.
+	UniqueViewersLastWeek int64 `json:"uniqueViewersLastWeek"`
+
+	// ProductViewsLastWeek is the number of views for all documents in the
+	// document's product in the last 7 days.
+	ProductViewsLastWeek int64 `json:"productViewsLastWeek"`
+
+	// ProductUniqueViewersLastWeek is the number of distinct users that viewed
+	// documents in the document's product in the last 7 days.
+	ProductUniqueViewersLastWeek int64 `json:"productUniqueViewersLastWeek"`
+}
+
+// documentsResourceAnalyticsHandler handles requests for a document's view
+// analytics. Only document owners are authorized.
+func documentsResourceAnalyticsHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	docID string,
+	docOwners []string,
+	model models.Document,
+	srv server.Server,
+) {
+	errResp := func(
+		httpCode int, userErrMsg, logErrMsg string, err error,
+		extraArgs ...interface{}) {
+		respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err,
+			append([]interface{}{"doc_id", docID}, extraArgs...)...)
+	}
+
+	switch r.Method {
+	case "GET":
+		// Authorize request.
+		userEmail := r.Context().Value("userEmail").(string)
+		if len(docOwners) == 0 || docOwners[0] != userEmail {
+			srv.Logger.Warn("user not authorized to view document analytics",
+				"doc_id", docID,
+				"method", r.Method,
+				"path", r.URL.Path,
+				"user", userEmail,
+			)
+			writeError(w, r, http.StatusForbidden, ErrCodeNotDocumentOwner,
+				"Only owners can view document analytics")
+			return
+		}
+
+		weekAgo := time.Now().UTC().AddDate(0, 0, -7)
+
+		allTime, err := models.GetDocumentViewStats(
+			srv.DB, model.ID, time.Unix(0, 0))
+		if err != nil {
+			errResp(http.StatusInternalServerError,
+				"Error getting document analytics",
+				"error getting document view stats", err)
+			return
+		}
+		lastWeek, err := models.GetDocumentViewStats(srv.DB, model.ID, weekAgo)
+		if err != nil {
+			errResp(http.StatusInternalServerError,
+				"Error getting document analytics",
+				"error getting document view stats for last week", err)
+			return
+		}
+		product, err := models.GetProductViewStats(
+			srv.DB, model.ProductID, weekAgo)
+		if err != nil {
+			errResp(http.StatusInternalServerError,
+				"Error getting document analytics",
+				"error getting product view stats for last week", err)
+			return
+		}
+
+		resp := documentAnalyticsResponse{
+			Views:                        allTime.Views,
+			UniqueViewers:                allTime.UniqueViewers,
+			ViewsLastWeek:                lastWeek.Views,
+			UniqueViewersLastWeek:        lastWeek.UniqueViewers,
+			ProductViewsLastWeek:         product.Views,
+			ProductUniqueViewersLastWeek: product.UniqueViewers,
+		}
+
+		// Write response.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		enc := json.NewEncoder(w)
+		if err := enc.Encode(resp); err != nil {
+			errResp(http.StatusInternalServerError,
+				"Error getting document analytics",
+				"error encoding response", err)
+			return
+		}
+
+	default:
+		writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
+		return
+	}
+}
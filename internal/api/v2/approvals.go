@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -15,6 +16,8 @@ import (
 
 func ApprovalsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		// Validate request.
 		docID, err := parseResourceIDFromURL(r.URL.Path, "approvals")
 		if err != nil {
@@ -23,7 +26,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Document ID not found", http.StatusNotFound)
+			writeError(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+				"Document ID not found")
 			return
 		}
 
@@ -38,8 +42,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
@@ -85,8 +89,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
@@ -96,19 +100,18 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 		case "DELETE":
 			// Authorize request.
 			if doc.Status != "In-Review" {
-				http.Error(w,
-					"Can only request changes of documents in the \"In-Review\" status",
-					http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeInvalidDocumentStatus,
+					"Can only request changes of documents in the \"In-Review\" status")
 				return
 			}
 			if !contains(doc.Approvers, userEmail) {
-				http.Error(w, "Not authorized as a document approver",
-					http.StatusUnauthorized)
+				writeError(w, r, http.StatusUnauthorized, ErrCodeNotDocumentApprover,
+					"Not authorized as a document approver")
 				return
 			}
 			if contains(doc.ChangesRequestedBy, userEmail) {
-				http.Error(w, "Document already has changes requested by user",
-					http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeChangesAlreadyRequested,
+					"Document already has changes requested by user")
 				return
 			}
 
@@ -121,12 +124,14 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error getting document status", http.StatusNotFound)
+				writeError(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Error getting document status")
 				return
 			}
 			// Don't continue if document is locked.
 			if locked {
-				http.Error(w, "Document is locked", http.StatusLocked)
+				writeError(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+					"Document is locked")
 				return
 			}
 
@@ -151,8 +156,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_id", docID)
-				http.Error(w, "Error requesting changes of document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting changes of document")
 				return
 			}
 
@@ -165,8 +170,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.Id)
-				http.Error(w, "Error updating document status",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document status")
 				return
 			}
 
@@ -189,8 +194,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.Id)
-				http.Error(w, "Error updating document status",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document status")
 				return
 			}
 
@@ -202,8 +207,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error updating document status",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document status")
 				return
 			}
 
@@ -217,8 +222,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error updating document status",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document status")
 				return
 			}
 
@@ -234,8 +239,10 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 
 			// Request post-processing.
 			go func() {
+				srv := srv.WithContext(context.Background())
+
 				// Convert document to Algolia object.
-				docObj, err := doc.ToAlgoliaObject(true)
+				docObj, err := doc.ToDocsIndexObject(srv.DB, model.ID, srv.Logger)
 				if err != nil {
 					srv.Logger.Error("error converting document to Algolia object",
 						"error", err,
@@ -243,39 +250,49 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error updating document status",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating document status")
 					return
 				}
 
 				// Save new modified doc object in Algolia.
-				res, err := srv.AlgoWrite.Docs.SaveObject(docObj)
+				res, err := srv.AlgoWrite.Docs.SaveObject(docObj, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving approved document in Algolia",
 						"error", err,
 						"method", r.Method,
 						"path", r.URL.Path,
 						"doc_id", docID)
-					http.Error(w, "Error updating document status",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating document status")
 					return
 				}
-				err = res.Wait()
+				err = res.Wait(srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving patched document in Algolia",
 						"error", err,
 						"method", r.Method,
 						"path", r.URL.Path,
 						"doc_id", docID)
-					http.Error(w, "Error updating document status",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating document status")
 					return
 				}
 
+				// Update the document's records in the doc sections index.
+				if err := srv.AlgoWrite.UpdateDocSections(
+					srv.Context(), docObj); err != nil {
+					srv.Logger.Error("error updating doc sections in Algolia",
+						"error", err,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"doc_id", docID)
+				}
+
 				// Compare Algolia and database documents to find data inconsistencies.
 				// Get document object from Algolia.
 				var algoDoc map[string]any
-				err = srv.AlgoSearch.Docs.GetObject(docID, &algoDoc)
+				err = srv.AlgoSearch.Docs.GetObject(docID, &algoDoc, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error getting Algolia object for data comparison",
 						"error", err,
@@ -351,8 +368,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
 				return
 			}
 			if !contains(doc.Approvers, userEmail) && !inApproverGroup {
@@ -367,15 +384,13 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 		case "POST":
 			// Authorize request.
 			if doc.Status != "In-Review" && doc.Status != "Approved" {
-				http.Error(w,
-					`Document status must be "In-Review" or "Approved" to approve`,
-					http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeInvalidDocumentStatus,
+					`Document status must be "In-Review" or "Approved" to approve`)
 				return
 			}
 			if contains(doc.ApprovedBy, userEmail) {
-				http.Error(w,
-					"Document already approved by user",
-					http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeAlreadyApproved,
+					"Document already approved by user")
 				return
 			}
 			inApproverGroup, err := isUserInGroups(
@@ -387,14 +402,13 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
 				return
 			}
 			if !contains(doc.Approvers, userEmail) && !inApproverGroup {
-				http.Error(w,
-					"Not authorized as a document approver",
-					http.StatusUnauthorized)
+				writeError(w, r, http.StatusUnauthorized, ErrCodeNotDocumentApprover,
+					"Not authorized as a document approver")
 				return
 			}
 
@@ -407,12 +421,14 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error getting document status", http.StatusNotFound)
+				writeError(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Error getting document status")
 				return
 			}
 			// Don't continue if document is locked.
 			if locked {
-				http.Error(w, "Document is locked", http.StatusLocked)
+				writeError(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+					"Document is locked")
 				return
 			}
 
@@ -432,8 +448,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error approving document",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error approving document")
 					return
 				}
 			}
@@ -459,8 +475,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_id", docID)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				return
 			}
 
@@ -473,8 +489,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.Id)
-				http.Error(w, "Error approving document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error approving document")
 				return
 			}
 
@@ -497,8 +513,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.Id)
-				http.Error(w, "Error updating document status",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document status")
 				return
 			}
 
@@ -510,8 +526,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error approving document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error approving document")
 				return
 			}
 
@@ -524,8 +540,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error approving document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error approving document")
 				return
 			}
 
@@ -541,6 +557,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 
 			// Request post-processing.
 			go func() {
+				srv := srv.WithContext(context.Background())
+
 				// Send email to document owner, if enabled.
 				if srv.Config.Email != nil && srv.Config.Email.Enabled &&
 					len(doc.Owners) > 0 {
@@ -604,7 +622,7 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 				}
 
 				// Convert document to Algolia object.
-				docObj, err := doc.ToAlgoliaObject(true)
+				docObj, err := doc.ToDocsIndexObject(srv.DB, model.ID, srv.Logger)
 				if err != nil {
 					srv.Logger.Error("error converting document to Algolia object",
 						"error", err,
@@ -612,39 +630,49 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error updating document status",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating document status")
 					return
 				}
 
 				// Save new modified doc object in Algolia.
-				res, err := srv.AlgoWrite.Docs.SaveObject(docObj)
+				res, err := srv.AlgoWrite.Docs.SaveObject(docObj, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving approved document in Algolia",
 						"error", err,
 						"method", r.Method,
 						"path", r.URL.Path,
 						"doc_id", docID)
-					http.Error(w, "Error updating document status",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating document status")
 					return
 				}
-				err = res.Wait()
+				err = res.Wait(srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving approved document in Algolia",
 						"error", err,
 						"method", r.Method,
 						"path", r.URL.Path,
 						"doc_id", docID)
-					http.Error(w, "Error updating document status",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating document status")
 					return
 				}
 
+				// Update the document's records in the doc sections index.
+				if err := srv.AlgoWrite.UpdateDocSections(
+					srv.Context(), docObj); err != nil {
+					srv.Logger.Error("error updating doc sections in Algolia",
+						"error", err,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"doc_id", docID)
+				}
+
 				// Compare Algolia and database documents to find data inconsistencies.
 				// Get document object from Algolia.
 				var algoDoc map[string]any
-				err = srv.AlgoSearch.Docs.GetObject(docID, &algoDoc)
+				err = srv.AlgoSearch.Docs.GetObject(docID, &algoDoc, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error getting Algolia object for data comparison",
 						"error", err,
@@ -698,7 +726,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 			}()
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
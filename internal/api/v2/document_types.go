@@ -9,6 +9,8 @@ import (
 
 func DocumentTypesHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		switch r.Method {
 		case "GET":
 			w.Header().Set("Content-Type", "application/json")
@@ -20,13 +22,14 @@ func DocumentTypesHandler(srv server.Server) http.Handler {
 					"error", err,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "{\"error\": \"Error getting document types\"}",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error getting document types")
 				return
 			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,8 +29,8 @@ type DocumentPatchRequest struct {
 	Owners         *[]string               `json:"owners,omitempty"`
 	Status         *string                 `json:"status,omitempty"`
 	Summary        *string                 `json:"summary,omitempty"`
-	// Tags                []string `json:"tags,omitempty"`
-	Title *string `json:"title,omitempty"`
+	Tags           *[]string               `json:"tags,omitempty"`
+	Title          *string                 `json:"title,omitempty"`
 }
 
 type documentSubcollectionRequestType int
@@ -39,10 +40,19 @@ const (
 	noSubcollectionRequestType
 	relatedResourcesDocumentSubcollectionRequestType
 	shareableDocumentSubcollectionRequestType
+	analyticsDocumentSubcollectionRequestType
+	similarDocumentSubcollectionRequestType
+	shortLinksDocumentSubcollectionRequestType
+	restoreDocumentSubcollectionRequestType
+	retentionDocumentSubcollectionRequestType
+	exportDocumentSubcollectionRequestType
+	moveDocumentSubcollectionRequestType
 )
 
 func DocumentHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		// Parse document ID and request type from the URL path.
 		docID, reqType, err := parseDocumentsURLPath(
 			r.URL.Path, "documents")
@@ -52,7 +62,7 @@ func DocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
-			http.Error(w, "Bad request", http.StatusBadRequest)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
 			return
 		}
 
@@ -67,7 +77,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Document not found", http.StatusNotFound)
+				writeError(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
 				return
 			} else {
 				srv.Logger.Error("error getting document from database",
@@ -76,8 +87,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error requesting document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document")
 				return
 			}
 		}
@@ -95,7 +106,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error processing request", http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error processing request")
 			return
 		}
 
@@ -125,7 +137,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error processing request", http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error processing request")
 			return
 		}
 
@@ -139,7 +152,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"doc_id", docID,
 			)
-			http.Error(w, "Document not found", http.StatusNotFound)
+			writeError(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+				"Document not found")
 			return
 		}
 
@@ -156,7 +170,34 @@ func DocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
-			http.Error(w, "Bad request", http.StatusBadRequest)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
+			return
+		case analyticsDocumentSubcollectionRequestType:
+			documentsResourceAnalyticsHandler(
+				w, r, docID, doc.Owners, model, srv)
+			return
+		case similarDocumentSubcollectionRequestType:
+			documentsResourceSimilarHandler(w, r, docID, model, srv)
+			return
+		case shortLinksDocumentSubcollectionRequestType:
+			documentsResourceShortLinksHandler(w, r, docID, *doc, model, srv)
+			return
+		case retentionDocumentSubcollectionRequestType:
+			documentsResourceRetentionHandler(w, r, docID, *doc, model, srv)
+			return
+		case exportDocumentSubcollectionRequestType:
+			documentsResourceExportHandler(w, r, docID, model, srv)
+			return
+		case moveDocumentSubcollectionRequestType:
+			documentsResourceMoveHandler(w, r, docID, *doc, model, srv)
+			return
+		case restoreDocumentSubcollectionRequestType:
+			srv.Logger.Warn("invalid restore request for documents collection",
+				"error", err,
+				"path", r.URL.Path,
+				"method", r.Method,
+			)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
 			return
 		}
 
@@ -173,8 +214,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w,
-					"Error requesting document", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document")
 				return
 			}
 
@@ -187,8 +228,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w,
-					"Error requesting document", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document")
 				return
 			}
 			doc.ModifiedTime = modifiedTime.Unix()
@@ -203,8 +244,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error processing request",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
@@ -217,8 +258,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error processing request",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 			projIDs := make([]int, len(projs))
@@ -238,8 +279,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"error", err,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error processing request",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
@@ -251,6 +292,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 
 			// Request post-processing.
 			go func() {
+				srv := srv.WithContext(context.Background())
+
 				// Update recently viewed documents if this is a document view event. The
 				// Add-To-Recently-Viewed header is set in the request from the frontend
 				// to differentiate between document views and requests to only retrieve
@@ -274,7 +317,7 @@ func DocumentHandler(srv server.Server) http.Handler {
 				// Compare Algolia and database documents to find data inconsistencies.
 				// Get document object from Algolia.
 				var algoDoc map[string]any
-				err = srv.AlgoSearch.Docs.GetObject(docID, &algoDoc)
+				err = srv.AlgoSearch.Docs.GetObject(docID, &algoDoc, srv.Context())
 				if err != nil {
 					// Only warn because we might be in the process of saving the Algolia
 					// object for a new document.
@@ -335,8 +378,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 			var req DocumentPatchRequest
 			if err := decodeRequest(r, &req); err != nil {
 				srv.Logger.Error("error decoding document patch request", "error", err)
-				http.Error(w, fmt.Sprintf("Bad request: %q", err),
-					http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
 				return
 			}
 
@@ -352,8 +395,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"doc_id", docID,
 					"user", userEmail,
 				)
-				http.Error(w,
-					fmt.Sprintf("Unauthorized: %v", err), http.StatusForbidden)
+				writeError(w, r, http.StatusForbidden, ErrCodeForbidden,
+					fmt.Sprintf("Unauthorized: %v", err))
 				return
 			}
 
@@ -364,9 +407,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"path", r.URL.Path,
 						"doc_id", docID)
-					http.Error(w,
-						"Bad request: invalid number of owners (only 1 allowed)",
-						http.StatusBadRequest)
+					writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Bad request: invalid number of owners (only 1 allowed)")
 					return
 				}
 			}
@@ -382,8 +424,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 							"path", r.URL.Path,
 							"custom_field", cf.Name,
 							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field",
-							http.StatusBadRequest)
+						writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+							"Bad request: invalid custom field")
 						return
 					}
 					if cf.DisplayName != cef.DisplayName {
@@ -394,8 +436,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 							"custom_field", cf.Name,
 							"custom_field_display_name", cf.DisplayName,
 							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field display name",
-							http.StatusBadRequest)
+						writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+							"Bad request: invalid custom field display name")
 						return
 					}
 					if cf.Type != cef.Type {
@@ -406,8 +448,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 							"custom_field", cf.Name,
 							"custom_field_type", cf.Type,
 							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field type",
-							http.StatusBadRequest)
+						writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+							"Bad request: invalid custom field type")
 						return
 					}
 				}
@@ -424,7 +466,24 @@ func DocumentHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"path", r.URL.Path,
 						"doc_id", docID)
-					http.Error(w, "Bad request: invalid status", http.StatusBadRequest)
+					writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Bad request: invalid status")
+					return
+				}
+			}
+
+			// Validate tags.
+			var tags []string
+			if req.Tags != nil {
+				tags, err = normalizeTags(*req.Tags)
+				if err != nil {
+					srv.Logger.Warn("invalid tags",
+						"error", err,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"doc_id", docID)
+					writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Bad request: %v", err))
 					return
 				}
 			}
@@ -438,12 +497,14 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error getting document status", http.StatusNotFound)
+				writeError(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Error getting document status")
 				return
 			}
 			// Don't continue if document is locked.
 			if locked {
-				http.Error(w, "Document is locked", http.StatusLocked)
+				writeError(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+					"Document is locked")
 				return
 			}
 
@@ -501,9 +562,9 @@ func DocumentHandler(srv server.Server) http.Handler {
 									"custom_field", cf.Name,
 									"doc_id", docID,
 								)
-								http.Error(w,
-									"Error patching document",
-									http.StatusInternalServerError)
+								writeError(w, r,
+									http.StatusInternalServerError, ErrCodeInternal,
+									"Error patching document")
 								return
 							}
 						}
@@ -515,12 +576,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 								"path", r.URL.Path,
 								"custom_field", cf.Name,
 								"doc_id", docID)
-							http.Error(w,
-								fmt.Sprintf(
-									"Bad request: invalid value type for custom field %q",
-									cf.Name,
-								),
-								http.StatusBadRequest)
+							writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+								fmt.Sprintf("Bad request: invalid value type for custom field %q", cf.Name))
 							return
 						}
 						for _, v := range cf.Value.([]any) {
@@ -531,12 +588,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 									"path", r.URL.Path,
 									"custom_field", cf.Name,
 									"doc_id", docID)
-								http.Error(w,
-									fmt.Sprintf(
-										"Bad request: invalid value type for custom field %q",
-										cf.Name,
-									),
-									http.StatusBadRequest)
+								writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+									fmt.Sprintf("Bad request: invalid value type for custom field %q", cf.Name))
 								return
 							}
 						}
@@ -548,9 +601,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 								"custom_field", cf.Name,
 								"doc_id", docID,
 							)
-							http.Error(w,
-								"Error patching document",
-								http.StatusInternalServerError)
+							writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+								"Error patching document")
 							return
 						}
 					default:
@@ -561,12 +613,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 							"custom_field", cf.Name,
 							"custom_field_type", cf.Type,
 							"doc_id", docID)
-						http.Error(w,
-							fmt.Sprintf(
-								"Bad request: invalid type for custom field %q",
-								cf.Name,
-							),
-							http.StatusBadRequest)
+						writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+							fmt.Sprintf("Bad request: invalid type for custom field %q", cf.Name))
 						return
 					}
 				}
@@ -584,8 +632,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 						"new_owner", doc.Owners[0])
-					http.Error(w, "Error patching document",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error patching document")
 					return
 				}
 			}
@@ -597,6 +645,10 @@ func DocumentHandler(srv server.Server) http.Handler {
 			if req.Summary != nil {
 				doc.Summary = *req.Summary
 			}
+			// Tags.
+			if req.Tags != nil {
+				doc.Tags = tags
+			}
 			// Title.
 			if req.Title != nil {
 				doc.Title = *req.Title
@@ -611,8 +663,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"path", r.URL.Path,
 						"approver", a)
-					http.Error(w, "Error patching document",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error patching document")
 					return
 				}
 			}
@@ -623,8 +675,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 			); err != nil {
 				srv.Logger.Error("error replacing document header",
 					"error", err, "doc_id", docID)
-				http.Error(w, "Error patching document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error patching document")
 				return
 			}
 
@@ -643,8 +695,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error patching document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error patching document")
 				return
 			} else {
 				// Approvers.
@@ -702,8 +754,9 @@ func DocumentHandler(srv server.Server) http.Handler {
 									"path", r.URL.Path,
 									"custom_field", cf.Name,
 									"doc_id", docID)
-								http.Error(w, "Error patching document",
-									http.StatusInternalServerError)
+								writeError(w, r,
+									http.StatusInternalServerError, ErrCodeInternal,
+									"Error patching document")
 								return
 							}
 						case "PEOPLE":
@@ -714,8 +767,9 @@ func DocumentHandler(srv server.Server) http.Handler {
 									"path", r.URL.Path,
 									"custom_field", cf.Name,
 									"doc_id", docID)
-								http.Error(w, "Error patching document",
-									http.StatusInternalServerError)
+								writeError(w, r,
+									http.StatusInternalServerError, ErrCodeInternal,
+									"Error patching document")
 								return
 							}
 							cfVal := []string{}
@@ -729,8 +783,9 @@ func DocumentHandler(srv server.Server) http.Handler {
 										"path", r.URL.Path,
 										"custom_field", cf.Name,
 										"doc_id", docID)
-									http.Error(w, "Error patching document",
-										http.StatusInternalServerError)
+									writeError(w, r,
+										http.StatusInternalServerError, ErrCodeInternal,
+										"Error patching document")
 									return
 								}
 							}
@@ -749,8 +804,9 @@ func DocumentHandler(srv server.Server) http.Handler {
 									"path", r.URL.Path,
 									"custom_field", cf.Name,
 									"doc_id", docID)
-								http.Error(w, "Error patching document",
-									http.StatusInternalServerError)
+								writeError(w, r,
+									http.StatusInternalServerError, ErrCodeInternal,
+									"Error patching document")
 								return
 							}
 						default:
@@ -761,12 +817,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 								"custom_field", cf.Name,
 								"custom_field_type", cf.Type,
 								"doc_id", docID)
-							http.Error(w,
-								fmt.Sprintf(
-									"Bad request: invalid type for custom field %q",
-									cf.Name,
-								),
-								http.StatusBadRequest)
+							writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+								fmt.Sprintf("Bad request: invalid type for custom field %q", cf.Name))
 							return
 						}
 					}
@@ -820,8 +872,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 							"method", r.Method,
 							"path", r.URL.Path,
 						)
-						http.Error(w, "Error patching document",
-							http.StatusInternalServerError)
+						writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error patching document")
 						return
 					}
 
@@ -885,8 +937,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 							"path", r.URL.Path,
 							"doc_id", docID,
 						)
-						http.Error(w, "Error patching document",
-							http.StatusInternalServerError)
+						writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error patching document")
 						return
 					}
 				}
@@ -903,8 +955,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 								"method", r.Method,
 								"path", r.URL.Path,
 							)
-							http.Error(w, "Error patching document",
-								http.StatusInternalServerError)
+							writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+								"Error patching document")
 							return
 						}
 
@@ -933,8 +985,9 @@ func DocumentHandler(srv server.Server) http.Handler {
 									"method", r.Method,
 									"path", r.URL.Path,
 								)
-								http.Error(w, "Error patching document",
-									http.StatusInternalServerError)
+								writeError(w, r,
+									http.StatusInternalServerError, ErrCodeInternal,
+									"Error patching document")
 								return
 							}
 						}
@@ -954,10 +1007,25 @@ func DocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error patching document",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error patching document")
 					return
 				}
+
+				// Replace tags in the database.
+				if req.Tags != nil {
+					if err := model.ReplaceTags(srv.DB, tags); err != nil {
+						srv.Logger.Error("error replacing document tags",
+							"error", err,
+							"method", r.Method,
+							"path", r.URL.Path,
+							"doc_id", docID,
+						)
+						writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error patching document")
+						return
+					}
+				}
 			}
 
 			w.WriteHeader(http.StatusOK)
@@ -969,8 +1037,10 @@ func DocumentHandler(srv server.Server) http.Handler {
 
 			// Request post-processing.
 			go func() {
+				srv := srv.WithContext(context.Background())
+
 				// Convert document to Algolia object.
-				docObj, err := doc.ToAlgoliaObject(true)
+				docObj, err := doc.ToDocsIndexObject(srv.DB, model.ID, srv.Logger)
 				if err != nil {
 					srv.Logger.Error("error converting document to Algolia object",
 						"error", err,
@@ -982,7 +1052,7 @@ func DocumentHandler(srv server.Server) http.Handler {
 				}
 
 				// Save new modified doc object in Algolia.
-				res, err := srv.AlgoWrite.Docs.SaveObject(docObj)
+				res, err := srv.AlgoWrite.Docs.SaveObject(docObj, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving patched document in Algolia",
 						"error", err,
@@ -991,7 +1061,7 @@ func DocumentHandler(srv server.Server) http.Handler {
 						"doc_id", docID)
 					return
 				}
-				err = res.Wait()
+				err = res.Wait(srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving patched document in Algolia",
 						"error", err,
@@ -1001,10 +1071,20 @@ func DocumentHandler(srv server.Server) http.Handler {
 					return
 				}
 
+				// Update the document's records in the doc sections index.
+				if err := srv.AlgoWrite.UpdateDocSections(
+					srv.Context(), docObj); err != nil {
+					srv.Logger.Error("error updating doc sections in Algolia",
+						"error", err,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"doc_id", docID)
+				}
+
 				// Compare Algolia and database documents to find data inconsistencies.
 				// Get document object from Algolia.
 				var algoDoc map[string]any
-				err = srv.AlgoSearch.Docs.GetObject(docID, &algoDoc)
+				err = srv.AlgoSearch.Docs.GetObject(docID, &algoDoc, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error getting Algolia object for data comparison",
 						"error", err,
@@ -1058,7 +1138,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 			}()
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -1151,6 +1232,36 @@ func parseDocumentsURLPath(path, collection string) (
 		fmt.Sprintf(
 			`^\/api\/v2\/%s\/([0-9A-Za-z_\-]+)\/related-resources$`,
 			collection))
+	analyticsRE := regexp.MustCompile(
+		fmt.Sprintf(
+			`^\/api\/v2\/%s\/([0-9A-Za-z_\-]+)\/analytics$`,
+			collection))
+	similarRE := regexp.MustCompile(
+		fmt.Sprintf(
+			`^\/api\/v2\/%s\/([0-9A-Za-z_\-]+)\/similar$`,
+			collection))
+	shortLinksRE := regexp.MustCompile(
+		fmt.Sprintf(
+			`^\/api\/v2\/%s\/([0-9A-Za-z_\-]+)\/short-links(?:\/[0-9A-Za-z\-]+)?$`,
+			collection))
+	retentionRE := regexp.MustCompile(
+		fmt.Sprintf(
+			`^\/api\/v2\/%s\/([0-9A-Za-z_\-]+)\/retention$`,
+			collection))
+	exportRE := regexp.MustCompile(
+		fmt.Sprintf(
+			`^\/api\/v2\/%s\/([0-9A-Za-z_\-]+)\/export$`,
+			collection))
+	// move isn't really a subcollection either.
+	moveRE := regexp.MustCompile(
+		fmt.Sprintf(
+			`^\/api\/v2\/%s\/([0-9A-Za-z_\-]+)\/move$`,
+			collection))
+	// restore isn't really a subcollection either.
+	restoreRE := regexp.MustCompile(
+		fmt.Sprintf(
+			`^\/api\/v2\/%s\/([0-9A-Za-z_\-]+)\/restore$`,
+			collection))
 	// shareable isn't really a subcollection, but we'll go with it.
 	shareableRE := regexp.MustCompile(
 		fmt.Sprintf(
@@ -1188,6 +1299,83 @@ func parseDocumentsURLPath(path, collection string) (
 		}
 		return matches[1], shareableDocumentSubcollectionRequestType, nil
 
+	case analyticsRE.MatchString(path):
+		matches := analyticsRE.
+			FindStringSubmatch(path)
+		if len(matches) != 2 {
+			return "",
+				analyticsDocumentSubcollectionRequestType,
+				fmt.Errorf(
+					"wrong number of string submatches for analytics subcollection URL path")
+		}
+		return matches[1], analyticsDocumentSubcollectionRequestType, nil
+
+	case similarRE.MatchString(path):
+		matches := similarRE.
+			FindStringSubmatch(path)
+		if len(matches) != 2 {
+			return "",
+				similarDocumentSubcollectionRequestType,
+				fmt.Errorf(
+					"wrong number of string submatches for similar subcollection URL path")
+		}
+		return matches[1], similarDocumentSubcollectionRequestType, nil
+
+	case shortLinksRE.MatchString(path):
+		matches := shortLinksRE.
+			FindStringSubmatch(path)
+		if len(matches) != 2 {
+			return "",
+				shortLinksDocumentSubcollectionRequestType,
+				fmt.Errorf(
+					"wrong number of string submatches for short links subcollection URL path")
+		}
+		return matches[1], shortLinksDocumentSubcollectionRequestType, nil
+
+	case retentionRE.MatchString(path):
+		matches := retentionRE.
+			FindStringSubmatch(path)
+		if len(matches) != 2 {
+			return "",
+				retentionDocumentSubcollectionRequestType,
+				fmt.Errorf(
+					"wrong number of string submatches for retention subcollection URL path")
+		}
+		return matches[1], retentionDocumentSubcollectionRequestType, nil
+
+	case exportRE.MatchString(path):
+		matches := exportRE.
+			FindStringSubmatch(path)
+		if len(matches) != 2 {
+			return "",
+				exportDocumentSubcollectionRequestType,
+				fmt.Errorf(
+					"wrong number of string submatches for export subcollection URL path")
+		}
+		return matches[1], exportDocumentSubcollectionRequestType, nil
+
+	case moveRE.MatchString(path):
+		matches := moveRE.
+			FindStringSubmatch(path)
+		if len(matches) != 2 {
+			return "",
+				moveDocumentSubcollectionRequestType,
+				fmt.Errorf(
+					"wrong number of string submatches for move URL path")
+		}
+		return matches[1], moveDocumentSubcollectionRequestType, nil
+
+	case restoreRE.MatchString(path):
+		matches := restoreRE.
+			FindStringSubmatch(path)
+		if len(matches) != 2 {
+			return "",
+				restoreDocumentSubcollectionRequestType,
+				fmt.Errorf(
+					"wrong number of string submatches for restore URL path")
+		}
+		return matches[1], restoreDocumentSubcollectionRequestType, nil
+
 	default:
 		return "",
 			unspecifiedDocumentSubcollectionRequestType,
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/export"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// documentsResourceExportHandler exports a document's content with its Hermes
+// metadata as front matter, in the format of the "format" query parameter
+// ("md", "html", or "pdf").
+func documentsResourceExportHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	docID string,
+	model models.Document,
+	srv server.Server,
+) {
+	if r.Method != http.MethodGet {
+		writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
+		return
+	}
+
+	// Parse format.
+	format := export.FormatMarkdown
+	if v := r.URL.Query().Get("format"); v != "" {
+		f, err := export.ParseFormat(v)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request: format must be one of \"md\", \"html\", or \"pdf\"")
+			return
+		}
+		format = f
+	}
+
+	f, err := export.Document(
+		srv.DB, srv.GWService, model, format, srv.Config.BaseURL)
+	if err != nil {
+		respondError(w, r, srv.Logger, http.StatusInternalServerError,
+			"Error exporting document",
+			"error exporting document", err,
+			"doc_id", docID,
+			"format", format,
+		)
+		return
+	}
+
+	srv.Logger.Info("exported document",
+		"doc_id", docID,
+		"format", format,
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+
+	// Write response.
+	contentType := format.MIMEType()
+	if strings.HasPrefix(contentType, "text/") {
+		contentType += "; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(
+		"attachment", map[string]string{"filename": f.Name}))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(f.Content); err != nil {
+		srv.Logger.Error("error writing export response",
+			"error", err,
+			"doc_id", docID,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+	}
+}
@@ -0,0 +1,399 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/docnumber"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
+	hcd "github.com/hashicorp-forge/hermes/pkg/hashicorpdocs"
+	"github.com/hashicorp-forge/hermes/pkg/links"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"google.golang.org/api/drive/v3"
+	"gorm.io/gorm"
+)
+
+// DocumentMovePostRequest is a request to move a document to another product.
+type DocumentMovePostRequest struct {
+	// Product is the name of the product to move the document to.
+	Product string `json:"product"`
+}
+
+// DocumentMoveResponse is the result of moving a document to another product.
+type DocumentMoveResponse struct {
+	// DocNumber is the document number assigned in the new product.
+	DocNumber string `json:"docNumber"`
+
+	// PreviousDocNumber is the document number in the previous product, which
+	// keeps redirecting to the document.
+	PreviousDocNumber string `json:"previousDocNumber"`
+
+	// Product is the name of the product the document was moved to.
+	Product string `json:"product"`
+}
+
+// documentsResourceMoveHandler handles requests to move a published document to
+// another product, which assigns it a new document number in that product.
+// Only owners and admins can move documents.
+func documentsResourceMoveHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	docID string,
+	doc document.Document,
+	model models.Document,
+	srv server.Server,
+) {
+	errResp := func(
+		httpCode int, userErrMsg, logErrMsg string, err error,
+		extraArgs ...interface{}) {
+		respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err,
+			append([]interface{}{"doc_id", docID}, extraArgs...)...)
+	}
+
+	if r.Method != "POST" {
+		writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
+		return
+	}
+
+	// Authorize request.
+	userEmail := r.Context().Value("userEmail").(string)
+	if (len(doc.Owners) == 0 || doc.Owners[0] != userEmail) &&
+		!srv.Config.IsAdmin(userEmail) {
+		writeError(w, r, http.StatusForbidden, ErrCodeNotDocumentOwner,
+			"Only owners and admins can move a document")
+		return
+	}
+
+	// Decode and validate request.
+	var req DocumentMovePostRequest
+	if err := decodeRequest(r, &req); err != nil {
+		errResp(http.StatusBadRequest,
+			"Bad request",
+			"error decoding move request", err)
+		return
+	}
+	if req.Product == "" {
+		writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Bad request: product is required")
+		return
+	}
+	if req.Product == doc.Product {
+		writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Bad request: document is already in the product")
+		return
+	}
+
+	// Only published documents have document numbers. Drafts are moved to
+	// another product by patching them.
+	if model.Status == models.WIPDocumentStatus || model.DocumentNumber == 0 {
+		writeError(w, r,
+			http.StatusUnprocessableEntity, ErrCodeInvalidDocumentStatus,
+			"Only published documents can be moved to another product")
+		return
+	}
+
+	// Get the product to move the document to.
+	product := models.Product{
+		Name: req.Product,
+	}
+	if err := product.Get(srv.DB); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request: product not found")
+			return
+		}
+		errResp(http.StatusInternalServerError,
+			"Error moving document",
+			"error getting product", err)
+		return
+	}
+
+	// Check if document is locked.
+	locked, err := hcd.IsLocked(docID, srv.DB, srv.GWService, srv.Logger)
+	if err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error moving document",
+			"error checking document locked status", err)
+		return
+	}
+	// Don't continue if document is locked.
+	if locked {
+		writeError(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+			"Document is locked")
+		return
+	}
+
+	// Get numbering scheme of the document type.
+	scheme, err := srv.Config.NumberingScheme(doc.DocType)
+	if err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error moving document",
+			"error getting document numbering scheme", err)
+		return
+	}
+
+	// Keep the document object before the move, to revert the document in
+	// Algolia.
+	oldDoc := doc
+	oldDocObj, err := oldDoc.ToDocsIndexObject(srv.DB, model.ID, srv.Logger)
+	if err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error moving document",
+			"error converting document to Algolia object", err)
+		return
+	}
+	var reverts []func() error
+	revert := func() {
+		for i := len(reverts) - 1; i >= 0; i-- {
+			if err := reverts[i](); err != nil {
+				srv.Logger.Error("error reverting document move",
+					"error", err,
+					"doc_id", docID,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+			}
+		}
+	}
+
+	// Assign a document number in the new product and move the document in the
+	// database. The sequence of document numbers in the new product is locked
+	// until the transaction is committed, so the transaction only includes
+	// database changes.
+	var dbDoc models.Document
+	if err := srv.DB.Transaction(func(tx *gorm.DB) error {
+		n, err := docnumber.Next(tx, scheme, product.Name, time.Now())
+		if err != nil {
+			return fmt.Errorf("error getting next document number: %w", err)
+		}
+		doc.Product = product.Name
+		doc.DocNumber = scheme.String(product.Abbreviation, doc.DocType, n)
+
+		// Update document in the database.
+		dbDoc = models.Document{
+			GoogleFileID: docID,
+		}
+		if err := dbDoc.Get(tx); err != nil {
+			return fmt.Errorf("error getting document from database: %w", err)
+		}
+		dbDoc.Product = models.Product{Name: product.Name}
+		dbDoc.DocumentNumber = n.Number
+		dbDoc.DocumentNumberYear = n.Year
+		dbDoc.DocumentNumberSequence = scheme.Sequence()
+		dbDoc.FormattedDocumentNumber = doc.DocNumber
+		if err := dbDoc.Upsert(tx); err != nil {
+			return fmt.Errorf("error updating document in database: %w", err)
+		}
+
+		// Save short links for the previous and new document numbers, so the
+		// previous number keeps redirecting to the document. The previous short
+		// link is saved in case the document was published before short links
+		// were stored in the database.
+		if err := links.SaveDocumentShortLink(
+			tx, docID, oldDoc.DocType, oldDoc.DocNumber); err != nil {
+			return err
+		}
+		return links.SaveDocumentShortLink(
+			tx, docID, doc.DocType, doc.DocNumber)
+	}); err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error moving document",
+			"error moving document in database", err,
+			"product", product.Name)
+		return
+	}
+	// Move the document back to its previous product and number in the
+	// database if a later step fails. Short links are kept, and the new number
+	// isn't assigned again.
+	reverts = append(reverts, func() error {
+		d := models.Document{
+			GoogleFileID: docID,
+		}
+		if err := d.Get(srv.DB); err != nil {
+			return fmt.Errorf("error getting document from database: %w", err)
+		}
+		d.Product = models.Product{Name: oldDoc.Product}
+		d.DocumentNumber = model.DocumentNumber
+		d.DocumentNumberYear = model.DocumentNumberYear
+		d.DocumentNumberSequence = model.DocumentNumberSequence
+		d.FormattedDocumentNumber = model.FormattedDocumentNumber
+		if err := d.Upsert(srv.DB); err != nil {
+			return fmt.Errorf("error updating document in database: %w", err)
+		}
+		return nil
+	})
+
+	if err := func() error {
+		// Replace the doc header, which also renames the file with the new
+		// document number.
+		reverts = append(reverts, func() error {
+			if err := oldDoc.ReplaceHeader(
+				srv.Config.BaseURL, false, srv.GWService); err != nil {
+				return fmt.Errorf("error replacing doc header: %w", err)
+			}
+			return nil
+		})
+		if err := doc.ReplaceHeader(
+			srv.Config.BaseURL, false, srv.GWService); err != nil {
+			return fmt.Errorf("error replacing doc header: %w", err)
+		}
+
+		// Move shortcut to the folder of the new product.
+		if err := moveShortcut(
+			srv.Config, doc, oldDoc.Product, srv.GWService); err != nil {
+			return fmt.Errorf("error moving shortcut: %w", err)
+		}
+		reverts = append(reverts, func() error {
+			return moveShortcut(srv.Config, oldDoc, doc.Product, srv.GWService)
+		})
+
+		// Record the move in the document's file revisions. The revision is
+		// saved in the database last, so it isn't recorded if the move is
+		// reverted.
+		latestRev, err := srv.GWService.GetLatestRevision(docID)
+		if err != nil {
+			return fmt.Errorf("error getting latest revision: %w", err)
+		}
+		if _, err := srv.GWService.KeepRevisionForever(
+			docID, latestRev.Id); err != nil {
+			return fmt.Errorf("error marking revision to keep forever: %w", err)
+		}
+		revisionName := fmt.Sprintf("Moved from %s (%s) by %s",
+			oldDoc.Product, oldDoc.DocNumber, userEmail)
+		doc.SetFileRevision(latestRev.Id, revisionName)
+
+		// Save moved document in Algolia. Saved searches are evaluated against
+		// the saved document when it is next indexed, so they use its new
+		// product and number.
+		docObj, err := doc.ToDocsIndexObject(srv.DB, model.ID, srv.Logger)
+		if err != nil {
+			return fmt.Errorf(
+				"error converting document to Algolia object: %w", err)
+		}
+		reverts = append(reverts, func() error {
+			return saveMovedDocInAlgolia(srv, oldDocObj)
+		})
+		if err := saveMovedDocInAlgolia(srv, docObj); err != nil {
+			return err
+		}
+
+		// Save the file revision in the database.
+		fr := models.DocumentFileRevision{
+			DocumentID:                dbDoc.ID,
+			GoogleDriveFileRevisionID: latestRev.Id,
+			Name:                      revisionName,
+		}
+		if err := fr.Create(srv.DB); err != nil {
+			return fmt.Errorf("error creating document file revision: %w", err)
+		}
+
+		return nil
+	}(); err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error moving document",
+			"error moving document", err,
+			"product", product.Name)
+		revert()
+		return
+	}
+
+	srv.Logger.Info("moved document",
+		"doc_id", docID,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"previous_product", oldDoc.Product,
+		"previous_doc_number", oldDoc.DocNumber,
+		"product", doc.Product,
+		"doc_number", doc.DocNumber,
+	)
+
+	// Write response.
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(DocumentMoveResponse{
+		DocNumber:         doc.DocNumber,
+		PreviousDocNumber: oldDoc.DocNumber,
+		Product:           doc.Product,
+	}); err != nil {
+		srv.Logger.Error("error encoding move response",
+			"error", err,
+			"doc_id", docID,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+	}
+}
+
+// saveMovedDocInAlgolia saves document object docObj in the docs index, and
+// updates the document's records in the doc sections index.
+func saveMovedDocInAlgolia(srv server.Server, docObj map[string]any) error {
+	res, err := srv.AlgoWrite.Docs.SaveObject(docObj, srv.Context())
+	if err != nil {
+		return fmt.Errorf("error saving document in Algolia: %w", err)
+	}
+	if err := res.Wait(srv.Context()); err != nil {
+		return fmt.Errorf("error saving document in Algolia: %w", err)
+	}
+	if err := srv.AlgoWrite.UpdateDocSections(srv.Context(), docObj); err != nil {
+		return fmt.Errorf("error updating doc sections in Algolia: %w", err)
+	}
+	return nil
+}
+
+// moveShortcut moves the shortcuts of document doc from the folder of product
+// oldProduct to the folder of its product in the hierarchical folder structure
+// ("Shortcuts Folder/RFC/MyProduct/"), and renames them with the document's
+// number and title. A shortcut is created if the document doesn't have one.
+func moveShortcut(
+	cfg *config.Config,
+	doc document.Document,
+	oldProduct string,
+	s *gw.Service) error {
+
+	// Find shortcuts in the folder of the previous product.
+	var shortcuts []*drive.File
+	docTypeFolder, err := s.GetSubfolder(
+		cfg.GoogleWorkspace.ShortcutsFolder, doc.DocType)
+	if err != nil {
+		return fmt.Errorf("error getting doc type subfolder: %w", err)
+	}
+	if docTypeFolder != nil {
+		oldFolder, err := s.GetSubfolder(docTypeFolder.Id, oldProduct)
+		if err != nil {
+			return fmt.Errorf("error getting product subfolder: %w", err)
+		}
+		if oldFolder != nil {
+			shortcuts, err = s.GetShortcuts(oldFolder.Id, doc.ObjectID)
+			if err != nil {
+				return fmt.Errorf("error getting shortcuts: %w", err)
+			}
+		}
+	}
+	if len(shortcuts) == 0 {
+		_, err := createShortcut(cfg, doc, s)
+		return err
+	}
+
+	folder, err := getShortcutFolder(cfg, doc.DocType, doc.Product, s)
+	if err != nil {
+		return err
+	}
+	for _, sc := range shortcuts {
+		if _, err := s.MoveFile(sc.Id, folder.Id); err != nil {
+			return fmt.Errorf("error moving shortcut: %w", err)
+		}
+		if err := s.RenameFile(sc.Id,
+			fmt.Sprintf("[%s] %s", doc.DocNumber, doc.Title)); err != nil {
+			return fmt.Errorf("error renaming shortcut: %w", err)
+		}
+	}
+
+	return nil
+}
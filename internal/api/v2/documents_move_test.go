@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/internal/test"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveDocumentMove serves a request by the user with email address userEmail
+// to move document doc (with database model model) with request body body.
+func serveDocumentMove(
+	srv server.Server,
+	method, userEmail, body string,
+	doc document.Document,
+	model models.Document,
+) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, "/api/v2/documents/doc123/move",
+		strings.NewReader(body))
+	r = r.WithContext(context.WithValue(r.Context(), "userEmail", userEmail))
+	w := httptest.NewRecorder()
+	documentsResourceMoveHandler(w, r, "doc123", doc, model, srv)
+	return w
+}
+
+func TestDocumentsResourceMoveHandler(t *testing.T) {
+	srv := server.Server{
+		Config: &config.Config{
+			Admins: []string{"admin@example.com"},
+		},
+		Logger: hclog.NewNullLogger(),
+	}
+	publishedDoc := document.Document{
+		ObjectID: "doc123",
+		Owners:   []string{"owner@example.com"},
+		Product:  "Terraform",
+		Status:   "In-Review",
+	}
+	publishedModel := models.Document{
+		DocumentNumber: 7,
+		Status:         models.InReviewDocumentStatus,
+	}
+
+	cases := map[string]struct {
+		method    string
+		userEmail string
+		body      string
+		doc       document.Document
+		model     models.Document
+
+		wantCode    int
+		wantErrCode ErrorCode
+	}{
+		"method not allowed": {
+			method:      "GET",
+			userEmail:   "owner@example.com",
+			doc:         publishedDoc,
+			model:       publishedModel,
+			wantCode:    http.StatusMethodNotAllowed,
+			wantErrCode: ErrCodeMethodNotAllowed,
+		},
+		"not an owner": {
+			method:      "POST",
+			userEmail:   "someone@example.com",
+			body:        `{"product":"Vault"}`,
+			doc:         publishedDoc,
+			model:       publishedModel,
+			wantCode:    http.StatusForbidden,
+			wantErrCode: ErrCodeNotDocumentOwner,
+		},
+		"document without owners": {
+			method:    "POST",
+			userEmail: "owner@example.com",
+			body:      `{"product":"Vault"}`,
+			doc: document.Document{
+				Product: "Terraform",
+				Status:  "In-Review",
+			},
+			model:       publishedModel,
+			wantCode:    http.StatusForbidden,
+			wantErrCode: ErrCodeNotDocumentOwner,
+		},
+		"admin is authorized": {
+			method:      "POST",
+			userEmail:   "admin@example.com",
+			body:        `{"product":"Terraform"}`,
+			doc:         publishedDoc,
+			model:       publishedModel,
+			wantCode:    http.StatusBadRequest,
+			wantErrCode: ErrCodeBadRequest,
+		},
+		"unknown request field": {
+			method:      "POST",
+			userEmail:   "owner@example.com",
+			body:        `{"product":"Vault","docNumber":"VLT-001"}`,
+			doc:         publishedDoc,
+			model:       publishedModel,
+			wantCode:    http.StatusBadRequest,
+			wantErrCode: ErrCodeBadRequest,
+		},
+		"missing product": {
+			method:      "POST",
+			userEmail:   "owner@example.com",
+			body:        `{}`,
+			doc:         publishedDoc,
+			model:       publishedModel,
+			wantCode:    http.StatusBadRequest,
+			wantErrCode: ErrCodeBadRequest,
+		},
+		"same product": {
+			method:      "POST",
+			userEmail:   "owner@example.com",
+			body:        `{"product":"Terraform"}`,
+			doc:         publishedDoc,
+			model:       publishedModel,
+			wantCode:    http.StatusBadRequest,
+			wantErrCode: ErrCodeBadRequest,
+		},
+		"draft": {
+			method:    "POST",
+			userEmail: "owner@example.com",
+			body:      `{"product":"Vault"}`,
+			doc:       publishedDoc,
+			model: models.Document{
+				Status: models.WIPDocumentStatus,
+			},
+			wantCode:    http.StatusUnprocessableEntity,
+			wantErrCode: ErrCodeInvalidDocumentStatus,
+		},
+		"published document without a number": {
+			method:    "POST",
+			userEmail: "owner@example.com",
+			body:      `{"product":"Vault"}`,
+			doc:       publishedDoc,
+			model: models.Document{
+				Status: models.InReviewDocumentStatus,
+			},
+			wantCode:    http.StatusUnprocessableEntity,
+			wantErrCode: ErrCodeInvalidDocumentStatus,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			w := serveDocumentMove(
+				srv, c.method, c.userEmail, c.body, c.doc, c.model)
+
+			assert.Equal(c.wantCode, w.Code)
+			var resp ErrorResponse
+			require.NoError(json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(c.wantErrCode, resp.Code)
+		})
+	}
+}
+
+func TestDocumentsResourceMoveHandlerUnknownProduct(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+	assert, require := assert.New(t), require.New(t)
+
+	db, _, err := test.CreateTestDatabase(t, dsn)
+	require.NoError(err)
+	require.NoError(db.Exec("CREATE EXTENSION IF NOT EXISTS citext;").Error)
+	require.NoError(db.AutoMigrate(models.ModelsToAutoMigrate()...))
+
+	srv := server.Server{
+		Config: &config.Config{},
+		DB:     db,
+		Logger: hclog.NewNullLogger(),
+	}
+	w := serveDocumentMove(srv, "POST", "owner@example.com",
+		`{"product":"Vault"}`,
+		document.Document{
+			ObjectID: "doc123",
+			Owners:   []string{"owner@example.com"},
+			Product:  "Terraform",
+			Status:   "In-Review",
+		},
+		models.Document{
+			DocumentNumber: 7,
+			Status:         models.InReviewDocumentStatus,
+		},
+	)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	var resp ErrorResponse
+	require.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(ErrCodeBadRequest, resp.Code)
+	assert.Equal("Bad request: product not found", resp.Message)
+}
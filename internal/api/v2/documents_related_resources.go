@@ -69,8 +69,8 @@ func documentsResourceRelatedResourcesHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document resources",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document resources")
 			return
 		}
 
@@ -83,8 +83,8 @@ func documentsResourceRelatedResourcesHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
@@ -102,8 +102,8 @@ func documentsResourceRelatedResourcesHandler(
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
 				return
 			}
 
@@ -118,7 +118,8 @@ func documentsResourceRelatedResourcesHandler(
 		for _, hdrr := range hdrrs {
 			// Get document object from Algolia.
 			var algoObj map[string]any
-			err = algoRead.Docs.GetObject(hdrr.Document.GoogleFileID, &algoObj)
+			err = algoRead.Docs.GetObject(
+				hdrr.Document.GoogleFileID, &algoObj, r.Context())
 			if err != nil {
 				l.Error("error getting related resource document from Algolia",
 					"error", err,
@@ -127,8 +128,8 @@ func documentsResourceRelatedResourcesHandler(
 					"doc_id", docID,
 					"target_doc_id", hdrr.Document.GoogleFileID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
 				return
 			}
 
@@ -140,8 +141,8 @@ func documentsResourceRelatedResourcesHandler(
 					"error", err,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error accessing draft document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing draft document")
 				return
 			}
 
@@ -166,7 +167,8 @@ func documentsResourceRelatedResourcesHandler(
 				"error", err,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document", http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
@@ -177,7 +179,8 @@ func documentsResourceRelatedResourcesHandler(
 		// resources).
 		userEmail := r.Context().Value("userEmail").(string)
 		if doc.Owners[0] != userEmail {
-			http.Error(w, "Not a document owner", http.StatusUnauthorized)
+			writeError(w, r, http.StatusUnauthorized, ErrCodeNotDocumentOwner,
+				"Not a document owner")
 			return
 		}
 
@@ -190,7 +193,7 @@ func documentsResourceRelatedResourcesHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Bad request", http.StatusBadRequest)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
 			return
 		}
 
@@ -236,7 +239,8 @@ func documentsResourceRelatedResourcesHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document", http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
@@ -247,7 +251,8 @@ func documentsResourceRelatedResourcesHandler(
 		)
 
 	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
 		return
 	}
 }
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// DocumentRetentionPutRequest is a request to update a document's retention
+// settings.
+type DocumentRetentionPutRequest struct {
+	// OptOut opts the document out of retention policies, if true.
+	OptOut *bool `json:"optOut"`
+}
+
+// DocumentRetentionResponse is the retention state of a document.
+type DocumentRetentionResponse struct {
+	// OptOut is true if the document is opted out of retention policies.
+	OptOut bool `json:"optOut"`
+
+	// ArchivedTime is the time the document was archived by a retention policy,
+	// in Unix time.
+	ArchivedTime *int64 `json:"archivedTime,omitempty"`
+
+	// FlaggedTime is the time the document was flagged for review by a retention
+	// policy, in Unix time.
+	FlaggedTime *int64 `json:"flaggedTime,omitempty"`
+
+	// Notices are the retention policies that the document's owner was notified
+	// will apply to the document.
+	Notices []retentionNotice `json:"notices"`
+}
+
+// retentionNotice is a notice that a retention policy will apply to a
+// document.
+type retentionNotice struct {
+	// Policy is the name of the retention policy.
+	Policy string `json:"policy"`
+
+	// Action is the action of the retention policy ("archive" or "flag"), or
+	// empty if the policy no longer exists.
+	Action string `json:"action"`
+
+	// ActionTime is the time the retention policy will apply to the document, in
+	// Unix time.
+	ActionTime int64 `json:"actionTime"`
+}
+
+// documentsResourceRetentionHandler handles requests for a document's
+// retention state. Only owners can opt documents out of retention policies.
+func documentsResourceRetentionHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	docID string,
+	doc document.Document,
+	model models.Document,
+	srv server.Server,
+) {
+	errResp := func(
+		httpCode int, userErrMsg, logErrMsg string, err error,
+		extraArgs ...interface{}) {
+		respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err,
+			append([]interface{}{"doc_id", docID}, extraArgs...)...)
+	}
+
+	switch r.Method {
+	case "GET":
+		// Nothing to update.
+
+	case "PUT":
+		// Authorize request.
+		userEmail := r.Context().Value("userEmail").(string)
+		if len(doc.Owners) == 0 || doc.Owners[0] != userEmail {
+			writeError(w, r, http.StatusForbidden, ErrCodeNotDocumentOwner,
+				"Only owners can change retention settings of a document")
+			return
+		}
+
+		// Decode and validate request.
+		var req DocumentRetentionPutRequest
+		if err := decodeRequest(r, &req); err != nil {
+			errResp(http.StatusBadRequest,
+				"Bad request",
+				"error decoding retention request", err)
+			return
+		}
+		if req.OptOut == nil {
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request: optOut is required")
+			return
+		}
+
+		if err := model.SetRetentionOptOut(srv.DB, *req.OptOut); err != nil {
+			errResp(http.StatusInternalServerError,
+				"Error updating retention settings",
+				"error setting retention opt-out", err)
+			return
+		}
+
+		srv.Logger.Info("updated document retention opt-out",
+			"doc_id", docID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"opt_out", *req.OptOut,
+		)
+
+	default:
+		writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
+		return
+	}
+
+	ns, err := models.GetDocumentRetentionNotices(srv.DB, model.ID)
+	if err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error getting retention state",
+			"error getting retention notices", err)
+		return
+	}
+
+	resp := DocumentRetentionResponse{
+		OptOut:  model.RetentionOptOut,
+		Notices: []retentionNotice{},
+	}
+	if model.ArchivedAt != nil {
+		t := model.ArchivedAt.Unix()
+		resp.ArchivedTime = &t
+	}
+	if model.RetentionFlaggedAt != nil {
+		t := model.RetentionFlaggedAt.Unix()
+		resp.FlaggedTime = &t
+	}
+	for _, n := range ns {
+		rn := retentionNotice{
+			Policy:     n.Policy,
+			ActionTime: n.ActionAt.Unix(),
+		}
+		if srv.Config.Retention != nil {
+			for _, p := range srv.Config.Retention.Policy {
+				if p.Name == n.Policy {
+					rn.Action = p.Action
+					break
+				}
+			}
+		}
+		resp.Notices = append(resp.Notices, rn)
+	}
+
+	// Write response.
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		srv.Logger.Error("error encoding retention response",
+			"error", err,
+			"doc_id", docID,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+	}
+}
@@ -0,0 +1,290 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/links"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// ShortLinkPostRequest is a request to create an alias short link for a
+// document.
+type ShortLinkPostRequest struct {
+	// Alias is the alias (e.g., "vault-ha" for the short link "/l/vault-ha").
+	Alias string `json:"alias"`
+}
+
+// shortLink is a short link that redirects to a document.
+type shortLink struct {
+	// Path is the path of the short link without the "/l" prefix (e.g.,
+	// "/rfc/lab-001").
+	Path string `json:"path"`
+
+	// URL is the full URL of the short link.
+	URL string `json:"url"`
+
+	// Alias is true if the short link was defined by a user.
+	Alias bool `json:"alias"`
+
+	// Current is true if the short link is for the document's current number.
+	// Short links for previous numbers keep redirecting to the document.
+	Current bool `json:"current"`
+
+	// CreatedBy is the email address of the user that created an alias.
+	CreatedBy string `json:"createdBy,omitempty"`
+
+	// CreatedTime is the time the short link was created, in Unix time.
+	CreatedTime int64 `json:"createdTime"`
+
+	// HitCount is the number of times the short link has been followed.
+	HitCount int64 `json:"hitCount"`
+
+	// LastHitTime is the time the short link was last followed, in Unix time.
+	LastHitTime *int64 `json:"lastHitTime,omitempty"`
+}
+
+// documentsResourceShortLinksHandler handles requests for a document's short
+// links. Document owners and contributors can create aliases, and aliases can
+// be deleted by their creator or the document owner.
+func documentsResourceShortLinksHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	docID string,
+	doc document.Document,
+	model models.Document,
+	srv server.Server,
+) {
+	errResp := func(
+		httpCode int, userErrMsg, logErrMsg string, err error,
+		extraArgs ...interface{}) {
+		respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err,
+			append([]interface{}{"doc_id", docID}, extraArgs...)...)
+	}
+
+	userEmail := r.Context().Value("userEmail").(string)
+	isOwner := len(doc.Owners) > 0 && doc.Owners[0] == userEmail
+
+	// Parse alias from the URL path, if any.
+	_, alias, _ := strings.Cut(r.URL.Path, "/short-links/")
+
+	switch {
+	case r.Method == http.MethodGet && alias == "":
+		var ls models.ShortLinks
+		if err := ls.FindByDocument(srv.DB, model.ID); err != nil {
+			errResp(http.StatusInternalServerError,
+				"Error getting short links",
+				"error finding short links", err)
+			return
+		}
+
+		currentPath := ""
+		if doc.DocNumber != "" {
+			currentPath = links.DocumentShortLinkPath(doc.DocType, doc.DocNumber)
+		}
+		resp := []shortLink{}
+		foundCurrent := false
+		for _, l := range ls {
+			if !l.Alias && l.Path == currentPath {
+				foundCurrent = true
+			}
+			resp = append(resp, newShortLink(l, currentPath, srv.Config))
+		}
+
+		// Documents published by earlier versions of Hermes may not have a
+		// short link for their number in the database yet.
+		if currentPath != "" && !foundCurrent {
+			l := models.ShortLink{
+				Path: currentPath,
+				Document: models.Document{
+					GoogleFileID: docID,
+				},
+			}
+			if err := l.Upsert(srv.DB); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error getting short links",
+					"error saving short link for document number", err,
+					"short_path", currentPath)
+				return
+			}
+			resp = append(resp, newShortLink(l, currentPath, srv.Config))
+		}
+
+		// Write response.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding short links response",
+				"error", err,
+				"doc_id", docID,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+
+	case r.Method == http.MethodPost && alias == "":
+		// Authorize request.
+		if !isOwner && !contains(doc.Contributors, userEmail) {
+			writeError(w, r, http.StatusForbidden, ErrCodeForbidden,
+				"Only owners and contributors can create short links")
+			return
+		}
+
+		// Decode and validate request.
+		var req ShortLinkPostRequest
+		if err := decodeRequest(r, &req); err != nil {
+			respondError(w, r, srv.Logger, http.StatusBadRequest,
+				"Bad request", "error decoding short link request", err,
+				"doc_id", docID)
+			return
+		}
+		a, err := models.NormalizeShortLinkAlias(req.Alias)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request: "+err.Error())
+			return
+		}
+		p := "/" + a
+
+		// Static redirects take precedence over aliases.
+		if srv.Config.ShortLinks.StaticRedirectURL(p) != "" {
+			writeError(w, r, http.StatusConflict, ErrCodeShortLinkExists,
+				"Short link already exists")
+			return
+		}
+
+		l := models.ShortLink{
+			Path: p,
+			Document: models.Document{
+				GoogleFileID: docID,
+			},
+			CreatedBy: &models.User{
+				EmailAddress: userEmail,
+			},
+		}
+		if err := l.Create(srv.DB); err != nil {
+			if errors.Is(err, models.ErrShortLinkExists) {
+				writeError(w, r, http.StatusConflict, ErrCodeShortLinkExists,
+					"Short link already exists")
+				return
+			}
+			errResp(http.StatusInternalServerError,
+				"Error creating short link",
+				"error creating short link", err,
+				"short_path", p)
+			return
+		}
+
+		srv.Logger.Info("created short link",
+			"doc_id", docID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"short_path", p,
+		)
+
+		// Write response.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(
+			newShortLink(l, "", srv.Config)); err != nil {
+			srv.Logger.Error("error encoding short link response",
+				"error", err,
+				"doc_id", docID,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+
+	case r.Method == http.MethodDelete && alias != "":
+		p := "/" + strings.ToLower(alias)
+
+		// Get short link.
+		l := models.ShortLink{
+			Path: p,
+		}
+		if err := l.Get(srv.DB); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeError(w, r, http.StatusNotFound, ErrCodeShortLinkNotFound,
+					"Short link not found")
+				return
+			}
+			errResp(http.StatusInternalServerError,
+				"Error deleting short link",
+				"error getting short link", err,
+				"short_path", p)
+			return
+		}
+		if !l.Alias || l.DocumentID != model.ID {
+			writeError(w, r, http.StatusNotFound, ErrCodeShortLinkNotFound,
+				"Short link not found")
+			return
+		}
+
+		// Authorize request.
+		if !isOwner &&
+			(l.CreatedBy == nil || l.CreatedBy.EmailAddress != userEmail) {
+			writeError(w, r, http.StatusForbidden, ErrCodeForbidden,
+				"Only owners and the creator of a short link can delete it")
+			return
+		}
+
+		if err := l.Delete(srv.DB); err != nil {
+			errResp(http.StatusInternalServerError,
+				"Error deleting short link",
+				"error deleting short link", err,
+				"short_path", p)
+			return
+		}
+
+		srv.Logger.Info("deleted short link",
+			"doc_id", docID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"short_path", p,
+		)
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
+		return
+	}
+}
+
+// newShortLink converts a short link database model to a short link response,
+// where currentPath is the path of the short link for the document's current
+// number.
+func newShortLink(
+	l models.ShortLink, currentPath string, cfg *config.Config) shortLink {
+	res := shortLink{
+		Path:        l.Path,
+		URL:         shortLinkBaseURL(cfg) + l.Path,
+		Alias:       l.Alias,
+		Current:     !l.Alias && l.Path == currentPath,
+		CreatedTime: l.CreatedAt.Unix(),
+		HitCount:    l.HitCount,
+	}
+	if l.CreatedBy != nil {
+		res.CreatedBy = l.CreatedBy.EmailAddress
+	}
+	if l.LastHitAt != nil {
+		t := l.LastHitAt.Unix()
+		res.LastHitTime = &t
+	}
+	return res
+}
+
+// shortLinkBaseURL returns the base URL for short links, which is the
+// shortener base URL if configured, or the "/l" path of the base URL.
+func shortLinkBaseURL(cfg *config.Config) string {
+	if u := strings.TrimSuffix(cfg.ShortenerBaseURL, "/"); u != "" {
+		return u
+	}
+	return strings.TrimSuffix(cfg.BaseURL, "/") + "/l"
+}
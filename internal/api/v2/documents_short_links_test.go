@@ -0,0 +1,84 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShortLink(t *testing.T) {
+	created := time.Unix(100, 0)
+	hit := time.Unix(200, 0)
+	hitTime := hit.Unix()
+
+	cases := map[string]struct {
+		link        models.ShortLink
+		currentPath string
+		cfg         config.Config
+		want        shortLink
+	}{
+		"current document number": {
+			link: models.ShortLink{
+				Path:      "/rfc/lab-002",
+				CreatedAt: created,
+				HitCount:  3,
+				LastHitAt: &hit,
+			},
+			currentPath: "/rfc/lab-002",
+			cfg: config.Config{
+				ShortenerBaseURL: "https://go.example.com/",
+			},
+			want: shortLink{
+				Path:        "/rfc/lab-002",
+				URL:         "https://go.example.com/rfc/lab-002",
+				Current:     true,
+				CreatedTime: 100,
+				HitCount:    3,
+				LastHitTime: &hitTime,
+			},
+		},
+		"previous document number": {
+			link: models.ShortLink{
+				Path:      "/rfc/lab-001",
+				CreatedAt: created,
+			},
+			currentPath: "/rfc/lab-002",
+			cfg: config.Config{
+				BaseURL: "https://hermes.example.com",
+			},
+			want: shortLink{
+				Path:        "/rfc/lab-001",
+				URL:         "https://hermes.example.com/l/rfc/lab-001",
+				CreatedTime: 100,
+			},
+		},
+		"alias": {
+			link: models.ShortLink{
+				Path:      "/vault-ha",
+				Alias:     true,
+				CreatedAt: created,
+				CreatedBy: &models.User{EmailAddress: "a@example.com"},
+			},
+			currentPath: "/rfc/lab-002",
+			cfg: config.Config{
+				BaseURL: "https://hermes.example.com/",
+			},
+			want: shortLink{
+				Path:        "/vault-ha",
+				URL:         "https://hermes.example.com/l/vault-ha",
+				Alias:       true,
+				CreatedBy:   "a@example.com",
+				CreatedTime: 100,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, newShortLink(c.link, c.currentPath, &c.cfg))
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp-forge/hermes/internal/embeddings"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultSimilarDocsLimit is the default number of similar documents
+	// returned.
+	defaultSimilarDocsLimit = 10
+
+	// maxSimilarDocsLimit is the maximum number of similar documents returned.
+	maxSimilarDocsLimit = 50
+)
+
+// similarDocument is a document that is similar to another document.
+type similarDocument struct {
+	ID        string   `json:"id"`
+	DocNumber string   `json:"docNumber"`
+	DocType   string   `json:"docType"`
+	Owners    []string `json:"owners"`
+	Product   string   `json:"product"`
+	Status    string   `json:"status"`
+	Title     string   `json:"title"`
+
+	// Score is the cosine similarity of the documents' content, between -1 and
+	// 1 (most similar).
+	Score float64 `json:"score"`
+}
+
+// documentsResourceSimilarHandler returns published documents that are
+// semantically similar to a document, using embeddings of their content.
+func documentsResourceSimilarHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	docID string,
+	model models.Document,
+	srv server.Server,
+) {
+	errResp := func(
+		httpCode int, userErrMsg, logErrMsg string, err error,
+		extraArgs ...interface{}) {
+		respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err,
+			append([]interface{}{"doc_id", docID}, extraArgs...)...)
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
+		return
+	}
+
+	if srv.Embeddings == nil || srv.Config.Embeddings == nil {
+		writeError(w, r, http.StatusUnprocessableEntity, ErrCodeFeatureNotEnabled,
+			"Semantic search is not enabled")
+		return
+	}
+
+	// Parse limit from query string.
+	limit := defaultSimilarDocsLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 || limit > maxSimilarDocsLimit {
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: limit must be between 1 and %d",
+					maxSimilarDocsLimit))
+			return
+		}
+	}
+
+	// Get the document's embeddings. Documents have no embeddings until they
+	// are indexed.
+	embModel := srv.Embeddings.Model()
+	var embs models.DocumentEmbeddings
+	if err := embs.Find(srv.DB, model.ID, embModel); err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error finding similar documents",
+			"error getting document embeddings", err)
+		return
+	}
+
+	res := []similarDocument{}
+	if len(embs) > 0 {
+		var vs [][]float32
+		for _, e := range embs {
+			vs = append(vs, e.Embedding)
+		}
+		// Get extra results because drafts are filtered out below.
+		sims, err := models.SearchDocumentEmbeddings(srv.DB,
+			embeddings.Mean(vs), embModel,
+			srv.Config.Embeddings.MaxDistanceOrDefault(), model.ID, limit*2)
+		if err != nil {
+			errResp(http.StatusInternalServerError,
+				"Error finding similar documents",
+				"error searching document embeddings", err)
+			return
+		}
+
+		for _, s := range sims {
+			if len(res) == limit {
+				break
+			}
+
+			doc := models.Document{
+				Model: gorm.Model{
+					ID: s.DocumentID,
+				},
+			}
+			if err := doc.Get(srv.DB); err != nil {
+				if !errors.Is(err, gorm.ErrRecordNotFound) {
+					srv.Logger.Error("error getting document in database",
+						"error", err,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"document_db_id", s.DocumentID,
+					)
+				}
+				continue
+			}
+
+			// Don't include drafts.
+			if doc.Status == models.WIPDocumentStatus && !doc.Imported {
+				continue
+			}
+
+			d, err := document.NewFromDatabaseModel(doc, nil, nil)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error finding similar documents",
+					"error converting database model to document type", err,
+					"similar_doc_id", s.GoogleFileID)
+				return
+			}
+			res = append(res, similarDocument{
+				ID:        d.ObjectID,
+				DocNumber: d.DocNumber,
+				DocType:   d.DocType,
+				Owners:    d.Owners,
+				Product:   d.Product,
+				Status:    d.Status,
+				Title:     d.Title,
+				Score:     1 - s.Distance,
+			})
+		}
+	}
+
+	// Write response.
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		srv.Logger.Error("error encoding similar documents response",
+			"error", err,
+			"doc_id", docID,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+	}
+}
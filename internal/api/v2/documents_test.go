@@ -33,6 +33,54 @@ func TestParseDocumentIDFromURLPath(t *testing.T) {
 			wantReqType: shareableDocumentSubcollectionRequestType,
 			wantDocID:   "doc123",
 		},
+		"good documents collection URL with analytics": {
+			path:        "/api/v2/documents/doc123/analytics",
+			collection:  "documents",
+			wantReqType: analyticsDocumentSubcollectionRequestType,
+			wantDocID:   "doc123",
+		},
+		"good documents collection URL with similar": {
+			path:        "/api/v2/documents/doc123/similar",
+			collection:  "documents",
+			wantReqType: similarDocumentSubcollectionRequestType,
+			wantDocID:   "doc123",
+		},
+		"good documents collection URL with short links": {
+			path:        "/api/v2/documents/doc123/short-links",
+			collection:  "documents",
+			wantReqType: shortLinksDocumentSubcollectionRequestType,
+			wantDocID:   "doc123",
+		},
+		"good documents collection URL with short link alias": {
+			path:        "/api/v2/documents/doc123/short-links/vault-ha",
+			collection:  "documents",
+			wantReqType: shortLinksDocumentSubcollectionRequestType,
+			wantDocID:   "doc123",
+		},
+		"good documents collection URL with retention": {
+			path:        "/api/v2/documents/doc123/retention",
+			collection:  "documents",
+			wantReqType: retentionDocumentSubcollectionRequestType,
+			wantDocID:   "doc123",
+		},
+		"good documents collection URL with export": {
+			path:        "/api/v2/documents/doc123/export",
+			collection:  "documents",
+			wantReqType: exportDocumentSubcollectionRequestType,
+			wantDocID:   "doc123",
+		},
+		"good documents collection URL with move": {
+			path:        "/api/v2/documents/doc123/move",
+			collection:  "documents",
+			wantReqType: moveDocumentSubcollectionRequestType,
+			wantDocID:   "doc123",
+		},
+		"good drafts collection URL with restore": {
+			path:        "/api/v2/drafts/doc123/restore",
+			collection:  "drafts",
+			wantReqType: restoreDocumentSubcollectionRequestType,
+			wantDocID:   "doc123",
+		},
 		"extra frontslash after related-resources": {
 			path:        "/api/v2/documents/doc123/related-resources/",
 			collection:  "documents",
@@ -46,8 +46,8 @@ type DraftsPatchRequest struct {
 	Owners         *[]string               `json:"owners,omitempty"`
 	Product        *string                 `json:"product,omitempty"`
 	Summary        *string                 `json:"summary,omitempty"`
-	// Tags                []string `json:"tags,omitempty"`
-	Title *string `json:"title,omitempty"`
+	Tags           *[]string               `json:"tags,omitempty"`
+	Title          *string                 `json:"title,omitempty"`
 }
 
 type DraftsResponse struct {
@@ -56,13 +56,15 @@ type DraftsResponse struct {
 
 func DraftsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
 			srv.Logger.Error(logErrMsg,
 				"method", r.Method,
 				"path", r.URL.Path,
 				"error", err,
 			)
-			http.Error(w, userErrMsg, httpCode)
+			writeError(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
 		}
 
 		// Authorize request.
@@ -83,8 +85,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 			var req DraftsRequest
 			if err := decodeRequest(r, &req); err != nil {
 				srv.Logger.Error("error decoding drafts request", "error", err)
-				http.Error(w, fmt.Sprintf("Bad request: %q", err),
-					http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
 				return
 			}
 
@@ -95,13 +97,14 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_type", req.DocType,
 				)
-				http.Error(
-					w, "Bad request: invalid document type", http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: invalid document type")
 				return
 			}
 
 			if req.Title == "" {
-				http.Error(w, "Bad request: title is required", http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: title is required")
 				return
 			}
 
@@ -114,9 +117,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_type", req.DocType,
 				)
-				http.Error(w,
-					"Bad request: no template configured for doc type",
-					http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: no template configured for doc type")
 				return
 			}
 
@@ -156,8 +158,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"path", r.URL.Path,
 					)
-					http.Error(
-						w, "Error processing request", http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 
@@ -176,8 +178,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 							TemporaryDraftsFolder,
 						"user", userEmail,
 					)
-					http.Error(w, "Error creating document draft",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error creating document draft")
 					return
 				}
 
@@ -195,8 +197,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 						"temporary_drafts_folder", srv.Config.GoogleWorkspace.
 							TemporaryDraftsFolder,
 					)
-					http.Error(w, "Error creating document draft",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error creating document draft")
 					return
 				}
 			} else {
@@ -211,8 +213,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 						"template", template,
 						"drafts_folder", srv.Config.GoogleWorkspace.DraftsFolder,
 					)
-					http.Error(w, "Error creating document draft",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error creating document draft")
 					return
 				}
 			}
@@ -226,8 +228,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", f.Id,
 				)
-				http.Error(w, "Error creating document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating document draft")
 				return
 			}
 			cd := ct.Format("Jan 2, 2006")
@@ -291,8 +293,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", f.Id,
 				)
-				http.Error(w, "Error creating document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating document draft")
 				return
 			}
 
@@ -311,8 +313,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", f.Id,
 				)
-				http.Error(w, "Error creating document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating document draft")
 				return
 			}
 			model := models.Document{
@@ -340,8 +342,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", f.Id,
 				)
-				http.Error(w, "Error creating document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating document draft")
 				return
 			}
 
@@ -353,8 +355,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", f.Id,
 				)
-				http.Error(w, "Error creating document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating document draft")
 				return
 			}
 
@@ -370,8 +372,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 						"doc_id", f.Id,
 						"contributor", c,
 					)
-					http.Error(w, "Error creating document draft",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error creating document draft")
 					return
 				}
 			}
@@ -395,8 +397,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", f.Id,
 				)
-				http.Error(w, "Error creating document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating document draft")
 				return
 			}
 
@@ -408,8 +410,10 @@ func DraftsHandler(srv server.Server) http.Handler {
 
 			// Request post-processing.
 			go func() {
+				srv := srv.WithContext(context.Background())
+
 				// Save document object in Algolia.
-				res, err := srv.AlgoWrite.Drafts.SaveObject(doc)
+				res, err := srv.AlgoWrite.Drafts.SaveObject(doc, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving draft doc in Algolia",
 						"error", err,
@@ -417,11 +421,11 @@ func DraftsHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", f.Id,
 					)
-					http.Error(w, "Error creating document draft",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error creating document draft")
 					return
 				}
-				err = res.Wait()
+				err = res.Wait(srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving draft doc in Algolia",
 						"error", err,
@@ -429,15 +433,15 @@ func DraftsHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", f.Id,
 					)
-					http.Error(w, "Error creating document draft",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error creating document draft")
 					return
 				}
 
 				// Compare Algolia and database documents to find data inconsistencies.
 				// Get document object from Algolia.
 				var algoDoc map[string]any
-				err = srv.AlgoSearch.Drafts.GetObject(f.Id, &algoDoc)
+				err = srv.AlgoSearch.Drafts.GetObject(f.Id, &algoDoc, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error getting Algolia object for data comparison",
 						"error", err,
@@ -512,8 +516,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"hits_per_page", hitsPerPageStr,
 				)
-				http.Error(w, "Error retrieving document drafts",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error retrieving document drafts")
 				return
 			}
 			maxValuesPerFacet, err := strconv.Atoi(maxValuesPerFacetStr)
@@ -524,8 +528,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"max_values_per_facet", maxValuesPerFacetStr,
 				)
-				http.Error(w, "Error retrieving document drafts",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error retrieving document drafts")
 				return
 			}
 			page, err := strconv.Atoi(pageStr)
@@ -536,8 +540,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"page", pageStr,
 				)
-				http.Error(w, "Error retrieving document drafts",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error retrieving document drafts")
 				return
 			}
 
@@ -553,6 +557,7 @@ func DraftsHandler(srv server.Server) http.Handler {
 				opt.HitsPerPage(hitsPerPage),
 				opt.MaxValuesPerFacet(maxValuesPerFacet),
 				opt.Page(page),
+				srv.Context(),
 			}
 
 			// Retrieve all documents
@@ -569,8 +574,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error retrieving document drafts",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error retrieving document drafts")
 				return
 			}
 
@@ -586,8 +591,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error requesting document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document draft")
 				return
 			}
 
@@ -598,7 +603,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 			)
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -606,6 +612,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 
 func DraftsDocumentHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		// Parse document ID and request type from the URL path.
 		docID, reqType, err := parseDocumentsURLPath(
 			r.URL.Path, "drafts")
@@ -615,7 +623,7 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
-			http.Error(w, "Bad request", http.StatusBadRequest)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
 			return
 		}
 
@@ -630,7 +638,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Draft not found", http.StatusNotFound)
+				writeError(w, r, http.StatusNotFound, ErrCodeDraftNotFound,
+					"Draft not found")
 				return
 			} else {
 				srv.Logger.Error("error getting document draft from database",
@@ -639,8 +648,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error requesting document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document draft")
 				return
 			}
 		}
@@ -687,14 +696,23 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing draft document",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing draft document")
 			return
 		}
 
 		// Make sure document is a draft.
 		if doc.Status != "WIP" {
-			http.Error(w, "Draft not found", http.StatusNotFound)
+			writeError(w, r, http.StatusNotFound, ErrCodeDraftNotFound,
+				"Draft not found")
+			return
+		}
+
+		// Drafts in the trash can only be restored.
+		if model.TrashedAt != nil &&
+			reqType != restoreDocumentSubcollectionRequestType {
+			writeError(w, r, http.StatusNotFound, ErrCodeDraftNotFound,
+				"Draft not found")
 			return
 		}
 
@@ -710,9 +728,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 			isContributor = true
 		}
 		if !isOwner && !isContributor && !model.ShareableAsDraft {
-			http.Error(w,
-				"Only owners or contributors can access a non-shared draft document",
-				http.StatusUnauthorized)
+			writeError(w, r, http.StatusUnauthorized, ErrCodeForbidden,
+				"Only owners or contributors can access a non-shared draft document")
 			return
 		}
 
@@ -727,6 +744,43 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 			draftsShareableHandler(w, r, docID, *doc, *srv.Config, srv.Logger,
 				srv.AlgoSearch, srv.GWService, srv.DB)
 			return
+		case analyticsDocumentSubcollectionRequestType:
+			srv.Logger.Warn("invalid analytics request for drafts collection",
+				"path", r.URL.Path,
+				"method", r.Method,
+			)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
+			return
+		case similarDocumentSubcollectionRequestType:
+			srv.Logger.Warn("invalid similar request for drafts collection",
+				"path", r.URL.Path,
+				"method", r.Method,
+			)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
+			return
+		case shortLinksDocumentSubcollectionRequestType:
+			srv.Logger.Warn("invalid short links request for drafts collection",
+				"path", r.URL.Path,
+				"method", r.Method,
+			)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
+			return
+		case retentionDocumentSubcollectionRequestType:
+			documentsResourceRetentionHandler(w, r, docID, *doc, model, srv)
+			return
+		case exportDocumentSubcollectionRequestType:
+			documentsResourceExportHandler(w, r, docID, model, srv)
+			return
+		case moveDocumentSubcollectionRequestType:
+			srv.Logger.Warn("invalid move request for drafts collection",
+				"path", r.URL.Path,
+				"method", r.Method,
+			)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
+			return
+		case restoreDocumentSubcollectionRequestType:
+			draftsRestoreHandler(w, r, docID, *doc, model, isOwner, srv)
+			return
 		}
 
 		switch r.Method {
@@ -742,8 +796,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w,
-					"Error requesting document draft", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document draft")
 				return
 			}
 
@@ -756,8 +810,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w,
-					"Error requesting document draft", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document draft")
 				return
 			}
 			doc.ModifiedTime = modifiedTime.Unix()
@@ -772,8 +826,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error getting document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error getting document draft")
 				return
 			}
 
@@ -790,8 +844,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error requesting document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document draft")
 				return
 			}
 
@@ -803,6 +857,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 
 			// Request post-processing.
 			go func() {
+				srv := srv.WithContext(context.Background())
+
 				// Update recently viewed documents if this is a document view event. The
 				// Add-To-Recently-Viewed header is set in the request from the frontend
 				// to differentiate between document views and requests to only retrieve
@@ -823,7 +879,7 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				// Compare Algolia and database documents to find data inconsistencies.
 				// Get document object from Algolia.
 				var algoDoc map[string]any
-				err = srv.AlgoSearch.Drafts.GetObject(docID, &algoDoc)
+				err = srv.AlgoSearch.Drafts.GetObject(docID, &algoDoc, srv.Context())
 				if err != nil {
 					// Only warn because we might be in the process of saving the Algolia
 					// object for a new draft.
@@ -881,70 +937,29 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 		case "DELETE":
 			// Authorize request.
 			if !isOwner {
-				http.Error(w,
-					"Only owners can delete a draft document",
-					http.StatusUnauthorized)
-				return
-			}
-
-			// Delete document in Google Drive.
-			err = srv.GWService.DeleteFile(docID)
-			if err != nil {
-				srv.Logger.Error(
-					"error deleting document",
-					"error", err,
-					"method", r.Method,
-					"path", r.URL.Path,
-					"doc_id", docID,
-				)
-				http.Error(w, "Error deleting document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusUnauthorized, ErrCodeNotDocumentOwner,
+					"Only owners can delete a draft document")
 				return
 			}
 
-			// Delete object in Algolia.
-			res, err := srv.AlgoWrite.Drafts.DeleteObject(docID)
-			if err != nil {
-				srv.Logger.Error(
-					"error deleting document draft from Algolia",
-					"error", err,
-					"method", r.Method,
-					"path", r.URL.Path,
-					"doc_id", docID,
-				)
-				http.Error(w, "Error deleting document draft",
-					http.StatusInternalServerError)
-				return
-			}
-			err = res.Wait()
+			trashed, err := deleteDraft(srv, docID)
 			if err != nil {
-				srv.Logger.Error(
-					"error deleting document draft from Algolia",
+				srv.Logger.Error("error deleting document draft",
 					"error", err,
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error deleting document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error deleting document draft")
 				return
 			}
-
-			// Delete document in the database.
-			d := models.Document{
-				GoogleFileID: docID,
-			}
-			if err := d.Delete(srv.DB); err != nil {
-				srv.Logger.Error(
-					"error deleting document draft in database",
-					"error", err,
+			if trashed {
+				srv.Logger.Info("moved document draft to trash",
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error deleting document draft",
-					http.StatusInternalServerError)
-				return
 			}
 
 			resp := &DraftsResponse{
@@ -965,17 +980,16 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error deleting document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error deleting document draft")
 				return
 			}
 
 		case "PATCH":
 			// Authorize request.
 			if !isOwner {
-				http.Error(w,
-					"Only owners can patch a draft document",
-					http.StatusUnauthorized)
+				writeError(w, r, http.StatusUnauthorized, ErrCodeNotDocumentOwner,
+					"Only owners can patch a draft document")
 				return
 			}
 
@@ -984,8 +998,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 			var req DraftsPatchRequest
 			if err := decodeRequest(r, &req); err != nil {
 				srv.Logger.Error("error decoding draft patch request", "error", err)
-				http.Error(w, fmt.Sprintf("Bad request: %q", err),
-					http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
 				return
 			}
 
@@ -996,9 +1010,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"path", r.URL.Path,
 						"doc_id", docID)
-					http.Error(w,
-						"Bad request: invalid number of owners (only 1 allowed)",
-						http.StatusBadRequest)
+					writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Bad request: invalid number of owners (only 1 allowed)")
 					return
 				}
 			}
@@ -1014,8 +1027,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"product", req.Product,
 						"doc_id", docID)
-					http.Error(w, "Bad request: invalid product",
-						http.StatusBadRequest)
+					writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Bad request: invalid product")
 					return
 				}
 
@@ -1024,6 +1037,22 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				productAbbreviation = p.Abbreviation
 			}
 
+			// Validate tags.
+			var tags []string
+			if req.Tags != nil {
+				tags, err = normalizeTags(*req.Tags)
+				if err != nil {
+					srv.Logger.Warn("invalid tags",
+						"error", err,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"doc_id", docID)
+					writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Bad request: %v", err))
+					return
+				}
+			}
+
 			// Validate custom fields.
 			if req.CustomFields != nil {
 				for _, cf := range *req.CustomFields {
@@ -1035,8 +1064,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 							"path", r.URL.Path,
 							"custom_field", cf.Name,
 							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field",
-							http.StatusBadRequest)
+						writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+							"Bad request: invalid custom field")
 						return
 					}
 					if cf.DisplayName != cef.DisplayName {
@@ -1047,8 +1076,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 							"custom_field", cf.Name,
 							"custom_field_display_name", cf.DisplayName,
 							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field display name",
-							http.StatusBadRequest)
+						writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+							"Bad request: invalid custom field display name")
 						return
 					}
 					if cf.Type != cef.Type {
@@ -1059,8 +1088,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 							"custom_field", cf.Name,
 							"custom_field_type", cf.Type,
 							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field type",
-							http.StatusBadRequest)
+						writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+							"Bad request: invalid custom field type")
 						return
 					}
 				}
@@ -1075,12 +1104,14 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error getting document status", http.StatusNotFound)
+				writeError(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Error getting document status")
 				return
 			}
 			// Don't continue if document is locked.
 			if locked {
-				http.Error(w, "Document is locked", http.StatusLocked)
+				writeError(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+					"Document is locked")
 				return
 			}
 
@@ -1123,8 +1154,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 						"contributor", c)
-					http.Error(w, "Error patching document draft",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error patching document draft")
 					return
 				}
 			}
@@ -1150,8 +1181,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 							"path", r.URL.Path,
 							"doc_id", docID,
 							"contributor", c)
-						http.Error(w, "Error patching document draft",
-							http.StatusInternalServerError)
+						writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error patching document draft")
 						return
 					}
 				}
@@ -1220,9 +1251,9 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 									"custom_field", cf.Name,
 									"doc_id", docID,
 								)
-								http.Error(w,
-									"Error patching document",
-									http.StatusInternalServerError)
+								writeError(w, r,
+									http.StatusInternalServerError, ErrCodeInternal,
+									"Error patching document")
 								return
 							}
 
@@ -1239,12 +1270,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 								"path", r.URL.Path,
 								"custom_field", cf.Name,
 								"doc_id", docID)
-							http.Error(w,
-								fmt.Sprintf(
-									"Bad request: invalid value type for custom field %q",
-									cf.Name,
-								),
-								http.StatusBadRequest)
+							writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+								fmt.Sprintf("Bad request: invalid value type for custom field %q", cf.Name))
 							return
 						}
 					case "PEOPLE":
@@ -1255,12 +1282,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 								"path", r.URL.Path,
 								"custom_field", cf.Name,
 								"doc_id", docID)
-							http.Error(w,
-								fmt.Sprintf(
-									"Bad request: invalid value type for custom field %q",
-									cf.Name,
-								),
-								http.StatusBadRequest)
+							writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+								fmt.Sprintf("Bad request: invalid value type for custom field %q", cf.Name))
 							return
 						}
 						cfVal := []string{}
@@ -1274,12 +1297,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 									"path", r.URL.Path,
 									"custom_field", cf.Name,
 									"doc_id", docID)
-								http.Error(w,
-									fmt.Sprintf(
-										"Bad request: invalid value type for custom field %q",
-										cf.Name,
-									),
-									http.StatusBadRequest)
+								writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+									fmt.Sprintf("Bad request: invalid value type for custom field %q", cf.Name))
 								return
 							}
 						}
@@ -1292,9 +1311,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 								"custom_field", cf.Name,
 								"doc_id", docID,
 							)
-							http.Error(w,
-								"Error patching document",
-								http.StatusInternalServerError)
+							writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+								"Error patching document")
 							return
 						}
 
@@ -1312,12 +1330,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 								"path", r.URL.Path,
 								"custom_field", cf.Name,
 								"doc_id", docID)
-							http.Error(w,
-								fmt.Sprintf(
-									"Bad request: invalid value type for custom field %q",
-									cf.Name,
-								),
-								http.StatusBadRequest)
+							writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+								fmt.Sprintf("Bad request: invalid value type for custom field %q", cf.Name))
 							return
 						}
 					default:
@@ -1328,12 +1342,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 							"custom_field", cf.Name,
 							"custom_field_type", cf.Type,
 							"doc_id", docID)
-						http.Error(w,
-							fmt.Sprintf(
-								"Bad request: invalid type for custom field %q",
-								cf.Name,
-							),
-							http.StatusBadRequest)
+						writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+							fmt.Sprintf("Bad request: invalid type for custom field %q", cf.Name))
 						return
 					}
 				}
@@ -1363,8 +1373,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 						"new_owner", doc.Owners[0])
-					http.Error(w, "Error patching document draft",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error patching document draft")
 					return
 				}
 			}
@@ -1388,6 +1398,11 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				model.Summary = req.Summary
 			}
 
+			// Tags.
+			if req.Tags != nil {
+				doc.Tags = tags
+			}
+
 			// Title.
 			if req.Title != nil {
 				doc.Title = *req.Title
@@ -1406,8 +1421,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"path", r.URL.Path,
 					)
-					http.Error(w, "Error updating document draft",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating document draft")
 					return
 				}
 
@@ -1471,8 +1486,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error updating document draft",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating document draft")
 					return
 				}
 			}
@@ -1485,11 +1500,26 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error updating document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document draft")
 				return
 			}
 
+			// Replace tags in the database.
+			if req.Tags != nil {
+				if err := model.ReplaceTags(srv.DB, tags); err != nil {
+					srv.Logger.Error("error replacing draft tags",
+						"error", err,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"doc_id", docID,
+					)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating document draft")
+					return
+				}
+			}
+
 			// Replace the doc header.
 			if err := doc.ReplaceHeader(
 				srv.Config.BaseURL, true, srv.GWService,
@@ -1500,8 +1530,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error replacing header of document draft",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error replacing header of document draft")
 				return
 			}
 
@@ -1519,6 +1549,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 
 			// Request post-processing.
 			go func() {
+				srv := srv.WithContext(context.Background())
+
 				// Convert document to Algolia object.
 				docObj, err := doc.ToAlgoliaObject(true)
 				if err != nil {
@@ -1532,7 +1564,7 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				}
 
 				// Save new modified draft doc object in Algolia.
-				res, err := srv.AlgoWrite.Drafts.SaveObject(docObj)
+				res, err := srv.AlgoWrite.Drafts.SaveObject(docObj, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving patched draft doc in Algolia",
 						"error", err,
@@ -1542,7 +1574,7 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					)
 					return
 				}
-				err = res.Wait()
+				err = res.Wait(srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving patched draft doc in Algolia",
 						"error", err,
@@ -1556,7 +1588,7 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				// Compare Algolia and database documents to find data inconsistencies.
 				// Get document object from Algolia.
 				var algoDoc map[string]any
-				err = srv.AlgoSearch.Drafts.GetObject(docID, &algoDoc)
+				err = srv.AlgoSearch.Drafts.GetObject(docID, &algoDoc, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error getting Algolia object for data comparison",
 						"error", err,
@@ -1610,12 +1642,55 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 			}()
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
 }
 
+// deleteDraft moves a draft to the trash folder, if configured, or deletes it
+// in Google Drive, and removes it from the drafts index and database. It
+// returns true if the draft was moved to the trash.
+func deleteDraft(srv server.Server, docID string) (bool, error) {
+	// Move document to the trash folder, if configured, or delete it in Google
+	// Drive.
+	trash := srv.Config.DraftsTrash != nil
+	var err error
+	if trash {
+		_, err = srv.GWService.MoveFile(docID, srv.Config.DraftsTrash.Folder)
+	} else {
+		err = srv.GWService.DeleteFile(docID)
+	}
+	if err != nil {
+		return false, fmt.Errorf("error deleting file in Google Drive: %w", err)
+	}
+
+	// Delete object in Algolia.
+	res, err := srv.AlgoWrite.Drafts.DeleteObject(docID, srv.Context())
+	if err != nil {
+		return false, fmt.Errorf("error deleting draft in Algolia: %w", err)
+	}
+	if err := res.Wait(srv.Context()); err != nil {
+		return false, fmt.Errorf("error deleting draft in Algolia: %w", err)
+	}
+
+	// Mark document as trashed, or delete it, in the database.
+	d := models.Document{
+		GoogleFileID: docID,
+	}
+	if trash {
+		err = d.Trash(srv.DB, time.Now())
+	} else {
+		err = d.Delete(srv.DB)
+	}
+	if err != nil {
+		return false, fmt.Errorf("error deleting draft in database: %w", err)
+	}
+
+	return trash, nil
+}
+
 // getDocTypeTemplate returns the file ID of the template for a specified
 // document type or an empty string if not found.
 func getDocTypeTemplate(
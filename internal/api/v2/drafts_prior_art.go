@@ -0,0 +1,385 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/hashicorp-forge/hermes/internal/auth/apitoken"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+const (
+	// priorArtSearchHits is the number of hits retrieved from each search for
+	// prior art.
+	priorArtSearchHits = 20
+
+	// maxPriorArtResults is the maximum number of documents and of projects
+	// returned as prior art.
+	maxPriorArtResults = 10
+
+	// minPriorArtScore is the minimum score of a document or project to be
+	// returned as prior art.
+	minPriorArtScore = 0.35
+
+	// similarTitleThreshold is the minimum title similarity for a title to be
+	// considered similar.
+	similarTitleThreshold = 0.5
+)
+
+// Reasons that a document or project is returned as prior art.
+const (
+	priorArtReasonContentMatch  = "content_match"
+	priorArtReasonSameProduct   = "same_product"
+	priorArtReasonSemanticMatch = "semantic_match"
+	priorArtReasonSimilarTitle  = "similar_title"
+)
+
+// DraftsPriorArtRequest is a request to find existing documents and projects
+// that are related to a draft before it is created.
+type DraftsPriorArtRequest struct {
+	// Title is the title of the draft.
+	Title string `json:"title"`
+
+	// Summary is the summary of the draft.
+	Summary string `json:"summary,omitempty"`
+
+	// Product is the product of the draft. Documents for the same product rank
+	// higher.
+	Product string `json:"product,omitempty"`
+}
+
+// DraftsPriorArtResponse is the response for finding prior art for a draft.
+type DraftsPriorArtResponse struct {
+	// Documents are documents (not drafts) that are likely related to the
+	// draft, most related first.
+	Documents []priorArtDocument `json:"documents"`
+
+	// Projects are projects that are likely related to the draft, most related
+	// first.
+	Projects []priorArtProject `json:"projects"`
+}
+
+type priorArtDocument struct {
+	ID        string   `json:"id"`
+	DocNumber string   `json:"docNumber"`
+	DocType   string   `json:"docType"`
+	Owners    []string `json:"owners"`
+	Product   string   `json:"product"`
+	Status    string   `json:"status"`
+	Title     string   `json:"title"`
+
+	// Score is how likely the document is related to the draft, between 0 and
+	// 1.
+	Score float64 `json:"score"`
+
+	// Reasons are why the document is likely related to the draft.
+	Reasons []string `json:"reasons"`
+}
+
+type priorArtProject struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+	Title  string `json:"title"`
+
+	// Score is how likely the project is related to the draft, between 0 and 1.
+	Score float64 `json:"score"`
+
+	// Reasons are why the project is likely related to the draft.
+	Reasons []string `json:"reasons"`
+}
+
+// priorArtCandidate is a search hit that may be prior art for a draft.
+type priorArtCandidate struct {
+	hit          SearchHit
+	contentMatch bool
+	semantic     bool
+}
+
+// DraftsPriorArtHandler returns existing documents and projects that are likely
+// related to a draft (by title similarity and content search), so users can
+// find prior art before creating a duplicate document.
+func DraftsPriorArtHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
+		// Using POST method to avoid logging the draft's details in server logs.
+		if r.Method != http.MethodPost {
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		// Decode and validate request.
+		var req DraftsPriorArtRequest
+		if err := decodeRequest(r, &req); err != nil {
+			respondError(w, r, srv.Logger, http.StatusBadRequest,
+				"Bad request", "error decoding prior art request", err)
+			return
+		}
+		req.Title = strings.TrimSpace(req.Title)
+		if req.Title == "" {
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request: title is required")
+			return
+		}
+
+		// Limit results to the resources an API token can read.
+		types := []string{searchTypeDocument, searchTypeProject}
+		if tok, ok := apitoken.FromContext(r.Context()); ok {
+			types = allowedSearchTypes(types, tok.ScopeList())
+		}
+
+		// Find documents that are semantically similar to the draft, if
+		// embeddings are enabled.
+		var sem *semanticSearch
+		if srv.Embeddings != nil && srv.Config.Embeddings != nil &&
+			contains(types, searchTypeDocument) {
+			embs, err := srv.Embeddings.Embed(r.Context(),
+				[]string{strings.TrimSpace(req.Title + "\n" + req.Summary)})
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error finding prior art", "error computing draft embedding", err)
+				return
+			}
+			sims, err := models.SearchDocumentEmbeddings(srv.DB, embs[0],
+				srv.Embeddings.Model(),
+				srv.Config.Embeddings.MaxDistanceOrDefault(), 0,
+				priorArtSearchHits)
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error finding prior art",
+					"error searching document embeddings", err)
+				return
+			}
+			sem = &semanticSearch{index: srv.AlgoSearch.Docs}
+			for _, s := range sims {
+				sem.docIDs = append(sem.docIDs, s.GoogleFileID)
+			}
+		}
+
+		idxs := map[string]searchIndex{}
+		if contains(types, searchTypeDocument) {
+			idxs[searchTypeDocument] = srv.AlgoSearch.Docs
+		}
+		if contains(types, searchTypeProject) {
+			idxs[searchTypeProject] = srv.AlgoSearch.Projects
+		}
+		resp, err := findPriorArt(srv.Context(), req, idxs, sem)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error finding prior art", "error finding prior art", err)
+			return
+		}
+
+		// Write response.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding prior art response",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+	})
+}
+
+// findPriorArt finds documents and projects in the indexes for each result
+// type (and documents that match semantically, if sem is not nil) that are
+// likely related to a draft. Searches are canceled when ctx is done.
+func findPriorArt(
+	ctx context.Context,
+	req DraftsPriorArtRequest,
+	idxs map[string]searchIndex,
+	sem *semanticSearch,
+) (*DraftsPriorArtResponse, error) {
+	candidates := map[string]*priorArtCandidate{}
+	var order []string
+	addHits := func(typ string, hits []SearchHit, semantic bool) {
+		for _, h := range hits {
+			k := typ + "/" + h.ObjectID
+			c, ok := candidates[k]
+			if !ok {
+				c = &priorArtCandidate{hit: h}
+				candidates[k] = c
+				order = append(order, k)
+			}
+			if semantic {
+				c.semantic = true
+			} else {
+				c.contentMatch = true
+			}
+		}
+	}
+
+	// Search by title and summary, ranking hits that match more words higher.
+	queries := []string{req.Title}
+	if s := strings.TrimSpace(req.Summary); s != "" {
+		queries = append(queries, s)
+	}
+	for _, typ := range []string{searchTypeDocument, searchTypeProject} {
+		idx, ok := idxs[typ]
+		if !ok {
+			continue
+		}
+		for _, q := range queries {
+			res, err := idx.Search(q,
+				opt.OptionalWords(strings.Fields(q)...),
+				opt.Page(0),
+				opt.HitsPerPage(priorArtSearchHits),
+				ctx,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("error searching %ss: %w", typ, err)
+			}
+			var hits []SearchHit
+			for i, h := range res.Hits {
+				hit, err := parseSearchHit(typ, i, h)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing %s search hit: %w",
+						typ, err)
+				}
+				hits = append(hits, hit)
+			}
+			addHits(typ, hits, false)
+		}
+	}
+
+	// Add documents that match semantically.
+	if sem != nil && len(sem.docIDs) > 0 {
+		hits, err := semanticSearchHits(ctx, SearchRequest{}, "", sem)
+		if err != nil {
+			return nil, err
+		}
+		addHits(searchTypeDocument, hits, true)
+	}
+
+	resp := &DraftsPriorArtResponse{
+		Documents: []priorArtDocument{},
+		Projects:  []priorArtProject{},
+	}
+	for _, k := range order {
+		c := candidates[k]
+		score, reasons := priorArtScore(req, *c)
+		if score < minPriorArtScore {
+			continue
+		}
+
+		switch c.hit.Type {
+		case searchTypeDocument:
+			owners := c.hit.Owners
+			if owners == nil {
+				owners = []string{}
+			}
+			resp.Documents = append(resp.Documents, priorArtDocument{
+				ID:        c.hit.ObjectID,
+				DocNumber: c.hit.DocNumber,
+				DocType:   c.hit.DocType,
+				Owners:    owners,
+				Product:   c.hit.Product,
+				Status:    c.hit.Status,
+				Title:     c.hit.Title,
+				Score:     score,
+				Reasons:   reasons,
+			})
+		case searchTypeProject:
+			id, err := strconv.Atoi(c.hit.ObjectID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid project ID %q: %w",
+					c.hit.ObjectID, err)
+			}
+			resp.Projects = append(resp.Projects, priorArtProject{
+				ID:      id,
+				Status:  c.hit.Status,
+				Title:   c.hit.Title,
+				Score:   score,
+				Reasons: reasons,
+			})
+		default:
+			return nil, errors.New("invalid result type")
+		}
+	}
+
+	sort.SliceStable(resp.Documents, func(i, j int) bool {
+		return resp.Documents[i].Score > resp.Documents[j].Score
+	})
+	sort.SliceStable(resp.Projects, func(i, j int) bool {
+		return resp.Projects[i].Score > resp.Projects[j].Score
+	})
+	if len(resp.Documents) > maxPriorArtResults {
+		resp.Documents = resp.Documents[:maxPriorArtResults]
+	}
+	if len(resp.Projects) > maxPriorArtResults {
+		resp.Projects = resp.Projects[:maxPriorArtResults]
+	}
+
+	return resp, nil
+}
+
+// priorArtScore returns how likely a candidate is related to a draft (between
+// 0 and 1), and the reasons why.
+func priorArtScore(
+	req DraftsPriorArtRequest, c priorArtCandidate) (float64, []string) {
+	reasons := []string{}
+	titleSim := titleSimilarity(req.Title, c.hit.Title)
+	score := 0.5 * titleSim
+	if titleSim >= similarTitleThreshold {
+		reasons = append(reasons, priorArtReasonSimilarTitle)
+	}
+	if c.contentMatch {
+		score += 0.25
+		reasons = append(reasons, priorArtReasonContentMatch)
+	}
+	if c.semantic {
+		score += 0.25
+		reasons = append(reasons, priorArtReasonSemanticMatch)
+	}
+	if req.Product != "" && strings.EqualFold(req.Product, c.hit.Product) {
+		score += 0.1
+		reasons = append(reasons, priorArtReasonSameProduct)
+	}
+	if score > 1 {
+		score = 1
+	}
+	return score, reasons
+}
+
+// titleSimilarity returns the similarity of two titles between 0 and 1, as
+// the Dice coefficient of the character trigrams of their words. Trigrams
+// match different forms of the same word (e.g., "deploy" and "deployments").
+func titleSimilarity(a, b string) float64 {
+	ta, tb := titleTrigrams(a), titleTrigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	var shared int
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(ta)+len(tb))
+}
+
+// titleTrigrams returns the set of character trigrams of the words in a title.
+func titleTrigrams(title string) map[string]bool {
+	res := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		rs := []rune("<" + w + ">")
+		for i := 0; i+3 <= len(rs); i++ {
+			res[string(rs[i:i+3])] = true
+		}
+	}
+	return res
+}
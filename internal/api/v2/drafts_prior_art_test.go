@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTitleSimilarity(t *testing.T) {
+	cases := map[string]struct {
+		a, b    string
+		wantMin float64
+		wantMax float64
+	}{
+		"identical": {
+			a:       "Deploying Services to Kubernetes",
+			b:       "deploying services to kubernetes!",
+			wantMin: 1,
+			wantMax: 1,
+		},
+		"different word forms": {
+			a:       "Service deployment strategy",
+			b:       "Strategies for deploying services",
+			wantMin: 0.5,
+			wantMax: 0.9,
+		},
+		"unrelated": {
+			a:       "Service deployment strategy",
+			b:       "Quarterly marketing budget",
+			wantMin: 0,
+			wantMax: 0.1,
+		},
+		"empty": {
+			a:       "Service deployment strategy",
+			b:       " - ",
+			wantMin: 0,
+			wantMax: 0,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got := titleSimilarity(c.a, c.b)
+			assert.GreaterOrEqual(got, c.wantMin)
+			assert.LessOrEqual(got, c.wantMax)
+			assert.Equal(got, titleSimilarity(c.b, c.a))
+		})
+	}
+}
+
+func TestFindPriorArt(t *testing.T) {
+	newIndexes := func() map[string]*fakeSearchIndex {
+		return map[string]*fakeSearchIndex{
+			searchTypeDocument: {res: search.QueryRes{
+				Hits: []map[string]interface{}{
+					{
+						"objectID":  "doc1",
+						"title":     "Kubernetes deployment strategy",
+						"docNumber": "ENG-123",
+						"docType":   "RFC",
+						"product":   "Engineering",
+						"status":    "Approved",
+						"owners":    []interface{}{"a@example.com"},
+					},
+					{
+						"objectID": "doc2",
+						"title":    "Quarterly marketing budget",
+						"docType":  "PRD",
+						"product":  "Marketing",
+						"status":   "In-Review",
+					},
+				},
+			}},
+			searchTypeProject: {res: search.QueryRes{
+				Hits: []map[string]interface{}{
+					{
+						"objectID": "7",
+						"title":    "Kubernetes deployments",
+						"status":   "active",
+					},
+				},
+			}},
+		}
+	}
+	indexes := func(idxs map[string]*fakeSearchIndex) map[string]searchIndex {
+		res := map[string]searchIndex{}
+		for typ, idx := range idxs {
+			res[typ] = idx
+		}
+		return res
+	}
+
+	t.Run("finds similar documents and projects", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		req := DraftsPriorArtRequest{
+			Title:   "Kubernetes deployment strategies",
+			Summary: "How we deploy services",
+			Product: "Engineering",
+		}
+		resp, err := findPriorArt(context.Background(),
+			req, indexes(newIndexes()), nil)
+		require.NoError(err)
+
+		// doc2 only matches a word of the query, so it isn't returned.
+		require.Len(resp.Documents, 1)
+		d := resp.Documents[0]
+		assert.Equal("doc1", d.ID)
+		assert.Equal("ENG-123", d.DocNumber)
+		assert.Equal([]string{"a@example.com"}, d.Owners)
+		assert.Equal([]string{
+			priorArtReasonSimilarTitle,
+			priorArtReasonContentMatch,
+			priorArtReasonSameProduct,
+		}, d.Reasons)
+		assert.Greater(d.Score, 0.7)
+		assert.LessOrEqual(d.Score, 1.0)
+
+		require.Len(resp.Projects, 1)
+		assert.Equal(7, resp.Projects[0].ID)
+		assert.Equal([]string{
+			priorArtReasonSimilarTitle,
+			priorArtReasonContentMatch,
+		}, resp.Projects[0].Reasons)
+	})
+
+	t.Run("adds semantic matches", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		idxs := newIndexes()
+		idxs[searchTypeDocument].res = search.QueryRes{}
+		semIdx := &fakeSearchIndex{res: search.QueryRes{
+			Hits: []map[string]interface{}{
+				{
+					"objectID": "doc3",
+					"title":    "Rolling out container workloads",
+					"product":  "Engineering",
+				},
+			},
+		}}
+		resp, err := findPriorArt(context.Background(),
+			DraftsPriorArtRequest{
+				Title:   "Kubernetes deployment strategies",
+				Product: "Engineering",
+			}, indexes(idxs), &semanticSearch{
+				index:  semIdx,
+				docIDs: []string{"doc3"},
+			})
+		require.NoError(err)
+
+		require.Len(resp.Documents, 1)
+		assert.Equal("doc3", resp.Documents[0].ID)
+		assert.Equal([]string{}, resp.Documents[0].Owners)
+		assert.Equal([]string{
+			priorArtReasonSemanticMatch,
+			priorArtReasonSameProduct,
+		}, resp.Documents[0].Reasons)
+		assert.Equal(`(objectID:"doc3")`, semIdx.filters)
+	})
+
+	t.Run("only searches indexes for allowed types", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		idxs := indexes(newIndexes())
+		delete(idxs, searchTypeDocument)
+		resp, err := findPriorArt(context.Background(),
+			DraftsPriorArtRequest{Title: "Kubernetes deployments"}, idxs, nil)
+		require.NoError(err)
+		assert.Empty(resp.Documents)
+		assert.NotNil(resp.Documents)
+		assert.Len(resp.Projects, 1)
+	})
+
+	t.Run("returns search errors", func(t *testing.T) {
+		require := require.New(t)
+
+		idxs := newIndexes()
+		idxs[searchTypeProject].err = errors.New("unavailable")
+		_, err := findPriorArt(context.Background(),
+			DraftsPriorArtRequest{Title: "Kubernetes"}, indexes(idxs), nil)
+		require.Error(err)
+	})
+}
@@ -45,8 +45,8 @@ func draftsShareableHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
@@ -63,7 +63,8 @@ func draftsShareableHandler(
 				"error", err,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error building response", http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error building response")
 			return
 		}
 
@@ -71,8 +72,8 @@ func draftsShareableHandler(
 		// Authorize request (only the document owner is authorized).
 		userEmail := r.Context().Value("userEmail").(string)
 		if doc.Owners[0] != userEmail {
-			http.Error(w, "Only the document owner can change shareable settings",
-				http.StatusForbidden)
+			writeError(w, r, http.StatusForbidden, ErrCodeNotDocumentOwner,
+				"Only the document owner can change shareable settings")
 			return
 		}
 
@@ -85,7 +86,7 @@ func draftsShareableHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Bad request", http.StatusBadRequest)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
 			return
 		}
 
@@ -96,9 +97,8 @@ func draftsShareableHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w,
-				"Bad request: missing required 'isShareable' field",
-				http.StatusBadRequest)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request: missing required 'isShareable' field")
 			return
 		}
 
@@ -113,8 +113,8 @@ func draftsShareableHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
@@ -127,9 +127,8 @@ func draftsShareableHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w,
-				"Error updating document permissions",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error updating document permissions")
 			return
 		}
 		alreadySharedPermIDs := []string{}
@@ -173,8 +172,8 @@ func draftsShareableHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error updating document draft",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error updating document draft")
 			return
 		}
 
@@ -186,7 +185,8 @@ func draftsShareableHandler(
 		)
 
 	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
 		return
 	}
 }
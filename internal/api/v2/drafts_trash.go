@@ -0,0 +1,196 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// trashedDraft is a draft in the trash.
+type trashedDraft struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	DocType     string `json:"docType"`
+	Product     string `json:"product"`
+	TrashedTime int64  `json:"trashedTime"`
+
+	// PurgeTime is the time that the draft will be permanently deleted, in Unix
+	// time.
+	PurgeTime int64 `json:"purgeTime"`
+}
+
+// DraftsTrashHandler lists the drafts in the trash that are owned by the user
+// and can still be restored.
+func DraftsTrashHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
+		if r.Method != http.MethodGet {
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		userEmail := r.Context().Value("userEmail").(string)
+
+		retention, err := srv.Config.DraftsTrashRetentionPeriod()
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting drafts in the trash",
+				"error getting drafts trash retention period", err)
+			return
+		}
+
+		docs, err := models.GetTrashedDocuments(srv.DB, userEmail, time.Now())
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting drafts in the trash",
+				"error getting trashed documents", err)
+			return
+		}
+
+		now := time.Now()
+		resp := []trashedDraft{}
+		for _, d := range docs {
+			purgeAt := d.TrashedAt.Add(retention)
+			// Drafts past the retention period are waiting to be purged.
+			if !purgeAt.After(now) {
+				continue
+			}
+			resp = append(resp, trashedDraft{
+				ID:          d.GoogleFileID,
+				Title:       d.Title,
+				DocType:     d.DocumentType.Name,
+				Product:     d.Product.Name,
+				TrashedTime: d.TrashedAt.Unix(),
+				PurgeTime:   purgeAt.Unix(),
+			})
+		}
+
+		// Write response.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding drafts trash response",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+	})
+}
+
+// draftsRestoreHandler restores a draft from the trash. Only the owner can
+// restore a draft, and only until its retention period has passed.
+func draftsRestoreHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	docID string,
+	doc document.Document,
+	model models.Document,
+	isOwner bool,
+	srv server.Server,
+) {
+	errResp := func(
+		httpCode int, userErrMsg, logErrMsg string, err error,
+		extraArgs ...interface{}) {
+		respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err,
+			append([]interface{}{"doc_id", docID}, extraArgs...)...)
+	}
+
+	if r.Method != http.MethodPost {
+		writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
+		return
+	}
+
+	// Authorize request.
+	if !isOwner {
+		writeError(w, r, http.StatusUnauthorized, ErrCodeNotDocumentOwner,
+			"Only owners can restore a draft document")
+		return
+	}
+
+	if model.TrashedAt == nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Draft is not in the trash")
+		return
+	}
+
+	// Drafts past the retention period are waiting to be purged.
+	retention, err := srv.Config.DraftsTrashRetentionPeriod()
+	if err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error restoring document draft",
+			"error getting drafts trash retention period", err)
+		return
+	}
+	if !model.TrashedAt.Add(retention).After(time.Now()) {
+		writeError(w, r, http.StatusNotFound, ErrCodeDraftNotFound,
+			"Draft not found")
+		return
+	}
+
+	// Move document back to the drafts folder.
+	if _, err := srv.GWService.MoveFile(
+		docID, srv.Config.GoogleWorkspace.DraftsFolder); err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error restoring document draft",
+			"error moving document to drafts folder", err)
+		return
+	}
+
+	// Mark document as restored in the database.
+	if err := model.Restore(srv.DB); err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error restoring document draft",
+			"error restoring document in database", err)
+		return
+	}
+
+	// Save document object in Algolia so the draft is listed again.
+	docObj, err := doc.ToAlgoliaObject(true)
+	if err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error restoring document draft",
+			"error converting document to Algolia object", err)
+		return
+	}
+	res, err := srv.AlgoWrite.Drafts.SaveObject(docObj, srv.Context())
+	if err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error restoring document draft",
+			"error saving draft doc in Algolia", err)
+		return
+	}
+	if err := res.Wait(srv.Context()); err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error restoring document draft",
+			"error saving draft doc in Algolia", err)
+		return
+	}
+
+	srv.Logger.Info("restored document draft from trash",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"doc_id", docID,
+	)
+
+	// Write response.
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&DraftsResponse{
+		ID: docID,
+	}); err != nil {
+		srv.Logger.Error("error encoding response",
+			"error", err,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"doc_id", docID,
+		)
+	}
+}
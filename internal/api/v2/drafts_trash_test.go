@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/internal/test"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftsRestoreHandler(t *testing.T) {
+	srv := server.Server{
+		Config: &config.Config{
+			DraftsTrash: &config.DraftsTrash{
+				RetentionPeriod: "720h",
+			},
+		},
+		Logger: hclog.NewNullLogger(),
+	}
+	expiredTrashedAt := time.Now().Add(-31 * 24 * time.Hour)
+
+	cases := map[string]struct {
+		method  string
+		isOwner bool
+		model   models.Document
+
+		wantCode    int
+		wantErrCode ErrorCode
+	}{
+		"method not allowed": {
+			method:      "GET",
+			isOwner:     true,
+			model:       models.Document{TrashedAt: &expiredTrashedAt},
+			wantCode:    http.StatusMethodNotAllowed,
+			wantErrCode: ErrCodeMethodNotAllowed,
+		},
+		"not an owner": {
+			method:      "POST",
+			isOwner:     false,
+			model:       models.Document{TrashedAt: &expiredTrashedAt},
+			wantCode:    http.StatusUnauthorized,
+			wantErrCode: ErrCodeNotDocumentOwner,
+		},
+		"not in the trash": {
+			method:      "POST",
+			isOwner:     true,
+			model:       models.Document{},
+			wantCode:    http.StatusBadRequest,
+			wantErrCode: ErrCodeBadRequest,
+		},
+		"past the retention period": {
+			method:      "POST",
+			isOwner:     true,
+			model:       models.Document{TrashedAt: &expiredTrashedAt},
+			wantCode:    http.StatusNotFound,
+			wantErrCode: ErrCodeDraftNotFound,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			r := httptest.NewRequest(c.method, "/api/v2/drafts/doc123/restore", nil)
+			w := httptest.NewRecorder()
+			draftsRestoreHandler(w, r, "doc123", document.Document{}, c.model,
+				c.isOwner, srv)
+
+			assert.Equal(c.wantCode, w.Code)
+			var resp ErrorResponse
+			require.NoError(json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(c.wantErrCode, resp.Code)
+		})
+	}
+}
+
+func TestDraftsTrashHandler(t *testing.T) {
+	t.Run("Method not allowed", func(t *testing.T) {
+		assert := assert.New(t)
+
+		srv := server.Server{
+			Config: &config.Config{},
+			Logger: hclog.NewNullLogger(),
+		}
+		r := httptest.NewRequest("POST", "/api/v2/drafts/trash", nil)
+		w := httptest.NewRecorder()
+		DraftsTrashHandler(srv).ServeHTTP(w, r)
+
+		assert.Equal(http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("List drafts in the trash", func(t *testing.T) {
+		dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+		if dsn == "" {
+			t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+		}
+		assert, require := assert.New(t), require.New(t)
+
+		db, _, err := test.CreateTestDatabase(t, dsn)
+		require.NoError(err)
+		require.NoError(db.Exec("CREATE EXTENSION IF NOT EXISTS citext;").Error)
+		require.NoError(db.AutoMigrate(models.ModelsToAutoMigrate()...))
+
+		dt := models.DocumentType{
+			Name:     "RFC",
+			LongName: "Request for Comments",
+		}
+		require.NoError(dt.FirstOrCreate(db))
+		p := models.Product{
+			Name:         "Terraform",
+			Abbreviation: "TF",
+		}
+		require.NoError(p.FirstOrCreate(db))
+
+		now := time.Now()
+		for _, d := range []struct {
+			id, owner string
+			trashedAt time.Time
+		}{
+			{"restorable", "owner@example.com", now.Add(-time.Hour)},
+			{"expired", "owner@example.com", now.Add(-31 * 24 * time.Hour)},
+			{"other-owner", "someone@example.com", now.Add(-time.Hour)},
+		} {
+			doc := models.Document{
+				GoogleFileID: d.id,
+				Title:        d.id,
+				DocumentType: models.DocumentType{Name: "RFC"},
+				Owner:        &models.User{EmailAddress: d.owner},
+				Product:      models.Product{Name: "Terraform"},
+				Status:       models.WIPDocumentStatus,
+			}
+			require.NoError(doc.Create(db))
+			require.NoError(doc.Trash(db, d.trashedAt))
+		}
+
+		srv := server.Server{
+			Config: &config.Config{},
+			DB:     db,
+			Logger: hclog.NewNullLogger(),
+		}
+		r := httptest.NewRequest("GET", "/api/v2/drafts/trash", nil)
+		r = r.WithContext(context.WithValue(
+			r.Context(), "userEmail", "owner@example.com"))
+		w := httptest.NewRecorder()
+		DraftsTrashHandler(srv).ServeHTTP(w, r)
+
+		require.Equal(http.StatusOK, w.Code)
+		var resp []trashedDraft
+		require.NoError(json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(resp, 1)
+		assert.Equal("restorable", resp[0].ID)
+		assert.Equal("RFC", resp[0].DocType)
+		assert.Equal("Terraform", resp[0].Product)
+		assert.InDelta(now.Add(-time.Hour).Add(30*24*time.Hour).Unix(),
+			resp[0].PurgeTime, 1)
+	})
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp-forge/hermes/internal/requestid"
+)
+
+// ErrorCode is a stable, machine-readable code that identifies the kind of
+// error in an API error response. Error codes must not be changed once added
+// because API consumers depend on them.
+type ErrorCode string
+
+const (
+	// ErrCodeBadRequest is used when a request is malformed or invalid.
+	ErrCodeBadRequest ErrorCode = "bad_request"
+
+	// ErrCodeUnauthorized is used when a request has no valid authentication.
+	ErrCodeUnauthorized ErrorCode = "unauthorized"
+
+	// ErrCodeForbidden is used when the user isn't allowed to perform the
+	// request.
+	ErrCodeForbidden ErrorCode = "forbidden"
+
+	// ErrCodeNotDocumentOwner is used when a request requires the user to be a
+	// document owner.
+	ErrCodeNotDocumentOwner ErrorCode = "not_document_owner"
+
+	// ErrCodeNotDocumentApprover is used when a request requires the user to be
+	// a document approver.
+	ErrCodeNotDocumentApprover ErrorCode = "not_document_approver"
+
+	// ErrCodeNotFound is used when the requested resource doesn't exist.
+	ErrCodeNotFound ErrorCode = "not_found"
+
+	// ErrCodeDocumentNotFound is used when the requested document doesn't exist.
+	ErrCodeDocumentNotFound ErrorCode = "document_not_found"
+
+	// ErrCodeDraftNotFound is used when the requested draft doesn't exist.
+	ErrCodeDraftNotFound ErrorCode = "draft_not_found"
+
+	// ErrCodeProjectNotFound is used when the requested project doesn't exist.
+	ErrCodeProjectNotFound ErrorCode = "project_not_found"
+
+	// ErrCodeJiraIssueNotFound is used when the requested Jira issue doesn't
+	// exist.
+	ErrCodeJiraIssueNotFound ErrorCode = "jira_issue_not_found"
+
+	// ErrCodeAPITokenNotFound is used when the requested API token doesn't
+	// exist.
+	ErrCodeAPITokenNotFound ErrorCode = "api_token_not_found"
+
+	// ErrCodeSavedSearchNotFound is used when the requested saved search
+	// doesn't exist.
+	ErrCodeSavedSearchNotFound ErrorCode = "saved_search_not_found"
+
+	// ErrCodeShortLinkNotFound is used when the requested short link doesn't
+	// exist.
+	ErrCodeShortLinkNotFound ErrorCode = "short_link_not_found"
+
+	// ErrCodeShortLinkExists is used when creating a short link with the path
+	// of another short link.
+	ErrCodeShortLinkExists ErrorCode = "short_link_exists"
+
+	// ErrCodeTagNotFound is used when the requested tag doesn't exist.
+	ErrCodeTagNotFound ErrorCode = "tag_not_found"
+
+	// ErrCodeTagExists is used when renaming a tag to the name of another tag.
+	ErrCodeTagExists ErrorCode = "tag_exists"
+
+	// ErrCodeMethodNotAllowed is used when the HTTP method isn't supported for
+	// the requested path.
+	ErrCodeMethodNotAllowed ErrorCode = "method_not_allowed"
+
+	// ErrCodeDocumentLocked is used when a document is locked and can't be
+	// modified.
+	ErrCodeDocumentLocked ErrorCode = "document_locked"
+
+	// ErrCodeInvalidDocumentStatus is used when a document's status doesn't
+	// allow the request.
+	ErrCodeInvalidDocumentStatus ErrorCode = "invalid_document_status"
+
+	// ErrCodeAlreadyApproved is used when the user has already approved a
+	// document.
+	ErrCodeAlreadyApproved ErrorCode = "already_approved"
+
+	// ErrCodeChangesAlreadyRequested is used when the user has already requested
+	// changes of a document.
+	ErrCodeChangesAlreadyRequested ErrorCode = "changes_already_requested"
+
+	// ErrCodeFeatureNotEnabled is used when a request requires a feature that
+	// isn't enabled.
+	ErrCodeFeatureNotEnabled ErrorCode = "feature_not_enabled"
+
+	// ErrCodeRateLimited is used when a user has made too many requests.
+	ErrCodeRateLimited ErrorCode = "rate_limited"
+
+	// ErrCodeInternal is used for unexpected server errors.
+	ErrCodeInternal ErrorCode = "internal_error"
+)
+
+// ErrorResponse is the response body for all API errors.
+type ErrorResponse struct {
+	// Code is the error code.
+	Code ErrorCode `json:"code"`
+
+	// Message is a human-readable error message.
+	Message string `json:"message"`
+
+	// Details contains additional information about the error, if any.
+	Details map[string]any `json:"details,omitempty"`
+
+	// RequestID is the ID of the request, which can be used to correlate the
+	// error with server logs.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// writeError writes an error response.
+func writeError(
+	w http.ResponseWriter, r *http.Request,
+	httpCode int, code ErrorCode, msg string,
+) {
+	writeErrorWithDetails(w, r, httpCode, code, msg, nil)
+}
+
+// writeErrorWithDetails writes an error response with additional details.
+func writeErrorWithDetails(
+	w http.ResponseWriter, r *http.Request,
+	httpCode int, code ErrorCode, msg string, details map[string]any,
+) {
+	resp := ErrorResponse{
+		Code:      code,
+		Message:   msg,
+		Details:   details,
+		RequestID: requestid.FromContext(r.Context()),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(httpCode)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// RateLimitedHandler returns a handler that writes the error response for rate
+// limited requests.
+func RateLimitedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, http.StatusTooManyRequests, ErrCodeRateLimited,
+			"Too many requests")
+	})
+}
+
+// errorCodeForStatus returns the default error code for an HTTP status code.
+func errorCodeForStatus(httpCode int) ErrorCode {
+	switch httpCode {
+	case http.StatusBadRequest:
+		return ErrCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrCodeForbidden
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrCodeMethodNotAllowed
+	case http.StatusLocked:
+		return ErrCodeDocumentLocked
+	case http.StatusTooManyRequests:
+		return ErrCodeRateLimited
+	default:
+		if httpCode >= 400 && httpCode < 500 {
+			return ErrCodeBadRequest
+		}
+		return ErrCodeInternal
+	}
+}
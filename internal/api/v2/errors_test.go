@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteError(t *testing.T) {
+	cases := map[string]struct {
+		requestID string
+		details   map[string]any
+
+		wantResp ErrorResponse
+	}{
+		"without request ID": {
+			wantResp: ErrorResponse{
+				Code:    ErrCodeDocumentLocked,
+				Message: "Document is locked",
+			},
+		},
+		"with request ID": {
+			requestID: "abc123",
+			wantResp: ErrorResponse{
+				Code:      ErrCodeDocumentLocked,
+				Message:   "Document is locked",
+				RequestID: "abc123",
+			},
+		},
+		"with details": {
+			requestID: "abc123",
+			details: map[string]any{
+				"field": "title",
+			},
+			wantResp: ErrorResponse{
+				Code:    ErrCodeDocumentLocked,
+				Message: "Document is locked",
+				Details: map[string]any{
+					"field": "title",
+				},
+				RequestID: "abc123",
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			r := httptest.NewRequest("GET", "/api/v2/documents/123", nil)
+			if c.requestID != "" {
+				r = r.WithContext(requestid.NewContext(r.Context(), c.requestID))
+			}
+			w := httptest.NewRecorder()
+
+			writeErrorWithDetails(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+				"Document is locked", c.details)
+
+			assert.Equal(http.StatusLocked, w.Code)
+			assert.Equal("application/json", w.Header().Get("Content-Type"))
+
+			var resp ErrorResponse
+			require.NoError(json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(c.wantResp, resp)
+		})
+	}
+}
+
+func TestRateLimitedHandler(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	r := httptest.NewRequest("GET", "/api/v2/people", nil)
+	r = r.WithContext(requestid.NewContext(r.Context(), "abc123"))
+	w := httptest.NewRecorder()
+
+	RateLimitedHandler().ServeHTTP(w, r)
+
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+	assert.Equal("application/json", w.Header().Get("Content-Type"))
+
+	var resp ErrorResponse
+	require.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(ErrorResponse{
+		Code:      ErrCodeRateLimited,
+		Message:   "Too many requests",
+		RequestID: "abc123",
+	}, resp)
+}
+
+func TestErrorCodeForStatus(t *testing.T) {
+	cases := map[int]ErrorCode{
+		http.StatusBadRequest:          ErrCodeBadRequest,
+		http.StatusUnauthorized:        ErrCodeUnauthorized,
+		http.StatusForbidden:           ErrCodeForbidden,
+		http.StatusNotFound:            ErrCodeNotFound,
+		http.StatusMethodNotAllowed:    ErrCodeMethodNotAllowed,
+		http.StatusLocked:              ErrCodeDocumentLocked,
+		http.StatusUnprocessableEntity: ErrCodeBadRequest,
+		http.StatusTooManyRequests:     ErrCodeRateLimited,
+		http.StatusInternalServerError: ErrCodeInternal,
+		http.StatusBadGateway:          ErrCodeInternal,
+	}
+
+	for httpCode, want := range cases {
+		assert.Equal(t, want, errorCodeForStatus(httpCode), httpCode)
+	}
+}
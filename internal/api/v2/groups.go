@@ -33,6 +33,8 @@ type GroupsPostResponseGroup struct {
 // GroupsHandler returns information about Google Groups.
 func GroupsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		logArgs := []any{
 			"method", r.Method,
 			"path", r.URL.Path,
@@ -42,16 +44,16 @@ func GroupsHandler(srv server.Server) http.Handler {
 		userEmail := r.Context().Value("userEmail").(string)
 		if userEmail == "" {
 			srv.Logger.Error("user email not found in request context", logArgs...)
-			http.Error(
-				w, "No authorization information in request", http.StatusUnauthorized)
+			writeError(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information in request")
 			return
 		}
 
 		// Respond with error if group approvals are not enabled.
 		if srv.Config.GoogleWorkspace.GroupApprovals == nil ||
 			!srv.Config.GoogleWorkspace.GroupApprovals.Enabled {
-			http.Error(w,
-				"Group approvals have not been enabled", http.StatusUnprocessableEntity)
+			writeError(w, r, http.StatusUnprocessableEntity, ErrCodeFeatureNotEnabled,
+				"Group approvals have not been enabled")
 			return
 		}
 
@@ -64,8 +66,8 @@ func GroupsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, fmt.Sprintf("Bad request: %q", err),
-					http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
 				return
 			}
 
@@ -95,14 +97,15 @@ func GroupsHandler(srv server.Server) http.Handler {
 					Domain(srv.Config.GoogleWorkspace.Domain).
 					MaxResults(maxPrefixGroupResults).
 					Query(fmt.Sprintf("email:%s*", prefixQuery)).
+					Context(srv.GWService.Context()).
 					Do()
 				if err != nil {
 					srv.Logger.Error("error searching groups with prefix",
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(w, fmt.Sprintf("Error searching groups: %q", err),
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						fmt.Sprintf("Error searching groups: %q", err))
 					return
 				}
 			}
@@ -112,14 +115,15 @@ func GroupsHandler(srv server.Server) http.Handler {
 				Domain(srv.Config.GoogleWorkspace.Domain).
 				MaxResults(int64(maxNonPrefixGroups)).
 				Query(fmt.Sprintf("email:%s*", query)).
+				Context(srv.GWService.Context()).
 				Do()
 			if err != nil {
 				srv.Logger.Error("error searching groups without prefix",
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, fmt.Sprintf("Error searching groups: %q", err),
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					fmt.Sprintf("Error searching groups: %q", err))
 				return
 			}
 
@@ -145,13 +149,14 @@ func GroupsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, "Error searching groups",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error searching groups")
 				return
 			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
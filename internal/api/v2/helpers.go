@@ -114,7 +114,7 @@ func respondError(
 			"path", r.URL.Path,
 		}, extraArgs...)...,
 	)
-	http.Error(w, userErrMsg, httpCode)
+	writeError(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
 }
 
 // fakeT fulfills the assert.TestingT interface so we can use
@@ -200,6 +200,11 @@ func CompareAlgoliaAndDatabaseDocument(
 			// we do when assigning a document number when a doc review is requested.
 			dbDocNumber = fmt.Sprintf(
 				"%s-%03d", dbDoc.Product.Abbreviation, dbDoc.DocumentNumber)
+			// Documents numbered with a numbering scheme store their formatted
+			// document number.
+			if dbDoc.DocumentNumber != 0 && dbDoc.FormattedDocumentNumber != "" {
+				dbDocNumber = dbDoc.FormattedDocumentNumber
+			}
 		}
 		if algoDocNumber != dbDocNumber {
 			// Some legacy documents may not have the three digit number padding so
@@ -538,6 +543,7 @@ func isUserInGroups(
 	// Get groups for user.
 	userGroups, err := svc.AdminDirectory.Groups.List().
 		UserKey(userEmail).
+		Context(svc.Context()).
 		Do()
 	if err != nil {
 		return false, fmt.Errorf("error getting groups for user: %w", err)
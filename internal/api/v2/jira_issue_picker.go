@@ -26,6 +26,8 @@ type JiraIssuePickerGetResponseIssue struct {
 // JiraIssuePickerHandler proxies Jira issue picker API requests.
 func JiraIssuePickerHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		log := srv.Logger
 		logArgs := []any{
 			"path", r.URL.Path,
@@ -35,16 +37,16 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 		userEmail := r.Context().Value("userEmail").(string)
 		if userEmail == "" {
 			log.Error("user email not found in request context", logArgs...)
-			http.Error(
-				w, "No authorization information for request", http.StatusUnauthorized)
+			writeError(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
 			return
 		}
 
 		// Respond with error if Jira is not enabled.
 		if srv.Jira == nil || srv.Config.Jira == nil || !srv.Config.Jira.Enabled {
 			log.Warn("Jira not enabled", logArgs...)
-			http.Error(
-				w, "Jira has not been enabled", http.StatusUnprocessableEntity)
+			writeError(w, r, http.StatusUnprocessableEntity, ErrCodeFeatureNotEnabled,
+				"Jira has not been enabled")
 			return
 		}
 
@@ -64,8 +66,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, "Error processing request",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
@@ -103,8 +105,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							"error", err,
 							"url", jiraGetIssueURL.String(),
 						}, logArgs...)...)
-					http.Error(w, "Error processing request",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 				defer resp.Body.Close()
@@ -116,8 +118,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(w, "Error processing request",
-							http.StatusInternalServerError)
+						writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 
@@ -127,8 +129,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(w, "Error processing request",
-							http.StatusInternalServerError)
+						writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 
@@ -169,8 +171,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(w, "Error processing request",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 				defer resp.Body.Close()
@@ -183,8 +185,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(w, "Error processing request",
-							http.StatusInternalServerError)
+						writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 
@@ -193,8 +195,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(w, "Error processing request",
-							http.StatusInternalServerError)
+						writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 				} else {
@@ -203,8 +205,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							"error", err,
 							"status_code", resp.StatusCode,
 						}, logArgs...)...)
-					http.Error(w, "Error processing request",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 			}
@@ -248,13 +250,14 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 						"error", err,
 					}, logArgs...)...,
 				)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -24,13 +24,15 @@ type MeGetResponse struct {
 
 func MeHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
 			srv.Logger.Error(logErrMsg,
 				"method", r.Method,
 				"path", r.URL.Path,
 				"error", err,
 			)
-			http.Error(w, userErrMsg, httpCode)
+			writeError(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
 		}
 
 		// Authorize request.
@@ -63,7 +65,7 @@ func MeHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 					}, extraArgs...)...,
 				)
-				http.Error(w, userErrMsg, httpCode)
+				writeError(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
 			}
 
 			ppl, err := srv.GWService.SearchPeople(
@@ -176,7 +178,8 @@ func MeHandler(srv server.Server) http.Handler {
 			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -18,6 +18,8 @@ type recentlyViewedDoc struct {
 
 func MeRecentlyViewedDocsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		errResp := func(
 			httpCode int, userErrMsg, logErrMsg string, err error,
 			extraArgs ...interface{}) {
@@ -91,6 +93,11 @@ func MeRecentlyViewedDocsHandler(srv server.Server) http.Handler {
 					continue
 				}
 
+				// Skip drafts in the trash.
+				if doc.TrashedAt != nil {
+					continue
+				}
+
 				isDraft := false
 				// The document is a draft if it's in WIP status and wasn't imported.
 				if doc.Status == models.WIPDocumentStatus && !doc.Imported {
@@ -119,7 +126,8 @@ func MeRecentlyViewedDocsHandler(srv server.Server) http.Handler {
 			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -15,6 +15,8 @@ type recentlyViewedProject struct {
 
 func MeRecentlyViewedProjectsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		errResp := func(
 			httpCode int, userErrMsg, logErrMsg string, err error,
 			extraArgs ...interface{}) {
@@ -89,7 +91,8 @@ func MeRecentlyViewedProjectsHandler(srv server.Server) http.Handler {
 			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -0,0 +1,362 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// MeSavedSearchesPostRequest is the request to create a saved search.
+type MeSavedSearchesPostRequest struct {
+	// Name is a name to identify the saved search.
+	Name string `json:"name"`
+
+	// Query is the search text.
+	Query string `json:"query"`
+
+	// DocTypes filters by document type (e.g., "RFC").
+	DocTypes []string `json:"docTypes"`
+
+	// Owners filters by owner email address.
+	Owners []string `json:"owners"`
+
+	// Products filters by product.
+	Products []string `json:"products"`
+
+	// Statuses filters by document status (e.g., "In-Review").
+	Statuses []string `json:"statuses"`
+
+	// AlertsEnabled enables emailing the user when a published document starts
+	// matching the saved search.
+	AlertsEnabled bool `json:"alertsEnabled"`
+}
+
+// MeSavedSearchPatchRequest is the request to update a saved search. Only
+// provided fields are updated.
+type MeSavedSearchPatchRequest struct {
+	Name          *string   `json:"name,omitempty"`
+	Query         *string   `json:"query,omitempty"`
+	DocTypes      *[]string `json:"docTypes,omitempty"`
+	Owners        *[]string `json:"owners,omitempty"`
+	Products      *[]string `json:"products,omitempty"`
+	Statuses      *[]string `json:"statuses,omitempty"`
+	AlertsEnabled *bool     `json:"alertsEnabled,omitempty"`
+}
+
+type savedSearch struct {
+	ID            uint     `json:"id"`
+	Name          string   `json:"name"`
+	Query         string   `json:"query"`
+	DocTypes      []string `json:"docTypes"`
+	Owners        []string `json:"owners"`
+	Products      []string `json:"products"`
+	Statuses      []string `json:"statuses"`
+	AlertsEnabled bool     `json:"alertsEnabled"`
+	CreatedTime   int64    `json:"createdTime"`
+	ModifiedTime  int64    `json:"modifiedTime"`
+}
+
+func newSavedSearch(s models.SavedSearch) savedSearch {
+	f := s.Filters.Data
+	res := savedSearch{
+		ID:            s.ID,
+		Name:          s.Name,
+		Query:         s.Query,
+		DocTypes:      f.DocTypes,
+		Owners:        f.Owners,
+		Products:      f.Products,
+		Statuses:      f.Statuses,
+		AlertsEnabled: s.AlertsEnabled,
+		CreatedTime:   s.CreatedAt.Unix(),
+		ModifiedTime:  s.UpdatedAt.Unix(),
+	}
+	if res.DocTypes == nil {
+		res.DocTypes = []string{}
+	}
+	if res.Owners == nil {
+		res.Owners = []string{}
+	}
+	if res.Products == nil {
+		res.Products = []string{}
+	}
+	if res.Statuses == nil {
+		res.Statuses = []string{}
+	}
+	return res
+}
+
+// MeSavedSearchesHandler manages the user's saved searches.
+func MeSavedSearchesHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
+		errResp := func(
+			httpCode int, userErrMsg, logErrMsg string, err error,
+			extraArgs ...interface{}) {
+			respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err,
+				extraArgs...)
+		}
+
+		// Authorize request.
+		userEmail := r.Context().Value("userEmail").(string)
+		if userEmail == "" {
+			errResp(
+				http.StatusUnauthorized,
+				"No authorization information for request",
+				"no user email found in request context",
+				nil,
+			)
+			return
+		}
+
+		// Parse saved search ID from the URL path, if provided.
+		var searchID uint
+		idStr := strings.Trim(
+			strings.TrimPrefix(r.URL.Path, "/api/v2/me/saved-searches"), "/")
+		if idStr != "" {
+			id, err := strconv.ParseUint(idStr, 10, 0)
+			if err != nil || id == 0 {
+				errResp(
+					http.StatusNotFound,
+					"Not found",
+					"error parsing saved search ID",
+					err,
+				)
+				return
+			}
+			searchID = uint(id)
+		}
+
+		writeSavedSearch := func(s models.SavedSearch) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(newSavedSearch(s)); err != nil {
+				srv.Logger.Error("error encoding saved search response",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+			}
+		}
+
+		// getSavedSearch gets the saved search from the URL path and writes an
+		// error response if it couldn't be found.
+		getSavedSearch := func() (*models.SavedSearch, bool) {
+			s := models.SavedSearch{
+				Model: gorm.Model{ID: searchID},
+				User: models.User{
+					EmailAddress: userEmail,
+				},
+			}
+			if err := s.Get(srv.DB); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					srv.Logger.Warn("saved search not found",
+						"saved_search_id", searchID,
+						"method", r.Method,
+						"path", r.URL.Path,
+					)
+					writeError(w, r, http.StatusNotFound, ErrCodeSavedSearchNotFound,
+						"Saved search not found")
+					return nil, false
+				}
+				errResp(
+					http.StatusInternalServerError,
+					"Error getting saved search",
+					"error getting saved search",
+					err,
+					"saved_search_id", searchID,
+				)
+				return nil, false
+			}
+			return &s, true
+		}
+
+		switch {
+		case r.Method == http.MethodGet && searchID == 0:
+			searches, err := models.GetUserSavedSearches(srv.DB, userEmail)
+			if err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error getting saved searches",
+					"error getting saved searches",
+					err,
+				)
+				return
+			}
+
+			res := []savedSearch{}
+			for _, s := range searches {
+				res = append(res, newSavedSearch(s))
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(res); err != nil {
+				srv.Logger.Error("error encoding saved searches response",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+			}
+
+		case r.Method == http.MethodPost && searchID == 0:
+			var req MeSavedSearchesPostRequest
+			if err := decodeRequest(r, &req); err != nil {
+				errResp(
+					http.StatusBadRequest,
+					"Bad request",
+					"error decoding request",
+					err,
+				)
+				return
+			}
+			if strings.TrimSpace(req.Name) == "" {
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: name is required")
+				return
+			}
+
+			s := models.SavedSearch{
+				Name:  req.Name,
+				Query: req.Query,
+				Filters: datatypes.JSONType[models.SavedSearchFilters]{
+					Data: models.SavedSearchFilters{
+						DocTypes: req.DocTypes,
+						Owners:   req.Owners,
+						Products: req.Products,
+						Statuses: req.Statuses,
+					},
+				},
+				AlertsEnabled: req.AlertsEnabled,
+				User: models.User{
+					EmailAddress: userEmail,
+				},
+			}
+			if err := s.Create(srv.DB); err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error creating saved search",
+					"error creating saved search in database",
+					err,
+				)
+				return
+			}
+
+			srv.Logger.Info("created saved search",
+				"saved_search_id", s.ID,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			writeSavedSearch(s)
+
+		case r.Method == http.MethodGet && searchID != 0:
+			s, ok := getSavedSearch()
+			if !ok {
+				return
+			}
+			writeSavedSearch(*s)
+
+		case r.Method == http.MethodPatch && searchID != 0:
+			var req MeSavedSearchPatchRequest
+			if err := decodeRequest(r, &req); err != nil {
+				errResp(
+					http.StatusBadRequest,
+					"Bad request",
+					"error decoding request",
+					err,
+				)
+				return
+			}
+			if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: name cannot be empty")
+				return
+			}
+
+			s, ok := getSavedSearch()
+			if !ok {
+				return
+			}
+			applySavedSearchPatch(s, req)
+			if err := s.Update(srv.DB); err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error updating saved search",
+					"error updating saved search",
+					err,
+					"saved_search_id", searchID,
+				)
+				return
+			}
+
+			srv.Logger.Info("updated saved search",
+				"saved_search_id", searchID,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			writeSavedSearch(*s)
+
+		case r.Method == http.MethodDelete && searchID != 0:
+			s, ok := getSavedSearch()
+			if !ok {
+				return
+			}
+			if err := s.Delete(srv.DB); err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error deleting saved search",
+					"error deleting saved search",
+					err,
+					"saved_search_id", searchID,
+				)
+				return
+			}
+
+			srv.Logger.Info("deleted saved search",
+				"saved_search_id", searchID,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			w.WriteHeader(http.StatusOK)
+
+		default:
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+	})
+}
+
+// applySavedSearchPatch applies the fields provided in a patch request to a
+// saved search.
+func applySavedSearchPatch(s *models.SavedSearch, req MeSavedSearchPatchRequest) {
+	if req.Name != nil {
+		s.Name = *req.Name
+	}
+	if req.Query != nil {
+		s.Query = *req.Query
+	}
+	f := &s.Filters.Data
+	if req.DocTypes != nil {
+		f.DocTypes = *req.DocTypes
+	}
+	if req.Owners != nil {
+		f.Owners = *req.Owners
+	}
+	if req.Products != nil {
+		f.Products = *req.Products
+	}
+	if req.Statuses != nil {
+		f.Statuses = *req.Statuses
+	}
+	if req.AlertsEnabled != nil {
+		s.AlertsEnabled = *req.AlertsEnabled
+	}
+}
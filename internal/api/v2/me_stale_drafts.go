@@ -0,0 +1,233 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// Actions for stale drafts.
+const (
+	staleDraftActionDelete  = "delete"
+	staleDraftActionKeep    = "keep"
+	staleDraftActionPublish = "publish"
+)
+
+// staleDraft is a draft that hasn't been modified within the stale drafts
+// threshold.
+type staleDraft struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	DocType string `json:"docType"`
+	Owner   string `json:"owner"`
+	Product string `json:"product"`
+
+	// ModifiedTime is the time the draft was last modified, in Unix time.
+	ModifiedTime int64 `json:"modifiedTime"`
+}
+
+// StaleDraftActionRequest is a request to take an action on a stale draft.
+type StaleDraftActionRequest struct {
+	// Token is the token from the latest email about the stale draft, when the
+	// action is confirmed from a link in the email.
+	Token string `json:"token,omitempty"`
+}
+
+func newStaleDraft(d models.Document) staleDraft {
+	res := staleDraft{
+		ID:           d.GoogleFileID,
+		Title:        d.Title,
+		DocType:      d.DocumentType.Name,
+		Product:      d.Product.Name,
+		ModifiedTime: d.DocumentModifiedAt.Unix(),
+	}
+	if d.Owner != nil {
+		res.Owner = d.Owner.EmailAddress
+	}
+	return res
+}
+
+// MeStaleDraftsHandler lists the user's stale drafts and handles actions to
+// keep, publish, or delete them. Links in stale draft emails open a web app page
+// that confirms the action and requests it with the token from the email,
+// which can only be used once.
+func MeStaleDraftsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
+		errResp := func(
+			httpCode int, userErrMsg, logErrMsg string, err error,
+			extraArgs ...interface{}) {
+			respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err,
+				extraArgs...)
+		}
+
+		// Authorize request.
+		userEmail := r.Context().Value("userEmail").(string)
+		if userEmail == "" {
+			errResp(
+				http.StatusUnauthorized,
+				"No authorization information for request",
+				"no user email found in request context",
+				nil,
+			)
+			return
+		}
+
+		threshold, err := srv.Config.StaleDraftsThreshold()
+		if err != nil {
+			errResp(http.StatusInternalServerError,
+				"Error getting stale drafts",
+				"error getting stale drafts threshold", err)
+			return
+		}
+
+		// Parse document ID and action from the URL path, if provided.
+		p := strings.Trim(
+			strings.TrimPrefix(r.URL.Path, "/api/v2/me/stale-drafts"), "/")
+		if p == "" {
+			if r.Method != http.MethodGet {
+				writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
+				return
+			}
+
+			docs, err := models.GetStaleDrafts(
+				srv.DB, userEmail, time.Now().Add(-threshold))
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error getting stale drafts",
+					"error getting stale drafts", err)
+				return
+			}
+
+			resp := []staleDraft{}
+			for _, d := range docs {
+				resp = append(resp, newStaleDraft(d))
+			}
+
+			// Write response.
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(resp); err != nil {
+				srv.Logger.Error("error encoding stale drafts response",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+			}
+			return
+		}
+
+		docID, action, ok := strings.Cut(p, "/")
+		if !ok || docID == "" || strings.Contains(action, "/") {
+			writeError(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		switch action {
+		case staleDraftActionDelete, staleDraftActionKeep, staleDraftActionPublish:
+		default:
+			writeError(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		if r.Method != http.MethodPost {
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		// Decode request.
+		var req StaleDraftActionRequest
+		if err := decodeRequest(r, &req); err != nil {
+			errResp(http.StatusBadRequest,
+				"Bad request",
+				"error decoding stale draft action request", err,
+				"doc_id", docID)
+			return
+		}
+
+		// Get document from database.
+		model := models.Document{
+			GoogleFileID: docID,
+		}
+		if err := model.Get(srv.DB); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeError(w, r, http.StatusNotFound, ErrCodeDraftNotFound,
+					"Draft not found")
+				return
+			}
+			errResp(http.StatusInternalServerError,
+				"Error getting document draft",
+				"error getting document from database", err,
+				"doc_id", docID)
+			return
+		}
+		if model.Status != models.WIPDocumentStatus || model.TrashedAt != nil {
+			writeError(w, r, http.StatusNotFound, ErrCodeDraftNotFound,
+				"Draft not found")
+			return
+		}
+		if model.Owner == nil || model.Owner.EmailAddress != userEmail {
+			writeError(w, r, http.StatusForbidden, ErrCodeNotDocumentOwner,
+				"Only owners can act on a stale draft")
+			return
+		}
+
+		// Actions confirmed from an email must have the token from the latest
+		// email, which is cleared so the link can't be used again.
+		if req.Token != "" {
+			n := models.StaleDraftNudge{
+				DocumentID: model.ID,
+			}
+			ok, err := n.UseToken(srv.DB, req.Token)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error getting document draft",
+					"error using stale draft nudge token", err,
+					"doc_id", docID)
+				return
+			}
+			if !ok {
+				writeError(w, r, http.StatusForbidden, ErrCodeForbidden,
+					"Invalid or expired link")
+				return
+			}
+		}
+
+		switch action {
+		case staleDraftActionDelete:
+			if _, err := deleteDraft(srv, docID); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error deleting document draft",
+					"error deleting stale draft", err,
+					"doc_id", docID)
+				return
+			}
+
+		case staleDraftActionKeep, staleDraftActionPublish:
+			// Publishing happens in the web app, so keep the draft until then.
+			if err := model.KeepStaleDraft(srv.DB, time.Now()); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error keeping document draft",
+					"error keeping stale draft", err,
+					"doc_id", docID)
+				return
+			}
+		}
+
+		srv.Logger.Info("acted on stale draft",
+			"action", action,
+			"doc_id", docID,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -14,13 +14,15 @@ type MeSubscriptionsPostRequest struct {
 
 func MeSubscriptionsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
 			srv.Logger.Error(logErrMsg,
 				"method", r.Method,
 				"path", r.URL.Path,
 				"error", err,
 			)
-			http.Error(w, userErrMsg, httpCode)
+			writeError(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
 		}
 
 		// Authorize request.
@@ -120,7 +122,8 @@ func MeSubscriptionsHandler(srv server.Server) http.Handler {
 			w.WriteHeader(http.StatusOK)
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// MeTagSubscriptionsPostRequest is the request to replace the user's tag
+// subscriptions.
+type MeTagSubscriptionsPostRequest struct {
+	Subscriptions []string `json:"subscriptions"`
+}
+
+// MeTagSubscriptionsHandler manages the tags that the user is subscribed to.
+// Subscribers are emailed when documents with the tags are published, like
+// product subscribers.
+func MeTagSubscriptionsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
+		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
+			srv.Logger.Error(logErrMsg,
+				"method", r.Method,
+				"path", r.URL.Path,
+				"error", err,
+			)
+			writeError(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
+		}
+
+		// Authorize request.
+		userEmail := r.Context().Value("userEmail").(string)
+		if userEmail == "" {
+			errResp(
+				http.StatusUnauthorized,
+				"No authorization information for request",
+				"no user email found in request context",
+				nil,
+			)
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			// Find or create user.
+			u := models.User{
+				EmailAddress: userEmail,
+			}
+			if err := u.FirstOrCreate(srv.DB); err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error authorizing the request",
+					"error finding or creating user",
+					err,
+				)
+				return
+			}
+
+			// Build response of tag subscriptions.
+			tags := []string{}
+			for _, t := range u.TagSubscriptions {
+				tags = append(tags, t.Name)
+			}
+
+			// Write response.
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			enc := json.NewEncoder(w)
+			if err := enc.Encode(tags); err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error finding tag subscriptions",
+					"error encoding tags to JSON",
+					err,
+				)
+				return
+			}
+
+		case "POST":
+			// Decode request.
+			var req MeTagSubscriptionsPostRequest
+			if err := decodeRequest(r, &req); err != nil {
+				errResp(
+					http.StatusBadRequest,
+					"Bad request",
+					"error decoding request",
+					err,
+				)
+				return
+			}
+			tags, err := models.NormalizeTagNames(req.Subscriptions)
+			if err != nil {
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+				return
+			}
+
+			// Replace user tag subscriptions.
+			u := models.User{
+				EmailAddress: userEmail,
+			}
+			if err := u.ReplaceTagSubscriptions(srv.DB, tags); err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error updating user subscriptions",
+					"error replacing user tag subscriptions",
+					err,
+				)
+				return
+			}
+
+			// Write response.
+			w.WriteHeader(http.StatusOK)
+
+		default:
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+	})
+}
@@ -0,0 +1,338 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/auth/apitoken"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// MeTokensPostRequest is the request to create an API token.
+type MeTokensPostRequest struct {
+	// Name is a name to identify the token.
+	Name string `json:"name"`
+
+	// Scopes are the scopes (e.g., "documents:read") granted to the token.
+	Scopes []string `json:"scopes"`
+
+	// ExpiresTime is the time (Unix seconds) that the token expires. Defaults to
+	// the configured default token lifetime.
+	ExpiresTime *int64 `json:"expiresTime,omitempty"`
+
+	// ServiceAccount is the name of the service account to create the token for.
+	// If not set, a personal access token is created.
+	ServiceAccount *string `json:"serviceAccount,omitempty"`
+}
+
+// MeTokensPostResponse is the response to creating an API token. It is the
+// only time that the token is returned.
+type MeTokensPostResponse struct {
+	apiToken
+
+	// Token is the API token.
+	Token string `json:"token"`
+}
+
+type apiToken struct {
+	ID             uint     `json:"id"`
+	Name           string   `json:"name"`
+	Kind           string   `json:"kind"`
+	ServiceAccount string   `json:"serviceAccount,omitempty"`
+	Scopes         []string `json:"scopes"`
+	TokenPrefix    string   `json:"tokenPrefix"`
+	CreatedTime    int64    `json:"createdTime"`
+	ExpiresTime    int64    `json:"expiresTime"`
+	LastUsedTime   *int64   `json:"lastUsedTime,omitempty"`
+	RevokedTime    *int64   `json:"revokedTime,omitempty"`
+}
+
+func newAPIToken(t models.APIToken) apiToken {
+	res := apiToken{
+		ID:             t.ID,
+		Name:           t.Name,
+		Kind:           string(t.Kind),
+		ServiceAccount: t.ServiceAccount,
+		Scopes:         t.ScopeList(),
+		TokenPrefix:    t.TokenPrefix,
+		CreatedTime:    t.CreatedAt.Unix(),
+		ExpiresTime:    t.ExpiresAt.Unix(),
+	}
+	if t.LastUsedAt != nil {
+		lu := t.LastUsedAt.Unix()
+		res.LastUsedTime = &lu
+	}
+	if t.RevokedAt != nil {
+		rt := t.RevokedAt.Unix()
+		res.RevokedTime = &rt
+	}
+	return res
+}
+
+// MeTokensHandler manages the user's API tokens (personal access tokens and
+// tokens for service accounts that the user owns).
+func MeTokensHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
+		errResp := func(
+			httpCode int, userErrMsg, logErrMsg string, err error,
+			extraArgs ...interface{}) {
+			respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err,
+				extraArgs...)
+		}
+
+		// Authorize request.
+		userEmail := r.Context().Value("userEmail").(string)
+		if userEmail == "" {
+			errResp(
+				http.StatusUnauthorized,
+				"No authorization information for request",
+				"no user email found in request context",
+				nil,
+			)
+			return
+		}
+
+		// API tokens can't be used to manage API tokens.
+		if _, ok := apitoken.FromContext(r.Context()); ok {
+			srv.Logger.Warn("attempted to manage API tokens using an API token",
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			writeError(w, r, http.StatusForbidden, ErrCodeForbidden,
+				"API tokens cannot be used to manage API tokens")
+			return
+		}
+
+		// Parse token ID from the URL path, if provided.
+		var tokenID uint
+		idStr := strings.Trim(
+			strings.TrimPrefix(r.URL.Path, "/api/v2/me/tokens"), "/")
+		if idStr != "" {
+			id, err := strconv.ParseUint(idStr, 10, 0)
+			if err != nil || id == 0 {
+				errResp(
+					http.StatusNotFound,
+					"Not found",
+					"error parsing API token ID",
+					err,
+				)
+				return
+			}
+			tokenID = uint(id)
+		}
+
+		switch {
+		case r.Method == http.MethodGet && tokenID == 0:
+			tokens, err := models.GetUserAPITokens(srv.DB, userEmail)
+			if err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error getting API tokens",
+					"error getting API tokens",
+					err,
+				)
+				return
+			}
+
+			res := []apiToken{}
+			for _, t := range tokens {
+				res = append(res, newAPIToken(t))
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(res); err != nil {
+				srv.Logger.Error("error encoding API tokens response",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+			}
+
+		case r.Method == http.MethodPost && tokenID == 0:
+			var req MeTokensPostRequest
+			if err := decodeRequest(r, &req); err != nil {
+				errResp(
+					http.StatusBadRequest,
+					"Bad request",
+					"error decoding request",
+					err,
+				)
+				return
+			}
+
+			t, err := newAPITokenFromRequest(srv, userEmail, req)
+			if err != nil {
+				srv.Logger.Warn("invalid API token request",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+				return
+			}
+
+			tok, hash, prefix, err := apitoken.Generate(t.Kind)
+			if err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error creating API token",
+					"error generating API token",
+					err,
+				)
+				return
+			}
+			t.TokenHash = hash
+			t.TokenPrefix = prefix
+
+			if err := t.Create(srv.DB); err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error creating API token",
+					"error creating API token in database",
+					err,
+				)
+				return
+			}
+
+			srv.Logger.Info("created API token",
+				"api_token_id", t.ID,
+				"kind", t.Kind,
+				"service_account", t.ServiceAccount,
+				"scopes", t.Scopes,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(MeTokensPostResponse{
+				apiToken: newAPIToken(*t),
+				Token:    tok,
+			}); err != nil {
+				srv.Logger.Error("error encoding API token response",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+			}
+
+		case r.Method == http.MethodDelete && tokenID != 0:
+			t := models.APIToken{
+				Model: gorm.Model{ID: tokenID},
+				User: models.User{
+					EmailAddress: userEmail,
+				},
+			}
+			if err := t.Revoke(srv.DB); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					srv.Logger.Warn("API token not found",
+						"api_token_id", tokenID,
+						"method", r.Method,
+						"path", r.URL.Path,
+					)
+					writeError(w, r, http.StatusNotFound, ErrCodeAPITokenNotFound,
+						"API token not found")
+					return
+				}
+				errResp(
+					http.StatusInternalServerError,
+					"Error revoking API token",
+					"error revoking API token",
+					err,
+					"api_token_id", tokenID,
+				)
+				return
+			}
+
+			srv.Logger.Info("revoked API token",
+				"api_token_id", tokenID,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			w.WriteHeader(http.StatusOK)
+
+		default:
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+	})
+}
+
+// newAPITokenFromRequest validates a request to create an API token and
+// returns the token to create (without the token hash).
+func newAPITokenFromRequest(
+	srv server.Server, userEmail string, req MeTokensPostRequest,
+) (*models.APIToken, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if err := apitoken.ValidateScopes(req.Scopes); err != nil {
+		return nil, err
+	}
+
+	t := &models.APIToken{
+		Name:   req.Name,
+		Kind:   models.PersonalAPITokenKind,
+		Scopes: strings.Join(req.Scopes, " "),
+		User: models.User{
+			EmailAddress: userEmail,
+		},
+	}
+
+	// Service account tokens can only be created by owners of the service
+	// account.
+	if req.ServiceAccount != nil {
+		sa := srv.Config.ServiceAccount(*req.ServiceAccount)
+		if sa == nil {
+			return nil, fmt.Errorf("unknown service account %q",
+				*req.ServiceAccount)
+		}
+		isOwner := false
+		for _, o := range sa.Owners {
+			if strings.EqualFold(o, userEmail) {
+				isOwner = true
+				break
+			}
+		}
+		if !isOwner {
+			return nil, fmt.Errorf("not an owner of service account %q", sa.Name)
+		}
+		t.Kind = models.ServiceAccountAPITokenKind
+		t.ServiceAccount = sa.Name
+	}
+
+	// Set expiration.
+	defaultTTL, err := srv.Config.APITokenDefaultTTL()
+	if err != nil {
+		return nil, err
+	}
+	maxTTL, err := srv.Config.APITokenMaxTTL()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	t.ExpiresAt = now.Add(defaultTTL)
+	if req.ExpiresTime != nil {
+		t.ExpiresAt = time.Unix(*req.ExpiresTime, 0).UTC()
+	}
+	if !t.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("expiration must be in the future")
+	}
+	if t.ExpiresAt.After(now.Add(maxTTL)) {
+		return nil, fmt.Errorf("expiration must be within %s", maxTTL)
+	}
+
+	return t, nil
+}
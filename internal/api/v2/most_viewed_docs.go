@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultMostViewedDocsLimit is the default number of most viewed documents
+	// returned.
+	defaultMostViewedDocsLimit = 10
+
+	// maxMostViewedDocsLimit is the maximum number of most viewed documents
+	// returned.
+	maxMostViewedDocsLimit = 50
+)
+
+type mostViewedDoc struct {
+	ID            string `json:"id"`
+	DocNumber     string `json:"docNumber"`
+	DocType       string `json:"docType"`
+	Product       string `json:"product"`
+	Title         string `json:"title"`
+	Views         int64  `json:"views"`
+	UniqueViewers int64  `json:"uniqueViewers"`
+}
+
+// MostViewedDocsHandler returns the most viewed published documents of the last
+// week.
+func MostViewedDocsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
+		errResp := func(
+			httpCode int, userErrMsg, logErrMsg string, err error,
+			extraArgs ...interface{}) {
+			respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err,
+				extraArgs...)
+		}
+
+		switch r.Method {
+		case "GET":
+			// Parse limit from query string.
+			limit := defaultMostViewedDocsLimit
+			if l := r.URL.Query().Get("limit"); l != "" {
+				var err error
+				limit, err = strconv.Atoi(l)
+				if err != nil || limit < 1 || limit > maxMostViewedDocsLimit {
+					errResp(
+						http.StatusBadRequest,
+						fmt.Sprintf(
+							"Bad request: limit must be between 1 and %d",
+							maxMostViewedDocsLimit),
+						"invalid limit query parameter",
+						err,
+						"limit", l,
+					)
+					return
+				}
+			}
+
+			weekAgo := time.Now().UTC().AddDate(0, 0, -7)
+			// Get extra results because drafts are filtered out below.
+			counts, err := models.GetMostViewedDocuments(srv.DB, weekAgo, limit*2)
+			if err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error finding most viewed documents",
+					"error getting most viewed documents from database",
+					err,
+				)
+				return
+			}
+
+			res := []mostViewedDoc{}
+			for _, c := range counts {
+				if len(res) == limit {
+					break
+				}
+
+				doc := models.Document{
+					Model: gorm.Model{
+						ID: c.DocumentID,
+					},
+				}
+				if err := doc.Get(srv.DB); err != nil {
+					if !errors.Is(err, gorm.ErrRecordNotFound) {
+						srv.Logger.Error("error getting document in database",
+							"error", err,
+							"method", r.Method,
+							"path", r.URL.Path,
+							"document_db_id", c.DocumentID,
+						)
+					}
+					continue
+				}
+
+				// Don't include drafts.
+				if doc.Status == models.WIPDocumentStatus && !doc.Imported {
+					continue
+				}
+
+				res = append(res, mostViewedDoc{
+					ID:            doc.GoogleFileID,
+					DocNumber:     doc.DocNumber(),
+					DocType:       doc.DocumentType.Name,
+					Product:       doc.Product.Name,
+					Title:         doc.Title,
+					Views:         c.Views,
+					UniqueViewers: c.UniqueViewers,
+				})
+			}
+
+			// Write response.
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			enc := json.NewEncoder(w)
+			if err := enc.Encode(res); err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error finding most viewed documents",
+					"error encoding response to JSON",
+					err,
+				)
+				return
+			}
+
+		default:
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+	})
+}
@@ -0,0 +1,296 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/structs"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref"`
+	Type                 string                    `json:"type"`
+	Properties           map[string]*openAPISchema `json:"properties"`
+	Items                *openAPISchema            `json:"items"`
+	AdditionalProperties json.RawMessage           `json:"additionalProperties"`
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+func parseOpenAPISpec(t *testing.T) (openAPIDocument, map[string]any) {
+	t.Helper()
+
+	var spec openAPIDocument
+	require.NoError(t, json.Unmarshal(OpenAPISpec(), &spec))
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(OpenAPISpec(), &raw))
+	return spec, raw
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	spec, raw := parseOpenAPISpec(t)
+	assert.True(strings.HasPrefix(spec.OpenAPI, "3.0."))
+	require.NotEmpty(spec.Paths)
+
+	// Every reference must resolve.
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, vv := range v {
+				if k == "$ref" {
+					ref := vv.(string)
+					require.True(strings.HasPrefix(ref, "#/"), ref)
+					var cur any = raw
+					for _, p := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+						m, ok := cur.(map[string]any)
+						require.True(ok, "unresolved reference %q", ref)
+						cur, ok = m[p]
+						require.True(ok, "unresolved reference %q", ref)
+					}
+					continue
+				}
+				walk(vv)
+			}
+		case []any:
+			for _, vv := range v {
+				walk(vv)
+			}
+		}
+	}
+	walk(raw)
+
+	// Every operation must have a unique ID and responses.
+	opIDs := map[string]bool{}
+	for path, item := range spec.Paths {
+		require.True(strings.HasPrefix(path, "/api/v2/"), path)
+		for method, rawOp := range item {
+			if method == "parameters" {
+				continue
+			}
+			var op struct {
+				OperationID string                     `json:"operationId"`
+				Responses   map[string]json.RawMessage `json:"responses"`
+			}
+			require.NoError(json.Unmarshal(rawOp, &op))
+			assert.NotEmpty(op.OperationID, "%s %s", method, path)
+			assert.NotEmpty(op.Responses, "%s %s", method, path)
+			assert.False(opIDs[op.OperationID],
+				"duplicate operation ID %q", op.OperationID)
+			opIDs[op.OperationID] = true
+		}
+	}
+}
+
+// TestOpenAPISchemasMatchTypes verifies that the schemas in the OpenAPI
+// specification have the same properties as the Go types that are used by the
+// API handlers.
+func TestOpenAPISchemasMatchTypes(t *testing.T) {
+	spec, _ := parseOpenAPISpec(t)
+
+	cases := map[string]any{
+		"APIToken":                       apiToken{},
+		"AnalyticsRequest":               AnalyticsRequest{},
+		"AnalyticsResponse":              AnalyticsResponse{},
+		"CustomField":                    document.CustomField{},
+		"Document":                       document.Document{},
+		"DocumentAnalytics":              documentAnalyticsResponse{},
+		"DocumentPatchRequest":           DocumentPatchRequest{},
+		"DocumentMovePostRequest":        DocumentMovePostRequest{},
+		"DocumentMoveResponse":           DocumentMoveResponse{},
+		"DocumentRetentionPutRequest":    DocumentRetentionPutRequest{},
+		"DocumentRetentionResponse":      DocumentRetentionResponse{},
+		"DocumentType":                   config.DocumentType{},
+		"DocumentTypeCheck":              config.DocumentTypeCheck{},
+		"DocumentTypeCustomField":        config.DocumentTypeCustomField{},
+		"DocumentTypeLink":               config.DocumentTypeLink{},
+		"DraftShareable":                 draftsShareableGetResponse{},
+		"DraftsPatchRequest":             DraftsPatchRequest{},
+		"DraftsPriorArtRequest":          DraftsPriorArtRequest{},
+		"DraftsPriorArtResponse":         DraftsPriorArtResponse{},
+		"DraftsRequest":                  DraftsRequest{},
+		"DraftsResponse":                 DraftsResponse{},
+		"ErrorResponse":                  ErrorResponse{},
+		"ExternalLink":                   externalLinkRelatedResourceGetResponse{},
+		"Group":                          GroupsPostResponseGroup{},
+		"GroupsPostRequest":              GroupsPostRequest{},
+		"JiraIssue":                      JiraIssueGetResponse{},
+		"JiraIssuePickerIssue":           JiraIssuePickerGetResponseIssue{},
+		"Me":                             MeGetResponse{},
+		"MeSavedSearchPatchRequest":      MeSavedSearchPatchRequest{},
+		"MeSavedSearchesPostRequest":     MeSavedSearchesPostRequest{},
+		"MeSubscriptionsPostRequest":     MeSubscriptionsPostRequest{},
+		"MeTokensPostRequest":            MeTokensPostRequest{},
+		"MeTokensPostResponse":           MeTokensPostResponse{},
+		"MostViewedDoc":                  mostViewedDoc{},
+		"PeopleDataRequest":              PeopleDataRequest{},
+		"ProductData":                    structs.ProductData{},
+		"ProductDocTypeData":             structs.ProductDocTypeData{},
+		"PriorArtDocument":               priorArtDocument{},
+		"PriorArtProject":                priorArtProject{},
+		"Project":                        project{},
+		"ProjectPatchRequest":            ProjectPatchRequest{},
+		"ProjectRelatedHermesDocument":   ProjectRelatedResourcesGetResponseHermesDocument{},
+		"ProjectRelatedResources":        ProjectRelatedResourcesGetResponse{},
+		"ProjectsGetResponse":            ProjectsGetResponse{},
+		"ProjectsPostRequest":            ProjectsPostRequest{},
+		"ProjectsPostResponse":           ProjectsPostResponse{},
+		"RecentlyViewedDoc":              recentlyViewedDoc{},
+		"RecentlyViewedProject":          recentlyViewedProject{},
+		"RelatedHermesDocument":          hermesDocumentRelatedResourceGetResponse{},
+		"RelatedHermesDocumentReference": hermesDocumentRelatedResourcePutRequest{},
+		"RelatedResources":               relatedResourcesGetResponse{},
+		"RelatedResourcesPutRequest":     relatedResourcesPutRequest{},
+		"RetentionNotice":                retentionNotice{},
+		"SavedSearch":                    savedSearch{},
+		"SearchHit":                      SearchHit{},
+		"SearchHitSection":               SearchHitSection{},
+		"ShortLink":                      shortLink{},
+		"ShortLinkPostRequest":           ShortLinkPostRequest{},
+		"SimilarDocument":                similarDocument{},
+		"SearchRequest":                  SearchRequest{},
+		"SearchResponse":                 SearchResponse{},
+		"StaleDraft":                     staleDraft{},
+		"StaleDraftActionRequest":        StaleDraftActionRequest{},
+		"StaleDraftsProduct":             staleDraftsProduct{},
+		"StaleDraftsResponse":            StaleDraftsResponse{},
+		"TrashedDraft":                   trashedDraft{},
+	}
+
+	for name, v := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			schema, ok := spec.Components.Schemas[name]
+			require.True(ok, "schema not found")
+
+			fields := jsonFields(reflect.TypeOf(v))
+			var got, want []string
+			for p := range schema.Properties {
+				got = append(got, p)
+			}
+			for f := range fields {
+				want = append(want, f)
+			}
+			sort.Strings(got)
+			sort.Strings(want)
+			require.Equal(want, got, "schema properties")
+
+			for f, ft := range fields {
+				assert.Equal(openAPIType(ft), schemaType(spec, schema.Properties[f]),
+					"type of property %q", f)
+			}
+		})
+	}
+}
+
+// jsonFields returns the JSON field names and types of a struct type,
+// including fields of embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && tag == "" {
+			for k, v := range jsonFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// openAPIType returns the OpenAPI type for a Go type, or an empty string if
+// the type can have any value.
+func openAPIType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return ""
+	}
+}
+
+// schemaType returns the type of a schema, resolving references.
+func schemaType(spec openAPIDocument, s *openAPISchema) string {
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		return schemaType(spec, spec.Components.Schemas[name])
+	}
+	return s.Type
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	cases := map[string]struct {
+		method   string
+		wantCode int
+	}{
+		"GET": {
+			method:   http.MethodGet,
+			wantCode: http.StatusOK,
+		},
+		"POST": {
+			method:   http.MethodPost,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := httptest.NewRequest(c.method, "/api/v2/openapi.json", nil)
+			w := httptest.NewRecorder()
+			OpenAPIHandler().ServeHTTP(w, r)
+
+			assert.Equal(c.wantCode, w.Code)
+			assert.Equal("application/json", w.Header().Get("Content-Type"))
+			if c.wantCode == http.StatusOK {
+				assert.JSONEq(string(OpenAPISpec()), w.Body.String())
+			}
+		})
+	}
+}
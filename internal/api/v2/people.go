@@ -20,6 +20,8 @@ type PeopleDataRequest struct {
 // to the Hermes frontend.
 func PeopleDataHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		req := &PeopleDataRequest{}
 		switch r.Method {
 		// Using POST method to avoid logging the query in browser history
@@ -27,8 +29,8 @@ func PeopleDataHandler(srv server.Server) http.Handler {
 		case "POST":
 			if err := decodeRequest(r, &req); err != nil {
 				srv.Logger.Error("error decoding people request", "error", err)
-				http.Error(w, fmt.Sprintf("Bad request: %q", err),
-					http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
 				return
 			}
 
@@ -39,11 +41,12 @@ func PeopleDataHandler(srv server.Server) http.Handler {
 				// in the future
 				ReadMask("emailAddresses,names,photos").
 				Sources("DIRECTORY_SOURCE_TYPE_DOMAIN_PROFILE").
+				Context(srv.GWService.Context()).
 				Do()
 			if err != nil {
 				srv.Logger.Error("error searching people directory", "error", err)
-				http.Error(w, fmt.Sprintf("Error searching people directory: %q", err),
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					fmt.Sprintf("Error searching people directory: %q", err))
 				return
 			}
 
@@ -55,8 +58,8 @@ func PeopleDataHandler(srv server.Server) http.Handler {
 			err = enc.Encode(users.People)
 			if err != nil {
 				srv.Logger.Error("error encoding people response", "error", err)
-				http.Error(w, "Error searching people directory",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error searching people directory")
 				return
 			}
 		case "GET":
@@ -64,9 +67,8 @@ func PeopleDataHandler(srv server.Server) http.Handler {
 			if len(query["emails"]) != 1 {
 				srv.Logger.Error(
 					"attempted to get users without providing any email addresses")
-				http.Error(w,
-					"Attempted to get users without providing a single value for the emails query parameter.",
-					http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Attempted to get users without providing a single value for the emails query parameter.")
 			} else {
 				emails := strings.Split(query["emails"][0], ",")
 				var people []*people.Person
@@ -76,6 +78,7 @@ func PeopleDataHandler(srv server.Server) http.Handler {
 						Query(email).
 						ReadMask("emailAddresses,names,photos").
 						Sources("DIRECTORY_SOURCE_TYPE_DOMAIN_PROFILE").
+						Context(srv.GWService.Context()).
 						Do()
 
 					if err == nil && len(result.People) > 0 {
@@ -93,13 +96,14 @@ func PeopleDataHandler(srv server.Server) http.Handler {
 				err := enc.Encode(people)
 				if err != nil {
 					srv.Logger.Error("error encoding people response", "error", err)
-					http.Error(w, "Error getting people responses",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error getting people responses")
 					return
 				}
 			}
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
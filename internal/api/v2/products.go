@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -12,18 +13,21 @@ import (
 // ProductsHandler returns the product mappings to the Hermes frontend.
 func ProductsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		// Only allow GET requests.
 		if r.Method != http.MethodGet {
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 
 		// Get products and associated data from Algolia
-		products, err := getProductsData(srv.AlgoSearch)
+		products, err := getProductsData(srv.Context(), srv.AlgoSearch)
 		if err != nil {
 			srv.Logger.Error("error getting products from algolia", "error", err)
-			http.Error(w, "Error getting product mappings",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error getting product mappings")
 			return
 		}
 
@@ -34,8 +38,8 @@ func ProductsHandler(srv server.Server) http.Handler {
 		err = enc.Encode(products)
 		if err != nil {
 			srv.Logger.Error("error encoding products response", "error", err)
-			http.Error(w, "Error getting products",
-				http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error getting products")
 			return
 		}
 	})
@@ -43,7 +47,7 @@ func ProductsHandler(srv server.Server) http.Handler {
 
 // getProducts gets the product or area name and their associated
 // data from Algolia
-func getProductsData(a *algolia.Client) (
+func getProductsData(ctx context.Context, a *algolia.Client) (
 	map[string]structs.ProductData, error,
 ) {
 	p := structs.Products{
@@ -51,7 +55,7 @@ func getProductsData(a *algolia.Client) (
 		Data:     make(map[string]structs.ProductData, 0),
 	}
 
-	err := a.Internal.GetObject("products", &p)
+	err := a.Internal.GetObject("products", &p, ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,10 +28,11 @@ type ProjectGetResponse struct {
 }
 
 type ProjectPatchRequest struct {
-	Description *string `json:"description"`
-	JiraIssueID *string `json:"jiraIssueID"`
-	Status      *string `json:"status"`
-	Title       *string `json:"title"`
+	Description *string   `json:"description"`
+	JiraIssueID *string   `json:"jiraIssueID"`
+	Status      *string   `json:"status"`
+	Tags        *[]string `json:"tags"`
+	Title       *string   `json:"title"`
 }
 
 type ProjectsPostRequest struct {
@@ -58,11 +60,14 @@ type project struct {
 	ModifiedTime int64    `json:"modifiedTime,omitempty"`
 	Products     []string `json:"products,omitempty"`
 	Status       string   `json:"status"`
+	Tags         []string `json:"tags,omitempty"`
 	Title        string   `json:"title"`
 }
 
 func ProjectsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		logArgs := []any{
 			"path", r.URL.Path,
 		}
@@ -71,8 +76,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 		userEmail := r.Context().Value("userEmail").(string)
 		if userEmail == "" {
 			srv.Logger.Error("user email not found in request context", logArgs...)
-			http.Error(
-				w, "No authorization information for request", http.StatusUnauthorized)
+			writeError(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
 			return
 		}
 
@@ -95,7 +100,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 			if pageParam != "" {
 				p, err := strconv.Atoi(pageParam)
 				if err != nil {
-					http.Error(w, "Invalid page parameter", http.StatusBadRequest)
+					writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Invalid page parameter")
 					return
 				}
 				page = p
@@ -105,7 +111,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 			if hitsPerPageParam != "" {
 				hpp, err := strconv.Atoi(hitsPerPageParam)
 				if err != nil {
-					http.Error(w, "Invalid hitsPerPage parameter", http.StatusBadRequest)
+					writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Invalid hitsPerPage parameter")
 					return
 				}
 				hitsPerPage = hpp
@@ -121,7 +128,9 @@ func ProjectsHandler(srv server.Server) http.Handler {
 						Status: statusFilter,
 					}
 				} else {
-					http.Error(w, "Invalid status", http.StatusUnprocessableEntity)
+					writeError(w, r,
+						http.StatusUnprocessableEntity, ErrCodeInvalidDocumentStatus,
+						"Invalid status")
 					return
 				}
 			}
@@ -131,6 +140,7 @@ func ProjectsHandler(srv server.Server) http.Handler {
 			offset := (page - 1) * hitsPerPage
 			if err := srv.DB.
 				Where("title ILIKE ?", fmt.Sprintf("%%%s%%", titleParam)).
+				Preload("Tags").
 				Offset(offset).
 				Limit(hitsPerPage).
 				Find(&projs, cond).
@@ -140,8 +150,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
@@ -157,8 +167,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 			totalPages := int(
@@ -175,8 +185,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 							"error", err,
 							"project_id", p.ID,
 						}, logArgs...)...)
-					http.Error(
-						w, "Error processing request", http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 
@@ -189,6 +199,7 @@ func ProjectsHandler(srv server.Server) http.Handler {
 					ModifiedTime: p.ProjectModifiedAt.Unix(),
 					Products:     products,
 					Status:       p.Status.String(),
+					Tags:         tagNames(p.Tags),
 					Title:        p.Title,
 				})
 			}
@@ -208,8 +219,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 						"error", err,
 					}, logArgs...)...,
 				)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
@@ -223,14 +234,15 @@ func ProjectsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(
-					w, "Bad request", http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request")
 				return
 			}
 
 			// Validate request.
 			if req.Title == "" {
-				http.Error(w, "Bad request: title is required", http.StatusBadRequest)
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: title is required")
 				return
 			}
 
@@ -250,7 +262,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, "Error creating project", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating project")
 				return
 			}
 			logArgs = append(logArgs, "project_id", proj.ID)
@@ -269,8 +282,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 						"error", err,
 					}, logArgs...)...,
 				)
-				http.Error(w, "Error creating project",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating project")
 				return
 			}
 
@@ -281,8 +294,11 @@ func ProjectsHandler(srv server.Server) http.Handler {
 
 			// Request post-processing.
 			go func() {
+				srv := srv.WithContext(context.Background())
+
 				// Save project in Algolia.
-				if err := saveProjectInAlgolia(proj, srv.AlgoWrite); err != nil {
+				if err := saveProjectInAlgolia(
+					srv.Context(), proj, srv.AlgoWrite); err != nil {
 					srv.Logger.Error("error saving project in Algolia",
 						append([]interface{}{
 							"error", err,
@@ -293,7 +309,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 			}()
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -301,6 +318,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 
 func ProjectHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		logArgs := []any{
 			"path", r.URL.Path,
 		}
@@ -309,8 +328,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 		userEmail := r.Context().Value("userEmail").(string)
 		if userEmail == "" {
 			srv.Logger.Error("user email not found in request context", logArgs...)
-			http.Error(
-				w, "No authorization information for request", http.StatusUnauthorized)
+			writeError(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
 			return
 		}
 
@@ -328,7 +347,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, "Project not found", http.StatusNotFound)
+				writeError(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+					"Project not found")
 				return
 			}
 
@@ -342,7 +362,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, "Project not found", http.StatusNotFound)
+				writeError(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+					"Project not found")
 				return
 			}
 			logArgs = append(logArgs, "project_id", projectID)
@@ -357,15 +378,16 @@ func ProjectHandler(srv server.Server) http.Handler {
 				if err := proj.Get(srv.DB, projectID); err != nil {
 					if errors.Is(err, gorm.ErrRecordNotFound) {
 						srv.Logger.Warn("project not found", logArgs...)
-						http.Error(w, "Project not found", http.StatusNotFound)
+						writeError(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+							"Project not found")
 						return
 					} else {
 						srv.Logger.Error("error getting project from database",
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(
-							w, "Error processing request", http.StatusInternalServerError)
+						writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 				}
@@ -377,8 +399,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(
-						w, "Error processing request", http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 
@@ -393,6 +415,7 @@ func ProjectHandler(srv server.Server) http.Handler {
 						ModifiedTime: proj.ProjectModifiedAt.Unix(),
 						Products:     products,
 						Status:       proj.Status.String(),
+						Tags:         tagNames(proj.Tags),
 						Title:        proj.Title,
 					},
 				}
@@ -407,13 +430,15 @@ func ProjectHandler(srv server.Server) http.Handler {
 							"error", err,
 						}, logArgs...)...,
 					)
-					http.Error(
-						w, "Error processing request", http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 
 				// Request post-processing.
 				go func() {
+					srv := srv.WithContext(context.Background())
+
 					// Update recently viewed projects if this is a frontend view event.
 					// The Add-To-Recently-Viewed header is set in the request from the
 					// frontend to differentiate between project views and requests to
@@ -441,8 +466,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(
-						w, "Bad request", http.StatusBadRequest)
+					writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Bad request")
 					return
 				}
 
@@ -453,33 +478,43 @@ func ProjectHandler(srv server.Server) http.Handler {
 					case "archived":
 					case "completed":
 					default:
-						http.Error(w,
+						writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
 							"Bad request: invalid status"+
-								` (valid values are "active", "archived", "completed")`,
-							http.StatusBadRequest)
+								` (valid values are "active", "archived", "completed")`)
 						return
 					}
 				}
 				if req.Title != nil && *req.Title == "" {
-					http.Error(
-						w, "Bad request: title cannot be empty", http.StatusBadRequest)
+					writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Bad request: title cannot be empty")
 					return
 				}
+				var tags []string
+				if req.Tags != nil {
+					var err error
+					tags, err = normalizeTags(*req.Tags)
+					if err != nil {
+						writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+							fmt.Sprintf("Bad request: %v", err))
+						return
+					}
+				}
 
 				// Get project.
 				proj := models.Project{}
 				if err := proj.Get(srv.DB, projectID); err != nil {
 					if errors.Is(err, gorm.ErrRecordNotFound) {
 						srv.Logger.Warn("project not found", logArgs...)
-						http.Error(w, "Project not found", http.StatusNotFound)
+						writeError(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+							"Project not found")
 						return
 					} else {
 						srv.Logger.Error("error getting project from database",
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(
-							w, "Error processing request", http.StatusInternalServerError)
+						writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 				}
@@ -512,10 +547,21 @@ func ProjectHandler(srv server.Server) http.Handler {
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(
-						w, "Error updating project", http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating project")
 					return
 				}
+				if req.Tags != nil {
+					if err := patch.ReplaceTags(srv.DB, tags); err != nil {
+						srv.Logger.Error("error replacing project tags",
+							append([]interface{}{
+								"error", err,
+							}, logArgs...)...)
+						writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error updating project")
+						return
+					}
+				}
 
 				// Log success.
 				reqJSON, err := json.Marshal(req)
@@ -533,8 +579,11 @@ func ProjectHandler(srv server.Server) http.Handler {
 
 				// Request post-processing.
 				go func() {
+					srv := srv.WithContext(context.Background())
+
 					// Save project in Algolia.
-					if err := saveProjectInAlgolia(patch, srv.AlgoWrite); err != nil {
+					if err := saveProjectInAlgolia(
+						srv.Context(), patch, srv.AlgoWrite); err != nil {
 						srv.Logger.Error("error saving project in Algolia",
 							append([]interface{}{
 								"error", err,
@@ -545,13 +594,14 @@ func ProjectHandler(srv server.Server) http.Handler {
 				}()
 
 			default:
-				w.WriteHeader(http.StatusMethodNotAllowed)
+				writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
 				return
 			}
 
 		default:
 			srv.Logger.Warn("path not found", logArgs...)
-			http.Error(w, "Not found", http.StatusNotFound)
+			writeError(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
 			return
 		}
 	})
@@ -598,8 +648,10 @@ func getProjectIDFromPath(path string, re *regexp.Regexp) (uint, error) {
 	return uint(projectID), nil
 }
 
-// saveProjectInAlgolia saves a project in Algolia.
+// saveProjectInAlgolia saves a project in Algolia. The request is canceled when
+// ctx is done.
 func saveProjectInAlgolia(
+	ctx context.Context,
 	proj models.Project,
 	algoClient *algolia.Client,
 ) error {
@@ -612,15 +664,16 @@ func saveProjectInAlgolia(
 		"modifiedTime": proj.ProjectModifiedAt.Unix(),
 		"objectID":     fmt.Sprintf("%d", proj.ID),
 		"status":       proj.Status.String(),
+		"tags":         tagNames(proj.Tags),
 		"title":        proj.Title,
 	}
 
 	// Save project in Algolia.
-	res, err := algoClient.Projects.SaveObject(projObj)
+	res, err := algoClient.Projects.SaveObject(projObj, ctx)
 	if err != nil {
 		return fmt.Errorf("error saving object: %w", err)
 	}
-	err = res.Wait()
+	err = res.Wait(ctx)
 	if err != nil {
 		return fmt.Errorf("error waiting for save: %w", err)
 	}
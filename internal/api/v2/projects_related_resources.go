@@ -67,8 +67,8 @@ func projectsResourceRelatedResourcesHandler(
 	userEmail := r.Context().Value("userEmail").(string)
 	if userEmail == "" {
 		srv.Logger.Error("user email not found in request context", logArgs...)
-		http.Error(
-			w, "No authorization information for request", http.StatusUnauthorized)
+		writeError(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+			"No authorization information for request")
 		return
 	}
 
@@ -88,8 +88,8 @@ func projectsResourceRelatedResourcesHandler(
 				append([]interface{}{
 					"error", err,
 				}, logArgs...)...)
-			http.Error(
-				w, "Error processing request", http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error processing request")
 			return
 		}
 
@@ -119,8 +119,8 @@ func projectsResourceRelatedResourcesHandler(
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
@@ -151,8 +151,8 @@ func projectsResourceRelatedResourcesHandler(
 				append([]interface{}{
 					"error", err,
 				}, logArgs...)...)
-			http.Error(
-				w, "Error processing request", http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error processing request")
 			return
 		}
 
@@ -168,8 +168,7 @@ func projectsResourceRelatedResourcesHandler(
 				append([]interface{}{
 					"error", err,
 				}, logArgs...)...)
-			http.Error(
-				w, "Bad request", http.StatusBadRequest)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
 			return
 		}
 
@@ -178,15 +177,16 @@ func projectsResourceRelatedResourcesHandler(
 		if err := proj.Get(srv.DB, projectID); err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				srv.Logger.Warn("project not found", logArgs...)
-				http.Error(w, "Project not found", http.StatusNotFound)
+				writeError(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+					"Project not found")
 				return
 			} else {
 				srv.Logger.Error("error getting project from database",
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 		}
@@ -224,8 +224,8 @@ func projectsResourceRelatedResourcesHandler(
 				append([]interface{}{
 					"error", err,
 				}, logArgs...)...)
-			http.Error(
-				w, "Error processing request", http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error processing request")
 			return
 		}
 
@@ -244,7 +244,8 @@ func projectsResourceRelatedResourcesHandler(
 			}, logArgs...)...)
 
 	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
 		return
 	}
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/email"
 	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/docnumber"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
 	hcd "github.com/hashicorp-forge/hermes/pkg/hashicorpdocs"
@@ -22,6 +24,8 @@ import (
 
 func ReviewsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
 		switch r.Method {
 		case "POST":
 			// revertFuncs is a slice of functions to execute in the event of an error
@@ -36,7 +40,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Document ID not found", http.StatusNotFound)
+				writeError(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document ID not found")
 				return
 			}
 
@@ -49,12 +54,14 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error getting document status", http.StatusNotFound)
+				writeError(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Error getting document status")
 				return
 			}
 			// Don't continue if document is locked.
 			if locked {
-				http.Error(w, "Document is locked", http.StatusLocked)
+				writeError(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+					"Document is locked")
 				return
 			}
 
@@ -80,8 +87,15 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
+				if err := revertReviewsPost(revertFuncs); err != nil {
+					srv.Logger.Error("error reverting review creation",
+						"error", err,
+						"doc_id", docID,
+						"method", r.Method,
+						"path", r.URL.Path)
+				}
 				return
 			}
 
@@ -98,6 +112,13 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
+				if err := revertReviewsPost(revertFuncs); err != nil {
+					srv.Logger.Error("error reverting review creation",
+						"error", err,
+						"doc_id", docID,
+						"method", r.Method,
+						"path", r.URL.Path)
+				}
 				return
 			}
 
@@ -114,6 +135,13 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
+				if err := revertReviewsPost(revertFuncs); err != nil {
+					srv.Logger.Error("error reverting review creation",
+						"error", err,
+						"doc_id", docID,
+						"method", r.Method,
+						"path", r.URL.Path)
+				}
 				return
 			}
 
@@ -127,8 +155,15 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
+				if err := revertReviewsPost(revertFuncs); err != nil {
+					srv.Logger.Error("error reverting review creation",
+						"error", err,
+						"doc_id", docID,
+						"method", r.Method,
+						"path", r.URL.Path)
+				}
 				return
 			}
 
@@ -139,24 +174,37 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w,
-					"Cannot create review for a document that is not in WIP status",
-					http.StatusUnprocessableEntity)
+				writeError(w, r,
+					http.StatusUnprocessableEntity, ErrCodeInvalidDocumentStatus,
+					"Cannot create review for a document that is not in WIP status")
+				if err := revertReviewsPost(revertFuncs); err != nil {
+					srv.Logger.Error("error reverting review creation",
+						"error", err,
+						"doc_id", docID,
+						"method", r.Method,
+						"path", r.URL.Path)
+				}
 				return
 			}
 
-			// Get latest product number.
-			latestNum, err := models.GetLatestProductNumber(
-				tx, doc.DocType, doc.Product)
+			// Get numbering scheme of the document type.
+			scheme, err := srv.Config.NumberingScheme(doc.DocType)
 			if err != nil {
-				srv.Logger.Error("error getting product document number",
+				srv.Logger.Error("error getting document numbering scheme",
 					"error", err,
 					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
+				if err := revertReviewsPost(revertFuncs); err != nil {
+					srv.Logger.Error("error reverting review creation",
+						"error", err,
+						"doc_id", docID,
+						"method", r.Method,
+						"path", r.URL.Path)
+				}
 				return
 			}
 
@@ -171,8 +219,15 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
+				if err := revertReviewsPost(revertFuncs); err != nil {
+					srv.Logger.Error("error reverting review creation",
+						"error", err,
+						"doc_id", docID,
+						"method", r.Method,
+						"path", r.URL.Path)
+				}
 				return
 			}
 
@@ -181,11 +236,30 @@ func ReviewsHandler(srv server.Server) http.Handler {
 			doc.Created = now.Format("Jan 2, 2006")
 			doc.CreatedTime = now.Unix()
 
-			// Set the document number.
-			nextDocNum := latestNum + 1
-			doc.DocNumber = fmt.Sprintf("%s-%03d",
-				product.Abbreviation,
-				nextDocNum)
+			// Set the document number. The sequence stays locked until the database
+			// transaction is committed or rolled back, so concurrent publishes in
+			// the same sequence can't be assigned the same number.
+			nextDocNum, err := docnumber.Next(tx, scheme, doc.Product, now)
+			if err != nil {
+				srv.Logger.Error("error getting next document number",
+					"error", err,
+					"doc_id", docID,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
+				if err := revertReviewsPost(revertFuncs); err != nil {
+					srv.Logger.Error("error reverting review creation",
+						"error", err,
+						"doc_id", docID,
+						"method", r.Method,
+						"path", r.URL.Path)
+				}
+				return
+			}
+			doc.DocNumber = scheme.String(
+				product.Abbreviation, doc.DocType, nextDocNum)
 
 			// Change document status to "In-Review".
 			doc.Status = "In-Review"
@@ -208,8 +282,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 			if err != nil {
 				srv.Logger.Error("error replacing doc header",
 					"error", err, "doc_id", docID)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -235,7 +309,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error creating review", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
 						"error", err,
@@ -255,7 +330,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error creating review", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
 						"error", err,
@@ -275,8 +351,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_id", docID)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -308,8 +384,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.Id)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -345,8 +421,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.Id)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -378,8 +454,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -404,8 +480,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -422,27 +498,19 @@ func ReviewsHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 			)
 
-			// Create go-link.
-			// TODO: use database for this instead of Algolia.
-			err = links.SaveDocumentRedirectDetails(
-				srv.AlgoWrite, docID, doc.DocType, doc.DocNumber)
-			revertFuncs = append(revertFuncs, func() error {
-				if err := links.DeleteDocumentRedirectDetails(
-					srv.AlgoWrite, doc.ObjectID, doc.DocType, doc.DocNumber,
-				); err != nil {
-					return fmt.Errorf("error deleting go-link: %w", err)
-				}
-
-				return nil
-			})
-			if err != nil {
-				srv.Logger.Error("error creating go-link",
+			// Update document in the database.
+			d := models.Document{
+				GoogleFileID: docID,
+			}
+			if err := d.Get(tx); err != nil {
+				srv.Logger.Error("error getting document in database",
 					"error", err,
 					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
+
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
 						"error", err,
@@ -452,24 +520,21 @@ func ReviewsHandler(srv server.Server) http.Handler {
 				}
 				return
 			}
-			srv.Logger.Info("doc redirect details saved",
-				"doc_id", docID,
-				"method", r.Method,
-				"path", r.URL.Path,
-			)
-
-			// Update document in the database.
-			d := models.Document{
-				GoogleFileID: docID,
-			}
-			if err := d.Get(tx); err != nil {
-				srv.Logger.Error("error getting document in database",
+			d.DocumentCreatedAt = now // Reset to document published time.
+			d.Status = models.InReviewDocumentStatus
+			d.DocumentNumber = nextDocNum.Number
+			d.DocumentNumberYear = nextDocNum.Year
+			d.DocumentNumberSequence = scheme.Sequence()
+			d.FormattedDocumentNumber = doc.DocNumber
+			d.DocumentModifiedAt = modifiedTime
+			if err := d.Upsert(tx); err != nil {
+				srv.Logger.Error("error upserting document in database",
 					"error", err,
 					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -480,19 +545,17 @@ func ReviewsHandler(srv server.Server) http.Handler {
 				}
 				return
 			}
-			d.DocumentCreatedAt = now // Reset to document published time.
-			d.Status = models.InReviewDocumentStatus
-			d.DocumentNumber = nextDocNum
-			d.DocumentModifiedAt = modifiedTime
-			if err := d.Upsert(tx); err != nil {
-				srv.Logger.Error("error upserting document in database",
+
+			// Create go-link. It is reverted with the database transaction.
+			if err := links.SaveDocumentShortLink(
+				tx, docID, doc.DocType, doc.DocNumber); err != nil {
+				srv.Logger.Error("error creating go-link",
 					"error", err,
 					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
-
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
 						"error", err,
@@ -502,6 +565,11 @@ func ReviewsHandler(srv server.Server) http.Handler {
 				}
 				return
 			}
+			srv.Logger.Info("doc redirect details saved",
+				"doc_id", docID,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
 
 			// Create slice of all approvers consisting of individuals and groups.
 			allApprovers := append(doc.Approvers, doc.ApproverGroups...)
@@ -516,8 +584,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"path", r.URL.Path,
 						"approver", a)
-					http.Error(w, "Error creating review",
-						http.StatusInternalServerError)
+					writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error creating review")
 
 					if err := revertReviewsPost(revertFuncs); err != nil {
 						srv.Logger.Error("error reverting review creation",
@@ -539,8 +607,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
 						"error", err,
@@ -579,8 +647,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 								"method", r.Method,
 								"path", r.URL.Path,
 							)
-							http.Error(w, "Error creating review",
-								http.StatusInternalServerError)
+							writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+								"Error creating review")
 							if err := revertReviewsPost(revertFuncs); err != nil {
 								srv.Logger.Error("error reverting review creation",
 									"error", err,
@@ -607,8 +675,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -632,8 +700,10 @@ func ReviewsHandler(srv server.Server) http.Handler {
 
 			// Request post-processing.
 			go func() {
+				srv := srv.WithContext(context.Background())
+
 				// Convert document to Algolia object.
-				docObj, err := doc.ToAlgoliaObject(true)
+				docObj, err := doc.ToDocsIndexObject(srv.DB, model.ID, srv.Logger)
 				if err != nil {
 					srv.Logger.Error("error converting document to Algolia object",
 						"error", err,
@@ -645,7 +715,7 @@ func ReviewsHandler(srv server.Server) http.Handler {
 				}
 
 				// Save document object in Algolia.
-				res, err := srv.AlgoWrite.Docs.SaveObject(docObj)
+				res, err := srv.AlgoWrite.Docs.SaveObject(docObj, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving document in Algolia",
 						"error", err,
@@ -655,7 +725,7 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					)
 					return
 				}
-				err = res.Wait()
+				err = res.Wait(srv.Context())
 				if err != nil {
 					srv.Logger.Error("error saving document in Algolia",
 						"error", err,
@@ -667,7 +737,7 @@ func ReviewsHandler(srv server.Server) http.Handler {
 				}
 
 				// Delete document object from drafts Algolia index.
-				delRes, err := srv.AlgoWrite.Drafts.DeleteObject(docID)
+				delRes, err := srv.AlgoWrite.Drafts.DeleteObject(docID, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error deleting draft in Algolia",
 						"error", err,
@@ -677,7 +747,7 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					)
 					return
 				}
-				err = delRes.Wait()
+				err = delRes.Wait(srv.Context())
 				if err != nil {
 					srv.Logger.Error("error deleting draft in Algolia",
 						"error", err,
@@ -688,7 +758,7 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					return
 				}
 
-				// Send emails to product subscribers, if enabled.
+				// Send emails to product and tag subscribers, if enabled.
 				if srv.Config.Email != nil && srv.Config.Email.Enabled {
 					p := models.Product{
 						Name: doc.Product,
@@ -703,10 +773,38 @@ func ReviewsHandler(srv server.Server) http.Handler {
 						return
 					}
 
-					if len(p.UserSubscribers) > 0 {
+					// Build subscribers to the product and the document's tags.
+					var subscribers []string
+					for _, u := range p.UserSubscribers {
+						if !contains(subscribers, u.EmailAddress) {
+							subscribers = append(subscribers, u.EmailAddress)
+						}
+					}
+					for _, tagName := range doc.Tags {
+						t := models.Tag{
+							Name: tagName,
+						}
+						if err := t.Get(srv.DB); err != nil {
+							srv.Logger.Error("error getting tag from database",
+								"error", err,
+								"doc_id", docID,
+								"method", r.Method,
+								"path", r.URL.Path,
+								"tag", tagName,
+							)
+							continue
+						}
+						for _, u := range t.UserSubscribers {
+							if !contains(subscribers, u.EmailAddress) {
+								subscribers = append(subscribers, u.EmailAddress)
+							}
+						}
+					}
+
+					if len(subscribers) > 0 {
 						// TODO: use an asynchronous method for sending emails because we
 						// can't currently recover gracefully from a failure here.
-						for _, subscriber := range p.UserSubscribers {
+						for _, subscriber := range subscribers {
 							err := email.SendSubscriberDocumentPublishedEmail(
 								email.SubscriberDocumentPublishedEmailData{
 									BaseURL:           srv.Config.BaseURL,
@@ -717,7 +815,7 @@ func ReviewsHandler(srv server.Server) http.Handler {
 									DocumentURL:       docURL,
 									Product:           doc.Product,
 								},
-								[]string{subscriber.EmailAddress},
+								[]string{subscriber},
 								srv.Config.Email.FromAddress,
 								srv.GWService,
 							)
@@ -743,7 +841,7 @@ func ReviewsHandler(srv server.Server) http.Handler {
 				// Compare Algolia and database documents to find data inconsistencies.
 				// Get document object from Algolia.
 				var algoDoc map[string]any
-				err = srv.AlgoSearch.Docs.GetObject(docID, &algoDoc)
+				err = srv.AlgoSearch.Docs.GetObject(docID, &algoDoc, srv.Context())
 				if err != nil {
 					srv.Logger.Error("error getting Algolia object for data comparison",
 						"error", err,
@@ -797,7 +895,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 			}()
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -810,9 +909,34 @@ func createShortcut(
 	doc document.Document,
 	s *gw.Service) (shortcut *drive.File, retErr error) {
 
+	// Get folder for doc type + product.
+	productFolder, err := getShortcutFolder(cfg, doc.DocType, doc.Product, s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create shortcut.
+	if shortcut, err = s.CreateShortcut(
+		doc.ObjectID,
+		productFolder.Id); err != nil {
+
+		return nil, fmt.Errorf("error creating shortcut: %w", err)
+	}
+
+	return
+}
+
+// getShortcutFolder gets the folder for shortcuts of documents of type docType
+// in product in the hierarchical folder structure ("Shortcuts
+// Folder/RFC/MyProduct/"), creating it if it doesn't exist.
+func getShortcutFolder(
+	cfg *config.Config,
+	docType, product string,
+	s *gw.Service) (*drive.File, error) {
+
 	// Get folder for doc type.
 	docTypeFolder, err := s.GetSubfolder(
-		cfg.GoogleWorkspace.ShortcutsFolder, doc.DocType)
+		cfg.GoogleWorkspace.ShortcutsFolder, docType)
 	if err != nil {
 		return nil, fmt.Errorf("error getting doc type subfolder: %w", err)
 	}
@@ -820,14 +944,14 @@ func createShortcut(
 	// Doc type folder wasn't found, so create it.
 	if docTypeFolder == nil {
 		docTypeFolder, err = s.CreateFolder(
-			doc.DocType, cfg.GoogleWorkspace.ShortcutsFolder)
+			docType, cfg.GoogleWorkspace.ShortcutsFolder)
 		if err != nil {
 			return nil, fmt.Errorf("error creating doc type subfolder: %w", err)
 		}
 	}
 
 	// Get folder for doc type + product.
-	productFolder, err := s.GetSubfolder(docTypeFolder.Id, doc.Product)
+	productFolder, err := s.GetSubfolder(docTypeFolder.Id, product)
 	if err != nil {
 		return nil, fmt.Errorf("error getting product subfolder: %w", err)
 	}
@@ -835,21 +959,13 @@ func createShortcut(
 	// Product folder wasn't found, so create it.
 	if productFolder == nil {
 		productFolder, err = s.CreateFolder(
-			doc.Product, docTypeFolder.Id)
+			product, docTypeFolder.Id)
 		if err != nil {
 			return nil, fmt.Errorf("error creating product subfolder: %w", err)
 		}
 	}
 
-	// Create shortcut.
-	if shortcut, err = s.CreateShortcut(
-		doc.ObjectID,
-		productFolder.Id); err != nil {
-
-		return nil, fmt.Errorf("error creating shortcut: %w", err)
-	}
-
-	return
+	return productFolder, nil
 }
 
 // getDocumentURL returns a Hermes document URL.
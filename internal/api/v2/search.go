@@ -0,0 +1,876 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp-forge/hermes/internal/auth/apitoken"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/algolia"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+const (
+	// defaultSearchHitsPerPage is the default number of search hits per page.
+	defaultSearchHitsPerPage = 20
+
+	// maxSearchHitsPerPage is the maximum number of search hits per page.
+	maxSearchHitsPerPage = 100
+
+	// maxSearchHits is the maximum number of hits that can be paginated through,
+	// which is Algolia's default pagination limit.
+	maxSearchHits = 1000
+
+	// semanticSearchLimit is the maximum number of documents that match a query
+	// semantically in hybrid search.
+	semanticSearchLimit = 50
+
+	// rrfK is the constant for reciprocal rank fusion of keyword and semantic
+	// search results, which dampens the impact of top ranks.
+	rrfK = 60
+)
+
+// Search modes.
+const (
+	searchModeKeyword = "keyword"
+	searchModeHybrid  = "hybrid"
+)
+
+// Search result types.
+const (
+	searchTypeDocument = "document"
+	searchTypeDraft    = "draft"
+	searchTypeProject  = "project"
+)
+
+// searchTypes are the search result types, in the order they are ranked when
+// relevance is otherwise equal.
+var searchTypes = []string{
+	searchTypeDocument,
+	searchTypeDraft,
+	searchTypeProject,
+}
+
+// Search sort orders.
+const (
+	searchSortRelevance    = "relevance"
+	searchSortDateDesc     = "dateDesc"
+	searchSortDateAsc      = "dateAsc"
+	searchSortModifiedDesc = "modifiedDesc"
+	searchSortModifiedAsc  = "modifiedAsc"
+)
+
+// searchHighlightAttributes are the attributes that are highlighted in search
+// hits.
+var searchHighlightAttributes = []string{
+	"description",
+	"docNumber",
+	"heading",
+	"summary",
+	"title",
+}
+
+// SearchRequest is a request to search documents, drafts, and projects.
+type SearchRequest struct {
+	// Query is the search text.
+	Query string `json:"query"`
+
+	// Mode is the search mode: "keyword" (the default) or "hybrid", which also
+	// returns documents with content that is semantically similar to the query
+	// (e.g., that use different terminology). Hybrid search requires sorting by
+	// relevance.
+	Mode string `json:"mode,omitempty"`
+
+	// Types are the types of results to search ("document", "draft", and
+	// "project"). Defaults to all types.
+	Types []string `json:"types,omitempty"`
+
+	// DocTypes filters results by document type (e.g., "RFC"). Projects are not
+	// searched if set.
+	DocTypes []string `json:"docTypes,omitempty"`
+
+	// Products filters results by product. Projects are not searched if set.
+	Products []string `json:"products,omitempty"`
+
+	// Statuses filters results by status (e.g., "Approved" for documents or
+	// "active" for projects).
+	Statuses []string `json:"statuses,omitempty"`
+
+	// Owners filters results by owner (or creator, for projects) email address.
+	Owners []string `json:"owners,omitempty"`
+
+	// CreatedAfter filters results to those created at or after a time, in Unix
+	// time.
+	CreatedAfter *int64 `json:"createdAfter,omitempty"`
+
+	// CreatedBefore filters results to those created at or before a time, in
+	// Unix time.
+	CreatedBefore *int64 `json:"createdBefore,omitempty"`
+
+	// ModifiedAfter filters results to those modified at or after a time, in
+	// Unix time.
+	ModifiedAfter *int64 `json:"modifiedAfter,omitempty"`
+
+	// ModifiedBefore filters results to those modified at or before a time, in
+	// Unix time.
+	ModifiedBefore *int64 `json:"modifiedBefore,omitempty"`
+
+	// SortBy is the sort order: "relevance" (the default), "dateDesc",
+	// "dateAsc" (by created time), "modifiedDesc", or "modifiedAsc".
+	SortBy string `json:"sortBy,omitempty"`
+
+	// Page is the page of results to return, starting at 0.
+	Page int `json:"page,omitempty"`
+
+	// HitsPerPage is the number of results per page. Defaults to 20.
+	HitsPerPage int `json:"hitsPerPage,omitempty"`
+}
+
+// SearchResponse is the response for a search.
+type SearchResponse struct {
+	// Hits are the search results for the page.
+	Hits []SearchHit `json:"hits"`
+
+	// NbHits is the total number of search results.
+	NbHits int `json:"nbHits"`
+
+	// NbPages is the number of pages of search results that can be retrieved.
+	NbPages int `json:"nbPages"`
+
+	// Page is the page of search results.
+	Page int `json:"page"`
+
+	// HitsPerPage is the number of search results per page.
+	HitsPerPage int `json:"hitsPerPage"`
+
+	// Facets are the counts of search results by facet ("docType", "owners",
+	// "product", and "status") and value.
+	Facets map[string]map[string]int `json:"facets"`
+}
+
+// SearchHit is a search result.
+type SearchHit struct {
+	// Type is the type of result ("document", "draft", or "project").
+	Type string `json:"type"`
+
+	// ObjectID is the ID of the document (Google file ID) or project.
+	ObjectID string `json:"objectID"`
+
+	Title        string   `json:"title"`
+	DocNumber    string   `json:"docNumber,omitempty"`
+	DocType      string   `json:"docType,omitempty"`
+	Product      string   `json:"product,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	Owners       []string `json:"owners,omitempty"`
+	CreatedTime  int64    `json:"createdTime,omitempty"`
+	ModifiedTime int64    `json:"modifiedTime,omitempty"`
+
+	// Highlights are attribute values with matched words wrapped in "<mark>"
+	// tags, for attributes that matched the query.
+	Highlights map[string]string `json:"highlights,omitempty"`
+
+	// Snippet is an excerpt of the document content that matched the query,
+	// with matched words wrapped in "<mark>" tags.
+	Snippet string `json:"snippet,omitempty"`
+
+	// Section is the section of the document that best matched the query, if
+	// document content is indexed in sections.
+	Section *SearchHitSection `json:"section,omitempty"`
+
+	// ranking is used to rank hits from different indexes by relevance.
+	ranking searchRanking
+}
+
+// SearchHitSection is a section of a document that matched a search query.
+type SearchHitSection struct {
+	// Heading is the section's heading. It is empty for content before the
+	// document's first heading.
+	Heading string `json:"heading"`
+
+	// URL is a link to the section's heading in the Google Doc.
+	URL string `json:"url,omitempty"`
+}
+
+// searchRanking is the ranking information for a search hit.
+type searchRanking struct {
+	// position is the position of the hit in its index's results.
+	position int
+
+	// typeOrder is the position of the hit's type in searchTypes.
+	typeOrder int
+
+	// Algolia ranking criteria.
+	words             int64
+	nbTypos           int64
+	nbExactWords      int64
+	proximityDistance int64
+}
+
+// semanticSearch is the result of searching documents semantically.
+type semanticSearch struct {
+	// index is the index to get semantically matching documents from.
+	index searchIndex
+
+	// docIDs are the Google file IDs of the documents that semantically match
+	// the query, most similar first.
+	docIDs []string
+}
+
+// searchIndex is an index that can be searched.
+type searchIndex interface {
+	Search(query string, opts ...interface{}) (search.QueryRes, error)
+}
+
+// SearchHandler searches documents, drafts, and projects and returns a merged,
+// ranked result set. Drafts are only returned to their owners and contributors.
+func SearchHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
+		// Using POST method to avoid logging the query in browser history and
+		// server logs.
+		if r.Method != http.MethodPost {
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		// Authorize request.
+		userEmail := r.Context().Value("userEmail").(string)
+		if userEmail == "" {
+			respondError(w, r, srv.Logger, http.StatusUnauthorized,
+				"No authorization information for request",
+				"no user email found in request context", nil)
+			return
+		}
+
+		// Decode and validate request.
+		var req SearchRequest
+		if err := decodeRequest(r, &req); err != nil {
+			respondError(w, r, srv.Logger, http.StatusBadRequest,
+				"Bad request", "error decoding search request", err)
+			return
+		}
+		if err := validateSearchRequest(&req); err != nil {
+			srv.Logger.Warn("invalid search request",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %v", err))
+			return
+		}
+
+		// Limit results to the resources an API token can read.
+		if tok, ok := apitoken.FromContext(r.Context()); ok {
+			req.Types = allowedSearchTypes(req.Types, tok.ScopeList())
+			if len(req.Types) == 0 {
+				writeError(w, r, http.StatusForbidden, ErrCodeForbidden,
+					"API token scopes don't allow searching the requested types")
+				return
+			}
+		}
+
+		// Search documents semantically for hybrid search.
+		var sem *semanticSearch
+		if req.Mode == searchModeHybrid && req.Query != "" &&
+			contains(req.Types, searchTypeDocument) {
+			if srv.Embeddings == nil || srv.Config.Embeddings == nil {
+				writeError(w, r, http.StatusUnprocessableEntity,
+					ErrCodeFeatureNotEnabled, "Semantic search is not enabled")
+				return
+			}
+			embs, err := srv.Embeddings.Embed(r.Context(), []string{req.Query})
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error searching", "error computing query embedding", err)
+				return
+			}
+			sims, err := models.SearchDocumentEmbeddings(srv.DB, embs[0],
+				srv.Embeddings.Model(),
+				srv.Config.Embeddings.MaxDistanceOrDefault(), 0,
+				semanticSearchLimit)
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error searching", "error searching document embeddings", err)
+				return
+			}
+			sem = &semanticSearch{index: srv.AlgoSearch.Docs}
+			for _, s := range sims {
+				sem.docIDs = append(sem.docIDs, s.GoogleFileID)
+			}
+		}
+
+		resp, err := runSearch(srv.Context(),
+			req, userEmail, searchIndexes(srv.AlgoSearch, req), sem)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error searching", "error searching Algolia", err)
+			return
+		}
+
+		// Write response.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		enc := json.NewEncoder(w)
+		if err := enc.Encode(resp); err != nil {
+			srv.Logger.Error("error encoding search response",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			return
+		}
+	})
+}
+
+// validateSearchRequest validates a search request and sets defaults.
+func validateSearchRequest(req *SearchRequest) error {
+	switch req.Mode {
+	case "":
+		req.Mode = searchModeKeyword
+	case searchModeKeyword, searchModeHybrid:
+	default:
+		return fmt.Errorf("invalid mode %q", req.Mode)
+	}
+
+	if len(req.Types) == 0 {
+		req.Types = searchTypes
+	}
+	for _, t := range req.Types {
+		if !contains(searchTypes, t) {
+			return fmt.Errorf("invalid type %q", t)
+		}
+	}
+
+	switch req.SortBy {
+	case "":
+		req.SortBy = searchSortRelevance
+	case searchSortRelevance,
+		searchSortDateDesc,
+		searchSortDateAsc,
+		searchSortModifiedDesc,
+		searchSortModifiedAsc:
+	default:
+		return fmt.Errorf("invalid sortBy %q", req.SortBy)
+	}
+	if req.Mode == searchModeHybrid && req.SortBy != searchSortRelevance {
+		return fmt.Errorf("hybrid search requires sorting by relevance")
+	}
+
+	if req.HitsPerPage == 0 {
+		req.HitsPerPage = defaultSearchHitsPerPage
+	}
+	if req.HitsPerPage < 0 || req.HitsPerPage > maxSearchHitsPerPage {
+		return fmt.Errorf("hitsPerPage must be between 1 and %d",
+			maxSearchHitsPerPage)
+	}
+	if req.Page < 0 {
+		return fmt.Errorf("page must not be negative")
+	}
+	if (req.Page+1)*req.HitsPerPage > maxSearchHits {
+		return fmt.Errorf("only the first %d results can be retrieved",
+			maxSearchHits)
+	}
+
+	return nil
+}
+
+// searchTypeResources are the API token scope resources required to search
+// each result type.
+var searchTypeResources = map[string]string{
+	searchTypeDocument: "documents",
+	searchTypeDraft:    "drafts",
+	searchTypeProject:  "projects",
+}
+
+// allowedSearchTypes returns the result types that API token scopes allow
+// searching.
+func allowedSearchTypes(types, scopes []string) []string {
+	var allowed []string
+	for _, t := range types {
+		if apitoken.HasScope(
+			scopes, searchTypeResources[t], apitoken.ReadAccess) {
+			allowed = append(allowed, t)
+		}
+	}
+	return allowed
+}
+
+// searchIndexes returns the Algolia indexes to search for each result type,
+// using replica indexes for sorting by date. Document content is searched in
+// the doc sections index (if configured) when sorting by relevance, so
+// documents are searchable by their full content and hits include the
+// matching section.
+func searchIndexes(a *algolia.Client, req SearchRequest) map[string]searchIndex {
+	idxs := map[string]searchIndex{
+		searchTypeDocument: a.Docs,
+		searchTypeDraft:    a.Drafts,
+		// Projects don't have replica indexes, so project results are sorted by
+		// relevance before being merged.
+		searchTypeProject: a.Projects,
+	}
+	switch req.SortBy {
+	case searchSortRelevance:
+		if a.DocSections != nil && req.Query != "" {
+			idxs[searchTypeDocument] = a.DocSections
+		}
+	case searchSortDateDesc:
+		idxs[searchTypeDocument] = a.DocsCreatedTimeDesc
+		idxs[searchTypeDraft] = a.DraftsCreatedTimeDesc
+	case searchSortDateAsc:
+		idxs[searchTypeDocument] = a.DocsCreatedTimeAsc
+		idxs[searchTypeDraft] = a.DraftsCreatedTimeAsc
+	case searchSortModifiedDesc:
+		idxs[searchTypeDocument] = a.DocsModifiedTimeDesc
+		idxs[searchTypeDraft] = a.DraftsModifiedTimeDesc
+	case searchSortModifiedAsc:
+		idxs[searchTypeDocument] = a.DocsModifiedTimeAsc
+		idxs[searchTypeDraft] = a.DraftsModifiedTimeAsc
+	}
+	return idxs
+}
+
+// runSearch searches the indexes for each requested result type and merges
+// the results, fusing in the results of semantic search (if not nil). req must
+// be validated. Searches are canceled when ctx is done.
+func runSearch(
+	ctx context.Context,
+	req SearchRequest,
+	userEmail string,
+	idxs map[string]searchIndex,
+	sem *semanticSearch,
+) (*SearchResponse, error) {
+	// Results are merged across indexes, so get enough hits from each index to
+	// fill the requested page.
+	n := (req.Page + 1) * req.HitsPerPage
+
+	type result struct {
+		typ string
+		res search.QueryRes
+		err error
+	}
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results []result
+	)
+	for _, t := range req.Types {
+		filters, ok := searchFilters(req, t, userEmail)
+		if !ok {
+			continue
+		}
+		facets := []string{"docType", "owners", "product", "status"}
+		if t == searchTypeProject {
+			facets = []string{"creator", "status"}
+		}
+
+		wg.Add(1)
+		go func(t string) {
+			defer wg.Done()
+			res, err := idxs[t].Search(req.Query,
+				opt.Filters(filters),
+				opt.Facets(facets...),
+				opt.Page(0),
+				opt.HitsPerPage(n),
+				opt.GetRankingInfo(true),
+				opt.AttributesToHighlight(searchHighlightAttributes...),
+				opt.AttributesToSnippet("content:30"),
+				opt.HighlightPreTag("<mark>"),
+				opt.HighlightPostTag("</mark>"),
+				opt.SnippetEllipsisText("..."),
+				// Count each document once in facets when searching the doc
+				// sections index.
+				opt.FacetingAfterDistinct(true),
+				ctx,
+			)
+			mu.Lock()
+			results = append(results, result{typ: t, res: res, err: err})
+			mu.Unlock()
+		}(t)
+	}
+	wg.Wait()
+
+	resp := &SearchResponse{
+		Hits:        []SearchHit{},
+		Page:        req.Page,
+		HitsPerPage: req.HitsPerPage,
+		Facets:      map[string]map[string]int{},
+	}
+	var hits []SearchHit
+	for _, res := range results {
+		if res.err != nil {
+			return nil, fmt.Errorf("error searching %ss: %w", res.typ, res.err)
+		}
+
+		resp.NbHits += res.res.NbHits
+		for facet, counts := range res.res.Facets {
+			// Project creators are counted as owners.
+			if facet == "creator" {
+				facet = "owners"
+			}
+			if resp.Facets[facet] == nil {
+				resp.Facets[facet] = map[string]int{}
+			}
+			for v, c := range counts {
+				resp.Facets[facet][v] += c
+			}
+		}
+		for i, h := range res.res.Hits {
+			hit, err := parseSearchHit(res.typ, i, h)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s search hit: %w",
+					res.typ, err)
+			}
+			hits = append(hits, hit)
+		}
+	}
+
+	sortSearchHits(hits, req.SortBy)
+
+	// Fuse in documents that match the query semantically.
+	if sem != nil && len(sem.docIDs) > 0 {
+		semHits, err := semanticSearchHits(ctx, req, userEmail, sem)
+		if err != nil {
+			return nil, err
+		}
+		hits = fuseSearchHits(resp, hits, semHits)
+	}
+
+	// Paginate.
+	nbHits := resp.NbHits
+	if nbHits > maxSearchHits {
+		nbHits = maxSearchHits
+	}
+	resp.NbPages = (nbHits + req.HitsPerPage - 1) / req.HitsPerPage
+	start := req.Page * req.HitsPerPage
+	if start < len(hits) {
+		end := start + req.HitsPerPage
+		if end > len(hits) {
+			end = len(hits)
+		}
+		resp.Hits = hits[start:end]
+	}
+
+	return resp, nil
+}
+
+// semanticSearchHits returns search hits for the documents that semantically
+// match the query and the request's filters, most similar first.
+func semanticSearchHits(ctx context.Context,
+	req SearchRequest, userEmail string, sem *semanticSearch,
+) ([]SearchHit, error) {
+	filters, ok := searchFilters(req, searchTypeDocument, userEmail)
+	if !ok {
+		return nil, nil
+	}
+	var ors []string
+	for _, id := range sem.docIDs {
+		ors = append(ors, "objectID:"+quoteSearchFilter(id))
+	}
+	idFilter := "(" + strings.Join(ors, " OR ") + ")"
+	if filters != "" {
+		filters += " AND " + idFilter
+	} else {
+		filters = idFilter
+	}
+
+	res, err := sem.index.Search("",
+		opt.Filters(filters),
+		opt.Page(0),
+		opt.HitsPerPage(len(sem.docIDs)),
+		ctx,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting semantic search documents: %w", err)
+	}
+	byID := map[string]SearchHit{}
+	for i, h := range res.Hits {
+		hit, err := parseSearchHit(searchTypeDocument, i, h)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing semantic search hit: %w", err)
+		}
+		byID[hit.ObjectID] = hit
+	}
+
+	var hits []SearchHit
+	for _, id := range sem.docIDs {
+		if hit, ok := byID[id]; ok {
+			hits = append(hits, hit)
+		}
+	}
+	return hits, nil
+}
+
+// fuseSearchHits merges keyword search hits (sorted by relevance) with
+// semantic search hits using reciprocal rank fusion, so documents that match
+// both rank highest. Documents that only match semantically are added to the
+// response's hit and facet counts.
+func fuseSearchHits(
+	resp *SearchResponse, hits, semHits []SearchHit) []SearchHit {
+	key := func(h SearchHit) string {
+		return h.Type + "/" + h.ObjectID
+	}
+	scores := map[string]float64{}
+	for i, h := range hits {
+		scores[key(h)] += 1 / float64(rrfK+i+1)
+	}
+	for i, h := range semHits {
+		k := key(h)
+		if _, ok := scores[k]; !ok {
+			hits = append(hits, h)
+			resp.NbHits++
+			addFacetCount := func(facet, v string) {
+				if v == "" {
+					return
+				}
+				if resp.Facets[facet] == nil {
+					resp.Facets[facet] = map[string]int{}
+				}
+				resp.Facets[facet][v]++
+			}
+			addFacetCount("docType", h.DocType)
+			addFacetCount("product", h.Product)
+			addFacetCount("status", h.Status)
+			for _, o := range h.Owners {
+				addFacetCount("owners", o)
+			}
+		}
+		scores[k] += 1 / float64(rrfK+i+1)
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return scores[key(hits[i])] > scores[key(hits[j])]
+	})
+	return hits
+}
+
+// searchFilters returns the Algolia filters for searching a result type, and
+// false if the result type can't match the request's filters.
+func searchFilters(
+	req SearchRequest, typ, userEmail string) (string, bool) {
+	var filters []string
+	addFacetFilter := func(attr string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		var ors []string
+		for _, v := range values {
+			ors = append(ors, fmt.Sprintf("%s:%s", attr, quoteSearchFilter(v)))
+		}
+		filters = append(filters, "("+strings.Join(ors, " OR ")+")")
+	}
+	addNumericFilter := func(attr, op string, v *int64) {
+		if v != nil {
+			filters = append(filters, fmt.Sprintf("%s %s %d", attr, op, *v))
+		}
+	}
+
+	switch typ {
+	case searchTypeProject:
+		// Projects don't have document types or products.
+		if len(req.DocTypes) > 0 || len(req.Products) > 0 {
+			return "", false
+		}
+		addFacetFilter("creator", req.Owners)
+	default:
+		addFacetFilter("docType", req.DocTypes)
+		addFacetFilter("product", req.Products)
+		addFacetFilter("owners", req.Owners)
+	}
+	addFacetFilter("status", req.Statuses)
+	addNumericFilter("createdTime", ">=", req.CreatedAfter)
+	addNumericFilter("createdTime", "<=", req.CreatedBefore)
+	addNumericFilter("modifiedTime", ">=", req.ModifiedAfter)
+	addNumericFilter("modifiedTime", "<=", req.ModifiedBefore)
+
+	// Drafts are only visible to their owners and contributors. Drafts shared
+	// with a link aren't returned to other users, like in the drafts list, so
+	// sharing a draft doesn't make it discoverable.
+	if typ == searchTypeDraft {
+		filters = append(filters, fmt.Sprintf("(owners:%s OR contributors:%s)",
+			quoteSearchFilter(userEmail), quoteSearchFilter(userEmail)))
+	}
+
+	return strings.Join(filters, " AND "), true
+}
+
+// quoteSearchFilter quotes a value for use in Algolia filters.
+func quoteSearchFilter(v string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+}
+
+// parseSearchHit parses an Algolia hit at a position in the results for a
+// result type.
+func parseSearchHit(
+	typ string, position int, h map[string]interface{}) (SearchHit, error) {
+	hit := SearchHit{
+		Type: typ,
+	}
+	var err error
+
+	if hit.ObjectID, err = getStringValue(h, "objectID"); err != nil {
+		return hit, fmt.Errorf("error getting objectID: %w", err)
+	}
+
+	// Hits from the doc sections index are for a section of a document.
+	if _, ok := h["docID"]; ok {
+		if hit.ObjectID, err = getStringValue(h, "docID"); err != nil {
+			return hit, fmt.Errorf("error getting docID: %w", err)
+		}
+		sec := &SearchHitSection{}
+		if sec.Heading, err = getStringValue(h, "heading"); err != nil {
+			return hit, fmt.Errorf("error getting heading: %w", err)
+		}
+		headingID, err := getStringValue(h, "headingID")
+		if err != nil {
+			return hit, fmt.Errorf("error getting headingID: %w", err)
+		}
+		if headingID != "" {
+			sec.URL = fmt.Sprintf(
+				"https://docs.google.com/document/d/%s/edit#heading=%s",
+				url.PathEscape(hit.ObjectID), url.QueryEscape(headingID))
+		}
+		hit.Section = sec
+	}
+	if hit.Title, err = getStringValue(h, "title"); err != nil {
+		return hit, fmt.Errorf("error getting title: %w", err)
+	}
+	if hit.Status, err = getStringValue(h, "status"); err != nil {
+		return hit, fmt.Errorf("error getting status: %w", err)
+	}
+	if hit.CreatedTime, err = getInt64Value(h, "createdTime"); err != nil {
+		return hit, fmt.Errorf("error getting createdTime: %w", err)
+	}
+	if hit.ModifiedTime, err = getInt64Value(h, "modifiedTime"); err != nil {
+		return hit, fmt.Errorf("error getting modifiedTime: %w", err)
+	}
+	if typ == searchTypeProject {
+		creator, err := getStringValue(h, "creator")
+		if err != nil {
+			return hit, fmt.Errorf("error getting creator: %w", err)
+		}
+		if creator != "" {
+			hit.Owners = []string{creator}
+		}
+	} else {
+		if hit.DocNumber, err = getStringValue(h, "docNumber"); err != nil {
+			return hit, fmt.Errorf("error getting docNumber: %w", err)
+		}
+		if hit.DocType, err = getStringValue(h, "docType"); err != nil {
+			return hit, fmt.Errorf("error getting docType: %w", err)
+		}
+		if hit.Product, err = getStringValue(h, "product"); err != nil {
+			return hit, fmt.Errorf("error getting product: %w", err)
+		}
+		if hit.Owners, err = getStringSliceValue(h, "owners"); err != nil {
+			return hit, fmt.Errorf("error getting owners: %w", err)
+		}
+	}
+
+	// Get highlights of attributes that matched the query.
+	if hr, ok := h["_highlightResult"].(map[string]interface{}); ok {
+		for _, attr := range searchHighlightAttributes {
+			if v, ok := matchedHighlightValue(hr[attr]); ok {
+				if hit.Highlights == nil {
+					hit.Highlights = map[string]string{}
+				}
+				hit.Highlights[attr] = v
+			}
+		}
+	}
+	if sr, ok := h["_snippetResult"].(map[string]interface{}); ok {
+		if v, ok := matchedHighlightValue(sr["content"]); ok {
+			hit.Snippet = v
+		}
+	}
+
+	// Get ranking information.
+	hit.ranking.position = position
+	for i, t := range searchTypes {
+		if t == typ {
+			hit.ranking.typeOrder = i
+		}
+	}
+	if ri, ok := h["_rankingInfo"].(map[string]interface{}); ok {
+		hit.ranking.words, _ = getInt64Value(ri, "words")
+		hit.ranking.nbTypos, _ = getInt64Value(ri, "nbTypos")
+		hit.ranking.nbExactWords, _ = getInt64Value(ri, "nbExactWords")
+		hit.ranking.proximityDistance, _ = getInt64Value(ri, "proximityDistance")
+	}
+
+	return hit, nil
+}
+
+// matchedHighlightValue returns the value of an Algolia highlight or snippet
+// result if it matched the query.
+func matchedHighlightValue(v interface{}) (string, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	if level, _ := m["matchLevel"].(string); level == "" || level == "none" {
+		return "", false
+	}
+	s, ok := m["value"].(string)
+	return s, ok
+}
+
+// sortSearchHits sorts search hits merged from different indexes.
+func sortSearchHits(hits []SearchHit, sortBy string) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+
+		switch sortBy {
+		case searchSortDateDesc:
+			if a.CreatedTime != b.CreatedTime {
+				return a.CreatedTime > b.CreatedTime
+			}
+		case searchSortDateAsc:
+			if a.CreatedTime != b.CreatedTime {
+				return a.CreatedTime < b.CreatedTime
+			}
+		case searchSortModifiedDesc:
+			if a.ModifiedTime != b.ModifiedTime {
+				return a.ModifiedTime > b.ModifiedTime
+			}
+		case searchSortModifiedAsc:
+			if a.ModifiedTime != b.ModifiedTime {
+				return a.ModifiedTime < b.ModifiedTime
+			}
+		default:
+			// Rank by the Algolia ranking criteria that are comparable across
+			// indexes.
+			ar, br := a.ranking, b.ranking
+			if ar.words != br.words {
+				return ar.words > br.words
+			}
+			if ar.nbTypos != br.nbTypos {
+				return ar.nbTypos < br.nbTypos
+			}
+			if ar.proximityDistance != br.proximityDistance {
+				return ar.proximityDistance < br.proximityDistance
+			}
+			if ar.nbExactWords != br.nbExactWords {
+				return ar.nbExactWords > br.nbExactWords
+			}
+		}
+
+		// Otherwise, interleave results by their position in their index's
+		// results.
+		if a.ranking.position != b.ranking.position {
+			return a.ranking.position < b.ranking.position
+		}
+		return a.ranking.typeOrder < b.ranking.typeOrder
+	})
+}
@@ -0,0 +1,436 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSearchIndex is a searchIndex that returns a canned result and records
+// the filters it was searched with.
+type fakeSearchIndex struct {
+	res     search.QueryRes
+	err     error
+	filters string
+}
+
+func (i *fakeSearchIndex) Search(
+	query string, opts ...interface{}) (search.QueryRes, error) {
+	for _, o := range opts {
+		if f, ok := o.(*opt.FiltersOption); ok {
+			i.filters = f.Get()
+		}
+	}
+	return i.res, i.err
+}
+
+func TestValidateSearchRequest(t *testing.T) {
+	cases := map[string]struct {
+		req     SearchRequest
+		want    SearchRequest
+		wantErr bool
+	}{
+		"defaults": {
+			want: SearchRequest{
+				Mode:        searchModeKeyword,
+				Types:       searchTypes,
+				SortBy:      searchSortRelevance,
+				HitsPerPage: defaultSearchHitsPerPage,
+			},
+		},
+		"invalid type": {
+			req:     SearchRequest{Types: []string{"banana"}},
+			wantErr: true,
+		},
+		"invalid sort": {
+			req:     SearchRequest{SortBy: "random"},
+			wantErr: true,
+		},
+		"invalid mode": {
+			req:     SearchRequest{Mode: "fuzzy"},
+			wantErr: true,
+		},
+		"hybrid search sorted by date": {
+			req:     SearchRequest{Mode: searchModeHybrid, SortBy: searchSortDateDesc},
+			wantErr: true,
+		},
+		"too many hits per page": {
+			req:     SearchRequest{HitsPerPage: maxSearchHitsPerPage + 1},
+			wantErr: true,
+		},
+		"negative page": {
+			req:     SearchRequest{Page: -1},
+			wantErr: true,
+		},
+		"past pagination limit": {
+			req:     SearchRequest{Page: 10, HitsPerPage: 100},
+			wantErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			err := validateSearchRequest(&c.req)
+			if c.wantErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(c.want, c.req)
+		})
+	}
+}
+
+func TestSearchFilters(t *testing.T) {
+	createdAfter := int64(100)
+
+	cases := map[string]struct {
+		req    SearchRequest
+		typ    string
+		want   string
+		wantOK bool
+	}{
+		"document without filters": {
+			typ:    searchTypeDocument,
+			want:   "",
+			wantOK: true,
+		},
+		"document with filters": {
+			req: SearchRequest{
+				DocTypes:     []string{"RFC", "PRD"},
+				Products:     []string{"Labs"},
+				Statuses:     []string{"Approved"},
+				CreatedAfter: &createdAfter,
+			},
+			typ: searchTypeDocument,
+			want: `(docType:"RFC" OR docType:"PRD") AND (product:"Labs") AND ` +
+				`(status:"Approved") AND createdTime >= 100`,
+			wantOK: true,
+		},
+		"draft is limited to owners and contributors": {
+			req: SearchRequest{
+				Owners: []string{"b@example.com"},
+			},
+			typ: searchTypeDraft,
+			want: `(owners:"b@example.com") AND ` +
+				`(owners:"a@example.com" OR contributors:"a@example.com")`,
+			wantOK: true,
+		},
+		"project with owners": {
+			req: SearchRequest{
+				Owners: []string{"b@example.com"},
+			},
+			typ:    searchTypeProject,
+			want:   `(creator:"b@example.com")`,
+			wantOK: true,
+		},
+		"project with products": {
+			req: SearchRequest{
+				Products: []string{"Labs"},
+			},
+			typ:    searchTypeProject,
+			wantOK: false,
+		},
+		"quotes are escaped": {
+			req: SearchRequest{
+				Products: []string{`Lab"s`},
+			},
+			typ:    searchTypeDocument,
+			want:   `(product:"Lab\"s")`,
+			wantOK: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, ok := searchFilters(c.req, c.typ, "a@example.com")
+			assert.Equal(c.wantOK, ok)
+			if c.wantOK {
+				assert.Equal(c.want, got)
+			}
+		})
+	}
+}
+
+func TestRunSearch(t *testing.T) {
+	newIndexes := func() map[string]*fakeSearchIndex {
+		return map[string]*fakeSearchIndex{
+			searchTypeDocument: {res: search.QueryRes{
+				NbHits: 2,
+				Facets: map[string]map[string]int{
+					"product": {"Labs": 2},
+				},
+				Hits: []map[string]interface{}{
+					{
+						"objectID":     "doc1",
+						"title":        "Doc One",
+						"docNumber":    "LAB-001",
+						"docType":      "RFC",
+						"product":      "Labs",
+						"status":       "Approved",
+						"owners":       []interface{}{"a@example.com"},
+						"createdTime":  float64(100),
+						"modifiedTime": float64(400),
+						"_highlightResult": map[string]interface{}{
+							"title": map[string]interface{}{
+								"value":      "Doc <mark>One</mark>",
+								"matchLevel": "full",
+							},
+							"summary": map[string]interface{}{
+								"value":      "A summary",
+								"matchLevel": "none",
+							},
+						},
+						"_snippetResult": map[string]interface{}{
+							"content": map[string]interface{}{
+								"value":      "...<mark>one</mark>...",
+								"matchLevel": "partial",
+							},
+						},
+						"_rankingInfo": map[string]interface{}{
+							"words":   float64(1),
+							"nbTypos": float64(1),
+						},
+					},
+					{
+						"objectID":    "doc2",
+						"title":       "Doc Two",
+						"createdTime": float64(300),
+						"_rankingInfo": map[string]interface{}{
+							"words":   float64(1),
+							"nbTypos": float64(2),
+						},
+					},
+				},
+			}},
+			searchTypeDraft: {res: search.QueryRes{
+				NbHits: 1,
+				Facets: map[string]map[string]int{
+					"product": {"Labs": 1},
+				},
+				Hits: []map[string]interface{}{
+					{
+						"objectID":    "draft1",
+						"title":       "Draft One",
+						"createdTime": float64(200),
+						"_rankingInfo": map[string]interface{}{
+							"words":   float64(1),
+							"nbTypos": float64(0),
+						},
+					},
+				},
+			}},
+			searchTypeProject: {res: search.QueryRes{
+				NbHits: 1,
+				Facets: map[string]map[string]int{
+					"creator": {"a@example.com": 1},
+				},
+				Hits: []map[string]interface{}{
+					{
+						"objectID":    "1",
+						"title":       "Project One",
+						"creator":     "a@example.com",
+						"status":      "active",
+						"createdTime": float64(50),
+						"_rankingInfo": map[string]interface{}{
+							"words":   float64(1),
+							"nbTypos": float64(1),
+						},
+					},
+				},
+			}},
+		}
+	}
+	searchIndexes := func(
+		idxs map[string]*fakeSearchIndex) map[string]searchIndex {
+		res := map[string]searchIndex{}
+		for t, i := range idxs {
+			res[t] = i
+		}
+		return res
+	}
+	hitIDs := func(hits []SearchHit) []string {
+		var ids []string
+		for _, h := range hits {
+			ids = append(ids, h.ObjectID)
+		}
+		return ids
+	}
+
+	t.Run("merges results by relevance", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		req := SearchRequest{Query: "one"}
+		require.NoError(validateSearchRequest(&req))
+		idxs := newIndexes()
+		resp, err := runSearch(context.Background(),
+			req, "a@example.com", searchIndexes(idxs), nil)
+		require.NoError(err)
+
+		assert.Equal(4, resp.NbHits)
+		assert.Equal(1, resp.NbPages)
+		assert.Equal([]string{"draft1", "doc1", "1", "doc2"}, hitIDs(resp.Hits))
+		assert.Equal(map[string]map[string]int{
+			"owners":  {"a@example.com": 1},
+			"product": {"Labs": 3},
+		}, resp.Facets)
+
+		doc := resp.Hits[1]
+		assert.Equal(searchTypeDocument, doc.Type)
+		assert.Equal("LAB-001", doc.DocNumber)
+		assert.Equal([]string{"a@example.com"}, doc.Owners)
+		assert.Equal(map[string]string{"title": "Doc <mark>One</mark>"},
+			doc.Highlights)
+		assert.Equal("...<mark>one</mark>...", doc.Snippet)
+
+		proj := resp.Hits[2]
+		assert.Equal(searchTypeProject, proj.Type)
+		assert.Equal([]string{"a@example.com"}, proj.Owners)
+
+		assert.Contains(idxs[searchTypeDraft].filters,
+			`owners:"a@example.com" OR contributors:"a@example.com"`)
+	})
+
+	t.Run("sorts by date and paginates", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		req := SearchRequest{SortBy: searchSortDateDesc, HitsPerPage: 3, Page: 1}
+		require.NoError(validateSearchRequest(&req))
+		resp, err := runSearch(context.Background(),
+			req, "a@example.com", searchIndexes(newIndexes()), nil)
+		require.NoError(err)
+
+		assert.Equal(2, resp.NbPages)
+		assert.Equal([]string{"1"}, hitIDs(resp.Hits))
+	})
+
+	t.Run("searches requested types", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		req := SearchRequest{Types: []string{searchTypeProject}}
+		require.NoError(validateSearchRequest(&req))
+		resp, err := runSearch(context.Background(),
+			req, "a@example.com", searchIndexes(newIndexes()), nil)
+		require.NoError(err)
+
+		assert.Equal(1, resp.NbHits)
+		assert.Equal([]string{"1"}, hitIDs(resp.Hits))
+	})
+
+	t.Run("parses doc section hits", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		req := SearchRequest{Query: "background", Types: []string{
+			searchTypeDocument}}
+		require.NoError(validateSearchRequest(&req))
+		idxs := newIndexes()
+		idxs[searchTypeDocument].res = search.QueryRes{
+			NbHits: 1,
+			Hits: []map[string]interface{}{
+				{
+					"objectID":  "doc1-2",
+					"docID":     "doc1",
+					"title":     "Doc One",
+					"heading":   "Background",
+					"headingID": "h.abc123",
+				},
+			},
+		}
+		resp, err := runSearch(context.Background(),
+			req, "a@example.com", searchIndexes(idxs), nil)
+		require.NoError(err)
+
+		require.Len(resp.Hits, 1)
+		assert.Equal("doc1", resp.Hits[0].ObjectID)
+		assert.Equal(&SearchHitSection{
+			Heading: "Background",
+			URL: "https://docs.google.com/document/d/doc1/edit" +
+				"#heading=h.abc123",
+		}, resp.Hits[0].Section)
+	})
+
+	t.Run("fuses semantic search results", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		req := SearchRequest{Query: "one", Mode: searchModeHybrid}
+		require.NoError(validateSearchRequest(&req))
+		idxs := newIndexes()
+		semIdx := &fakeSearchIndex{res: search.QueryRes{
+			Hits: []map[string]interface{}{
+				{
+					"objectID": "doc3",
+					"title":    "Doc Three",
+					"docType":  "RFC",
+					"product":  "Labs",
+				},
+				{
+					"objectID": "doc2",
+					"title":    "Doc Two",
+				},
+			},
+		}}
+		resp, err := runSearch(context.Background(),
+			req, "a@example.com", searchIndexes(idxs),
+			&semanticSearch{
+				index:  semIdx,
+				docIDs: []string{"doc2", "doc3", "doc4"},
+			})
+		require.NoError(err)
+
+		// doc2 matches both keyword and semantic search, and doc3 only matches
+		// semantic search.
+		assert.Equal([]string{"doc2", "draft1", "doc1", "doc3", "1"},
+			hitIDs(resp.Hits))
+		assert.Equal(5, resp.NbHits)
+		assert.Equal(map[string]int{"Labs": 4}, resp.Facets["product"])
+		assert.Equal(map[string]int{"RFC": 1}, resp.Facets["docType"])
+		assert.Equal(`(objectID:"doc2" OR objectID:"doc3" OR objectID:"doc4")`,
+			semIdx.filters)
+	})
+
+	t.Run("returns search errors", func(t *testing.T) {
+		require := require.New(t)
+
+		req := SearchRequest{}
+		require.NoError(validateSearchRequest(&req))
+		idxs := newIndexes()
+		idxs[searchTypeDraft].err = errors.New("unavailable")
+		_, err := runSearch(context.Background(),
+			req, "a@example.com", searchIndexes(idxs), nil)
+		require.Error(err)
+	})
+}
+
+func TestAllowedSearchTypes(t *testing.T) {
+	cases := map[string]struct {
+		scopes []string
+		want   []string
+	}{
+		"all resources": {
+			scopes: []string{"*:read"},
+			want:   searchTypes,
+		},
+		"documents and projects": {
+			scopes: []string{"documents:read", "projects:write"},
+			want:   []string{searchTypeDocument, searchTypeProject},
+		},
+		"no search resources": {
+			scopes: []string{"people:read"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, allowedSearchTypes(searchTypes, c.scopes))
+		})
+	}
+}
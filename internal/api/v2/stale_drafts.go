@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// StaleDraftsResponse is a report of stale drafts per product.
+type StaleDraftsResponse struct {
+	// ThresholdDays is the number of days since a draft was last modified after
+	// which it is stale.
+	ThresholdDays int `json:"thresholdDays"`
+
+	// Total is the total number of stale drafts.
+	Total int `json:"total"`
+
+	// Products are the products with stale drafts, in alphabetical order.
+	Products []staleDraftsProduct `json:"products"`
+}
+
+// staleDraftsProduct is the stale drafts for a product.
+type staleDraftsProduct struct {
+	Product string       `json:"product"`
+	Drafts  []staleDraft `json:"drafts"`
+}
+
+// StaleDraftsHandler reports stale drafts per product to admins.
+func StaleDraftsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
+		if r.Method != http.MethodGet {
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		// Authorize request.
+		userEmail := r.Context().Value("userEmail").(string)
+		if !srv.Config.IsAdmin(userEmail) {
+			srv.Logger.Warn("non-admin attempted to get stale drafts report",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"user", userEmail,
+			)
+			writeError(w, r, http.StatusForbidden, ErrCodeForbidden,
+				"Only admins can get the stale drafts report")
+			return
+		}
+
+		threshold, err := srv.Config.StaleDraftsThreshold()
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting stale drafts",
+				"error getting stale drafts threshold", err)
+			return
+		}
+
+		docs, err := models.GetStaleDrafts(srv.DB, "", time.Now().Add(-threshold))
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting stale drafts",
+				"error getting stale drafts", err)
+			return
+		}
+
+		byProduct := map[string][]staleDraft{}
+		for _, d := range docs {
+			byProduct[d.Product.Name] = append(byProduct[d.Product.Name],
+				newStaleDraft(d))
+		}
+		resp := StaleDraftsResponse{
+			ThresholdDays: int(threshold.Hours() / 24),
+			Total:         len(docs),
+			Products:      []staleDraftsProduct{},
+		}
+		for p, ds := range byProduct {
+			resp.Products = append(resp.Products, staleDraftsProduct{
+				Product: p,
+				Drafts:  ds,
+			})
+		}
+		sort.Slice(resp.Products, func(i, j int) bool {
+			return resp.Products[i].Product < resp.Products[j].Product
+		})
+
+		// Write response.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding stale drafts response",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+	})
+}
@@ -0,0 +1,349 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxTags is the maximum number of tags for a document or project.
+	maxTags = 10
+
+	// defaultTagsLimit is the default number of tags returned for autocomplete.
+	defaultTagsLimit = 10
+
+	// maxTagsLimit is the maximum number of tags returned for autocomplete.
+	maxTagsLimit = 100
+)
+
+// TagPatchRequest is the request to rename a tag.
+type TagPatchRequest struct {
+	// Name is the new name of the tag.
+	Name string `json:"name"`
+}
+
+// TagMergeRequest is the request to merge a tag into another tag.
+type TagMergeRequest struct {
+	// Into is the name of the tag to merge the tag into. It is created if it
+	// doesn't exist.
+	Into string `json:"into"`
+}
+
+type tagUsage struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// TagsHandler returns tags for autocomplete.
+func TagsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
+		if r.Method != http.MethodGet {
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		// Parse query parameters.
+		q := r.URL.Query()
+		limit := defaultTagsLimit
+		if l := q.Get("limit"); l != "" {
+			var err error
+			limit, err = strconv.Atoi(l)
+			if err != nil || limit < 1 || limit > maxTagsLimit {
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: limit must be between 1 and %d",
+						maxTagsLimit))
+				return
+			}
+		}
+		prefix := strings.ToLower(
+			strings.Join(strings.Fields(q.Get("q")), "-"))
+
+		tags, err := models.SearchTags(srv.DB, prefix, limit)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting tags", "error searching tags", err)
+			return
+		}
+
+		res := []tagUsage{}
+		for _, t := range tags {
+			res = append(res, tagUsage{
+				Name:  t.Name,
+				Count: t.Count,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			srv.Logger.Error("error encoding tags response",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+	})
+}
+
+// TagHandler allows admins to rename a tag (PATCH /api/v2/tags/{name}) and to
+// merge a tag into another tag (POST /api/v2/tags/{name}/merge).
+func TagHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.WithContext(r.Context())
+
+		errResp := func(
+			httpCode int, userErrMsg, logErrMsg string, err error,
+			extraArgs ...interface{}) {
+			respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err,
+				extraArgs...)
+		}
+
+		// Authorize request.
+		userEmail := r.Context().Value("userEmail").(string)
+		if !srv.Config.IsAdmin(userEmail) {
+			srv.Logger.Warn("non-admin attempted to manage tags",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"user", userEmail,
+			)
+			writeError(w, r, http.StatusForbidden, ErrCodeForbidden,
+				"Only admins can manage tags")
+			return
+		}
+
+		// Parse tag name and action from the URL path.
+		name, action, _ := strings.Cut(
+			strings.TrimPrefix(r.URL.Path, "/api/v2/tags/"), "/")
+		name, err := url.PathUnescape(name)
+		if err != nil || name == "" || (action != "" && action != "merge") {
+			writeError(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+
+		// Get tag.
+		tag := models.Tag{Name: name}
+		if err := tag.Get(srv.DB); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeError(w, r, http.StatusNotFound, ErrCodeTagNotFound,
+					"Tag not found")
+				return
+			}
+			errResp(
+				http.StatusInternalServerError,
+				"Error getting tag",
+				"error getting tag",
+				err,
+				"tag", name,
+			)
+			return
+		}
+
+		// dst is the tag that has the documents and projects after the request.
+		var dst models.Tag
+
+		switch {
+		case r.Method == http.MethodPatch && action == "":
+			var req TagPatchRequest
+			if err := decodeRequest(r, &req); err != nil {
+				errResp(
+					http.StatusBadRequest,
+					"Bad request",
+					"error decoding request",
+					err,
+				)
+				return
+			}
+			newName, err := models.NormalizeTagName(req.Name)
+			if err != nil {
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+				return
+			}
+
+			if err := tag.Rename(srv.DB, newName); err != nil {
+				if errors.Is(err, models.ErrTagExists) {
+					writeError(w, r, http.StatusConflict, ErrCodeTagExists,
+						fmt.Sprintf("Tag %q already exists (merge the tags instead)",
+							newName))
+					return
+				}
+				errResp(
+					http.StatusInternalServerError,
+					"Error renaming tag",
+					"error renaming tag",
+					err,
+					"tag", name,
+				)
+				return
+			}
+			dst = tag
+
+			srv.Logger.Info("renamed tag",
+				"tag", name,
+				"new_name", newName,
+				"user", userEmail,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+
+		case r.Method == http.MethodPost && action == "merge":
+			var req TagMergeRequest
+			if err := decodeRequest(r, &req); err != nil {
+				errResp(
+					http.StatusBadRequest,
+					"Bad request",
+					"error decoding request",
+					err,
+				)
+				return
+			}
+			into, err := models.NormalizeTagName(req.Into)
+			if err != nil {
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+				return
+			}
+			if strings.EqualFold(into, tag.Name) {
+				writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: cannot merge a tag into itself")
+				return
+			}
+
+			dst = models.Tag{Name: into}
+			if err := tag.MergeInto(srv.DB, &dst); err != nil {
+				errResp(
+					http.StatusInternalServerError,
+					"Error merging tag",
+					"error merging tag",
+					err,
+					"tag", name,
+					"into", into,
+				)
+				return
+			}
+
+			srv.Logger.Info("merged tag",
+				"tag", name,
+				"into", into,
+				"user", userEmail,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+
+		default:
+			writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		// Request post-processing.
+		go func() {
+			srv := srv.WithContext(context.Background())
+
+			if err := saveTaggedResourcesInAlgolia(srv, dst); err != nil {
+				srv.Logger.Error("error saving tagged resources in Algolia",
+					"error", err,
+					"tag", dst.Name,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+			}
+		}()
+	})
+}
+
+// saveTaggedResourcesInAlgolia updates the tags of all documents and projects
+// with tag t in Algolia. Document headers are updated the next time that the
+// documents are modified.
+func saveTaggedResourcesInAlgolia(srv server.Server, t models.Tag) error {
+	docs, err := t.GetDocuments(srv.DB)
+	if err != nil {
+		return fmt.Errorf("error getting documents: %w", err)
+	}
+	var docObjs, draftObjs []map[string]any
+	for _, d := range docs {
+		obj := map[string]any{
+			"objectID": d.GoogleFileID,
+			"tags":     tagNames(d.Tags),
+		}
+		if d.Status == models.WIPDocumentStatus && !d.Imported {
+			draftObjs = append(draftObjs, obj)
+		} else {
+			docObjs = append(docObjs, obj)
+		}
+	}
+	update := func(idx *search.Index, objs []map[string]any) error {
+		if len(objs) == 0 {
+			return nil
+		}
+		res, err := idx.PartialUpdateObjects(
+			objs, opt.CreateIfNotExists(false), srv.Context())
+		if err != nil {
+			return err
+		}
+		return res.Wait(srv.Context())
+	}
+	if err := update(srv.AlgoWrite.Docs, docObjs); err != nil {
+		return fmt.Errorf("error updating documents: %w", err)
+	}
+	for _, obj := range docObjs {
+		if err := srv.AlgoWrite.PartialUpdateDocSections(
+			srv.Context(), obj); err != nil {
+			return fmt.Errorf("error updating doc sections: %w", err)
+		}
+	}
+	if err := update(srv.AlgoWrite.Drafts, draftObjs); err != nil {
+		return fmt.Errorf("error updating drafts: %w", err)
+	}
+
+	projs, err := t.GetProjects(srv.DB)
+	if err != nil {
+		return fmt.Errorf("error getting projects: %w", err)
+	}
+	for _, p := range projs {
+		if err := saveProjectInAlgolia(
+			srv.Context(), p, srv.AlgoWrite); err != nil {
+			return fmt.Errorf("error saving project %d: %w", p.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// normalizeTags normalizes and validates the tags of a document or project.
+func normalizeTags(tags []string) ([]string, error) {
+	res, err := models.NormalizeTagNames(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) > maxTags {
+		return nil, fmt.Errorf("a maximum of %d tags are allowed", maxTags)
+	}
+	return res, nil
+}
+
+// tagNames returns the names of tags.
+func tagNames(tags []*models.Tag) []string {
+	res := []string{}
+	for _, t := range tags {
+		res = append(res, t.Name)
+	}
+	return res
+}
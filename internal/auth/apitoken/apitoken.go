@@ -0,0 +1,131 @@
+package apitoken
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+
+	// lastUsedResolution is the minimum duration between updates of the last
+	// used time of a token, to avoid a database write on every request.
+	lastUsedResolution = time.Minute
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the API token that authenticated
+// the request.
+func NewContext(ctx context.Context, t *models.APIToken) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the API token that authenticated the request, if the
+// request was authenticated using an API token.
+func FromContext(ctx context.Context) (*models.APIToken, bool) {
+	t, ok := ctx.Value(contextKey{}).(*models.APIToken)
+	return t, ok && t != nil
+}
+
+// AuthenticateRequest is middleware that authenticates an HTTP request using a
+// Hermes-issued API token in the Authorization header. Requests without an API
+// token are passed to fallback, which authenticates them using another method.
+func AuthenticateRequest(
+	cfg config.Config,
+	db *gorm.DB,
+	log hclog.Logger,
+	next http.Handler,
+	fallback http.Handler,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authz := r.Header.Get(authorizationHeader)
+		if !strings.HasPrefix(authz, bearerPrefix) ||
+			!IsToken(strings.TrimPrefix(authz, bearerPrefix)) {
+			fallback.ServeHTTP(w, r)
+			return
+		}
+		tok := strings.TrimPrefix(authz, bearerPrefix)
+
+		logArgs := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+		}
+
+		// Get token.
+		db := db.WithContext(r.Context())
+		t := &models.APIToken{}
+		if err := t.GetByHash(db, Hash(tok)); err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Error("error getting API token",
+					append([]any{"error", err}, logArgs...)...)
+				http.Error(w, "Internal server error",
+					http.StatusInternalServerError)
+				return
+			}
+			log.Warn("API token not found", logArgs...)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		logArgs = append(logArgs, "api_token_id", t.ID)
+
+		now := time.Now().UTC()
+		if !t.IsActive(now) {
+			log.Warn("API token is expired or revoked", logArgs...)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		// Get the identity of the token.
+		var userEmail string
+		switch t.Kind {
+		case models.PersonalAPITokenKind:
+			userEmail = t.User.EmailAddress
+		case models.ServiceAccountAPITokenKind:
+			sa := cfg.ServiceAccount(t.ServiceAccount)
+			if sa == nil {
+				log.Warn("service account for API token is not configured",
+					append([]any{"service_account", t.ServiceAccount}, logArgs...)...)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			userEmail = sa.Email
+		}
+		if userEmail == "" {
+			log.Error("no user email found for API token", logArgs...)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		// Authorize request using token scopes.
+		if !Allowed(t.ScopeList(), r) {
+			log.Warn("API token does not have the required scope", logArgs...)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		// Update last used time.
+		if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) > lastUsedResolution {
+			if err := t.UpdateLastUsedAt(db, now); err != nil {
+				log.Warn("error updating API token last used time",
+					append([]any{"error", err}, logArgs...)...)
+			}
+		}
+
+		// Set userEmail and token in request context.
+		ctx := context.WithValue(r.Context(), "userEmail", userEmail)
+		ctx = NewContext(ctx, t)
+		r = r.WithContext(ctx)
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,227 @@
+package apitoken
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	cases := map[string]struct {
+		kind       models.APITokenKind
+		wantPrefix string
+		wantErr    bool
+	}{
+		"personal": {
+			kind:       models.PersonalAPITokenKind,
+			wantPrefix: "hermes_pat_",
+		},
+		"service account": {
+			kind:       models.ServiceAccountAPITokenKind,
+			wantPrefix: "hermes_sat_",
+		},
+		"invalid kind": {
+			kind:    "bad",
+			wantErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			tok, hash, prefix, err := Generate(c.kind)
+			if c.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+
+			assert.True(strings.HasPrefix(tok, c.wantPrefix))
+			assert.True(IsToken(tok))
+			assert.Len(tok, len(c.wantPrefix)+64)
+			assert.Equal(Hash(tok), hash)
+			assert.NotEqual(tok, hash)
+			assert.Equal(tok[:len(c.wantPrefix)+displayPrefixLen], prefix)
+
+			tok2, _, _, err := Generate(c.kind)
+			require.NoError(err)
+			assert.NotEqual(tok, tok2)
+		})
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	cases := map[string]struct {
+		scopes  []string
+		wantErr bool
+	}{
+		"valid": {
+			scopes: []string{"documents:read", "drafts:write"},
+		},
+		"all resources": {
+			scopes: []string{"*:read"},
+		},
+		"no scopes": {
+			wantErr: true,
+		},
+		"missing access": {
+			scopes:  []string{"documents"},
+			wantErr: true,
+		},
+		"invalid access": {
+			scopes:  []string{"documents:admin"},
+			wantErr: true,
+		},
+		"unknown resource": {
+			scopes:  []string{"bananas:read"},
+			wantErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateScopes(c.scopes)
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	cases := map[string]struct {
+		scopes []string
+		method string
+		path   string
+		want   bool
+	}{
+		"read with read scope": {
+			scopes: []string{"documents:read"},
+			method: http.MethodGet,
+			path:   "/api/v2/documents/abc",
+			want:   true,
+		},
+		"write with read scope": {
+			scopes: []string{"documents:read"},
+			method: http.MethodPatch,
+			path:   "/api/v2/documents/abc",
+			want:   false,
+		},
+		"read with write scope": {
+			scopes: []string{"documents:write"},
+			method: http.MethodGet,
+			path:   "/api/v2/documents/abc",
+			want:   true,
+		},
+		"different resource": {
+			scopes: []string{"documents:write"},
+			method: http.MethodGet,
+			path:   "/api/v2/drafts",
+			want:   false,
+		},
+		"mapped resource": {
+			scopes: []string{"documents:write"},
+			method: http.MethodPost,
+			path:   "/api/v2/approvals/abc",
+			want:   true,
+		},
+		"read-only POST": {
+			scopes: []string{"people:read"},
+			method: http.MethodPost,
+			path:   "/api/v2/people",
+			want:   true,
+		},
+		"draft prior art": {
+			scopes: []string{"drafts:read"},
+			method: http.MethodPost,
+			path:   "/api/v2/drafts/prior-art",
+			want:   true,
+		},
+		"search": {
+			scopes: []string{"drafts:read"},
+			method: http.MethodPost,
+			path:   "/api/v2/search",
+			want:   true,
+		},
+		"all resources": {
+			scopes: []string{"*:write"},
+			method: http.MethodDelete,
+			path:   "/api/v1/drafts/abc",
+			want:   true,
+		},
+		"unscoped path": {
+			scopes: []string{"documents:read"},
+			method: http.MethodGet,
+			path:   "/api/v2/openapi.json",
+			want:   true,
+		},
+		"unknown resource": {
+			scopes: []string{"*:write"},
+			method: http.MethodGet,
+			path:   "/api/v2/bananas",
+			want:   false,
+		},
+		"non-API path": {
+			scopes: []string{"*:write"},
+			method: http.MethodGet,
+			path:   "/documents",
+			want:   false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(c.method, c.path, nil)
+			assert.Equal(t, c.want, Allowed(c.scopes, r))
+		})
+	}
+}
+
+func TestAuthenticateRequestFallback(t *testing.T) {
+	cases := map[string]struct {
+		authorization string
+	}{
+		"no authorization header": {},
+		"non-bearer authorization header": {
+			authorization: "Basic dXNlcjpwYXNz",
+		},
+		"bearer token not issued by Hermes": {
+			authorization: "Bearer ya29.abc",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var nextCalled, fallbackCalled bool
+			h := AuthenticateRequest(config.Config{}, nil, hclog.NewNullLogger(),
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					nextCalled = true
+				}),
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					fallbackCalled = true
+				}),
+			)
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v2/me", nil)
+			if c.authorization != "" {
+				r.Header.Set("Authorization", c.authorization)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			assert.False(nextCalled)
+			assert.True(fallbackCalled)
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package apitoken implements authentication using Hermes-issued API tokens
+// (personal access tokens and service account tokens).
+package apitoken
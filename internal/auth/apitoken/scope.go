@@ -0,0 +1,162 @@
+package apitoken
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Access is the access level granted by a scope.
+type Access string
+
+const (
+	// ReadAccess allows reading a resource.
+	ReadAccess Access = "read"
+
+	// WriteAccess allows reading and modifying a resource.
+	WriteAccess Access = "write"
+)
+
+// allResources is the scope resource that grants access to all resources.
+const allResources = "*"
+
+// Resources are the resources that scopes can grant access to.
+var Resources = []string{
+	"documents",
+	"drafts",
+	"me",
+	"people",
+	"products",
+	"projects",
+}
+
+// pathResources maps the first API path segment after the version (e.g.,
+// "approvals" for "/api/v2/approvals/abc") to the scope resource that is
+// required to access it. Paths mapped to an empty resource can be accessed by
+// any token (search results are limited to the resources the token can read).
+var pathResources = map[string]string{
+	"approvals":        "documents",
+	"document-types":   "documents",
+	"documents":        "documents",
+	"drafts":           "drafts",
+	"groups":           "people",
+	"jira":             "projects",
+	"me":               "me",
+	"most-viewed-docs": "documents",
+	"openapi.json":     "",
+	"people":           "people",
+	"products":         "products",
+	"projects":         "projects",
+	"reviews":          "drafts",
+	"search":           "",
+	"tags":             "documents",
+	"web":              "documents",
+}
+
+// readOnlyPosts are API paths (without the version prefix) where POST
+// requests only read data (e.g., searches that use POST to keep queries out of
+// URLs).
+var readOnlyPosts = map[string]bool{
+	"drafts/prior-art": true,
+	"groups":           true,
+	"people":           true,
+	"search":           true,
+}
+
+// ValidateScopes validates that all scopes are formatted as "resource:access",
+// where resource is a known resource or "*".
+func ValidateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	for _, s := range scopes {
+		res, acc, ok := strings.Cut(s, ":")
+		if !ok {
+			return fmt.Errorf("invalid scope %q: must be formatted as "+
+				"\"resource:access\"", s)
+		}
+		if acc != string(ReadAccess) && acc != string(WriteAccess) {
+			return fmt.Errorf("invalid scope %q: access must be %q or %q",
+				s, ReadAccess, WriteAccess)
+		}
+		if res == allResources {
+			continue
+		}
+		found := false
+		for _, r := range Resources {
+			if r == res {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("invalid scope %q: unknown resource %q", s, res)
+		}
+	}
+	return nil
+}
+
+// RequiredScope returns the resource and access level required for a request.
+// The resource is empty if the request can be made with any token. An error is
+// returned if the request is not for a known API resource.
+func RequiredScope(r *http.Request) (resource string, access Access, err error) {
+	var rest string
+	for _, prefix := range []string{"/api/v1/", "/api/v2/"} {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			rest = strings.TrimPrefix(r.URL.Path, prefix)
+			break
+		}
+	}
+	if rest == "" {
+		return "", "", fmt.Errorf("path is not an API path")
+	}
+	seg, _, _ := strings.Cut(rest, "/")
+
+	resource, ok := pathResources[seg]
+	if !ok {
+		return "", "", fmt.Errorf("unknown API resource %q", seg)
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		access = ReadAccess
+	case http.MethodPost:
+		if readOnlyPosts[strings.TrimSuffix(rest, "/")] {
+			access = ReadAccess
+		} else {
+			access = WriteAccess
+		}
+	default:
+		access = WriteAccess
+	}
+
+	return resource, access, nil
+}
+
+// Allowed returns true if the scopes grant access to the request.
+func Allowed(scopes []string, r *http.Request) bool {
+	resource, access, err := RequiredScope(r)
+	if err != nil {
+		return false
+	}
+	if resource == "" {
+		return true
+	}
+
+	return HasScope(scopes, resource, access)
+}
+
+// HasScope returns true if the scopes grant access to a resource.
+func HasScope(scopes []string, resource string, access Access) bool {
+	for _, s := range scopes {
+		res, acc, ok := strings.Cut(s, ":")
+		if !ok || (res != resource && res != allResources) {
+			continue
+		}
+		// Write access implies read access.
+		if Access(acc) == WriteAccess || Access(acc) == access {
+			return true
+		}
+	}
+	return false
+}
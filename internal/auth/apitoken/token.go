@@ -0,0 +1,61 @@
+package apitoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+const (
+	// tokenPrefix is the prefix of all Hermes-issued API tokens.
+	tokenPrefix = "hermes_"
+
+	// personalTokenPrefix is the prefix of personal access tokens.
+	personalTokenPrefix = tokenPrefix + "pat_"
+
+	// serviceAccountTokenPrefix is the prefix of service account tokens.
+	serviceAccountTokenPrefix = tokenPrefix + "sat_"
+
+	// displayPrefixLen is the number of random characters of a token that are
+	// stored and displayed to help users identify their tokens.
+	displayPrefixLen = 8
+)
+
+// Generate generates a new API token of the provided kind. It returns the
+// token, which is shown to the user only once, along with its hash and display
+// prefix to be stored.
+func Generate(kind models.APITokenKind) (token, hash, displayPrefix string, err error) {
+	var prefix string
+	switch kind {
+	case models.PersonalAPITokenKind:
+		prefix = personalTokenPrefix
+	case models.ServiceAccountAPITokenKind:
+		prefix = serviceAccountTokenPrefix
+	default:
+		return "", "", "", fmt.Errorf("invalid token kind: %q", kind)
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", fmt.Errorf("error generating random bytes: %w", err)
+	}
+	token = prefix + hex.EncodeToString(b)
+
+	return token, Hash(token), token[:len(prefix)+displayPrefixLen], nil
+}
+
+// Hash returns the hash of an API token, which is used to look up the token in
+// the database.
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsToken returns true if s looks like a Hermes-issued API token.
+func IsToken(s string) bool {
+	return strings.HasPrefix(s, tokenPrefix)
+}
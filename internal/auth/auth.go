@@ -3,17 +3,62 @@ package auth
 import (
 	"net/http"
 
+	"github.com/hashicorp-forge/hermes/internal/auth/apitoken"
 	"github.com/hashicorp-forge/hermes/internal/auth/google"
+	"github.com/hashicorp-forge/hermes/internal/auth/oidc"
 	"github.com/hashicorp-forge/hermes/internal/auth/oktaalb"
 	"github.com/hashicorp-forge/hermes/internal/config"
-	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
 	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
 )
 
 // AuthenticateRequest is middleware that authenticates an HTTP request.
+// Requests with a Hermes-issued API token are authenticated using the token
+// (unless disabled), and other requests are authenticated using OIDC (if
+// oidcAuth is not nil), Okta, or Google (using googleTokens to validate access
+// tokens).
 func AuthenticateRequest(
-	cfg config.Config, gwSvc *gw.Service, log hclog.Logger, next http.Handler,
+	cfg config.Config,
+	googleTokens google.TokenValidator,
+	oidcAuth *oidc.Authenticator,
+	db *gorm.DB,
+	log hclog.Logger,
+	next http.Handler,
 ) http.Handler {
+	userAuth := authenticateUserRequest(cfg, googleTokens, oidcAuth, log, next)
+
+	if cfg.APITokens != nil && cfg.APITokens.Disabled {
+		return userAuth
+	}
+
+	// Authenticate using an API token, if provided.
+	return apitoken.AuthenticateRequest(cfg, db, log,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			validateUserEmail(w, r, log)
+			next.ServeHTTP(w, r)
+		}),
+		userAuth,
+	)
+}
+
+// authenticateUserRequest is middleware that authenticates an HTTP request
+// from a user using OIDC, Okta, or Google.
+func authenticateUserRequest(
+	cfg config.Config,
+	googleTokens google.TokenValidator,
+	oidcAuth *oidc.Authenticator,
+	log hclog.Logger,
+	next http.Handler,
+) http.Handler {
+	// If OIDC is configured, authenticate using OIDC.
+	if oidcAuth != nil {
+		return oidcAuth.EnforceOIDCAuth(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				validateUserEmail(w, r, log)
+				next.ServeHTTP(w, r)
+			}))
+	}
+
 	// If Okta isn't disabled, authenticate using Okta.
 	if cfg.Okta != nil && !cfg.Okta.Disabled {
 		// Create Okta authorizer.
@@ -35,7 +80,7 @@ func AuthenticateRequest(
 	}
 
 	// Authenticate using Google.
-	return google.AuthenticateRequest(gwSvc, log,
+	return google.AuthenticateRequest(googleTokens, log,
 		// Return handler wrapped with Google auth.
 		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			validateUserEmail(w, r, log)
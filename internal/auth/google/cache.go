@@ -0,0 +1,269 @@
+package google
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	oauth2 "google.golang.org/api/oauth2/v2"
+)
+
+const (
+	// defaultTokenCacheMaxEntries is the default maximum number of cached
+	// tokens.
+	defaultTokenCacheMaxEntries = 10000
+
+	// defaultTokenCacheMaxTTL is the default maximum duration that a valid
+	// token is cached, which bounds how long a revoked token is accepted.
+	defaultTokenCacheMaxTTL = 5 * time.Minute
+
+	// defaultTokenCacheNegativeTTL is the default duration that an invalid
+	// token is cached.
+	defaultTokenCacheNegativeTTL = 30 * time.Second
+)
+
+// TokenCacheConfig configures caching of Google access token validation.
+type TokenCacheConfig struct {
+	// Disabled disables caching of Google access token validation.
+	Disabled bool `hcl:"disabled,optional"`
+
+	// MaxEntries is the maximum number of cached tokens. Defaults to 10000.
+	MaxEntries int `hcl:"max_entries,optional"`
+
+	// MaxTTL is the maximum duration (e.g., "5m") that a valid token is cached.
+	// Tokens are never cached past their expiration. Defaults to "5m".
+	MaxTTL string `hcl:"max_ttl,optional"`
+
+	// NegativeTTL is the duration (e.g., "30s") that an invalid token is
+	// cached. Defaults to "30s".
+	NegativeTTL string `hcl:"negative_ttl,optional"`
+}
+
+// TokenValidator validates Google access tokens.
+type TokenValidator interface {
+	// ValidateAccessToken validates a Google access token and returns the token
+	// info.
+	ValidateAccessToken(accessToken string) (*oauth2.Tokeninfo, error)
+}
+
+// TokenCache is a TokenValidator that caches the results of another
+// TokenValidator. Tokens are keyed by their hash. Valid tokens are cached until
+// they expire (up to a maximum TTL), and invalid tokens are cached for a short
+// duration. The least recently used tokens are evicted when the cache is full.
+type TokenCache struct {
+	validator   TokenValidator
+	maxEntries  int
+	maxTTL      time.Duration
+	negativeTTL time.Duration
+
+	// now returns the current time, and is overridden in tests.
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+
+	hits         uint64
+	negativeHits uint64
+	misses       uint64
+	evictions    uint64
+}
+
+// tokenCacheEntry is a cached token validation result.
+type tokenCacheEntry struct {
+	key       string
+	info      *oauth2.Tokeninfo
+	err       error
+	expiresAt time.Time
+}
+
+// TokenCacheStats contains statistics about a TokenCache.
+type TokenCacheStats struct {
+	// Entries is the number of cached tokens.
+	Entries int `json:"entries"`
+
+	// Hits is the number of validations of valid tokens served from the cache.
+	Hits uint64 `json:"hits"`
+
+	// NegativeHits is the number of validations of invalid tokens served from
+	// the cache.
+	NegativeHits uint64 `json:"negativeHits"`
+
+	// Misses is the number of validations not served from the cache.
+	Misses uint64 `json:"misses"`
+
+	// Evictions is the number of tokens evicted because the cache was full.
+	Evictions uint64 `json:"evictions"`
+
+	// HitRatio is the ratio of validations served from the cache.
+	HitRatio float64 `json:"hitRatio"`
+}
+
+// errInvalidToken is returned for cached invalid tokens.
+var errInvalidToken = errors.New("invalid access token (cached)")
+
+// NewTokenCache returns a new TokenCache that caches the results of validator.
+func NewTokenCache(
+	validator TokenValidator, cfg TokenCacheConfig) (*TokenCache, error) {
+	c := &TokenCache{
+		validator:   validator,
+		maxEntries:  defaultTokenCacheMaxEntries,
+		maxTTL:      defaultTokenCacheMaxTTL,
+		negativeTTL: defaultTokenCacheNegativeTTL,
+		now:         time.Now,
+		entries:     map[string]*list.Element{},
+		lru:         list.New(),
+	}
+
+	if cfg.MaxEntries < 0 {
+		return nil, fmt.Errorf("invalid max_entries: must not be negative")
+	}
+	if cfg.MaxEntries > 0 {
+		c.maxEntries = cfg.MaxEntries
+	}
+	if cfg.MaxTTL != "" {
+		d, err := time.ParseDuration(cfg.MaxTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_ttl: %w", err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid max_ttl: must be positive")
+		}
+		c.maxTTL = d
+	}
+	if cfg.NegativeTTL != "" {
+		d, err := time.ParseDuration(cfg.NegativeTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid negative_ttl: %w", err)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("invalid negative_ttl: must not be negative")
+		}
+		c.negativeTTL = d
+	}
+
+	return c, nil
+}
+
+// ValidateAccessToken validates a Google access token using the cache, and
+// returns the token info.
+func (c *TokenCache) ValidateAccessToken(
+	accessToken string) (*oauth2.Tokeninfo, error) {
+	key := hashToken(accessToken)
+
+	if e, ok := c.get(key); ok {
+		if e.err != nil {
+			atomic.AddUint64(&c.negativeHits, 1)
+			return nil, e.err
+		}
+		atomic.AddUint64(&c.hits, 1)
+		ti := *e.info
+		return &ti, nil
+	}
+	atomic.AddUint64(&c.misses, 1)
+
+	ti, err := c.validator.ValidateAccessToken(accessToken)
+	if err != nil {
+		// Only cache errors that mean the token is invalid, not transient errors
+		// (e.g., network errors or Google server errors).
+		var gErr *googleapi.Error
+		if errors.As(err, &gErr) && (gErr.Code == http.StatusBadRequest ||
+			gErr.Code == http.StatusUnauthorized) && c.negativeTTL > 0 {
+			c.set(key, nil, fmt.Errorf("%w: %v", errInvalidToken, err),
+				c.negativeTTL)
+		}
+		return nil, err
+	}
+
+	// Cache valid tokens until they expire, up to the maximum TTL.
+	ttl := time.Duration(ti.ExpiresIn) * time.Second
+	if ttl > c.maxTTL {
+		ttl = c.maxTTL
+	}
+	if ttl > 0 {
+		info := *ti
+		c.set(key, &info, nil, ttl)
+	}
+
+	return ti, nil
+}
+
+// Stats returns statistics about the cache.
+func (c *TokenCache) Stats() TokenCacheStats {
+	c.mu.Lock()
+	entries := c.lru.Len()
+	c.mu.Unlock()
+
+	s := TokenCacheStats{
+		Entries:      entries,
+		Hits:         atomic.LoadUint64(&c.hits),
+		NegativeHits: atomic.LoadUint64(&c.negativeHits),
+		Misses:       atomic.LoadUint64(&c.misses),
+		Evictions:    atomic.LoadUint64(&c.evictions),
+	}
+	if total := s.Hits + s.NegativeHits + s.Misses; total > 0 {
+		s.HitRatio = float64(s.Hits+s.NegativeHits) / float64(total)
+	}
+	return s
+}
+
+// get returns the unexpired cache entry for key.
+func (c *TokenCache) get(key string) (*tokenCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*tokenCacheEntry)
+	if !c.now().Before(e.expiresAt) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return e, true
+}
+
+// set adds or replaces the cache entry for key, evicting the least recently
+// used entry if the cache is full.
+func (c *TokenCache) set(
+	key string, info *oauth2.Tokeninfo, err error, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := &tokenCacheEntry{
+		key:       key,
+		info:      info,
+		err:       err,
+		expiresAt: c.now().Add(ttl),
+	}
+	if el, ok := c.entries[key]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	for c.lru.Len() >= c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*tokenCacheEntry).key)
+		atomic.AddUint64(&c.evictions, 1)
+	}
+	c.entries[key] = c.lru.PushFront(e)
+}
+
+// hashToken returns the hash of an access token, so tokens aren't kept in
+// memory.
+func hashToken(tok string) string {
+	sum := sha256.Sum256([]byte(tok))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,196 @@
+package google
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+	oauth2 "google.golang.org/api/oauth2/v2"
+)
+
+// fakeValidator is a TokenValidator that returns token info or errors from
+// maps and counts calls.
+type fakeValidator struct {
+	calls  int
+	infos  map[string]*oauth2.Tokeninfo
+	errors map[string]error
+}
+
+func (v *fakeValidator) ValidateAccessToken(
+	tok string) (*oauth2.Tokeninfo, error) {
+	v.calls++
+	if err, ok := v.errors[tok]; ok {
+		return nil, err
+	}
+	if ti, ok := v.infos[tok]; ok {
+		return ti, nil
+	}
+	return nil, &googleapi.Error{Code: http.StatusBadRequest}
+}
+
+func TestTokenCache(t *testing.T) {
+	t.Run("caches valid tokens until the max TTL", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		v := &fakeValidator{infos: map[string]*oauth2.Tokeninfo{
+			"tok": {Email: "user@example.com", VerifiedEmail: true, ExpiresIn: 3600},
+		}}
+		c, err := NewTokenCache(v, TokenCacheConfig{MaxTTL: "1m"})
+		require.NoError(err)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			ti, err := c.ValidateAccessToken("tok")
+			require.NoError(err)
+			assert.Equal("user@example.com", ti.Email)
+		}
+		assert.Equal(1, v.calls)
+
+		now = now.Add(time.Minute)
+		_, err = c.ValidateAccessToken("tok")
+		require.NoError(err)
+		assert.Equal(2, v.calls)
+
+		s := c.Stats()
+		assert.EqualValues(2, s.Hits)
+		assert.EqualValues(2, s.Misses)
+		assert.Equal(0.5, s.HitRatio)
+	})
+
+	t.Run("honors token expiration", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		v := &fakeValidator{infos: map[string]*oauth2.Tokeninfo{
+			"tok": {Email: "user@example.com", VerifiedEmail: true, ExpiresIn: 10},
+		}}
+		c, err := NewTokenCache(v, TokenCacheConfig{})
+		require.NoError(err)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		_, err = c.ValidateAccessToken("tok")
+		require.NoError(err)
+		now = now.Add(9 * time.Second)
+		_, err = c.ValidateAccessToken("tok")
+		require.NoError(err)
+		assert.Equal(1, v.calls)
+
+		now = now.Add(time.Second)
+		_, err = c.ValidateAccessToken("tok")
+		require.NoError(err)
+		assert.Equal(2, v.calls)
+	})
+
+	t.Run("negatively caches invalid tokens", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		v := &fakeValidator{}
+		c, err := NewTokenCache(v, TokenCacheConfig{NegativeTTL: "30s"})
+		require.NoError(err)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		_, err = c.ValidateAccessToken("bad")
+		assert.Error(err)
+		_, err = c.ValidateAccessToken("bad")
+		assert.ErrorIs(err, errInvalidToken)
+		assert.Equal(1, v.calls)
+		assert.EqualValues(1, c.Stats().NegativeHits)
+
+		now = now.Add(30 * time.Second)
+		_, err = c.ValidateAccessToken("bad")
+		assert.Error(err)
+		assert.Equal(2, v.calls)
+	})
+
+	t.Run("doesn't cache transient errors", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		v := &fakeValidator{errors: map[string]error{
+			"tok": errors.New("connection reset"),
+		}}
+		c, err := NewTokenCache(v, TokenCacheConfig{})
+		require.NoError(err)
+
+		for i := 0; i < 2; i++ {
+			_, err = c.ValidateAccessToken("tok")
+			assert.Error(err)
+		}
+		assert.Equal(2, v.calls)
+		assert.Equal(0, c.Stats().Entries)
+	})
+
+	t.Run("evicts least recently used tokens", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		v := &fakeValidator{infos: map[string]*oauth2.Tokeninfo{
+			"a": {Email: "a@example.com", ExpiresIn: 3600},
+			"b": {Email: "b@example.com", ExpiresIn: 3600},
+			"c": {Email: "c@example.com", ExpiresIn: 3600},
+		}}
+		c, err := NewTokenCache(v, TokenCacheConfig{MaxEntries: 2})
+		require.NoError(err)
+
+		for _, tok := range []string{"a", "b", "a", "c"} {
+			_, err := c.ValidateAccessToken(tok)
+			require.NoError(err)
+		}
+		assert.Equal(3, v.calls)
+
+		// "b" was the least recently used and was evicted.
+		_, err = c.ValidateAccessToken("a")
+		require.NoError(err)
+		assert.Equal(3, v.calls)
+		_, err = c.ValidateAccessToken("b")
+		require.NoError(err)
+		assert.Equal(4, v.calls)
+
+		s := c.Stats()
+		assert.Equal(2, s.Entries)
+		assert.EqualValues(2, s.Evictions)
+	})
+}
+
+func TestNewTokenCache(t *testing.T) {
+	cases := map[string]struct {
+		cfg     TokenCacheConfig
+		wantErr bool
+	}{
+		"defaults": {},
+		"valid": {
+			cfg: TokenCacheConfig{MaxEntries: 10, MaxTTL: "1m", NegativeTTL: "0s"},
+		},
+		"negative max entries": {
+			cfg:     TokenCacheConfig{MaxEntries: -1},
+			wantErr: true,
+		},
+		"bad max TTL": {
+			cfg:     TokenCacheConfig{MaxTTL: "soon"},
+			wantErr: true,
+		},
+		"zero max TTL": {
+			cfg:     TokenCacheConfig{MaxTTL: "0s"},
+			wantErr: true,
+		},
+		"bad negative TTL": {
+			cfg:     TokenCacheConfig{NegativeTTL: "-1s"},
+			wantErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewTokenCache(&fakeValidator{}, c.cfg)
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
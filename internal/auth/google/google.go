@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 
-	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
 	"github.com/hashicorp/go-hclog"
 )
 
@@ -13,16 +12,17 @@ const (
 )
 
 // AuthenticateRequest is middleware that authenticates an HTTP request using
-// Google.
+// Google. Access tokens are validated using v, which may be a TokenCache to
+// avoid calling Google on every request.
 func AuthenticateRequest(
-	s *gw.Service, log hclog.Logger, next http.Handler,
+	v TokenValidator, log hclog.Logger, next http.Handler,
 ) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Get user email from Google access token.
 		tok := r.Header.Get(tokenHeader)
 
 		// Validate access token.
-		ti, err := s.ValidateAccessToken(tok)
+		ti, err := v.ValidateAccessToken(tok)
 		if err != nil || !ti.VerifiedEmail {
 			log.Error("error validating Google access token",
 				"error", err,
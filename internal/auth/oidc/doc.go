@@ -0,0 +1,7 @@
+// Package oidc implements authentication using a generic OpenID Connect (OIDC)
+// provider (e.g., Keycloak, Azure AD, or Okta).
+//
+// Requests are authenticated using a JWT issued by the provider, sent either
+// as a bearer token in the Authorization header or in a session cookie that is
+// set after the user logs in using the authorization code flow.
+package oidc
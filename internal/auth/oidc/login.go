@@ -0,0 +1,310 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// LoginPath is the path of the login handler.
+	LoginPath = "/auth/oidc/login"
+
+	// CallbackPath is the path of the login callback handler, which must be
+	// registered as a redirect URI with the provider.
+	CallbackPath = "/auth/oidc/callback"
+
+	// LogoutPath is the path of the logout handler.
+	LogoutPath = "/auth/oidc/logout"
+
+	// sessionCookieName is the name of the cookie that contains the user's ID
+	// token.
+	sessionCookieName = "hermes_oidc_session"
+
+	// loginStateCookieName is the name of the cookie that contains the login
+	// state.
+	loginStateCookieName = "hermes_oidc_login"
+
+	// loginStateMaxAge is the maximum duration of a login.
+	loginStateMaxAge = 10 * time.Minute
+)
+
+// loginState is the state of a login, which is stored in a cookie during the
+// authorization code flow.
+type loginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Redirect string `json:"redirect"`
+}
+
+// LoginHandler starts the authorization code flow by redirecting the user to
+// the provider. The "redirect" query parameter is the path to return to after
+// logging in.
+func (a *Authenticator) LoginHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		oc, err := a.oauth2Config(r.Context())
+		if err != nil {
+			a.log.Error("error configuring OIDC login", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		ls := loginState{
+			Redirect: safeRedirect(r.URL.Query().Get("redirect")),
+		}
+		if ls.State, err = randomString(); err != nil {
+			a.log.Error("error generating OIDC login state", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if ls.Nonce, err = randomString(); err != nil {
+			a.log.Error("error generating OIDC login nonce", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		b, err := json.Marshal(ls)
+		if err != nil {
+			a.log.Error("error encoding OIDC login state", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     loginStateCookieName,
+			Value:    base64.RawURLEncoding.EncodeToString(b),
+			Path:     "/auth/oidc/",
+			MaxAge:   int(loginStateMaxAge.Seconds()),
+			HttpOnly: true,
+			Secure:   a.secureCookies(),
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r,
+			oc.AuthCodeURL(ls.State, oauth2.SetAuthURLParam("nonce", ls.Nonce)),
+			http.StatusFound)
+	})
+}
+
+// CallbackHandler completes the authorization code flow by exchanging the
+// authorization code for an ID token, which is stored in a session cookie.
+func (a *Authenticator) CallbackHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Clear login state cookie.
+		http.SetCookie(w, &http.Cookie{
+			Name:     loginStateCookieName,
+			Path:     "/auth/oidc/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   a.secureCookies(),
+		})
+
+		ls, err := loginStateFromRequest(r)
+		if err != nil {
+			a.log.Warn("invalid OIDC login state", "error", err)
+			http.Error(w, "Invalid login state, please try again",
+				http.StatusBadRequest)
+			return
+		}
+		q := r.URL.Query()
+		if subtle.ConstantTimeCompare(
+			[]byte(q.Get("state")), []byte(ls.State)) != 1 {
+			a.log.Warn("OIDC login state mismatch")
+			http.Error(w, "Invalid login state, please try again",
+				http.StatusBadRequest)
+			return
+		}
+		if e := q.Get("error"); e != "" {
+			a.log.Warn("OIDC provider returned an error",
+				"error", e,
+				"error_description", q.Get("error_description"),
+			)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		oc, err := a.oauth2Config(r.Context())
+		if err != nil {
+			a.log.Error("error configuring OIDC login", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// Exchange authorization code for tokens.
+		ctx := context.WithValue(r.Context(), oauth2.HTTPClient, a.httpClient)
+		tok, err := oc.Exchange(ctx, q.Get("code"))
+		if err != nil {
+			a.log.Error("error exchanging OIDC authorization code", "error", err)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		idToken, ok := tok.Extra("id_token").(string)
+		if !ok || idToken == "" {
+			a.log.Error("no ID token in OIDC token response")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		// Verify ID token.
+		claims, err := a.verifyToken(r.Context(), idToken, a.cfg.ClientID)
+		if err != nil {
+			a.log.Error("error verifying OIDC ID token", "error", err)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if nonce, _ := claims["nonce"].(string); subtle.ConstantTimeCompare(
+			[]byte(nonce), []byte(ls.Nonce)) != 1 {
+			a.log.Error("OIDC ID token nonce mismatch")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		email, err := a.emailFromClaims(claims)
+		if err != nil {
+			a.log.Error("error getting email from OIDC ID token", "error", err)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		exp, _ := claims.GetExpirationTime()
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     sessionCookieName,
+			Value:    idToken,
+			Path:     "/",
+			Expires:  exp.Time,
+			HttpOnly: true,
+			Secure:   a.secureCookies(),
+			SameSite: http.SameSiteLaxMode,
+		})
+		a.log.Info("user logged in using OIDC", "email", email)
+
+		http.Redirect(w, r, ls.Redirect, http.StatusFound)
+	})
+}
+
+// LogoutHandler clears the user's session and redirects to the provider's
+// logout endpoint, if it has one.
+func (a *Authenticator) LogoutHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{
+			Name:     sessionCookieName,
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   a.secureCookies(),
+		})
+
+		redirect := "/"
+		if md, err := a.providerMetadata(r.Context()); err == nil &&
+			md.EndSessionEndpoint != "" {
+			u, err := url.Parse(md.EndSessionEndpoint)
+			if err == nil {
+				q := u.Query()
+				q.Set("client_id", a.cfg.ClientID)
+				q.Set("post_logout_redirect_uri", a.baseURL.String())
+				u.RawQuery = q.Encode()
+				redirect = u.String()
+			}
+		}
+		http.Redirect(w, r, redirect, http.StatusFound)
+	})
+}
+
+// redirectToLogin redirects the user to log in and return to the requested
+// page.
+func (a *Authenticator) redirectToLogin(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r,
+		LoginPath+"?redirect="+url.QueryEscape(r.URL.RequestURI()),
+		http.StatusFound)
+}
+
+// oauth2Config returns the OAuth 2.0 configuration for the authorization code
+// flow.
+func (a *Authenticator) oauth2Config(
+	ctx context.Context) (*oauth2.Config, error) {
+	md, err := a.providerMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" {
+		return nil, fmt.Errorf(
+			"provider does not support the authorization code flow")
+	}
+
+	scopes := a.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &oauth2.Config{
+		ClientID:     a.cfg.ClientID,
+		ClientSecret: a.cfg.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  md.AuthorizationEndpoint,
+			TokenURL: md.TokenEndpoint,
+		},
+		RedirectURL: a.baseURL.ResolveReference(
+			&url.URL{Path: CallbackPath}).String(),
+		Scopes: scopes,
+	}, nil
+}
+
+// secureCookies returns true if cookies should only be sent over HTTPS.
+func (a *Authenticator) secureCookies() bool {
+	return a.baseURL.Scheme == "https"
+}
+
+func loginStateFromRequest(r *http.Request) (loginState, error) {
+	var ls loginState
+	c, err := r.Cookie(loginStateCookieName)
+	if err != nil {
+		return ls, fmt.Errorf("login state cookie not found")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(c.Value)
+	if err != nil {
+		return ls, fmt.Errorf("error decoding login state: %w", err)
+	}
+	if err := json.Unmarshal(b, &ls); err != nil {
+		return ls, fmt.Errorf("error decoding login state: %w", err)
+	}
+	if ls.State == "" || ls.Nonce == "" {
+		return ls, fmt.Errorf("login state is incomplete")
+	}
+	ls.Redirect = safeRedirect(ls.Redirect)
+	return ls, nil
+}
+
+// safeRedirect returns redirect if it is a local path, or "/" otherwise, to
+// prevent redirecting to other sites after logging in.
+func safeRedirect(redirect string) string {
+	if !strings.HasPrefix(redirect, "/") ||
+		strings.HasPrefix(redirect, "//") ||
+		strings.HasPrefix(redirect, "/\\") {
+		return "/"
+	}
+	return redirect
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,288 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// defaultEmailClaim is the default claim that contains the user's email
+	// address.
+	defaultEmailClaim = "email"
+
+	// defaultJWKSCacheDuration is the default duration that the provider's
+	// signing keys are cached.
+	defaultJWKSCacheDuration = time.Hour
+
+	// leeway is the allowed clock skew when validating token times.
+	leeway = time.Minute
+)
+
+// signingMethods are the allowed JWT signing algorithms. Symmetric algorithms
+// (e.g., HS256) are not allowed because the keys are public.
+var signingMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+}
+
+// Config is the configuration for OIDC authentication.
+type Config struct {
+	// Audience is the expected audience ("aud" claim) of tokens. Defaults to
+	// the client ID.
+	Audience string `hcl:"audience,optional"`
+
+	// ClientID is the OIDC client ID.
+	ClientID string `hcl:"client_id,optional"`
+
+	// ClientSecret is the OIDC client secret, which is used to exchange
+	// authorization codes for tokens when users log in.
+	ClientSecret string `hcl:"client_secret,optional"`
+
+	// Disabled disables OIDC authentication.
+	Disabled bool `hcl:"disabled,optional"`
+
+	// EmailClaim is the token claim that contains the user's email address.
+	// Defaults to "email".
+	EmailClaim string `hcl:"email_claim,optional"`
+
+	// IssuerURL is the URL of the OIDC provider, which must serve a discovery
+	// document at "/.well-known/openid-configuration".
+	IssuerURL string `hcl:"issuer_url,optional"`
+
+	// JWKSCacheDuration is the duration (e.g., "1h") that the provider's
+	// signing keys are cached. Defaults to "1h".
+	JWKSCacheDuration string `hcl:"jwks_cache_duration,optional"`
+
+	// Scopes are the scopes requested when users log in. Defaults to
+	// ["openid", "email", "profile"].
+	Scopes []string `hcl:"scopes,optional"`
+}
+
+// Authenticator implements authentication using an OIDC provider.
+type Authenticator struct {
+	// cfg is the configuration for the authenticator.
+	cfg Config
+
+	// audience is the expected token audience.
+	audience string
+
+	// baseURL is the public URL of Hermes, which is used to build the login
+	// callback URL.
+	baseURL *url.URL
+
+	// emailClaim is the claim that contains the user's email address.
+	emailClaim string
+
+	// httpClient is the HTTP client used to make requests to the provider.
+	httpClient *http.Client
+
+	// jwksCacheDuration is the duration that signing keys are cached.
+	jwksCacheDuration time.Duration
+
+	// log is the logger to use.
+	log hclog.Logger
+
+	// fetchMu serializes fetching the discovery document and signing keys, so
+	// concurrent requests don't fetch them more than once. mu must not be held
+	// when acquiring it.
+	fetchMu sync.Mutex
+
+	// mu protects the fields below. It isn't held during requests to the
+	// provider.
+	mu sync.Mutex
+
+	// metadata is the provider metadata from the discovery document.
+	metadata *providerMetadata
+
+	// keys are the provider's signing keys by key ID.
+	keys map[string]any
+
+	// keysFetchedAt is the time that keys were last fetched.
+	keysFetchedAt time.Time
+}
+
+// New returns a new OIDC authenticator. baseURL is the public URL of Hermes.
+func New(cfg Config, baseURL string, log hclog.Logger) (*Authenticator, error) {
+	if cfg.IssuerURL == "" {
+		return nil, fmt.Errorf("issuer URL is required")
+	}
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("client ID is required")
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base URL: %w", err)
+	}
+
+	a := &Authenticator{
+		cfg:               cfg,
+		audience:          cfg.ClientID,
+		baseURL:           u,
+		emailClaim:        defaultEmailClaim,
+		httpClient:        &http.Client{Timeout: 10 * time.Second},
+		jwksCacheDuration: defaultJWKSCacheDuration,
+		log:               log,
+	}
+	if cfg.Audience != "" {
+		a.audience = cfg.Audience
+	}
+	if cfg.EmailClaim != "" {
+		a.emailClaim = cfg.EmailClaim
+	}
+	if cfg.JWKSCacheDuration != "" {
+		d, err := time.ParseDuration(cfg.JWKSCacheDuration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS cache duration: %w", err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid JWKS cache duration: must be positive")
+		}
+		a.jwksCacheDuration = d
+	}
+
+	return a, nil
+}
+
+// EnforceOIDCAuth is HTTP middleware that enforces OIDC authentication.
+// Unauthenticated browser requests for web pages are redirected to log in.
+func (a *Authenticator) EnforceOIDCAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tok, fromCookie := tokenFromRequest(r)
+		if tok == "" {
+			if isPageRequest(r) {
+				a.redirectToLogin(w, r)
+				return
+			}
+			a.log.Warn("no OIDC token found in request",
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		// Session cookies contain ID tokens, which are issued to the client.
+		aud := a.audience
+		if fromCookie {
+			aud = a.cfg.ClientID
+		}
+		email, err := a.verify(r.Context(), tok, aud)
+		if err != nil {
+			if isPageRequest(r) {
+				a.redirectToLogin(w, r)
+				return
+			}
+			a.log.Error("error verifying OIDC token",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		// Set user email from the token claims.
+		ctx := context.WithValue(r.Context(), "userEmail", email)
+		r = r.WithContext(ctx)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Verify verifies a token issued by the OIDC provider for the configured
+// audience and returns the user's email address.
+func (a *Authenticator) Verify(ctx context.Context, tok string) (string, error) {
+	return a.verify(ctx, tok, a.audience)
+}
+
+func (a *Authenticator) verify(
+	ctx context.Context, tok, audience string) (string, error) {
+	claims, err := a.verifyToken(ctx, tok, audience)
+	if err != nil {
+		return "", err
+	}
+	return a.emailFromClaims(claims)
+}
+
+// verifyToken verifies the signature, issuer, audience, and expiration of a
+// token and returns its claims.
+func (a *Authenticator) verifyToken(
+	ctx context.Context, tok, audience string) (jwt.MapClaims, error) {
+	md, err := a.providerMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(tok, claims,
+		func(t *jwt.Token) (interface{}, error) {
+			kid, _ := t.Header["kid"].(string)
+			return a.signingKey(ctx, kid)
+		},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(md.Issuer),
+		jwt.WithAudience(audience),
+		jwt.WithLeeway(leeway),
+	); err != nil {
+		return nil, fmt.Errorf("error parsing token: %w", err)
+	}
+
+	// Require an expiration time.
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return nil, fmt.Errorf("token has no expiration time")
+	}
+
+	return claims, nil
+}
+
+// emailFromClaims returns the user's email address from token claims.
+func (a *Authenticator) emailFromClaims(claims jwt.MapClaims) (string, error) {
+	raw, ok := claims[a.emailClaim]
+	if !ok {
+		return "", fmt.Errorf("%s claim not found", a.emailClaim)
+	}
+	email, ok := raw.(string)
+	if !ok || email == "" {
+		return "", fmt.Errorf("%s claim is invalid", a.emailClaim)
+	}
+
+	// Reject email addresses that the provider says are unverified.
+	if a.emailClaim == defaultEmailClaim {
+		if v, ok := claims["email_verified"].(bool); ok && !v {
+			return "", fmt.Errorf("email address is not verified")
+		}
+	}
+
+	return email, nil
+}
+
+// tokenFromRequest returns the token from the Authorization header or session
+// cookie of a request, and if it was from the session cookie.
+func tokenFromRequest(r *http.Request) (tok string, fromCookie bool) {
+	if authz := r.Header.Get("Authorization"); strings.HasPrefix(authz, "Bearer ") {
+		return strings.TrimPrefix(authz, "Bearer "), false
+	}
+	if c, err := r.Cookie(sessionCookieName); err == nil {
+		return c.Value, true
+	}
+	return "", false
+}
+
+// isPageRequest returns true if a request is from a browser navigating to a
+// web page (as opposed to an API request).
+func isPageRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		!strings.HasPrefix(r.URL.Path, "/api/") &&
+		strings.Contains(r.Header.Get("Accept"), "text/html")
+}
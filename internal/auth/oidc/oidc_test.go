@@ -0,0 +1,367 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hashicorp-forge/hermes/internal/auth/oidc/oidctest"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAuthenticator(t *testing.T, idp *oidctest.Server, cfg Config) *Authenticator {
+	t.Helper()
+
+	cfg.IssuerURL = idp.Issuer()
+	cfg.ClientID = oidctest.ClientID
+	cfg.ClientSecret = oidctest.ClientSecret
+	a, err := New(cfg, "http://hermes.example.com", hclog.NewNullLogger())
+	require.NoError(t, err)
+	return a
+}
+
+func TestVerify(t *testing.T) {
+	idp := oidctest.NewServer()
+	defer idp.Close()
+
+	cases := map[string]struct {
+		cfg    Config
+		claims jwt.MapClaims
+		token  func(idp *oidctest.Server) string
+
+		wantEmail string
+		wantErr   bool
+	}{
+		"valid token": {
+			wantEmail: "user@example.com",
+		},
+		"custom email claim": {
+			cfg: Config{
+				EmailClaim: "preferred_username",
+			},
+			claims: jwt.MapClaims{
+				"preferred_username": "user2@example.com",
+			},
+			wantEmail: "user2@example.com",
+		},
+		"custom audience": {
+			cfg: Config{
+				Audience: "api://hermes",
+			},
+			claims: jwt.MapClaims{
+				"aud": "api://hermes",
+			},
+			wantEmail: "user@example.com",
+		},
+		"wrong audience": {
+			claims: jwt.MapClaims{
+				"aud": "other-client",
+			},
+			wantErr: true,
+		},
+		"wrong issuer": {
+			claims: jwt.MapClaims{
+				"iss": "https://evil.example.com",
+			},
+			wantErr: true,
+		},
+		"expired": {
+			claims: jwt.MapClaims{
+				"exp": time.Now().Add(-time.Hour).Unix(),
+			},
+			wantErr: true,
+		},
+		"no expiration": {
+			claims: jwt.MapClaims{
+				"exp": nil,
+			},
+			wantErr: true,
+		},
+		"missing email claim": {
+			claims: jwt.MapClaims{
+				"email": nil,
+			},
+			wantErr: true,
+		},
+		"unverified email": {
+			claims: jwt.MapClaims{
+				"email_verified": false,
+			},
+			wantErr: true,
+		},
+		"unsigned token": {
+			token: func(idp *oidctest.Server) string {
+				tok, _ := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+					"iss":   idp.Issuer(),
+					"aud":   oidctest.ClientID,
+					"email": "user@example.com",
+					"exp":   time.Now().Add(time.Hour).Unix(),
+				}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+				return tok
+			},
+			wantErr: true,
+		},
+		"token signed by another key": {
+			token: func(_ *oidctest.Server) string {
+				other := oidctest.NewServer()
+				defer other.Close()
+				return other.Token(jwt.MapClaims{"iss": idp.Issuer()})
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			a := newTestAuthenticator(t, idp, c.cfg)
+			tok := idp.Token(c.claims)
+			if c.token != nil {
+				tok = c.token(idp)
+			}
+
+			email, err := a.Verify(context.Background(), tok)
+			if c.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(c.wantEmail, email)
+		})
+	}
+}
+
+// blockingTransport blocks requests to the JWKS URI until unblock is closed.
+type blockingTransport struct {
+	started chan struct{}
+	unblock chan struct{}
+}
+
+func (bt *blockingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.URL.Path == "/jwks" {
+		close(bt.started)
+		<-bt.unblock
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestSigningKeyDoesNotBlockDuringFetch(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	idp := oidctest.NewServer()
+	defer idp.Close()
+
+	a := newTestAuthenticator(t, idp, Config{})
+	ctx := context.Background()
+	_, err := a.signingKey(ctx, oidctest.KeyID)
+	require.NoError(err)
+
+	// Allow refetching keys, and block fetching them.
+	bt := &blockingTransport{
+		started: make(chan struct{}),
+		unblock: make(chan struct{}),
+	}
+	a.mu.Lock()
+	a.httpClient = &http.Client{Transport: bt}
+	a.keysFetchedAt = time.Now().Add(-minKeyRefreshInterval)
+	a.mu.Unlock()
+
+	// An unknown key ID refetches keys.
+	done := make(chan error)
+	go func() {
+		_, err := a.signingKey(ctx, "unknown")
+		done <- err
+	}()
+	<-bt.started
+
+	// Cached keys are returned while keys are fetched.
+	got := make(chan error)
+	go func() {
+		_, err := a.signingKey(ctx, oidctest.KeyID)
+		got <- err
+	}()
+	select {
+	case err := <-got:
+		assert.NoError(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("signingKey blocked while keys were fetched")
+	}
+
+	close(bt.unblock)
+	assert.Error(<-done)
+}
+
+func TestEnforceOIDCAuth(t *testing.T) {
+	idp := oidctest.NewServer()
+	defer idp.Close()
+	a := newTestAuthenticator(t, idp, Config{})
+
+	cases := map[string]struct {
+		path   string
+		header http.Header
+
+		wantCode     int
+		wantEmail    string
+		wantLocation string
+	}{
+		"bearer token": {
+			path: "/api/v2/me",
+			header: http.Header{
+				"Authorization": {"Bearer " + idp.Token(nil)},
+			},
+			wantCode:  http.StatusOK,
+			wantEmail: "user@example.com",
+		},
+		"invalid bearer token": {
+			path: "/api/v2/me",
+			header: http.Header{
+				"Authorization": {"Bearer abc"},
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		"no token for API request": {
+			path:     "/api/v2/me",
+			wantCode: http.StatusUnauthorized,
+		},
+		"no token for page request": {
+			path: "/documents/abc",
+			header: http.Header{
+				"Accept": {"text/html,application/xhtml+xml"},
+			},
+			wantCode:     http.StatusFound,
+			wantLocation: LoginPath + "?redirect=%2Fdocuments%2Fabc",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var gotEmail any
+			h := a.EnforceOIDCAuth(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotEmail = r.Context().Value("userEmail")
+				}))
+
+			r := httptest.NewRequest(http.MethodGet, c.path, nil)
+			for k, v := range c.header {
+				r.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assert.Equal(c.wantCode, w.Code)
+			if c.wantEmail != "" {
+				assert.Equal(c.wantEmail, gotEmail)
+			} else {
+				assert.Nil(gotEmail)
+			}
+			if c.wantLocation != "" {
+				assert.Equal(c.wantLocation, w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	idp := oidctest.NewServer()
+	defer idp.Close()
+	idp.SetUser("login@example.com")
+
+	// Start Hermes with the login handlers and an authenticated page.
+	mux := http.NewServeMux()
+	hermes := httptest.NewServer(mux)
+	defer hermes.Close()
+
+	a, err := New(Config{
+		IssuerURL:    idp.Issuer(),
+		ClientID:     oidctest.ClientID,
+		ClientSecret: oidctest.ClientSecret,
+	}, hermes.URL, hclog.NewNullLogger())
+	require.NoError(err)
+
+	mux.Handle(LoginPath, a.LoginHandler())
+	mux.Handle(CallbackPath, a.CallbackHandler())
+	mux.Handle(LogoutPath, a.LogoutHandler())
+	mux.Handle("/", a.EnforceOIDCAuth(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.Context().Value("userEmail").(string)))
+		})))
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(err)
+	client := &http.Client{Jar: jar}
+
+	// Requesting a page redirects through the provider and back to the page.
+	req, err := http.NewRequest(http.MethodGet, hermes.URL+"/projects/1", nil)
+	require.NoError(err)
+	req.Header.Set("Accept", "text/html")
+	resp, err := client.Do(req)
+	require.NoError(err)
+	defer resp.Body.Close()
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("/projects/1", resp.Request.URL.Path)
+
+	u, err := url.Parse(hermes.URL)
+	require.NoError(err)
+	var session string
+	for _, c := range jar.Cookies(u) {
+		if c.Name == sessionCookieName {
+			session = c.Value
+		}
+	}
+	require.NotEmpty(session)
+
+	// API requests are authenticated using the session cookie.
+	resp, err = client.Get(hermes.URL + "/api/v2/me")
+	require.NoError(err)
+	defer resp.Body.Close()
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	email, err := a.Verify(context.Background(), session)
+	require.NoError(err)
+	assert.Equal("login@example.com", email)
+
+	// Logging out clears the session.
+	resp, err = client.Get(hermes.URL + LogoutPath)
+	require.NoError(err)
+	defer resp.Body.Close()
+	resp, err = client.Get(hermes.URL + "/api/v2/me")
+	require.NoError(err)
+	defer resp.Body.Close()
+	assert.Equal(http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestLoginCallbackInvalidState(t *testing.T) {
+	idp := oidctest.NewServer()
+	defer idp.Close()
+	a := newTestAuthenticator(t, idp, Config{})
+
+	r := httptest.NewRequest(http.MethodGet, CallbackPath+"?code=abc&state=xyz", nil)
+	w := httptest.NewRecorder()
+	a.CallbackHandler().ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSafeRedirect(t *testing.T) {
+	cases := map[string]string{
+		"/documents/abc?draft=true": "/documents/abc?draft=true",
+		"":                          "/",
+		"https://evil.example.com":  "/",
+		"//evil.example.com":        "/",
+		"/\\evil.example.com":       "/",
+	}
+
+	for in, want := range cases {
+		assert.Equal(t, want, safeRedirect(in), in)
+	}
+}
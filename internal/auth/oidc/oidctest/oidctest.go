@@ -0,0 +1,214 @@
+// Package oidctest provides a local OpenID Connect provider for testing.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// ClientID is the client ID registered with the provider.
+	ClientID = "hermes-test"
+
+	// ClientSecret is the client secret registered with the provider.
+	ClientSecret = "hermes-test-secret"
+
+	// KeyID is the ID of the provider's signing key.
+	KeyID = "test-key"
+)
+
+// Server is a local OpenID Connect provider. It serves a discovery document
+// and JWKS, and implements the authorization code flow by immediately
+// redirecting back with a code for the configured user.
+type Server struct {
+	*httptest.Server
+
+	key *rsa.PrivateKey
+
+	mu sync.Mutex
+
+	// email is the email address of the user that logs in.
+	email string
+
+	// codes are issued authorization codes and their nonces.
+	codes map[string]string
+}
+
+// NewServer starts and returns a new local OpenID Connect provider. The caller
+// should call Close when finished.
+func NewServer() *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic("oidctest: error generating key: " + err.Error())
+	}
+
+	s := &Server{
+		key:   key,
+		email: "user@example.com",
+		codes: map[string]string{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.handleDiscovery)
+	mux.HandleFunc("/jwks", s.handleJWKS)
+	mux.HandleFunc("/authorize", s.handleAuthorize)
+	mux.HandleFunc("/token", s.handleToken)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Issuer returns the issuer URL of the provider.
+func (s *Server) Issuer() string {
+	return s.URL
+}
+
+// SetUser sets the email address of the user that logs in using the
+// authorization code flow.
+func (s *Server) SetUser(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.email = email
+}
+
+// Token returns a token signed by the provider with standard claims for the
+// client (issuer, audience, subject, email, and issued and expiration times),
+// overridden by the provided claims.
+func (s *Server) Token(claims jwt.MapClaims) string {
+	now := time.Now()
+	c := jwt.MapClaims{
+		"iss":            s.Issuer(),
+		"aud":            ClientID,
+		"sub":            "user",
+		"email":          "user@example.com",
+		"email_verified": true,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		if v == nil {
+			delete(c, k)
+			continue
+		}
+		c[k] = v
+	}
+	return s.sign(c)
+}
+
+func (s *Server) sign(claims jwt.MapClaims) string {
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	t.Header["kid"] = KeyID
+	tok, err := t.SignedString(s.key)
+	if err != nil {
+		panic("oidctest: error signing token: " + err.Error())
+	}
+	return tok
+}
+
+func (s *Server) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]string{
+		"issuer":                 s.Issuer(),
+		"authorization_endpoint": s.URL + "/authorize",
+		"token_endpoint":         s.URL + "/token",
+		"jwks_uri":               s.URL + "/jwks",
+	})
+}
+
+func (s *Server) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	pub := s.key.PublicKey
+	writeJSON(w, map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": KeyID,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				"e": base64.RawURLEncoding.EncodeToString(
+					big.NewInt(int64(pub.E)).Bytes()),
+			},
+		},
+	})
+}
+
+func (s *Server) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != ClientID || q.Get("response_type") != "code" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || redirect.String() == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	s.mu.Lock()
+	s.codes[code] = q.Get("nonce")
+	s.mu.Unlock()
+
+	rq := redirect.Query()
+	rq.Set("code", code)
+	rq.Set("state", q.Get("state"))
+	redirect.RawQuery = rq.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if id != ClientID || secret != ClientSecret {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	nonce, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	email := s.email
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, map[string]any{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token": s.Token(jwt.MapClaims{
+			"email": email,
+			"nonce": nonce,
+		}),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic("oidctest: error generating random string: " + err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
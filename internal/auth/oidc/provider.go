@@ -0,0 +1,276 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// minKeyRefreshInterval is the minimum duration between fetches of the
+// provider's signing keys when a token has an unknown key ID, to avoid making
+// a request to the provider for every invalid token.
+const minKeyRefreshInterval = time.Minute
+
+// providerMetadata is the subset of the provider's discovery document that is
+// used.
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+}
+
+// jsonWebKey is a JSON Web Key (RFC 7517).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// providerMetadata returns the provider metadata, fetching the discovery
+// document if it hasn't been successfully fetched yet.
+func (a *Authenticator) providerMetadata(
+	ctx context.Context) (*providerMetadata, error) {
+	a.mu.Lock()
+	md := a.metadata
+	a.mu.Unlock()
+	if md != nil {
+		return md, nil
+	}
+
+	a.fetchMu.Lock()
+	defer a.fetchMu.Unlock()
+
+	// Check if another request fetched the discovery document while waiting.
+	a.mu.Lock()
+	md = a.metadata
+	a.mu.Unlock()
+	if md != nil {
+		return md, nil
+	}
+
+	issuer := strings.TrimSuffix(a.cfg.IssuerURL, "/")
+	md = &providerMetadata{}
+	if err := a.getJSON(
+		ctx, issuer+"/.well-known/openid-configuration", md); err != nil {
+		return nil, fmt.Errorf("error getting discovery document: %w", err)
+	}
+	if strings.TrimSuffix(md.Issuer, "/") != issuer {
+		return nil, fmt.Errorf(
+			"discovery document issuer %q does not match issuer URL %q",
+			md.Issuer, a.cfg.IssuerURL)
+	}
+	if md.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document has no jwks_uri")
+	}
+
+	a.mu.Lock()
+	a.metadata = md
+	a.mu.Unlock()
+	return md, nil
+}
+
+// signingKey returns the provider's public key with key ID kid. Keys are
+// cached, and refetched if the cache has expired or the key ID is unknown.
+// Keys are fetched without holding a.mu, so requests with cached keys aren't
+// blocked by a slow provider.
+func (a *Authenticator) signingKey(ctx context.Context, kid string) (any, error) {
+	md, err := a.providerMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok, refetch := a.cachedKey(kid)
+	if !refetch {
+		if ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("signing key %q not found", kid)
+	}
+
+	a.fetchMu.Lock()
+	defer a.fetchMu.Unlock()
+
+	// Check if another request refetched keys while waiting.
+	key, ok, refetch = a.cachedKey(kid)
+	if !refetch {
+		if ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("signing key %q not found", kid)
+	}
+
+	keys, err := a.fetchKeys(ctx, md.JWKSURI)
+	if err != nil {
+		// Use a cached key if the provider is unavailable.
+		if ok {
+			a.log.Warn("error refreshing OIDC signing keys, using cached key",
+				"error", err)
+			return key, nil
+		}
+		return nil, err
+	}
+
+	a.mu.Lock()
+	a.keys = keys
+	a.keysFetchedAt = time.Now()
+	key, ok = a.lookupKey(kid)
+	a.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("signing key %q not found", kid)
+}
+
+// cachedKey returns the cached key with key ID kid, and true for refetch if
+// keys should be refetched because the cache has expired or the key ID is
+// unknown (unless keys were just fetched).
+func (a *Authenticator) cachedKey(kid string) (key any, ok, refetch bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key, ok = a.lookupKey(kid)
+	if ok && time.Since(a.keysFetchedAt) < a.jwksCacheDuration {
+		return key, true, false
+	}
+	refetch = a.keys == nil ||
+		time.Since(a.keysFetchedAt) >= minKeyRefreshInterval
+	return key, ok, refetch
+}
+
+// lookupKey returns the cached key with key ID kid. If kid is empty and there
+// is only one key, that key is returned. a.mu must be held.
+func (a *Authenticator) lookupKey(kid string) (any, bool) {
+	if kid == "" && len(a.keys) == 1 {
+		for _, k := range a.keys {
+			return k, true
+		}
+	}
+	k, ok := a.keys[kid]
+	return k, ok
+}
+
+// fetchKeys fetches the provider's signing keys from the JWKS URI.
+func (a *Authenticator) fetchKeys(
+	ctx context.Context, jwksURI string) (map[string]any, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := a.getJSON(ctx, jwksURI, &jwks); err != nil {
+		return nil, fmt.Errorf("error getting JWKS: %w", err)
+	}
+
+	keys := make(map[string]any, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			a.log.Warn("skipping invalid OIDC signing key",
+				"error", err,
+				"kid", jwk.Kid,
+			)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no valid signing keys found in JWKS")
+	}
+
+	return keys, nil
+}
+
+// getJSON makes a GET request to url and decodes the JSON response into out.
+func (a *Authenticator) getJSON(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// publicKey returns the public key of a JSON Web Key.
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC y coordinate: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("EC point is not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
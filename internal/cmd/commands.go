@@ -28,11 +28,36 @@ func initCommands(log hclog.Logger, ui cli.Ui) {
 				Command: b,
 			}, nil
 		},
+		"operator build-site": func() (cli.Command, error) {
+			return &operator.BuildSiteCommand{
+				Command: b,
+			}, nil
+		},
+		"operator export": func() (cli.Command, error) {
+			return &operator.ExportCommand{
+				Command: b,
+			}, nil
+		},
+		"operator import": func() (cli.Command, error) {
+			return &operator.ImportCommand{
+				Command: b,
+			}, nil
+		},
 		"operator migrate-algolia-to-postgresql": func() (cli.Command, error) {
 			return &operator.MigrateAlgoliaToPostgreSQLCommand{
 				Command: b,
 			}, nil
 		},
+		"operator renumber": func() (cli.Command, error) {
+			return &operator.RenumberCommand{
+				Command: b,
+			}, nil
+		},
+		"operator repair-doc-numbers": func() (cli.Command, error) {
+			return &operator.RepairDocNumbersCommand{
+				Command: b,
+			}, nil
+		},
 		"server": func() (cli.Command, error) {
 			return &server.Command{
 				Command: b,
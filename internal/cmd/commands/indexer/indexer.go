@@ -10,9 +10,11 @@ import (
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/datadog"
 	"github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/internal/embeddings"
 	"github.com/hashicorp-forge/hermes/internal/indexer"
 	"github.com/hashicorp-forge/hermes/pkg/algolia"
 	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
+	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp/go-hclog"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 )
@@ -166,6 +168,62 @@ func (c *Command) Run(args []string) int {
 		idxOpts = append(idxOpts,
 			indexer.WithUseDatabaseForDocumentData(true))
 	}
+	if cfg.DraftsTrash != nil {
+		retention, err := cfg.DraftsTrashRetentionPeriod()
+		if err != nil {
+			ui.Error(fmt.Sprintf("error parsing drafts trash config: %v", err))
+			return 1
+		}
+		idxOpts = append(idxOpts,
+			indexer.WithDraftsTrashRetention(retention))
+	}
+	if cfg.Retention != nil {
+		if err := config.ValidateRetention(cfg.Retention); err != nil {
+			ui.Error(fmt.Sprintf("error validating retention config: %v", err))
+			return 1
+		}
+		notice, err := cfg.RetentionNoticePeriod()
+		if err != nil {
+			ui.Error(fmt.Sprintf("error parsing retention config: %v", err))
+			return 1
+		}
+		idxOpts = append(idxOpts,
+			indexer.WithRetention(cfg.Retention),
+			indexer.WithRetentionNoticePeriod(notice))
+	}
+	if cfg.StaleDrafts != nil {
+		threshold, err := cfg.StaleDraftsThreshold()
+		if err != nil {
+			ui.Error(fmt.Sprintf("error parsing stale drafts config: %v", err))
+			return 1
+		}
+		interval, err := cfg.StaleDraftsNudgeInterval()
+		if err != nil {
+			ui.Error(fmt.Sprintf("error parsing stale drafts config: %v", err))
+			return 1
+		}
+		idxOpts = append(idxOpts,
+			indexer.WithAdmins(cfg.Admins),
+			indexer.WithStaleDraftsNudgeInterval(interval),
+			indexer.WithStaleDraftsThreshold(threshold))
+	}
+	if cfg.Email != nil && cfg.Email.Enabled {
+		idxOpts = append(idxOpts,
+			indexer.WithEmailFromAddress(cfg.Email.FromAddress))
+	}
+	if cfg.Embeddings != nil && cfg.Embeddings.Enabled {
+		emb, err := embeddings.New(*cfg.Embeddings)
+		if err != nil {
+			ui.Error(fmt.Sprintf("error initializing embeddings provider: %v", err))
+			return 1
+		}
+		if err := models.MigrateDocumentEmbeddings(db); err != nil {
+			ui.Error(fmt.Sprintf(
+				"error migrating document embeddings: %v", err))
+			return 1
+		}
+		idxOpts = append(idxOpts, indexer.WithEmbeddings(emb))
+	}
 	idx, err := indexer.NewIndexer(idxOpts...)
 	if err != nil {
 		ui.Error(fmt.Sprintf("error creating indexer: %v", err))
@@ -173,13 +231,29 @@ func (c *Command) Run(args []string) int {
 	}
 
 	ui.Info("starting indexer...")
-	go func() int {
-		if err := idx.Run(); err != nil {
-			ui.Error(err.Error())
-			// TODO: get this return value from indexer.Run().
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- idx.Run(c.Context)
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			ui.Error(fmt.Sprintf("error running indexer: %v", err))
 			return 1
 		}
 		return 0
-	}()
-	return c.WaitForInterrupt(func() {})
+	case <-c.ShutdownCh:
+		var runErr error
+		exitCode := c.WaitForInterrupt(func() {
+			log.Info("waiting for the indexer to finish the current document...")
+			if runErr = <-errCh; runErr != nil {
+				ui.Error(fmt.Sprintf("error running indexer: %v", runErr))
+			}
+		})
+		if runErr != nil {
+			return 1
+		}
+		return exitCode
+	}
 }
@@ -0,0 +1,267 @@
+package operator
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/internal/export"
+	"github.com/hashicorp-forge/hermes/internal/site"
+	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
+	"github.com/hashicorp-forge/hermes/pkg/links"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type BuildSiteCommand struct {
+	*base.Command
+
+	flagBasePath string
+	flagConfig   string
+	flagDocType  string
+	flagOutput   string
+	flagProduct  string
+	flagStatus   string
+	flagTitle    string
+}
+
+func (c *BuildSiteCommand) Synopsis() string {
+	return "Build a static HTML site of published documents"
+}
+
+func (c *BuildSiteCommand) Help() string {
+	return `Usage: hermes operator build-site
+
+  This command renders published documents matching the doc-type, product, and
+  status flags to a static HTML site in the output directory, for read-only
+  documentation sites. Each document page includes the document's Hermes
+  metadata and links to related resources (to pages on the site for related
+  documents that are published to it). The site has index pages per product
+  and document type, and short link pages that redirect to documents under the
+  same "/l" paths as Hermes.` +
+		c.Flags().Help()
+}
+
+func (c *BuildSiteCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("build-site", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagBasePath, "base-path", "/",
+		"URL path that the site is served from (e.g., \"/docs/\").",
+	)
+	f.StringVar(
+		&c.flagConfig, "config", "", "(Required) Path to Hermes config file",
+	)
+	f.StringVar(
+		&c.flagDocType, "doc-type", "",
+		"Comma-separated document types of the documents to publish. Publishes "+
+			"all document types if empty.",
+	)
+	f.StringVar(
+		&c.flagOutput, "output", "",
+		"(Required) Directory to write the site to.",
+	)
+	f.StringVar(
+		&c.flagProduct, "product", "",
+		"Comma-separated products of the documents to publish. Publishes all "+
+			"products if empty.",
+	)
+	f.StringVar(
+		&c.flagStatus, "status", "In-Review,Approved,Obsolete",
+		"Comma-separated statuses of the documents to publish (\"In-Review\", "+
+			"\"Approved\", or \"Obsolete\").",
+	)
+	f.StringVar(
+		&c.flagTitle, "title", "Hermes",
+		"Title of the site.",
+	)
+
+	return f
+}
+
+func (c *BuildSiteCommand) Run(args []string) int {
+	logger, ui := c.Log, c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagConfig == "" {
+		ui.Error("config flag is required")
+		return 1
+	}
+	if c.flagOutput == "" {
+		ui.Error("output flag is required")
+		return 1
+	}
+	statuses, err := parseDocumentStatuses(c.flagStatus)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing status flag: %v", err))
+		return 1
+	}
+	for _, s := range statuses {
+		if s == models.WIPDocumentStatus {
+			ui.Error("drafts can't be published to a site")
+			return 1
+		}
+	}
+
+	// Parse configuration.
+	cfg, err := config.NewConfig(c.flagConfig)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing config file: %v", err))
+		return 1
+	}
+
+	// Validate document types and products.
+	docTypes, err := parseDocTypes(cfg, c.flagDocType)
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+	products, err := parseProducts(cfg, c.flagProduct)
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+
+	// Initialize database.
+	if val, ok := os.LookupEnv("HERMES_SERVER_POSTGRES_PASSWORD"); ok {
+		cfg.Postgres.Password = val
+	}
+	db, err := db.NewDB(*cfg.Postgres)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error initializing database: %v", err))
+		return 1
+	}
+	// Create GORM-compatible logger.
+	stdLogger := logger.StandardLogger(&hclog.StandardLoggerOptions{
+		InferLevels: true,
+	})
+	// Ignore "record not found" errors.
+	db = db.Session(&gorm.Session{Logger: gormlogger.New(
+		stdLogger,
+		gormlogger.Config{IgnoreRecordNotFoundError: true},
+	)})
+
+	// Initialize Google Workspace service.
+	var goog *gw.Service
+	if cfg.GoogleWorkspace.Auth != nil {
+		// Use Google Workspace auth if it is defined in the config.
+		goog = gw.NewFromConfig(cfg.GoogleWorkspace.Auth)
+	} else {
+		// Use OAuth if Google Workspace auth is not defined in the config.
+		goog = gw.New()
+	}
+
+	start := time.Now()
+
+	// Get documents to publish. Documents without a status filter would include
+	// drafts, so default to all published statuses.
+	if len(statuses) == 0 {
+		statuses = []models.DocumentStatus{
+			models.InReviewDocumentStatus,
+			models.ApprovedDocumentStatus,
+			models.ObsoleteDocumentStatus,
+		}
+	}
+	docs, err := models.GetDocuments(db, models.DocumentFilter{
+		DocumentTypes: docTypes,
+		Products:      products,
+		Statuses:      statuses,
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf("error getting documents: %v", err))
+		return 1
+	}
+
+	s := site.New(c.flagTitle, c.flagBasePath)
+	var docsWithErrors int
+	for _, d := range docs {
+		p, err := newSitePage(db, goog, d, cfg.BaseURL)
+		if err == nil {
+			err = s.Add(p)
+		}
+		if err != nil {
+			logger.Error("error adding document to site",
+				"error", err,
+				"document_id", d.GoogleFileID,
+			)
+			docsWithErrors++
+		}
+	}
+
+	if err := s.Build(c.flagOutput); err != nil {
+		ui.Error(fmt.Sprintf("error building site: %v", err))
+		return 1
+	}
+	ui.Info(fmt.Sprintf("Built site with %d documents in %s (took %s)",
+		len(docs)-docsWithErrors, c.flagOutput, time.Since(start)))
+
+	if docsWithErrors > 0 {
+		ui.Error(fmt.Sprintf("%d documents could not be added to the site",
+			docsWithErrors))
+		return 1
+	}
+
+	return 0
+}
+
+// newSitePage returns the site page of document model, with its content
+// exported from Google Workspace as HTML.
+func newSitePage(
+	db *gorm.DB,
+	goog *gw.Service,
+	model models.Document,
+	baseURL string,
+) (site.Page, error) {
+	md, err := export.NewMetadata(db, model, baseURL)
+	if err != nil {
+		return site.Page{}, err
+	}
+
+	content, err := goog.ExportFile(
+		model.GoogleFileID, export.FormatHTML.MIMEType())
+	if err != nil {
+		return site.Page{}, fmt.Errorf("error exporting document: %w", err)
+	}
+
+	// Get short links, including links for the document's previous numbers and
+	// aliases.
+	var sls models.ShortLinks
+	if err := sls.FindByDocument(db, model.ID); err != nil {
+		return site.Page{}, fmt.Errorf("error getting short links: %w", err)
+	}
+	var paths []string
+	seen := map[string]bool{}
+	for _, l := range sls {
+		if !seen[l.Path] {
+			seen[l.Path] = true
+			paths = append(paths, l.Path)
+		}
+	}
+	// Documents published before short links were stored in the database may
+	// not have a short link for their current number.
+	if model.DocumentNumber != 0 {
+		p := links.DocumentShortLinkPath(md.DocType, md.DocNumber)
+		if !seen[p] {
+			paths = append(paths, p)
+		}
+	}
+
+	return site.Page{
+		Metadata:   md,
+		Content:    content,
+		ShortLinks: paths,
+	}, nil
+}
@@ -0,0 +1,358 @@
+package operator
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/internal/export"
+	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type ExportCommand struct {
+	*base.Command
+
+	flagConfig  string
+	flagDocID   string
+	flagDocType string
+	flagFormat  string
+	flagOutput  string
+	flagProduct string
+	flagStatus  string
+}
+
+func (c *ExportCommand) Synopsis() string {
+	return "Export documents with their Hermes metadata"
+}
+
+func (c *ExportCommand) Help() string {
+	return `Usage: hermes operator export
+
+  This command exports documents from Google Workspace with their Hermes
+  metadata (document number, status, owner, approvers, approvals, and related
+  resources) as YAML front matter, for offline archives and legal holds.
+
+  If the doc-id flag is set, the document is written to the output directory.
+  Otherwise, documents matching the doc-type, product, and status flags are
+  written to a gzipped tarball per product in the output directory. The
+  metadata of PDF exports is written to a YAML file next to each document.` +
+		c.Flags().Help()
+}
+
+func (c *ExportCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("export", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagConfig, "config", "", "(Required) Path to Hermes config file",
+	)
+	f.StringVar(
+		&c.flagDocID, "doc-id", "",
+		"ID of a document to export. Exports all matching documents if empty.",
+	)
+	f.StringVar(
+		&c.flagDocType, "doc-type", "",
+		"Comma-separated document types of the documents to export. Exports "+
+			"all document types if empty.",
+	)
+	f.StringVar(
+		&c.flagFormat, "format", string(export.FormatMarkdown),
+		"Export format (\"md\", \"html\", or \"pdf\").",
+	)
+	f.StringVar(
+		&c.flagOutput, "output", "",
+		"(Required) Directory to write exported documents to.",
+	)
+	f.StringVar(
+		&c.flagProduct, "product", "",
+		"Comma-separated products of the documents to export. Exports all "+
+			"products if empty.",
+	)
+	f.StringVar(
+		&c.flagStatus, "status", "In-Review,Approved,Obsolete",
+		"Comma-separated statuses of the documents to export. Include \"WIP\" "+
+			"to export drafts.",
+	)
+
+	return f
+}
+
+func (c *ExportCommand) Run(args []string) int {
+	logger, ui := c.Log, c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagConfig == "" {
+		ui.Error("config flag is required")
+		return 1
+	}
+	if c.flagOutput == "" {
+		ui.Error("output flag is required")
+		return 1
+	}
+	format, err := export.ParseFormat(c.flagFormat)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing format flag: %v", err))
+		return 1
+	}
+	statuses, err := parseDocumentStatuses(c.flagStatus)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing status flag: %v", err))
+		return 1
+	}
+
+	// Parse configuration.
+	cfg, err := config.NewConfig(c.flagConfig)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing config file: %v", err))
+		return 1
+	}
+
+	// Validate document types and products.
+	docTypes, err := parseDocTypes(cfg, c.flagDocType)
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+	products, err := parseProducts(cfg, c.flagProduct)
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+
+	// Initialize database.
+	if val, ok := os.LookupEnv("HERMES_SERVER_POSTGRES_PASSWORD"); ok {
+		cfg.Postgres.Password = val
+	}
+	db, err := db.NewDB(*cfg.Postgres)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error initializing database: %v", err))
+		return 1
+	}
+	// Create GORM-compatible logger.
+	stdLogger := logger.StandardLogger(&hclog.StandardLoggerOptions{
+		InferLevels: true,
+	})
+	// Ignore "record not found" errors.
+	db = db.Session(&gorm.Session{Logger: gormlogger.New(
+		stdLogger,
+		gormlogger.Config{IgnoreRecordNotFoundError: true},
+	)})
+
+	// Initialize Google Workspace service.
+	var goog *gw.Service
+	if cfg.GoogleWorkspace.Auth != nil {
+		// Use Google Workspace auth if it is defined in the config.
+		goog = gw.NewFromConfig(cfg.GoogleWorkspace.Auth)
+	} else {
+		// Use OAuth if Google Workspace auth is not defined in the config.
+		goog = gw.New()
+	}
+
+	if err := os.MkdirAll(c.flagOutput, 0755); err != nil {
+		ui.Error(fmt.Sprintf("error creating output directory: %v", err))
+		return 1
+	}
+
+	// Export a single document.
+	if c.flagDocID != "" {
+		model := models.Document{
+			GoogleFileID: c.flagDocID,
+		}
+		if err := model.Get(db); err != nil {
+			ui.Error(fmt.Sprintf("error getting document: %v", err))
+			return 1
+		}
+		f, err := export.Document(db, goog, model, format, cfg.BaseURL)
+		if err != nil {
+			ui.Error(fmt.Sprintf("error exporting document: %v", err))
+			return 1
+		}
+		name := filepath.Join(c.flagOutput, f.Name)
+		if err := os.WriteFile(name, f.Content, 0644); err != nil {
+			ui.Error(fmt.Sprintf("error writing exported document: %v", err))
+			return 1
+		}
+		ui.Info(fmt.Sprintf("Exported document to %s", name))
+		return 0
+	}
+
+	// Get documents to export.
+	docs, err := models.GetDocuments(db, models.DocumentFilter{
+		DocumentTypes: docTypes,
+		Products:      products,
+		Statuses:      statuses,
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf("error getting documents: %v", err))
+		return 1
+	}
+	if len(docs) == 0 {
+		ui.Info("No documents to export")
+		return 0
+	}
+
+	// Group documents by product. Documents are ordered by product.
+	var (
+		byProduct      [][]models.Document
+		docsWithErrors int
+	)
+	for i, d := range docs {
+		if i == 0 || d.Product.Name != docs[i-1].Product.Name {
+			byProduct = append(byProduct, nil)
+		}
+		byProduct[len(byProduct)-1] = append(byProduct[len(byProduct)-1], d)
+	}
+
+	// Write an archive per product.
+	for _, pds := range byProduct {
+		product := pds[0].Product.Name
+		name := filepath.Join(c.flagOutput,
+			export.SanitizeFileName(product)+".tar.gz")
+		n, errs, err := writeExportArchive(
+			db, goog, pds, format, cfg.BaseURL, name, logger)
+		if err != nil {
+			ui.Error(fmt.Sprintf("error writing archive for product %q: %v",
+				product, err))
+			return 1
+		}
+		docsWithErrors += errs
+		ui.Info(fmt.Sprintf("Exported %d documents to %s", n, name))
+	}
+
+	if docsWithErrors > 0 {
+		ui.Error(fmt.Sprintf("%d documents could not be exported", docsWithErrors))
+		return 1
+	}
+
+	return 0
+}
+
+// writeExportArchive exports documents docs to an archive at path name, with a
+// directory per document type. It returns the number of exported documents and
+// the number of documents that could not be exported.
+func writeExportArchive(
+	db *gorm.DB,
+	goog *gw.Service,
+	docs []models.Document,
+	format export.Format,
+	baseURL string,
+	name string,
+	logger hclog.Logger,
+) (int, int, error) {
+	out, err := os.Create(name)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error creating archive file: %w", err)
+	}
+	defer out.Close()
+
+	var exported, errs int
+	a := export.NewArchive(out)
+	for _, d := range docs {
+		f, err := export.Document(db, goog, d, format, baseURL)
+		if err != nil {
+			logger.Error("error exporting document",
+				"error", err,
+				"document_id", d.GoogleFileID,
+			)
+			errs++
+			continue
+		}
+		dir := filepath.ToSlash(filepath.Join(
+			export.SanitizeFileName(d.Product.Name),
+			export.SanitizeFileName(d.DocumentType.Name),
+		))
+		if err := a.Add(dir, f); err != nil {
+			return exported, errs, err
+		}
+		exported++
+	}
+	if err := a.Close(); err != nil {
+		return exported, errs, err
+	}
+	if err := out.Close(); err != nil {
+		return exported, errs, fmt.Errorf("error closing archive file: %w", err)
+	}
+
+	return exported, errs, nil
+}
+
+// parseDocTypes parses a comma-separated list of document types in config cfg.
+func parseDocTypes(cfg *config.Config, s string) ([]string, error) {
+	var docTypes []string
+	for _, v := range splitList(s) {
+		docType := ""
+		for _, dt := range cfg.DocumentTypes.DocumentType {
+			if strings.EqualFold(dt.Name, v) {
+				docType = dt.Name
+				break
+			}
+		}
+		if docType == "" {
+			return nil, fmt.Errorf("document type not found in config: %s", v)
+		}
+		docTypes = append(docTypes, docType)
+	}
+	return docTypes, nil
+}
+
+// parseProducts parses a comma-separated list of products (names or
+// abbreviations) in config cfg.
+func parseProducts(cfg *config.Config, s string) ([]string, error) {
+	var products []string
+	for _, v := range splitList(s) {
+		p := findProduct(cfg.Products.Product, v)
+		if p == nil {
+			return nil, fmt.Errorf("product not found in config: %s", v)
+		}
+		products = append(products, p.Name)
+	}
+	return products, nil
+}
+
+// parseDocumentStatuses parses a comma-separated list of document statuses.
+func parseDocumentStatuses(s string) ([]models.DocumentStatus, error) {
+	var statuses []models.DocumentStatus
+	for _, v := range splitList(s) {
+		status, ok := normalizeImportStatus(v)
+		if !ok {
+			return nil, fmt.Errorf("invalid document status: %q", v)
+		}
+		switch status {
+		case "WIP":
+			statuses = append(statuses, models.WIPDocumentStatus)
+		case "In-Review":
+			statuses = append(statuses, models.InReviewDocumentStatus)
+		case "Approved":
+			statuses = append(statuses, models.ApprovedDocumentStatus)
+		case "Obsolete":
+			statuses = append(statuses, models.ObsoleteDocumentStatus)
+		}
+	}
+	return statuses, nil
+}
+
+// splitList splits a comma-separated list, ignoring empty values.
+func splitList(s string) []string {
+	var vals []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			vals = append(vals, v)
+		}
+	}
+	return vals
+}
@@ -0,0 +1,46 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDocumentStatuses(t *testing.T) {
+	cases := map[string]struct {
+		want      []models.DocumentStatus
+		shouldErr bool
+	}{
+		"": {},
+		"In-Review,Approved,Obsolete": {
+			want: []models.DocumentStatus{
+				models.InReviewDocumentStatus,
+				models.ApprovedDocumentStatus,
+				models.ObsoleteDocumentStatus,
+			},
+		},
+		"wip, in review,": {
+			want: []models.DocumentStatus{
+				models.WIPDocumentStatus,
+				models.InReviewDocumentStatus,
+			},
+		},
+		"Approved,Shipped": {
+			shouldErr: true,
+		},
+	}
+
+	for s, c := range cases {
+		t.Run(s, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := parseDocumentStatuses(s)
+			if c.shouldErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(c.want, got)
+		})
+	}
+}
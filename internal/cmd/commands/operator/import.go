@@ -0,0 +1,755 @@
+package operator
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/mail"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/pkg/algolia"
+	"github.com/hashicorp-forge/hermes/pkg/docnumber"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
+	"github.com/hashicorp-forge/hermes/pkg/hashicorpdocs"
+	"github.com/hashicorp-forge/hermes/pkg/links"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/cli"
+	"google.golang.org/api/drive/v3"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type ImportCommand struct {
+	*base.Command
+
+	flagAutoApprove bool
+	flagConfig      string
+	flagDocType     string
+	flagDryRun      bool
+	flagFolder      string
+	flagProduct     string
+	flagVerbose     bool
+}
+
+// importer contains the importer configuration.
+type importer struct {
+	Algolia  *algolia.Client
+	Config   *config.Config
+	Database *gorm.DB
+	DocType  string
+	Goog     *gw.Service
+	Logger   hclog.Logger
+	Product  string
+
+	// users is a cache of email addresses to the primary email addresses of
+	// users in the Google Workspace directory, or an empty string if there is no
+	// such user.
+	users map[string]string
+}
+
+// importDoc is a document to import.
+type importDoc struct {
+	Doc document.Document
+
+	// Conflicts are problems that prevent importing the document.
+	Conflicts []string
+
+	// Exists is true if the document already exists in Hermes.
+	Exists bool
+
+	// NumberAssigned is true if the document number is assigned when the
+	// document is imported, instead of preserved from the document.
+	NumberAssigned bool
+
+	// Warnings are problems that don't prevent importing the document.
+	Warnings []string
+}
+
+func (c *ImportCommand) Synopsis() string {
+	return "Import existing Google Docs into Hermes"
+}
+
+func (c *ImportCommand) Help() string {
+	return `Usage: hermes operator import
+
+  This command imports existing Google Docs in a Google Drive folder (and all of
+  its subfolders) into Hermes. Document headers are parsed for metadata, owners
+  and approvers are mapped to users in the Google Workspace directory, and
+  document numbers are preserved or assigned. Documents with conflicts are
+  reported and not imported. The Google Docs are not modified.` +
+		c.Flags().Help()
+}
+
+func (c *ImportCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("import", flag.ExitOnError))
+
+	f.BoolVar(
+		&c.flagAutoApprove, "auto-approve", false,
+		"Skip interactive approval for importing documents.",
+	)
+	f.StringVar(
+		&c.flagConfig, "config", "", "(Required) Path to Hermes config file",
+	)
+	f.StringVar(
+		&c.flagDocType, "doc-type", "",
+		"(Required) Document type of the documents (e.g., \"RFC\").",
+	)
+	f.BoolVar(
+		&c.flagDryRun, "dry-run", false,
+		"Only print the documents that would be imported and any conflicts.",
+	)
+	f.StringVar(
+		&c.flagFolder, "folder", "",
+		"(Required) ID of the Google Drive folder to import documents from.",
+	)
+	f.StringVar(
+		&c.flagProduct, "product", "",
+		"Product of the documents. Defaults to the name of each document's "+
+			"parent folder.",
+	)
+	f.BoolVar(
+		&c.flagVerbose, "verbose", false,
+		"Print extra information.",
+	)
+
+	return f
+}
+
+func (c *ImportCommand) Run(args []string) int {
+	logger, ui := c.Log, c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagConfig == "" {
+		ui.Error("config flag is required")
+		return 1
+	}
+	if c.flagDocType == "" {
+		ui.Error("doc-type flag is required")
+		return 1
+	}
+	if c.flagFolder == "" {
+		ui.Error("folder flag is required")
+		return 1
+	}
+
+	// Parse configuration.
+	cfg, err := config.NewConfig(c.flagConfig)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing config file: %v", err))
+		return 1
+	}
+
+	// Validate document type and product.
+	docType := ""
+	for _, dt := range cfg.DocumentTypes.DocumentType {
+		if strings.EqualFold(dt.Name, c.flagDocType) {
+			docType = dt.Name
+			break
+		}
+	}
+	if docType == "" {
+		ui.Error(fmt.Sprintf("document type not found in config: %s",
+			c.flagDocType))
+		return 1
+	}
+	product := ""
+	if c.flagProduct != "" {
+		p := findProduct(cfg.Products.Product, c.flagProduct)
+		if p == nil {
+			ui.Error(fmt.Sprintf("product not found in config: %s", c.flagProduct))
+			return 1
+		}
+		product = p.Name
+	}
+
+	// Initialize Algolia client.
+	algo, err := algolia.New(cfg.Algolia)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error initializing Algolia: %v", err))
+		return 1
+	}
+
+	// Initialize database.
+	if val, ok := os.LookupEnv("HERMES_SERVER_POSTGRES_PASSWORD"); ok {
+		cfg.Postgres.Password = val
+	}
+	db, err := db.NewDB(*cfg.Postgres)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error initializing database: %v", err))
+		return 1
+	}
+	// Create GORM-compatible logger.
+	stdLogger := logger.StandardLogger(&hclog.StandardLoggerOptions{
+		InferLevels: true,
+	})
+	// Ignore "record not found" errors.
+	db = db.Session(&gorm.Session{Logger: gormlogger.New(
+		stdLogger,
+		gormlogger.Config{IgnoreRecordNotFoundError: true},
+	)})
+
+	// Initialize Google Workspace service.
+	var goog *gw.Service
+	if cfg.GoogleWorkspace.Auth != nil {
+		// Use Google Workspace auth if it is defined in the config.
+		goog = gw.NewFromConfig(cfg.GoogleWorkspace.Auth)
+	} else {
+		// Use OAuth if Google Workspace auth is not defined in the config.
+		goog = gw.New()
+	}
+
+	imp := &importer{
+		Algolia:  algo,
+		Config:   cfg,
+		Database: db,
+		DocType:  docType,
+		Goog:     goog,
+		Logger:   logger,
+		Product:  product,
+		users:    map[string]string{},
+	}
+
+	start := time.Now()
+
+	// Get all docs in the folder and its subfolders.
+	folders, err := goog.GetFoldersRecursive(c.flagFolder)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error getting subfolders: %v", err))
+		return 1
+	}
+	folderIDs := []string{c.flagFolder}
+	for _, f := range folders {
+		folderIDs = append(folderIDs, f.Id)
+	}
+	var files []*drive.File
+	for _, id := range folderIDs {
+		fs, err := goog.GetDocs(id)
+		if err != nil {
+			ui.Error(fmt.Sprintf("error getting docs in folder %s: %v", id, err))
+			return 1
+		}
+		files = append(files, fs...)
+	}
+
+	// Parse docs.
+	var (
+		docs           []*importDoc
+		docsWithErrors []string
+	)
+	for _, f := range files {
+		d, err := imp.parseDoc(f, folderIDs)
+		if err != nil {
+			logger.Error("error parsing document",
+				"error", err,
+				"document_id", f.Id,
+			)
+			docsWithErrors = append(docsWithErrors, f.Id)
+			continue
+		}
+		docs = append(docs, d)
+	}
+
+	// Preserve document numbers, or plan to assign them.
+	if err := planDocNumbers(
+		docs,
+		cfg.Products.Product,
+		cfg.NumberingScheme,
+		func(
+			s docnumber.Scheme, product string, n docnumber.Number,
+		) (string, error) {
+			d, err := models.GetDocumentBySequenceNumber(
+				db, product, s.DocumentTypes, n.Year, n.Number)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return "", nil
+				}
+				return "", err
+			}
+			return d.GoogleFileID, nil
+		},
+	); err != nil {
+		ui.Error(fmt.Sprintf("error planning document numbers: %v", err))
+		return 1
+	}
+
+	printImportPlan(ui, docs, c.flagVerbose)
+
+	var toImport []*importDoc
+	conflicts := 0
+	existing := 0
+	for _, d := range docs {
+		switch {
+		case d.Exists:
+			existing++
+		case len(d.Conflicts) > 0:
+			conflicts++
+		default:
+			toImport = append(toImport, d)
+		}
+	}
+	sortImportDocs(toImport)
+
+	if c.flagDryRun {
+		fmt.Println("\nResults (dry run):")
+		fmt.Printf("  %d documents would be imported\n", len(toImport))
+		fmt.Printf("  %d documents already exist\n", existing)
+		fmt.Printf("  %d documents with conflicts\n", conflicts)
+		fmt.Printf("  %d documents with errors\n", len(docsWithErrors))
+		return 0
+	}
+
+	// Get confirmation that it is okay to import the documents.
+	if len(toImport) > 0 && !c.flagAutoApprove {
+		ui.Info(fmt.Sprintf(
+			"This will import %d documents into Hermes.", len(toImport)))
+		ask, err := ui.Ask("Do you want to continue? (only \"yes\" will continue)")
+		if err != nil || ask != "yes" {
+			ui.Info("No \"yes\" confirmation, so exiting...")
+			return 0
+		}
+	}
+
+	// Import documents.
+	imported := 0
+	for _, d := range toImport {
+		if err := imp.importDocument(d); err != nil {
+			logger.Error("error importing document",
+				"error", err,
+				"document_id", d.Doc.ObjectID,
+			)
+			docsWithErrors = append(docsWithErrors, d.Doc.ObjectID)
+			continue
+		}
+		imported++
+		logger.Info("document imported",
+			"document_id", d.Doc.ObjectID,
+			"doc_number", d.Doc.DocNumber,
+		)
+	}
+
+	// Print results.
+	fmt.Println("\nResults:")
+	fmt.Printf("  %d documents imported\n", imported)
+	fmt.Printf("  %d documents already exist\n", existing)
+	fmt.Printf("  %d documents with conflicts\n", conflicts)
+	fmt.Printf("  %d documents with errors\n", len(docsWithErrors))
+	fmt.Printf("\n\nCompleted in: %s\n", time.Since(start))
+	if len(docsWithErrors) > 0 {
+		fmt.Printf("\n\nDocuments with errors:\n%v\n", docsWithErrors)
+		return 1
+	}
+
+	return 0
+}
+
+// parseDoc parses the header of Google Drive file f into a document to
+// import.
+func (imp *importer) parseDoc(
+	f *drive.File, allFolders []string) (*importDoc, error) {
+	hd, err := hashicorpdocs.ParseDoc(imp.DocType, f, imp.Goog, allFolders)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &importDoc{
+		Doc: document.Document{
+			ObjectID:     f.Id,
+			Title:        hd.GetTitle(),
+			DocType:      imp.DocType,
+			DocNumber:    hd.GetDocNumber(),
+			CreatedTime:  hd.GetCreatedTime(),
+			ModifiedTime: hd.GetModifiedTime(),
+			Product:      hd.GetProduct(),
+			Summary:      hd.GetSummary(),
+		},
+	}
+	if d.Doc.CreatedTime != 0 {
+		d.Doc.Created = time.Unix(d.Doc.CreatedTime, 0).Format("Jan 2, 2006")
+	}
+	if rfc, ok := hd.(*hashicorpdocs.RFC); ok {
+		d.Doc.Content = rfc.Content
+	} else if prd, ok := hd.(*hashicorpdocs.PRD); ok {
+		d.Doc.Content = prd.Content
+	} else if frd, ok := hd.(*hashicorpdocs.FRD); ok {
+		d.Doc.Content = frd.Content
+	}
+
+	// Product.
+	if imp.Product != "" {
+		d.Doc.Product = imp.Product
+	}
+	if p := findProduct(imp.Config.Products.Product, d.Doc.Product); p != nil {
+		d.Doc.Product = p.Name
+	} else {
+		d.Conflicts = append(d.Conflicts,
+			fmt.Sprintf("product not found in config: %q", d.Doc.Product))
+	}
+
+	// Status.
+	if s, ok := normalizeImportStatus(hd.GetStatus()); ok {
+		d.Doc.Status = s
+	} else {
+		d.Conflicts = append(d.Conflicts,
+			fmt.Sprintf("unknown status: %q", hd.GetStatus()))
+	}
+
+	// Owner.
+	var owner string
+	for _, o := range hd.GetOwners() {
+		u, ok, err := imp.resolveUser(o)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			owner = u
+			break
+		}
+		d.Warnings = append(d.Warnings,
+			fmt.Sprintf("owner not found in directory: %q", o))
+	}
+	if owner == "" {
+		d.Conflicts = append(d.Conflicts, "no owner found in directory")
+	} else {
+		d.Doc.Owners = []string{owner}
+	}
+
+	// Approvers, contributors, and reviews.
+	for _, people := range []struct {
+		label string
+		in    []string
+		out   *[]string
+	}{
+		{"approver", hd.GetApprovers(), &d.Doc.Approvers},
+		{"approved by", hd.GetApprovedBy(), &d.Doc.ApprovedBy},
+		{"changes requested by", hd.GetChangesRequestedBy(),
+			&d.Doc.ChangesRequestedBy},
+		{"contributor", hd.GetContributors(), &d.Doc.Contributors},
+	} {
+		for _, p := range people.in {
+			u, ok, err := imp.resolveUser(p)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				d.Warnings = append(d.Warnings,
+					fmt.Sprintf("%s not found in directory: %q", people.label, p))
+				continue
+			}
+			*people.out = append(*people.out, u)
+		}
+	}
+
+	// Check if the document already exists.
+	existing := models.Document{
+		GoogleFileID: f.Id,
+	}
+	if err := existing.Get(imp.Database); err == nil {
+		d.Exists = true
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error getting document from database: %w", err)
+	}
+
+	return d, nil
+}
+
+// resolveUser returns the primary email address of the user in the Google
+// Workspace directory with email address email, and false if there is no such
+// user.
+func (imp *importer) resolveUser(email string) (string, bool, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if _, err := mail.ParseAddress(email); err != nil {
+		return "", false, nil
+	}
+	if u, ok := imp.users[email]; ok {
+		return u, u != "", nil
+	}
+
+	ppl, err := imp.Goog.SearchPeople(email, "emailAddresses")
+	if err != nil {
+		return "", false, fmt.Errorf("error searching directory: %w", err)
+	}
+	u := ""
+	for _, p := range ppl {
+		match, primary := false, ""
+		for _, e := range p.EmailAddresses {
+			if strings.EqualFold(e.Value, email) {
+				match = true
+			}
+			if primary == "" || (e.Metadata != nil && e.Metadata.Primary) {
+				primary = e.Value
+			}
+		}
+		if match {
+			u = strings.ToLower(primary)
+			break
+		}
+	}
+	imp.users[email] = u
+
+	return u, u != "", nil
+}
+
+// importDocument creates the database records, search object, and short link
+// for a document.
+func (imp *importer) importDocument(d *importDoc) error {
+	isDraft := d.Doc.Status == "WIP"
+
+	if err := imp.Database.Transaction(func(tx *gorm.DB) error {
+		// Assign the next document number in the sequence, which stays locked
+		// until the transaction is committed.
+		if d.NumberAssigned {
+			if err := imp.assignDocNumber(tx, d); err != nil {
+				return err
+			}
+		}
+
+		dbDoc, reviews, err := d.Doc.ToDatabaseModels(
+			imp.Config.DocumentTypes.DocumentType, imp.Config.Products.Product)
+		if err != nil {
+			return fmt.Errorf(
+				"error converting document to database models: %w", err)
+		}
+		if err := dbDoc.Create(tx); err != nil {
+			return fmt.Errorf("error creating document in database: %w", err)
+		}
+
+		// Create reviews.
+		for _, dr := range reviews {
+			if err := dr.Update(tx); err != nil {
+				return fmt.Errorf("error upserting document review: %w", err)
+			}
+		}
+
+		// Save short link.
+		if !isDraft {
+			if err := links.SaveDocumentShortLink(
+				tx, d.Doc.ObjectID, d.Doc.DocType, d.Doc.DocNumber); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Save document in Algolia.
+	obj, err := d.Doc.ToAlgoliaObject(true)
+	if err != nil {
+		return fmt.Errorf("error converting document to Algolia object: %w", err)
+	}
+	idx := imp.Algolia.Docs
+	if isDraft {
+		idx = imp.Algolia.Drafts
+	}
+	res, err := idx.SaveObject(obj)
+	if err != nil {
+		return fmt.Errorf("error saving document in Algolia: %w", err)
+	}
+	if err := res.Wait(); err != nil {
+		return fmt.Errorf("error saving document in Algolia: %w", err)
+	}
+
+	return nil
+}
+
+// planDocNumbers preserves the document numbers of docs to import, which are
+// parsed with the numbering scheme of their document type returned by scheme.
+// Published documents without a number are marked to be assigned the next
+// number in their sequence when they are imported. taken returns the Google
+// file ID of the existing document with a number in the sequence of a scheme
+// (or an empty string if there is none). Documents with numbers that are
+// already taken, or that don't match the numbering scheme, have conflicts
+// added.
+func planDocNumbers(
+	docs []*importDoc,
+	products []*config.Product,
+	scheme func(docType string) (docnumber.Scheme, error),
+	taken func(
+		s docnumber.Scheme, product string, n docnumber.Number) (string, error),
+) error {
+	type key struct {
+		product, docTypes string
+		year              int
+	}
+	used := map[key]map[int]string{}
+
+	for _, d := range docs {
+		if d.Exists || len(d.Conflicts) > 0 {
+			continue
+		}
+		p := findProduct(products, d.Doc.Product)
+		if p == nil {
+			continue
+		}
+		if d.Doc.Status == "WIP" {
+			d.Doc.DocNumber = docnumber.Draft(p.Abbreviation)
+			continue
+		}
+
+		s, err := scheme(d.Doc.DocType)
+		if err != nil {
+			return fmt.Errorf("error getting numbering scheme: %w", err)
+		}
+
+		// Assign numbers to documents without one when they are imported.
+		num := strings.TrimSpace(d.Doc.DocNumber)
+		if num == "" || strings.HasSuffix(num, "-???") {
+			d.Doc.DocNumber = ""
+			d.NumberAssigned = true
+			continue
+		}
+
+		n, ok := s.Parse(num, p.Abbreviation, d.Doc.DocType)
+		if !ok {
+			d.Conflicts = append(d.Conflicts, fmt.Sprintf(
+				"document number %q doesn't match numbering scheme %q of product "+
+					"abbreviation %q", d.Doc.DocNumber, s.Format, p.Abbreviation))
+			continue
+		}
+
+		k := key{p.Name, strings.Join(s.DocumentTypes, ","), n.Year}
+		if used[k] == nil {
+			used[k] = map[int]string{}
+		}
+		if id, ok := used[k][n.Number]; ok {
+			d.Conflicts = append(d.Conflicts, fmt.Sprintf(
+				"document number %q is also used by imported document %s",
+				d.Doc.DocNumber, id))
+			continue
+		}
+		id, err := taken(s, p.Name, n)
+		if err != nil {
+			return fmt.Errorf("error getting document by number: %w", err)
+		}
+		if id != "" && id != d.Doc.ObjectID {
+			d.Conflicts = append(d.Conflicts, fmt.Sprintf(
+				"document number %q is already used by document %s",
+				d.Doc.DocNumber, id))
+			continue
+		}
+
+		used[k][n.Number] = d.Doc.ObjectID
+		d.Doc.DocNumber = s.String(p.Abbreviation, d.Doc.DocType, n)
+	}
+
+	return nil
+}
+
+// sortImportDocs sorts docs to import documents with preserved numbers first,
+// so they are in the database before numbers are assigned, and then documents
+// that are assigned numbers, oldest first.
+func sortImportDocs(docs []*importDoc) {
+	sort.SliceStable(docs, func(i, j int) bool {
+		if docs[i].NumberAssigned != docs[j].NumberAssigned {
+			return !docs[i].NumberAssigned
+		}
+		if docs[i].NumberAssigned {
+			return docs[i].Doc.CreatedTime < docs[j].Doc.CreatedTime
+		}
+		return false
+	})
+}
+
+// printImportPlan prints the documents to import and any conflicts.
+func printImportPlan(ui cli.Ui, docs []*importDoc, verbose bool) {
+	ui.Output("Documents:")
+	for _, d := range docs {
+		switch {
+		case d.Exists:
+			if verbose {
+				ui.Output(fmt.Sprintf("  %s %q: already exists",
+					d.Doc.ObjectID, d.Doc.Title))
+			}
+			continue
+		case len(d.Conflicts) > 0:
+			ui.Output(fmt.Sprintf("  %s %q: conflicts:",
+				d.Doc.ObjectID, d.Doc.Title))
+			for _, c := range d.Conflicts {
+				ui.Output("    - " + c)
+			}
+		default:
+			num := d.Doc.DocNumber
+			if d.NumberAssigned {
+				num = "number assigned on import"
+			}
+			ui.Output(fmt.Sprintf("  %s %q: %s, %s, owner %s",
+				d.Doc.ObjectID, d.Doc.Title, num, d.Doc.Status,
+				strings.Join(d.Doc.Owners, ", ")))
+		}
+		if verbose {
+			for _, w := range d.Warnings {
+				ui.Output("    warning: " + w)
+			}
+		}
+	}
+}
+
+// assignDocNumber assigns the next document number in the sequence of the
+// numbering scheme of document d, in the year it was created for schemes with
+// numbers that restart each year.
+func (imp *importer) assignDocNumber(tx *gorm.DB, d *importDoc) error {
+	scheme, err := imp.Config.NumberingScheme(d.Doc.DocType)
+	if err != nil {
+		return fmt.Errorf("error getting numbering scheme: %w", err)
+	}
+	p := findProduct(imp.Config.Products.Product, d.Doc.Product)
+	if p == nil {
+		return fmt.Errorf("product not found in config: %q", d.Doc.Product)
+	}
+	t := time.Now()
+	if d.Doc.CreatedTime != 0 {
+		t = time.Unix(d.Doc.CreatedTime, 0)
+	}
+
+	n, err := docnumber.Next(tx, scheme, p.Name, t)
+	if err != nil {
+		return err
+	}
+	d.Doc.DocNumber = scheme.String(p.Abbreviation, d.Doc.DocType, n)
+	return nil
+}
+
+// findProduct returns the product with name (or abbreviation) s, or nil if
+// not found.
+func findProduct(products []*config.Product, s string) *config.Product {
+	s = strings.TrimSpace(s)
+	for _, p := range products {
+		if strings.EqualFold(p.Name, s) || strings.EqualFold(p.Abbreviation, s) {
+			return p
+		}
+	}
+	return nil
+}
+
+// normalizeImportStatus returns the Hermes document status for a status parsed
+// from a document header, and false if the status is unknown.
+func normalizeImportStatus(s string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "wip", "draft":
+		return "WIP", true
+	case "in review", "in-review":
+		return "In-Review", true
+	case "approved":
+		return "Approved", true
+	case "obsolete":
+		return "Obsolete", true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,163 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/docnumber"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanDocNumbers(t *testing.T) {
+	products := []*config.Product{
+		{Name: "Terraform", Abbreviation: "TF"},
+		{Name: "Vault", Abbreviation: "VLT"},
+	}
+	schemes := map[string]docnumber.Scheme{
+		"RFC": docnumber.DefaultScheme("RFC"),
+		"PRD": {
+			Format:        "{product}-{doc_type}-{year}-{number}",
+			Padding:       2,
+			DocumentTypes: []string{"PRD"},
+		},
+	}
+	newDoc := func(
+		id, product, status, number string, created int64) *importDoc {
+		return &importDoc{
+			Doc: document.Document{
+				ObjectID:    id,
+				DocType:     "RFC",
+				DocNumber:   number,
+				Product:     product,
+				Status:      status,
+				CreatedTime: created,
+			},
+		}
+	}
+
+	docs := []*importDoc{
+		newDoc("preserved", "Terraform", "Approved", "TF-7", 1),
+		newDoc("assigned-2", "Terraform", "In-Review", "", 3),
+		newDoc("assigned-1", "Terraform", "Approved", "TF-???", 2),
+		newDoc("draft", "Terraform", "WIP", "TF-5", 1),
+		newDoc("duplicate", "Terraform", "Approved", "TF-007", 1),
+		newDoc("taken", "Terraform", "Approved", "TF-3", 1),
+		newDoc("wrong-prefix", "Terraform", "Approved", "VLT-1", 1),
+		newDoc("vault", "Vault", "Obsolete", "", 1),
+	}
+	newPRD := func(id, number string) *importDoc {
+		d := newDoc(id, "Terraform", "Approved", number, 1)
+		d.Doc.DocType = "PRD"
+		return d
+	}
+	docs = append(docs,
+		newPRD("yearly", "tf-prd-2024-3"),
+		newPRD("yearly-other-year", "TF-PRD-2023-3"),
+		newPRD("yearly-duplicate", "TF-PRD-2024-03"),
+		newPRD("yearly-without-year", "TF-3"),
+	)
+	docs = append(docs, &importDoc{
+		Doc:    document.Document{ObjectID: "exists", DocNumber: "TF-1"},
+		Exists: true,
+	})
+
+	err := planDocNumbers(docs, products,
+		func(docType string) (docnumber.Scheme, error) {
+			return schemes[docType], nil
+		},
+		func(
+			s docnumber.Scheme, product string, n docnumber.Number,
+		) (string, error) {
+			if product == "Terraform" && s.DocumentTypes[0] == "RFC" &&
+				n.Number == 3 {
+				return "other", nil
+			}
+			return "", nil
+		},
+	)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		wantNumber    string
+		wantAssigned  bool
+		wantConflicts int
+	}{
+		"preserved":           {wantNumber: "TF-007"},
+		"assigned-1":          {wantAssigned: true},
+		"assigned-2":          {wantAssigned: true},
+		"draft":               {wantNumber: "TF-???"},
+		"duplicate":           {wantNumber: "TF-007", wantConflicts: 1},
+		"taken":               {wantNumber: "TF-3", wantConflicts: 1},
+		"wrong-prefix":        {wantNumber: "VLT-1", wantConflicts: 1},
+		"vault":               {wantAssigned: true},
+		"yearly":              {wantNumber: "TF-PRD-2024-03"},
+		"yearly-other-year":   {wantNumber: "TF-PRD-2023-03"},
+		"yearly-duplicate":    {wantNumber: "TF-PRD-2024-03", wantConflicts: 1},
+		"yearly-without-year": {wantNumber: "TF-3", wantConflicts: 1},
+		"exists":              {wantNumber: "TF-1"},
+	}
+
+	for _, d := range docs {
+		c, ok := cases[d.Doc.ObjectID]
+		require.True(t, ok, d.Doc.ObjectID)
+		t.Run(d.Doc.ObjectID, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(c.wantNumber, d.Doc.DocNumber)
+			assert.Equal(c.wantAssigned, d.NumberAssigned)
+			assert.Len(d.Conflicts, c.wantConflicts)
+		})
+	}
+}
+
+func TestSortImportDocs(t *testing.T) {
+	newDoc := func(id string, assigned bool, created int64) *importDoc {
+		return &importDoc{
+			Doc: document.Document{
+				ObjectID:    id,
+				CreatedTime: created,
+			},
+			NumberAssigned: assigned,
+		}
+	}
+	docs := []*importDoc{
+		newDoc("assigned-2", true, 3),
+		newDoc("preserved-1", false, 5),
+		newDoc("assigned-1", true, 1),
+		newDoc("preserved-2", false, 2),
+	}
+
+	sortImportDocs(docs)
+
+	var got []string
+	for _, d := range docs {
+		got = append(got, d.Doc.ObjectID)
+	}
+	assert.Equal(t, []string{
+		"preserved-1", "preserved-2", "assigned-1", "assigned-2"}, got)
+}
+
+func TestNormalizeImportStatus(t *testing.T) {
+	cases := map[string]struct {
+		want   string
+		wantOK bool
+	}{
+		"WIP":       {want: "WIP", wantOK: true},
+		"In Review": {want: "In-Review", wantOK: true},
+		"in-review": {want: "In-Review", wantOK: true},
+		"Approved ": {want: "Approved", wantOK: true},
+		"Obsolete":  {want: "Obsolete", wantOK: true},
+		"":          {},
+		"Shipped":   {},
+	}
+
+	for s, c := range cases {
+		t.Run(s, func(t *testing.T) {
+			assert := assert.New(t)
+			got, ok := normalizeImportStatus(s)
+			assert.Equal(c.want, got)
+			assert.Equal(c.wantOK, ok)
+		})
+	}
+}
@@ -0,0 +1,613 @@
+package operator
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/pkg/algolia"
+	"github.com/hashicorp-forge/hermes/pkg/docnumber"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
+	"github.com/hashicorp-forge/hermes/pkg/links"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
+	"github.com/mitchellh/cli"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type RenumberCommand struct {
+	*base.Command
+
+	flagAutoApprove bool
+	flagConfig      string
+	flagDocType     string
+	flagDryRun      bool
+	flagProduct     string
+	flagResequence  bool
+	flagVerbose     bool
+}
+
+// renumberer contains the renumberer configuration.
+type renumberer struct {
+	Algolia  *algolia.Client
+	Config   *config.Config
+	Database *gorm.DB
+	Goog     *gw.Service
+}
+
+// renumberDoc is a document to renumber.
+type renumberDoc struct {
+	Doc models.Document
+
+	// Conflicts are problems that prevent renumbering the document.
+	Conflicts []string
+
+	// New is the new document number.
+	New docnumber.Number
+
+	// NewDocNumber is the new formatted document number.
+	NewDocNumber string
+}
+
+// changed returns true if the document number changes.
+func (d *renumberDoc) changed() bool {
+	return d.NewDocNumber != d.Doc.DocNumber() ||
+		d.New.Number != d.Doc.DocumentNumber ||
+		d.New.Year != d.Doc.DocumentNumberYear
+}
+
+// renumberAlgoliaObject is the partial Algolia object of a renumbered
+// document.
+type renumberAlgoliaObject struct {
+	ObjectID  string `json:"objectID"`
+	DocNumber string `json:"docNumber"`
+}
+
+func (c *RenumberCommand) Synopsis() string {
+	return "Renumber documents with their document type's numbering scheme"
+}
+
+func (c *RenumberCommand) Help() string {
+	return `Usage: hermes operator renumber
+
+  This command updates the document numbers of published documents to the
+  numbering scheme of their document type, after the scheme is changed. By
+  default, documents keep their numbers and only the format changes (e.g.,
+  "TF-7" to "TF-2024-007"). Use the resequence flag to assign new numbers in
+  each sequence in the order documents were published, which is needed when
+  document types start sharing a sequence or numbers start restarting each
+  year.
+
+  The database, search index, and document header are updated for each
+  document. Short links for previous document numbers keep redirecting to the
+  documents, unless another document is assigned the same number. Nothing is
+  changed if any document numbers conflict, and the database is rolled back if
+  any document can't be renumbered.` +
+		c.Flags().Help()
+}
+
+func (c *RenumberCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("renumber", flag.ExitOnError))
+
+	f.BoolVar(
+		&c.flagAutoApprove, "auto-approve", false,
+		"Skip interactive approval for renumbering documents.",
+	)
+	f.StringVar(
+		&c.flagConfig, "config", "", "(Required) Path to Hermes config file",
+	)
+	f.StringVar(
+		&c.flagDocType, "doc-type", "",
+		"Comma-separated document types of the documents to renumber. "+
+			"Renumbers all document types if empty.",
+	)
+	f.BoolVar(
+		&c.flagDryRun, "dry-run", false,
+		"Only print the new document numbers and any conflicts.",
+	)
+	f.StringVar(
+		&c.flagProduct, "product", "",
+		"Comma-separated products of the documents to renumber. Renumbers all "+
+			"products if empty.",
+	)
+	f.BoolVar(
+		&c.flagResequence, "resequence", false,
+		"Assign new numbers in each sequence, in the order documents were "+
+			"published.",
+	)
+	f.BoolVar(
+		&c.flagVerbose, "verbose", false,
+		"Print extra information.",
+	)
+
+	return f
+}
+
+func (c *RenumberCommand) Run(args []string) int {
+	logger, ui := c.Log, c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagConfig == "" {
+		ui.Error("config flag is required")
+		return 1
+	}
+
+	// Parse configuration.
+	cfg, err := config.NewConfig(c.flagConfig)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing config file: %v", err))
+		return 1
+	}
+
+	// Validate document types and products.
+	docTypes, err := parseDocTypes(cfg, c.flagDocType)
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+	if len(docTypes) == 0 {
+		for _, dt := range cfg.DocumentTypes.DocumentType {
+			docTypes = append(docTypes, dt.Name)
+		}
+	}
+	products, err := parseProducts(cfg, c.flagProduct)
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+
+	// Get numbering schemes. Resequencing renumbers all document types that
+	// share a sequence.
+	schemes := map[string]docnumber.Scheme{}
+	for i := 0; i < len(docTypes); i++ {
+		s, err := cfg.NumberingScheme(docTypes[i])
+		if err != nil {
+			ui.Error(fmt.Sprintf("error getting numbering scheme: %v", err))
+			return 1
+		}
+		schemes[docTypes[i]] = s
+		if c.flagResequence {
+			for _, dt := range s.DocumentTypes {
+				if !containsString(docTypes, dt) {
+					docTypes = append(docTypes, dt)
+				}
+			}
+		}
+	}
+
+	// Initialize Algolia client.
+	algo, err := algolia.New(cfg.Algolia)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error initializing Algolia: %v", err))
+		return 1
+	}
+
+	// Initialize database.
+	if val, ok := os.LookupEnv("HERMES_SERVER_POSTGRES_PASSWORD"); ok {
+		cfg.Postgres.Password = val
+	}
+	db, err := db.NewDB(*cfg.Postgres)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error initializing database: %v", err))
+		return 1
+	}
+	// Create GORM-compatible logger.
+	stdLogger := logger.StandardLogger(&hclog.StandardLoggerOptions{
+		InferLevels: true,
+	})
+	// Ignore "record not found" errors.
+	db = db.Session(&gorm.Session{Logger: gormlogger.New(
+		stdLogger,
+		gormlogger.Config{IgnoreRecordNotFoundError: true},
+	)})
+
+	// Initialize Google Workspace service.
+	var goog *gw.Service
+	if cfg.GoogleWorkspace.Auth != nil {
+		// Use Google Workspace auth if it is defined in the config.
+		goog = gw.NewFromConfig(cfg.GoogleWorkspace.Auth)
+	} else {
+		// Use OAuth if Google Workspace auth is not defined in the config.
+		goog = gw.New()
+	}
+
+	ren := &renumberer{
+		Algolia:  algo,
+		Config:   cfg,
+		Database: db,
+		Goog:     goog,
+	}
+
+	start := time.Now()
+
+	// Get numbered documents in the products. Documents of other document
+	// types are checked for conflicting document numbers.
+	all, err := models.GetDocuments(db, models.DocumentFilter{
+		Products: products,
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf("error getting documents: %v", err))
+		return 1
+	}
+	var docs, others []models.Document
+	for _, d := range all {
+		if d.DocumentNumber == 0 {
+			continue
+		}
+		if _, ok := schemes[d.DocumentType.Name]; ok {
+			docs = append(docs, d)
+		} else {
+			others = append(others, d)
+		}
+	}
+
+	plan := planRenumber(docs, others, schemes, c.flagResequence)
+	printRenumberPlan(ui, plan, c.flagVerbose)
+
+	var toRenumber []*renumberDoc
+	conflicts := 0
+	for _, d := range plan {
+		switch {
+		case len(d.Conflicts) > 0:
+			conflicts++
+		case d.changed():
+			toRenumber = append(toRenumber, d)
+		}
+	}
+
+	if c.flagDryRun {
+		fmt.Println("\nResults (dry run):")
+		fmt.Printf("  %d documents would be renumbered\n", len(toRenumber))
+		fmt.Printf("  %d documents unchanged\n",
+			len(plan)-len(toRenumber)-conflicts)
+		fmt.Printf("  %d documents with conflicts\n", conflicts)
+		return 0
+	}
+	if conflicts > 0 {
+		ui.Error(fmt.Sprintf(
+			"%d documents have conflicting document numbers, so no documents "+
+				"were renumbered", conflicts))
+		return 1
+	}
+	if len(toRenumber) == 0 {
+		ui.Info("No documents to renumber")
+		return 0
+	}
+
+	// Get confirmation that it is okay to renumber the documents.
+	if !c.flagAutoApprove {
+		ui.Info(fmt.Sprintf(
+			"This will renumber %d documents.", len(toRenumber)))
+		ask, err := ui.Ask("Do you want to continue? (only \"yes\" will continue)")
+		if err != nil || ask != "yes" {
+			ui.Info("No \"yes\" confirmation, so exiting...")
+			return 0
+		}
+	}
+
+	// Renumber documents in a single database transaction, so no documents are
+	// renumbered in the database if any fail and the command can be run again.
+	// When resequencing, the documents' numbers are cleared first so new numbers
+	// can be assigned in any order without violating the unique index of
+	// document numbers.
+	renumbered := 0
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if c.flagResequence {
+			ids := make([]uint, 0, len(toRenumber))
+			for _, d := range toRenumber {
+				ids = append(ids, d.Doc.ID)
+			}
+			if err := tx.
+				Model(&models.Document{}).
+				Where("id IN ?", ids).
+				UpdateColumn("document_number", 0).
+				Error; err != nil {
+				return fmt.Errorf("error clearing document numbers: %w", err)
+			}
+		}
+
+		for _, d := range toRenumber {
+			if err := ren.renumberDocument(tx, d); err != nil {
+				return fmt.Errorf("error renumbering document %s: %w",
+					d.Doc.GoogleFileID, err)
+			}
+			renumbered++
+			logger.Info("document renumbered",
+				"document_id", d.Doc.GoogleFileID,
+				"old_doc_number", d.Doc.DocNumber(),
+				"doc_number", d.NewDocNumber,
+			)
+		}
+		return nil
+	}); err != nil {
+		ui.Error(fmt.Sprintf(
+			"%v\n\nNo documents were renumbered in the database. The search index "+
+				"and headers of %d documents were updated and will be updated again "+
+				"when the command is run again.", err, renumbered))
+		return 1
+	}
+
+	// Print results.
+	fmt.Println("\nResults:")
+	fmt.Printf("  %d documents renumbered\n", renumbered)
+	fmt.Printf("\n\nCompleted in: %s\n", time.Since(start))
+
+	return 0
+}
+
+// planRenumber returns the new document numbers of docs with the numbering
+// schemes of their document types. If resequence is true, numbers are
+// reassigned in each sequence in the order documents were published.
+// Documents with numbers that would conflict with each other or with documents
+// others have conflicts added.
+func planRenumber(
+	docs []models.Document,
+	others []models.Document,
+	schemes map[string]docnumber.Scheme,
+	resequence bool,
+) []*renumberDoc {
+	// Order documents by the time they were published.
+	sorted := make([]models.Document, len(docs))
+	copy(sorted, docs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if !a.DocumentCreatedAt.Equal(b.DocumentCreatedAt) {
+			return a.DocumentCreatedAt.Before(b.DocumentCreatedAt)
+		}
+		if a.DocumentNumber != b.DocumentNumber {
+			return a.DocumentNumber < b.DocumentNumber
+		}
+		return a.GoogleFileID < b.GoogleFileID
+	})
+
+	type seqKey struct {
+		product, sequence string
+		year              int
+	}
+	next := map[seqKey]int{}
+	usedNumbers := map[seqKey]map[int]string{}
+	usedDocNumbers := map[string]map[string]string{}
+	for _, d := range others {
+		if usedDocNumbers[d.Product.Name] == nil {
+			usedDocNumbers[d.Product.Name] = map[string]string{}
+		}
+		usedDocNumbers[d.Product.Name][strings.ToLower(d.DocNumber())] =
+			d.GoogleFileID
+	}
+
+	var plan []*renumberDoc
+	for _, d := range sorted {
+		s := schemes[d.DocumentType.Name]
+		rd := &renumberDoc{
+			Doc: d,
+			New: docnumber.Number{Number: d.DocumentNumber},
+		}
+		if s.Yearly() {
+			rd.New.Year = d.DocumentNumberYear
+			if rd.New.Year == 0 {
+				rd.New.Year = d.DocumentCreatedAt.Year()
+			}
+		}
+
+		dts := append([]string{}, s.DocumentTypes...)
+		sort.Strings(dts)
+		k := seqKey{
+			product:  d.Product.Name,
+			sequence: strings.Join(dts, ","),
+			year:     rd.New.Year,
+		}
+		if resequence {
+			next[k]++
+			rd.New.Number = next[k]
+		}
+		rd.NewDocNumber = s.String(
+			d.Product.Abbreviation, d.DocumentType.Name, rd.New)
+
+		// Check for conflicts.
+		if usedNumbers[k] == nil {
+			usedNumbers[k] = map[int]string{}
+		}
+		if id, ok := usedNumbers[k][rd.New.Number]; ok {
+			rd.Conflicts = append(rd.Conflicts, fmt.Sprintf(
+				"number %d is also used by document %s in the same sequence "+
+					"(use -resequence to assign new numbers)",
+				rd.New.Number, id))
+		} else {
+			usedNumbers[k][rd.New.Number] = d.GoogleFileID
+		}
+		if usedDocNumbers[d.Product.Name] == nil {
+			usedDocNumbers[d.Product.Name] = map[string]string{}
+		}
+		lower := strings.ToLower(rd.NewDocNumber)
+		if id, ok := usedDocNumbers[d.Product.Name][lower]; ok {
+			rd.Conflicts = append(rd.Conflicts, fmt.Sprintf(
+				"document number %q is also used by document %s",
+				rd.NewDocNumber, id))
+		} else {
+			usedDocNumbers[d.Product.Name][lower] = d.GoogleFileID
+		}
+
+		plan = append(plan, rd)
+	}
+
+	return plan
+}
+
+// printRenumberPlan prints the new document numbers and any conflicts.
+func printRenumberPlan(ui cli.Ui, plan []*renumberDoc, verbose bool) {
+	ui.Output("Documents:")
+	for _, d := range plan {
+		switch {
+		case len(d.Conflicts) > 0:
+			ui.Output(fmt.Sprintf("  %s %q: conflicts:",
+				d.Doc.GoogleFileID, d.Doc.Title))
+			for _, c := range d.Conflicts {
+				ui.Output("    - " + c)
+			}
+		case d.changed():
+			ui.Output(fmt.Sprintf("  %s %q: %s -> %s",
+				d.Doc.GoogleFileID, d.Doc.Title, d.Doc.DocNumber(), d.NewDocNumber))
+		case verbose:
+			ui.Output(fmt.Sprintf("  %s %q: %s (unchanged)",
+				d.Doc.GoogleFileID, d.Doc.Title, d.Doc.DocNumber()))
+		}
+	}
+}
+
+// renumberDocument updates the document number of a document in the database
+// (using transaction tx), short links, search index, and document header.
+func (ren *renumberer) renumberDocument(tx *gorm.DB, d *renumberDoc) error {
+	return tx.Transaction(func(tx *gorm.DB) error {
+		model := models.Document{
+			GoogleFileID: d.Doc.GoogleFileID,
+		}
+		if err := model.Get(tx); err != nil {
+			return fmt.Errorf("error getting document from database: %w", err)
+		}
+		oldModel := model
+		scheme, err := ren.Config.NumberingScheme(model.DocumentType.Name)
+		if err != nil {
+			return fmt.Errorf("error getting numbering scheme: %w", err)
+		}
+		model.DocumentNumber = d.New.Number
+		model.DocumentNumberYear = d.New.Year
+		model.DocumentNumberSequence = scheme.Sequence()
+		model.FormattedDocumentNumber = d.NewDocNumber
+		if err := model.Upsert(tx); err != nil {
+			return fmt.Errorf("error updating document in database: %w", err)
+		}
+
+		// Save short link for the new document number. Short links for previous
+		// numbers are kept.
+		if err := links.SaveDocumentShortLink(
+			tx, model.GoogleFileID, model.DocumentType.Name, d.NewDocNumber,
+		); err != nil {
+			return err
+		}
+
+		// Replace the document header and update the search index last, so the
+		// database is rolled back if either fails and the document can be
+		// renumbered again. The header is reverted if updating the search index
+		// fails.
+		var reviews models.DocumentReviews
+		if err := reviews.Find(tx, models.DocumentReview{
+			Document: models.Document{
+				GoogleFileID: model.GoogleFileID,
+			},
+		}); err != nil {
+			return fmt.Errorf("error getting reviews: %w", err)
+		}
+		var groupReviews models.DocumentGroupReviews
+		if err := groupReviews.Find(tx, models.DocumentGroupReview{
+			Document: models.Document{
+				GoogleFileID: model.GoogleFileID,
+			},
+		}); err != nil {
+			return fmt.Errorf("error getting group reviews: %w", err)
+		}
+		doc, err := document.NewFromDatabaseModel(model, reviews, groupReviews)
+		if err != nil {
+			return fmt.Errorf(
+				"error converting database model to document: %w", err)
+		}
+		oldDoc, err := document.NewFromDatabaseModel(
+			oldModel, reviews, groupReviews)
+		if err != nil {
+			return fmt.Errorf(
+				"error converting database model to document: %w", err)
+		}
+		if err := doc.ReplaceHeader(
+			ren.Config.BaseURL, false, ren.Goog); err != nil {
+			return fmt.Errorf("error replacing document header: %w", err)
+		}
+
+		if err := ren.updateAlgoliaDocNumber(
+			model, d.NewDocNumber, oldDoc.DocNumber); err != nil {
+			var result *multierror.Error
+			result = multierror.Append(result, err)
+			if err := oldDoc.ReplaceHeader(
+				ren.Config.BaseURL, false, ren.Goog); err != nil {
+				result = multierror.Append(result,
+					fmt.Errorf("error reverting document header: %w", err))
+			}
+			return result.ErrorOrNil()
+		}
+
+		return nil
+	})
+}
+
+// updateAlgoliaDocNumber updates the document number of the document with
+// database model model in the search index from oldDocNumber to docNumber. The
+// search index is reverted to oldDocNumber if a later step of the update fails.
+func (ren *renumberer) updateAlgoliaDocNumber(
+	model models.Document, docNumber, oldDocNumber string) error {
+	// Drafts that weren't imported are in the drafts index.
+	idx := ren.Algolia.Docs
+	isDraft := model.Status == models.WIPDocumentStatus && !model.Imported
+	if isDraft {
+		idx = ren.Algolia.Drafts
+	}
+
+	update := func(docNumber string) error {
+		obj := renumberAlgoliaObject{
+			ObjectID:  model.GoogleFileID,
+			DocNumber: docNumber,
+		}
+		res, err := idx.PartialUpdateObject(obj, opt.CreateIfNotExists(false))
+		if err != nil {
+			return fmt.Errorf("error updating document in Algolia: %w", err)
+		}
+		if err := res.Wait(); err != nil {
+			return fmt.Errorf("error updating document in Algolia: %w", err)
+		}
+		if !isDraft {
+			if err := ren.Algolia.PartialUpdateDocSections(
+				context.Background(), obj); err != nil {
+				return fmt.Errorf(
+					"error updating doc sections in Algolia: %w", err)
+			}
+		}
+		return nil
+	}
+
+	if err := update(docNumber); err != nil {
+		var result *multierror.Error
+		result = multierror.Append(result, err)
+		if err := update(oldDocNumber); err != nil {
+			result = multierror.Append(result,
+				fmt.Errorf("error reverting document in Algolia: %w", err))
+		}
+		return result.ErrorOrNil()
+	}
+
+	return nil
+}
+
+// containsString returns true if slice s contains string v.
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
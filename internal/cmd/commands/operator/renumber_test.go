@@ -0,0 +1,154 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/docnumber"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanRenumber(t *testing.T) {
+	newDoc := func(
+		id, docType string, number int, formatted string, created time.Time,
+	) models.Document {
+		return models.Document{
+			GoogleFileID:            id,
+			DocumentCreatedAt:       created,
+			DocumentNumber:          number,
+			DocumentType:            models.DocumentType{Name: docType},
+			FormattedDocumentNumber: formatted,
+			Product: models.Product{
+				Name:         "Terraform",
+				Abbreviation: "TF",
+			},
+		}
+	}
+	date := func(year int, month time.Month) time.Time {
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+	type result struct {
+		docNumber string
+		conflicts int
+		changed   bool
+	}
+	results := func(plan []*renumberDoc) map[string]result {
+		res := map[string]result{}
+		for _, d := range plan {
+			res[d.Doc.GoogleFileID] = result{
+				docNumber: d.NewDocNumber,
+				conflicts: len(d.Conflicts),
+				changed:   d.changed(),
+			}
+		}
+		return res
+	}
+	perYear := docnumber.Scheme{
+		Format:        "{product}-{year}-{number}",
+		Padding:       3,
+		DocumentTypes: []string{"RFC"},
+	}
+	shared := docnumber.Scheme{
+		Format:        "{doc_type}-{product}-{number}",
+		Padding:       3,
+		DocumentTypes: []string{"PRD", "RFC"},
+	}
+
+	cases := map[string]struct {
+		docs       []models.Document
+		others     []models.Document
+		schemes    map[string]docnumber.Scheme
+		resequence bool
+		want       map[string]result
+	}{
+		"unchanged": {
+			docs: []models.Document{
+				newDoc("doc1", "RFC", 1, "", date(2024, 1)),
+				newDoc("doc2", "RFC", 2, "TF-002", date(2024, 2)),
+			},
+			schemes: map[string]docnumber.Scheme{
+				"RFC": docnumber.DefaultScheme("RFC"),
+			},
+			want: map[string]result{
+				"doc1": {docNumber: "TF-001"},
+				"doc2": {docNumber: "TF-002"},
+			},
+		},
+		"reformat per-year": {
+			docs: []models.Document{
+				newDoc("doc1", "RFC", 1, "", date(2024, 1)),
+				newDoc("doc2", "RFC", 2, "", date(2025, 2)),
+			},
+			schemes: map[string]docnumber.Scheme{"RFC": perYear},
+			want: map[string]result{
+				"doc1": {docNumber: "TF-2024-001", changed: true},
+				"doc2": {docNumber: "TF-2025-002", changed: true},
+			},
+		},
+		"resequence per-year": {
+			docs: []models.Document{
+				newDoc("doc1", "RFC", 1, "", date(2024, 1)),
+				newDoc("doc3", "RFC", 3, "", date(2025, 3)),
+				newDoc("doc2", "RFC", 2, "", date(2025, 2)),
+			},
+			schemes:    map[string]docnumber.Scheme{"RFC": perYear},
+			resequence: true,
+			want: map[string]result{
+				"doc1": {docNumber: "TF-2024-001", changed: true},
+				"doc2": {docNumber: "TF-2025-001", changed: true},
+				"doc3": {docNumber: "TF-2025-002", changed: true},
+			},
+		},
+		"shared sequence without resequence": {
+			docs: []models.Document{
+				newDoc("doc1", "RFC", 1, "", date(2024, 1)),
+				newDoc("doc2", "PRD", 1, "", date(2024, 2)),
+			},
+			schemes: map[string]docnumber.Scheme{
+				"PRD": shared,
+				"RFC": shared,
+			},
+			want: map[string]result{
+				"doc1": {docNumber: "RFC-TF-001", changed: true},
+				"doc2": {docNumber: "PRD-TF-001", changed: true, conflicts: 1},
+			},
+		},
+		"shared sequence with resequence": {
+			docs: []models.Document{
+				newDoc("doc1", "RFC", 1, "", date(2024, 1)),
+				newDoc("doc2", "PRD", 1, "", date(2024, 2)),
+				newDoc("doc3", "RFC", 2, "", date(2024, 3)),
+			},
+			schemes: map[string]docnumber.Scheme{
+				"PRD": shared,
+				"RFC": shared,
+			},
+			resequence: true,
+			want: map[string]result{
+				"doc1": {docNumber: "RFC-TF-001", changed: true},
+				"doc2": {docNumber: "PRD-TF-002", changed: true},
+				"doc3": {docNumber: "RFC-TF-003", changed: true},
+			},
+		},
+		"conflict with other document type": {
+			docs: []models.Document{
+				newDoc("doc1", "RFC", 1, "", date(2024, 1)),
+			},
+			others: []models.Document{
+				newDoc("doc2", "PRD", 1, "TF-2024-001", date(2024, 2)),
+			},
+			schemes: map[string]docnumber.Scheme{"RFC": perYear},
+			want: map[string]result{
+				"doc1": {docNumber: "TF-2024-001", changed: true, conflicts: 1},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			plan := planRenumber(c.docs, c.others, c.schemes, c.resequence)
+			assert.Equal(t, c.want, results(plan))
+		})
+	}
+}
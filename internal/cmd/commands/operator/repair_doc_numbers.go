@@ -0,0 +1,392 @@
+package operator
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	hermesdb "github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/pkg/algolia"
+	"github.com/hashicorp-forge/hermes/pkg/docnumber"
+	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
+	"github.com/hashicorp-forge/hermes/pkg/links"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/cli"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type RepairDocNumbersCommand struct {
+	*base.Command
+
+	flagAutoApprove bool
+	flagConfig      string
+	flagDryRun      bool
+	flagProduct     string
+}
+
+// docNumberDuplicate is a document number used by more than one document in
+// the same numbering sequence of a product.
+type docNumberDuplicate struct {
+	// Kept is the document that keeps the number, which was published first.
+	Kept models.Document
+
+	// Renumber are the other documents with the number, which are assigned new
+	// numbers.
+	Renumber []models.Document
+}
+
+func (c *RepairDocNumbersCommand) Synopsis() string {
+	return "Repair duplicate document numbers"
+}
+
+func (c *RepairDocNumbersCommand) Help() string {
+	return `Usage: hermes operator repair-doc-numbers
+
+  This command finds documents with the same document number as another
+  document in the same numbering sequence of a product, which could be
+  assigned by concurrent publishes in earlier versions of Hermes. The document
+  that was published first keeps the number, and the others are assigned the
+  next numbers in their sequence.
+
+  The database, search index, and document header are updated for each
+  renumbered document. Short links for the duplicate number keep redirecting to
+  the document that keeps it.
+
+  Once there are no duplicate document numbers, the unique index of document
+  numbers is created. The server requires the index, so it doesn't start
+  while documents have duplicate numbers.` +
+		c.Flags().Help()
+}
+
+func (c *RepairDocNumbersCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("repair-doc-numbers", flag.ExitOnError))
+
+	f.BoolVar(
+		&c.flagAutoApprove, "auto-approve", false,
+		"Skip interactive approval for renumbering documents.",
+	)
+	f.StringVar(
+		&c.flagConfig, "config", "", "(Required) Path to Hermes config file",
+	)
+	f.BoolVar(
+		&c.flagDryRun, "dry-run", false,
+		"Only print the duplicate document numbers.",
+	)
+	f.StringVar(
+		&c.flagProduct, "product", "",
+		"Comma-separated products of the documents to repair. Repairs all "+
+			"products if empty.",
+	)
+
+	return f
+}
+
+func (c *RepairDocNumbersCommand) Run(args []string) int {
+	logger, ui := c.Log, c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagConfig == "" {
+		ui.Error("config flag is required")
+		return 1
+	}
+
+	// Parse configuration.
+	cfg, err := config.NewConfig(c.flagConfig)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing config file: %v", err))
+		return 1
+	}
+
+	// Validate products.
+	products, err := parseProducts(cfg, c.flagProduct)
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+
+	// Initialize Algolia client.
+	algo, err := algolia.New(cfg.Algolia)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error initializing Algolia: %v", err))
+		return 1
+	}
+
+	// Initialize database.
+	if val, ok := os.LookupEnv("HERMES_SERVER_POSTGRES_PASSWORD"); ok {
+		cfg.Postgres.Password = val
+	}
+	db, err := hermesdb.NewDB(*cfg.Postgres)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error initializing database: %v", err))
+		return 1
+	}
+	// Create GORM-compatible logger.
+	stdLogger := logger.StandardLogger(&hclog.StandardLoggerOptions{
+		InferLevels: true,
+	})
+	// Ignore "record not found" errors.
+	db = db.Session(&gorm.Session{Logger: gormlogger.New(
+		stdLogger,
+		gormlogger.Config{IgnoreRecordNotFoundError: true},
+	)})
+
+	// Initialize Google Workspace service.
+	var goog *gw.Service
+	if cfg.GoogleWorkspace.Auth != nil {
+		// Use Google Workspace auth if it is defined in the config.
+		goog = gw.NewFromConfig(cfg.GoogleWorkspace.Auth)
+	} else {
+		// Use OAuth if Google Workspace auth is not defined in the config.
+		goog = gw.New()
+	}
+
+	ren := &renumberer{
+		Algolia:  algo,
+		Config:   cfg,
+		Database: db,
+		Goog:     goog,
+	}
+
+	start := time.Now()
+
+	// Find duplicate document numbers.
+	docs, err := models.GetDocuments(db, models.DocumentFilter{
+		Products: products,
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf("error getting documents: %v", err))
+		return 1
+	}
+	// Documents numbered before sequences were recorded are in the sequence of
+	// the numbering scheme of their document type, which is recorded when the
+	// index is created.
+	for i, d := range docs {
+		if d.DocumentNumber <= 0 || d.DocumentNumberSequence != "" {
+			continue
+		}
+		scheme, err := cfg.NumberingScheme(d.DocumentType.Name)
+		if err != nil {
+			ui.Error(fmt.Sprintf("error getting numbering scheme: %v", err))
+			return 1
+		}
+		docs[i].DocumentNumberSequence = scheme.Sequence()
+	}
+	dups := planDocNumberRepair(docs)
+	printDocNumberRepairPlan(ui, dups)
+
+	var toRenumber []models.Document
+	for _, dup := range dups {
+		toRenumber = append(toRenumber, dup.Renumber...)
+	}
+
+	if c.flagDryRun {
+		fmt.Println("\nResults (dry run):")
+		fmt.Printf("  %d duplicate document numbers\n", len(dups))
+		fmt.Printf("  %d documents would be renumbered\n", len(toRenumber))
+		return 0
+	}
+
+	if len(toRenumber) > 0 {
+		// Get confirmation that it is okay to renumber the documents.
+		if !c.flagAutoApprove {
+			ui.Info(fmt.Sprintf(
+				"This will renumber %d documents.", len(toRenumber)))
+			ask, err := ui.Ask(
+				"Do you want to continue? (only \"yes\" will continue)")
+			if err != nil || ask != "yes" {
+				ui.Info("No \"yes\" confirmation, so exiting...")
+				return 0
+			}
+		}
+
+		// Renumber documents.
+		var docsWithErrors []string
+		renumbered := 0
+		for _, dup := range dups {
+			for _, d := range dup.Renumber {
+				rd, err := ren.repairDocNumber(d)
+				if err != nil {
+					logger.Error("error renumbering document",
+						"error", err,
+						"document_id", d.GoogleFileID,
+					)
+					docsWithErrors = append(docsWithErrors, d.GoogleFileID)
+					continue
+				}
+				renumbered++
+				logger.Info("document renumbered",
+					"document_id", d.GoogleFileID,
+					"old_doc_number", d.DocNumber(),
+					"doc_number", rd.NewDocNumber,
+				)
+			}
+
+			// Point the short link of the duplicate number to the document that
+			// keeps it.
+			if err := links.SaveDocumentShortLink(db, dup.Kept.GoogleFileID,
+				dup.Kept.DocumentType.Name, dup.Kept.DocNumber()); err != nil {
+				logger.Error("error saving short link",
+					"error", err,
+					"document_id", dup.Kept.GoogleFileID,
+				)
+				docsWithErrors = append(docsWithErrors, dup.Kept.GoogleFileID)
+			}
+		}
+
+		// Print results.
+		fmt.Println("\nResults:")
+		fmt.Printf("  %d documents renumbered\n", renumbered)
+		fmt.Printf("  %d documents with errors\n", len(docsWithErrors))
+		fmt.Printf("\n\nCompleted in: %s\n", time.Since(start))
+		if len(docsWithErrors) > 0 {
+			fmt.Printf("\n\nDocuments with errors:\n%v\n", docsWithErrors)
+			return 1
+		}
+	} else {
+		ui.Info("No duplicate document numbers")
+	}
+
+	// Create the unique index of document numbers, which fails if there are
+	// duplicate document numbers in products that weren't repaired.
+	if err := hermesdb.CreateDocumentNumberIndex(db, cfg); err != nil {
+		if errors.Is(err, models.ErrDuplicateDocumentNumbers) {
+			ui.Warn("The unique index of document numbers will be created once " +
+				"the duplicate document numbers in all products are repaired.")
+			return 0
+		}
+		ui.Error(fmt.Sprintf("error creating document number index: %v", err))
+		return 1
+	}
+
+	return 0
+}
+
+// planDocNumberRepair returns the document numbers of numbered documents docs
+// that are used by more than one document in the same numbering sequence of a
+// product. The document that was published first keeps each number.
+func planDocNumberRepair(docs []models.Document) []docNumberDuplicate {
+	type numberKey struct {
+		productID    uint
+		sequence     string
+		year, number int
+	}
+	byNumber := map[numberKey][]models.Document{}
+	var keys []numberKey
+	for _, d := range docs {
+		if d.DocumentNumber <= 0 {
+			continue
+		}
+		k := numberKey{
+			productID: d.ProductID,
+			sequence:  d.NumberSequence(),
+			year:      d.DocumentNumberYear,
+			number:    d.DocumentNumber,
+		}
+		if _, ok := byNumber[k]; !ok {
+			keys = append(keys, k)
+		}
+		byNumber[k] = append(byNumber[k], d)
+	}
+
+	var dups []docNumberDuplicate
+	for _, k := range keys {
+		ds := byNumber[k]
+		if len(ds) < 2 {
+			continue
+		}
+		sort.SliceStable(ds, func(i, j int) bool {
+			if !ds[i].DocumentCreatedAt.Equal(ds[j].DocumentCreatedAt) {
+				return ds[i].DocumentCreatedAt.Before(ds[j].DocumentCreatedAt)
+			}
+			return ds[i].ID < ds[j].ID
+		})
+		dups = append(dups, docNumberDuplicate{
+			Kept:     ds[0],
+			Renumber: ds[1:],
+		})
+	}
+
+	// Order duplicates by product, document type, and number.
+	sort.SliceStable(dups, func(i, j int) bool {
+		a, b := dups[i].Kept, dups[j].Kept
+		if a.Product.Name != b.Product.Name {
+			return a.Product.Name < b.Product.Name
+		}
+		if a.DocumentType.Name != b.DocumentType.Name {
+			return a.DocumentType.Name < b.DocumentType.Name
+		}
+		if a.DocumentNumberYear != b.DocumentNumberYear {
+			return a.DocumentNumberYear < b.DocumentNumberYear
+		}
+		return a.DocumentNumber < b.DocumentNumber
+	})
+
+	return dups
+}
+
+// printDocNumberRepairPlan prints the duplicate document numbers.
+func printDocNumberRepairPlan(ui cli.Ui, dups []docNumberDuplicate) {
+	if len(dups) == 0 {
+		return
+	}
+	ui.Output("Duplicate document numbers:")
+	for _, dup := range dups {
+		ui.Output(fmt.Sprintf("  %s (%s):",
+			dup.Kept.DocNumber(), dup.Kept.DocumentType.Name))
+		ui.Output(fmt.Sprintf("    - keep: %s %q",
+			dup.Kept.GoogleFileID, dup.Kept.Title))
+		for _, d := range dup.Renumber {
+			ui.Output(fmt.Sprintf("    - renumber: %s %q",
+				d.GoogleFileID, d.Title))
+		}
+	}
+}
+
+// repairDocNumber assigns the next document number in its sequence to document
+// d, which has a duplicate document number, and returns the renumbered
+// document.
+func (ren *renumberer) repairDocNumber(d models.Document) (*renumberDoc, error) {
+	scheme, err := ren.Config.NumberingScheme(d.DocumentType.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting numbering scheme: %w", err)
+	}
+
+	// Keep the year of numbers that restart each year.
+	t := d.DocumentCreatedAt
+	if d.DocumentNumberYear != 0 {
+		t = time.Date(d.DocumentNumberYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	rd := &renumberDoc{
+		Doc: d,
+	}
+	if err := ren.Database.Transaction(func(tx *gorm.DB) error {
+		n, err := docnumber.Next(tx, scheme, d.Product.Name, t)
+		if err != nil {
+			return err
+		}
+		rd.New = n
+		rd.NewDocNumber = scheme.String(
+			d.Product.Abbreviation, d.DocumentType.Name, n)
+
+		return ren.renumberDocument(tx, rd)
+	}); err != nil {
+		return nil, err
+	}
+
+	return rd, nil
+}
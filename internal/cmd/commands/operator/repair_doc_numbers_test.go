@@ -0,0 +1,101 @@
+package operator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPlanDocNumberRepair(t *testing.T) {
+	docTypes := map[uint]string{1: "PRD", 2: "RFC", 3: "FRD"}
+	newDoc := func(
+		id uint, docTypeID uint, number, year int, created time.Time,
+	) models.Document {
+		return models.Document{
+			Model:              gorm.Model{ID: id},
+			GoogleFileID:       fmt.Sprintf("doc%d", id),
+			DocumentCreatedAt:  created,
+			DocumentNumber:     number,
+			DocumentNumberYear: year,
+			DocumentType:       models.DocumentType{Name: docTypes[docTypeID]},
+			DocumentTypeID:     docTypeID,
+			ProductID:          1,
+		}
+	}
+	shared := func(d models.Document) models.Document {
+		d.DocumentNumberSequence = "PRD,RFC"
+		return d
+	}
+	date := func(month time.Month) time.Time {
+		return time.Date(2024, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+	type result struct {
+		kept     string
+		renumber []string
+	}
+
+	cases := map[string]struct {
+		docs []models.Document
+		want []result
+	}{
+		"no duplicates": {
+			docs: []models.Document{
+				newDoc(1, 1, 1, 0, date(1)),
+				newDoc(2, 1, 2, 0, date(2)),
+				// Same number with a different document type.
+				newDoc(3, 2, 1, 0, date(3)),
+				// Same number in a different year.
+				newDoc(4, 3, 1, 2023, date(4)),
+				newDoc(5, 3, 1, 2024, date(5)),
+				// Drafts without numbers.
+				newDoc(6, 1, 0, 0, date(6)),
+				newDoc(7, 1, 0, 0, date(7)),
+			},
+		},
+		"duplicates": {
+			docs: []models.Document{
+				newDoc(1, 1, 1, 0, date(1)),
+				newDoc(2, 1, 2, 0, date(3)),
+				newDoc(3, 1, 2, 0, date(2)),
+				newDoc(4, 1, 2, 0, date(2)),
+				newDoc(5, 2, 1, 0, date(1)),
+				newDoc(6, 2, 1, 0, date(4)),
+			},
+			want: []result{
+				{kept: "doc3", renumber: []string{"doc4", "doc2"}},
+				{kept: "doc5", renumber: []string{"doc6"}},
+			},
+		},
+		"duplicates in a sequence shared by document types": {
+			docs: []models.Document{
+				shared(newDoc(1, 1, 1, 0, date(1))),
+				shared(newDoc(2, 2, 1, 0, date(2))),
+				// Not in the shared sequence.
+				newDoc(3, 3, 1, 0, date(3)),
+			},
+			want: []result{
+				{kept: "doc1", renumber: []string{"doc2"}},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var got []result
+			for _, dup := range planDocNumberRepair(c.docs) {
+				r := result{kept: dup.Kept.GoogleFileID}
+				for _, d := range dup.Renumber {
+					r.renumber = append(r.renumber, d.GoogleFileID)
+				}
+				got = append(got, r)
+			}
+			assert.Equal(c.want, got)
+		})
+	}
+}
@@ -3,23 +3,32 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/DataDog/datadog-go/v5/statsd"
 	"github.com/hashicorp-forge/hermes/internal/api"
 	apiv2 "github.com/hashicorp-forge/hermes/internal/api/v2"
 	"github.com/hashicorp-forge/hermes/internal/auth"
+	"github.com/hashicorp-forge/hermes/internal/auth/google"
+	"github.com/hashicorp-forge/hermes/internal/auth/oidc"
 	"github.com/hashicorp-forge/hermes/internal/cmd/base"
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/datadog"
-	"github.com/hashicorp-forge/hermes/internal/db"
+	hermesdb "github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/internal/embeddings"
+	"github.com/hashicorp-forge/hermes/internal/health"
 	"github.com/hashicorp-forge/hermes/internal/jira"
 	"github.com/hashicorp-forge/hermes/internal/pkg/doctypes"
 	"github.com/hashicorp-forge/hermes/internal/pub"
+	"github.com/hashicorp-forge/hermes/internal/ratelimit"
+	"github.com/hashicorp-forge/hermes/internal/requestid"
 	"github.com/hashicorp-forge/hermes/internal/server"
 	"github.com/hashicorp-forge/hermes/internal/structs"
 	"github.com/hashicorp-forge/hermes/pkg/algolia"
@@ -34,6 +43,10 @@ import (
 	"gorm.io/gorm"
 )
 
+// tokenCacheMetricsInterval is the interval for reporting statistics of the
+// Google token cache to Datadog.
+const tokenCacheMetricsInterval = time.Minute
+
 type Command struct {
 	*base.Command
 
@@ -167,6 +180,20 @@ func (c *Command) Run(args []string) int {
 			return 1
 		}
 	}
+	if _, err := cfg.ViewDeduplicationWindow(); err != nil {
+		c.UI.Error(fmt.Sprintf("error initializing server: %v", err))
+		return 1
+	}
+	healthCheckTimeout, err := cfg.HealthCheckTimeout()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("error initializing server: %v", err))
+		return 1
+	}
+	indexerMaxLag, err := cfg.IndexerMaxLag()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("error initializing server: %v", err))
+		return 1
+	}
 
 	// Configure logger.
 	switch cfg.LogFormat {
@@ -202,6 +229,23 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	// Build OIDC authenticator.
+	var oidcAuth *oidc.Authenticator
+	if cfg.OIDC != nil && !cfg.OIDC.Disabled {
+		if !cfg.Okta.Disabled {
+			c.UI.Error("error initializing server: only one of Okta or OIDC " +
+				"authentication can be enabled")
+			return 1
+		}
+		oidcAuth, err = oidc.New(*cfg.OIDC, cfg.BaseURL, c.Log)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf(
+				"error initializing server: error creating OIDC authenticator: %v",
+				err))
+			return 1
+		}
+	}
+
 	// Initialize Datadog.
 	dd := datadog.NewConfig(*cfg)
 	if dd.Enabled {
@@ -243,6 +287,37 @@ func (c *Command) Run(args []string) int {
 		goog = gw.New()
 	}
 
+	// Cache validation of users' Google access tokens, shared by all
+	// authenticated endpoints (including the Algolia proxy).
+	var googleTokens google.TokenValidator = goog
+	var tokenCacheCfg google.TokenCacheConfig
+	if cfg.GoogleWorkspace.OAuth2 != nil &&
+		cfg.GoogleWorkspace.OAuth2.TokenCache != nil {
+		tokenCacheCfg = *cfg.GoogleWorkspace.OAuth2.TokenCache
+	}
+	if !tokenCacheCfg.Disabled {
+		tc, err := google.NewTokenCache(goog, tokenCacheCfg)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf(
+				"error initializing server: error configuring Google token cache: %v",
+				err))
+			return 1
+		}
+		googleTokens = tc
+
+		// Report cache statistics (including the hit ratio) to Datadog.
+		if dd.Enabled {
+			metrics, err := datadog.NewMetricsClient(dd)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf(
+					"error initializing server: error configuring Datadog metrics: %v",
+					err))
+				return 1
+			}
+			go reportTokenCacheMetrics(metrics, tc, tokenCacheMetricsInterval)
+		}
+	}
+
 	reqOpts := map[interface{}]string{
 		cfg.Algolia.ApplicationID:           "Algolia Application ID is required",
 		cfg.Algolia.SearchAPIKey:            "Algolia Search API Key is required",
@@ -287,12 +362,65 @@ func (c *Command) Run(args []string) int {
 	if val, ok := os.LookupEnv("HERMES_SERVER_POSTGRES_PASSWORD"); ok {
 		cfg.Postgres.Password = val
 	}
-	db, err := db.NewDB(*cfg.Postgres)
+	db, err := hermesdb.NewDB(*cfg.Postgres)
 	if err != nil {
 		c.UI.Error(fmt.Sprintf("error initializing database: %v", err))
 		return 1
 	}
 
+	// Create the unique index of document numbers, which requires documents with
+	// duplicate numbers to be repaired first.
+	if err := hermesdb.CreateDocumentNumberIndex(db, cfg); err != nil {
+		if errors.Is(err, models.ErrDuplicateDocumentNumbers) {
+			c.UI.Error("error initializing database: documents have duplicate " +
+				"document numbers, run \"hermes operator repair-doc-numbers\" " +
+				"to repair them")
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf(
+			"error creating document number index: %v", err))
+		return 1
+	}
+
+	// Initialize rate limiter.
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimit != nil && !cfg.RateLimit.Disabled {
+		var store ratelimit.Store
+		switch cfg.RateLimit.Store {
+		case "", ratelimit.MemoryStoreType:
+			store = ratelimit.NewMemoryStore()
+		case ratelimit.PostgresStoreType:
+			store = ratelimit.NewPostgresStore(db)
+		default:
+			c.UI.Error(fmt.Sprintf(
+				"error initializing rate limiter: invalid store %q",
+				cfg.RateLimit.Store))
+			return 1
+		}
+		limiter, err = ratelimit.New(*cfg.RateLimit, store, c.Log)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("error initializing rate limiter: %v", err))
+			return 1
+		}
+		limiter.Limited = apiv2.RateLimitedHandler()
+	}
+
+	// Initialize embeddings provider.
+	var emb embeddings.Provider
+	if cfg.Embeddings != nil && cfg.Embeddings.Enabled {
+		emb, err = embeddings.New(*cfg.Embeddings)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf(
+				"error initializing embeddings provider: %v", err))
+			return 1
+		}
+		if err := models.MigrateDocumentEmbeddings(db); err != nil {
+			c.UI.Error(fmt.Sprintf(
+				"error migrating document embeddings: %v", err))
+			return 1
+		}
+	}
+
 	// Register document types.
 	// for _, d := range cfg.DocumentTypes.DocumentType {
 	// 	if err := models.RegisterDocumentType(*d, db); err != nil {
@@ -341,11 +469,26 @@ func (c *Command) Run(args []string) int {
 		AlgoWrite:  algoWrite,
 		Config:     cfg,
 		DB:         db,
+		Embeddings: emb,
 		GWService:  goog,
 		Jira:       jiraSvc,
 		Logger:     c.Log,
 	}
 
+	// Configure readiness checks for dependencies.
+	readinessChecker := &health.Checker{
+		Checks: []health.Check{
+			health.AlgoliaCheck(algoSearch),
+			health.DatabaseCheck(db),
+			health.GoogleWorkspaceCheck(goog),
+		},
+		Timeout: healthCheckTimeout,
+	}
+	if jiraSvc != nil {
+		readinessChecker.Checks = append(readinessChecker.Checks,
+			health.JiraCheck(jiraSvc))
+	}
+
 	// Define handlers for authenticated endpoints.
 	authenticatedEndpoints := []endpoint{
 		// Algolia proxy.
@@ -383,6 +526,8 @@ func (c *Command) Run(args []string) int {
 		{"/api/v2/documents/", apiv2.DocumentHandler(srv)},
 		{"/api/v2/drafts", apiv2.DraftsHandler(srv)},
 		{"/api/v2/drafts/", apiv2.DraftsDocumentHandler(srv)},
+		{"/api/v2/drafts/prior-art", apiv2.DraftsPriorArtHandler(srv)},
+		{"/api/v2/drafts/trash", apiv2.DraftsTrashHandler(srv)},
 		{"/api/v2/groups", apiv2.GroupsHandler(srv)},
 		{"/api/v2/jira/issues/", apiv2.JiraIssueHandler(srv)},
 		{"/api/v2/jira/issue/picker", apiv2.JiraIssuePickerHandler(srv)},
@@ -391,52 +536,101 @@ func (c *Command) Run(args []string) int {
 		{"/api/v2/me/recently-viewed-projects",
 			apiv2.MeRecentlyViewedProjectsHandler(srv)},
 		{"/api/v2/me/subscriptions", apiv2.MeSubscriptionsHandler(srv)},
+		{"/api/v2/me/tag-subscriptions", apiv2.MeTagSubscriptionsHandler(srv)},
+		{"/api/v2/me/tokens", apiv2.MeTokensHandler(srv)},
+		{"/api/v2/me/tokens/", apiv2.MeTokensHandler(srv)},
+		{"/api/v2/me/saved-searches", apiv2.MeSavedSearchesHandler(srv)},
+		{"/api/v2/me/saved-searches/", apiv2.MeSavedSearchesHandler(srv)},
+		{"/api/v2/me/stale-drafts", apiv2.MeStaleDraftsHandler(srv)},
+		{"/api/v2/me/stale-drafts/", apiv2.MeStaleDraftsHandler(srv)},
+		{"/api/v2/most-viewed-docs", apiv2.MostViewedDocsHandler(srv)},
+		{"/api/v2/openapi.json", apiv2.OpenAPIHandler()},
 		{"/api/v2/people", apiv2.PeopleDataHandler(srv)},
 		{"/api/v2/products", apiv2.ProductsHandler(srv)},
 		{"/api/v2/projects", apiv2.ProjectsHandler(srv)},
 		{"/api/v2/projects/", apiv2.ProjectHandler(srv)},
 		{"/api/v2/reviews/", apiv2.ReviewsHandler(srv)},
+		{"/api/v2/search", apiv2.SearchHandler(srv)},
+		{"/api/v2/stale-drafts", apiv2.StaleDraftsHandler(srv)},
+		{"/api/v2/tags", apiv2.TagsHandler(srv)},
+		{"/api/v2/tags/", apiv2.TagHandler(srv)},
 		{"/api/v2/web/analytics", apiv2.AnalyticsHandler(srv)},
 	}
 
 	// Define handlers for unauthenticated endpoints.
 	unauthenticatedEndpoints := []endpoint{
 		{"/health", healthHandler()},
+		{"/health/indexer", health.IndexerHandler(db, indexerMaxLag, c.Log)},
+		{"/health/live", health.LiveHandler()},
+		{"/health/ready", health.ReadyHandler(readinessChecker, c.Log)},
 		{"/pub/", http.StripPrefix("/pub/", pub.Handler())},
 	}
 
-	// Web endpoints are conditionally authenticated based on if Okta is enabled.
+	// Add OIDC login endpoints.
+	if oidcAuth != nil {
+		unauthenticatedEndpoints = append(unauthenticatedEndpoints,
+			endpoint{oidc.LoginPath, oidcAuth.LoginHandler()},
+			endpoint{oidc.CallbackPath, oidcAuth.CallbackHandler()},
+			endpoint{oidc.LogoutPath, oidcAuth.LogoutHandler()},
+		)
+	}
+
+	// Web endpoints are conditionally authenticated based on if Okta or OIDC is
+	// enabled.
 	webEndpoints := []endpoint{
 		{"/", web.Handler()},
 		{"/api/v1/web/config", web.ConfigHandler(cfg, algoSearch, c.Log)},
 		{"/api/v2/web/config", web.ConfigHandler(cfg, algoSearch, c.Log)},
-		{"/l/", links.RedirectHandler(algoSearch, cfg.Algolia, c.Log)},
+		{"/l/", links.RedirectHandler(
+			algoSearch, cfg.Algolia, db, cfg.ShortLinks, c.Log)},
 	}
 
-	// If Okta is enabled, add the web endpoints for the single page app as
-	// authenticated endpoints.
-	if cfg.Okta != nil && !cfg.Okta.Disabled {
+	// If Okta or OIDC is enabled, add the web endpoints for the single page app
+	// as authenticated endpoints.
+	if (cfg.Okta != nil && !cfg.Okta.Disabled) || oidcAuth != nil {
 		authenticatedEndpoints = append(authenticatedEndpoints, webEndpoints...)
 	} else {
-		// If Okta is disabled, we need to add the web endpoints for the SPA as
+		// If Okta and OIDC are disabled, we need to add the web endpoints for the SPA as
 		// unauthenticated endpoints so the application will load.
 		unauthenticatedEndpoints = append(unauthenticatedEndpoints, webEndpoints...)
 	}
 
 	// Register handlers.
 	for _, e := range authenticatedEndpoints {
+		h := e.handler
+		if limiter != nil {
+			h = limiter.Limit(e.pattern, h)
+		}
 		mux.Handle(
 			e.pattern,
-			auth.AuthenticateRequest(*cfg, goog, c.Log, e.handler),
+			auth.AuthenticateRequest(*cfg, googleTokens, oidcAuth, db, c.Log, h),
 		)
 	}
 	for _, e := range unauthenticatedEndpoints {
 		mux.Handle(e.pattern, e.handler)
 	}
 
+	// Route budgets that don't match an authenticated route would silently not
+	// rate limit anything.
+	if limiter != nil {
+		if unused := limiter.UnusedRoutes(); len(unused) > 0 {
+			c.UI.Error(fmt.Sprintf(
+				"error initializing rate limiter: route budgets %q don't match "+
+					"the pattern of any authenticated route", unused))
+			return 1
+		}
+	}
+
+	// Requests use a base context that is canceled if in-flight requests don't
+	// finish draining during shutdown, which cancels their backend calls.
+	reqCtx, cancelReqs := context.WithCancel(context.Background())
+	defer cancelReqs()
 	server := &http.Server{
 		Addr:    cfg.Server.Addr,
-		Handler: mux,
+		Handler: requestid.Middleware(mux),
+		BaseContext: func(net.Listener) context.Context {
+			return reqCtx
+		},
 	}
 	go func() {
 		c.Log.Info(fmt.Sprintf("listening on %s...", cfg.Server.Addr))
@@ -447,10 +641,12 @@ func (c *Command) Run(args []string) int {
 		}
 	}()
 
-	return c.WaitForInterrupt(c.ShutdownServer(server))
+	return c.WaitForInterrupt(c.ShutdownServer(server, cancelReqs))
 }
 
 // healthHandler responds with the health of the service.
+//
+// Deprecated: use the /health/live and /health/ready endpoints instead.
 func healthHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -458,14 +654,23 @@ func healthHandler() http.Handler {
 	})
 }
 
-// ShutdownServer gracefully shuts down the HTTP server.
-func (c *Command) ShutdownServer(s *http.Server) func() {
+// ShutdownServer gracefully shuts down the HTTP server. In-flight requests are
+// given time to finish, after which cancelReqs is called to cancel their
+// contexts and the server is closed.
+func (c *Command) ShutdownServer(
+	s *http.Server, cancelReqs context.CancelFunc) func() {
 	return func() {
 		c.Log.Debug("shutting down HTTP server...")
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 		if err := s.Shutdown(ctx); err != nil {
 			c.Log.Error(fmt.Sprintf("error shutting down HTTP server: %v", err))
+
+			// Cancel requests that are still in flight and close their connections.
+			cancelReqs()
+			if err := s.Close(); err != nil {
+				c.Log.Error(fmt.Sprintf("error closing HTTP server: %v", err))
+			}
 		}
 	}
 }
@@ -572,3 +777,28 @@ func registerProducts(
 
 	return nil
 }
+
+// reportTokenCacheMetrics reports statistics of Google token cache tc to
+// Datadog every interval. Hits, misses, and evictions are reported as counts
+// since the previous report.
+func reportTokenCacheMetrics(
+	metrics statsd.ClientInterface,
+	tc *google.TokenCache,
+	interval time.Duration,
+) {
+	var prev google.TokenCacheStats
+	for range time.Tick(interval) {
+		s := tc.Stats()
+		metrics.Gauge("google_token_cache.entries", float64(s.Entries), nil, 1)
+		metrics.Gauge("google_token_cache.hit_ratio", s.HitRatio, nil, 1)
+		metrics.Count("google_token_cache.hits",
+			int64(s.Hits-prev.Hits), nil, 1)
+		metrics.Count("google_token_cache.negative_hits",
+			int64(s.NegativeHits-prev.NegativeHits), nil, 1)
+		metrics.Count("google_token_cache.misses",
+			int64(s.Misses-prev.Misses), nil, 1)
+		metrics.Count("google_token_cache.evictions",
+			int64(s.Evictions-prev.Evictions), nil, 1)
+		prev = s
+	}
+}
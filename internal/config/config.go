@@ -3,17 +3,32 @@ package config
 import (
 	"fmt"
 
+	"github.com/hashicorp-forge/hermes/internal/auth/google"
+	"github.com/hashicorp-forge/hermes/internal/auth/oidc"
 	"github.com/hashicorp-forge/hermes/internal/auth/oktaalb"
+	"github.com/hashicorp-forge/hermes/internal/embeddings"
+	"github.com/hashicorp-forge/hermes/internal/ratelimit"
 	"github.com/hashicorp-forge/hermes/pkg/algolia"
 	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
+	"github.com/hashicorp-forge/hermes/pkg/links"
 	"github.com/hashicorp/hcl/v2/hclsimple"
 )
 
 // Config contains the Hermes configuration.
 type Config struct {
+	// Admins are the email addresses of users who can administer Hermes (e.g.,
+	// rename and merge tags).
+	Admins []string `hcl:"admins,optional"`
+
 	// Algolia configures Hermes to work with Algolia.
 	Algolia *algolia.Config `hcl:"algolia,block"`
 
+	// Analytics configures document view analytics.
+	Analytics *Analytics `hcl:"analytics,block"`
+
+	// APITokens configures Hermes-issued API tokens for machine access.
+	APITokens *APITokens `hcl:"api_tokens,block"`
+
 	// BaseURL is the base URL used for building links.
 	BaseURL string `hcl:"base_url,optional"`
 
@@ -23,9 +38,17 @@ type Config struct {
 	// DocumentTypes contain available document types.
 	DocumentTypes *DocumentTypes `hcl:"document_types,block"`
 
+	// DraftsTrash configures moving deleted drafts to a trash folder, where they
+	// can be restored by their owners until they are purged.
+	DraftsTrash *DraftsTrash `hcl:"drafts_trash,block"`
+
 	// Email configures Hermes to send email notifications.
 	Email *Email `hcl:"email,block"`
 
+	// Embeddings configures semantic search using embeddings of document
+	// content.
+	Embeddings *embeddings.Config `hcl:"embeddings,block"`
+
 	// FeatureFlags contain available feature flags.
 	FeatureFlags *FeatureFlags `hcl:"feature_flags,block"`
 
@@ -45,6 +68,10 @@ type Config struct {
 	// "json".
 	LogFormat string `hcl:"log_format,optional"`
 
+	// OIDC configures Hermes to authenticate users using a generic OpenID
+	// Connect provider.
+	OIDC *oidc.Config `hcl:"oidc,block"`
+
 	// Okta configures Hermes to work with Okta.
 	Okta *oktaalb.Config `hcl:"okta,block"`
 
@@ -54,16 +81,83 @@ type Config struct {
 	// Postgres configures PostgreSQL as the app database.
 	Postgres *Postgres `hcl:"postgres,block"`
 
+	// RateLimit configures rate limiting of authenticated requests.
+	RateLimit *ratelimit.Config `hcl:"rate_limit,block"`
+
+	// Retention configures retention policies that archive or flag documents
+	// that haven't been modified in a while.
+	Retention *Retention `hcl:"retention,block"`
+
 	// Server contains the configuration for the Hermes server.
 	Server *Server `hcl:"server,block"`
 
 	// ShortenerBaseURL is the base URL for building short links.
 	ShortenerBaseURL string `hcl:"shortener_base_url,optional"`
 
+	// ShortLinks configures short links.
+	ShortLinks *links.Config `hcl:"short_links,block"`
+
+	// StaleDrafts configures nudging the owners of drafts that haven't been
+	// modified in a while.
+	StaleDrafts *StaleDrafts `hcl:"stale_drafts,block"`
+
 	// SupportLinkURL is the URL for the support documentation.
 	SupportLinkURL string `hcl:"support_link_url,optional"`
 }
 
+// Analytics configures document view analytics.
+type Analytics struct {
+	// ViewDeduplicationWindow is the duration (e.g., "30m") during which
+	// repeated views of a document by the same user are only counted once.
+	// Defaults to 30 minutes.
+	ViewDeduplicationWindow string `hcl:"view_deduplication_window,optional"`
+}
+
+// APITokens configures Hermes-issued API tokens (personal access tokens and
+// service account tokens) for machine access.
+type APITokens struct {
+	// DefaultTTL is the lifetime (e.g., "720h") of tokens that are created
+	// without an explicit expiration. Defaults to 30 days.
+	DefaultTTL string `hcl:"default_ttl,optional"`
+
+	// Disabled disables authentication using API tokens.
+	Disabled bool `hcl:"disabled,optional"`
+
+	// MaxTTL is the maximum lifetime (e.g., "2160h") of tokens. Defaults to 90
+	// days.
+	MaxTTL string `hcl:"max_ttl,optional"`
+
+	// ServiceAccounts are the service accounts that tokens can be issued for.
+	ServiceAccounts []*ServiceAccount `hcl:"service_account,block"`
+}
+
+// ServiceAccount is a non-human identity (e.g., a bot) that can be issued API
+// tokens.
+type ServiceAccount struct {
+	// Name is the name of the service account.
+	Name string `hcl:"name,label"`
+
+	// Email is the email address that requests authenticated as the service
+	// account act as.
+	Email string `hcl:"email"`
+
+	// Owners are the email addresses of users who can issue and revoke tokens
+	// for the service account.
+	Owners []string `hcl:"owners"`
+}
+
+// DraftsTrash configures moving deleted drafts to a trash folder. Drafts are
+// deleted immediately if it isn't configured.
+type DraftsTrash struct {
+	// Folder is the Google Drive folder that deleted drafts are moved to.
+	Folder string `hcl:"folder"`
+
+	// RetentionPeriod is the duration (e.g., "720h") that deleted drafts can be
+	// restored before they are permanently deleted by the indexer. Defaults to
+	// 30 days.
+	RetentionPeriod string `hcl:"retention_period,optional"`
+}
+
 // Datadog configures Hermes to send metrics to Datadog.
 type Datadog struct {
 	// Enabled enables sending metrics to Datadog.
@@ -118,6 +212,11 @@ type DocumentType struct {
 
 	// CustomFields are custom fields specific to the document type.
 	CustomFields []*DocumentTypeCustomField `hcl:"custom_field,block" json:"customFields"`
+
+	// Numbering configures the numbering scheme of documents of the document
+	// type. Documents are numbered per product and document type (e.g.,
+	// "TF-123") if not configured.
+	Numbering *DocumentTypeNumbering `hcl:"numbering,block" json:"-"`
 }
 
 // DocumentTypeCheck is a document type check, which require acknowledging a
@@ -146,6 +245,26 @@ type DocumentTypeCustomField struct {
 	Type string `hcl:"type" json:"type"`
 }
 
+// DocumentTypeNumbering configures the numbering scheme of a document type.
+type DocumentTypeNumbering struct {
+	// Format is the format of document numbers. "{product}" is replaced with the
+	// product abbreviation, "{doc_type}" with the document type, "{year}" with
+	// the year the number was assigned, and "{number}" with the number. Numbers
+	// restart each year if the format contains "{year}". Defaults to
+	// "{product}-{number}".
+	// Example: "{product}-{year}-{number}" (e.g., "TF-2024-007")
+	Format string `hcl:"format,optional"`
+
+	// Padding is the minimum number of digits of the number, which is padded
+	// with leading zeros. Defaults to 3.
+	Padding *int `hcl:"padding,optional"`
+
+	// Sequence is the name of the sequence of numbers. Document types with the
+	// same sequence share numbers within a product. Defaults to the name of the
+	// document type.
+	Sequence string `hcl:"sequence,optional"`
+}
+
 // DocumentTypeLink is a document type link.
 type DocumentTypeLink struct {
 	// Text is the displayed text for a document type link.
@@ -188,6 +307,10 @@ type Indexer struct {
 	// simultaneously indexed.
 	MaxParallelDocs int `hcl:"max_parallel_docs,optional"`
 
+	// MaxLag is the maximum duration (e.g., "1h") since the last completed full
+	// index before the indexer is reported as unhealthy. Defaults to "1h".
+	MaxLag string `hcl:"max_lag,optional"`
+
 	// UpdateDocHeaders enables the indexer to automatically update document
 	// headers for Hermes-managed documents with Hermes document metadata.
 	UpdateDocHeaders bool `hcl:"update_doc_headers,optional"`
@@ -265,6 +388,10 @@ type GoogleWorkspaceOAuth2 struct {
 	// RedirectURI is an authorized redirect URI for the given client_id as
 	// specified in the Google API Console Credentials page.
 	RedirectURI string `hcl:"redirect_uri,optional"`
+
+	// TokenCache is the configuration for caching the validation of users'
+	// Google access tokens.
+	TokenCache *google.TokenCacheConfig `hcl:"token_cache,block"`
 }
 
 // GoogleWorkspaceUserNotFoundEmail is the configuration to send an email when a
@@ -329,16 +456,87 @@ type Product struct {
 	Abbreviation string `hcl:"abbreviation" json:"abbreviation"`
 }
 
+// Retention configures retention policies, which are applied by the indexer.
+type Retention struct {
+	// ArchiveFolder is the Google Drive folder that documents archived by
+	// retention policies are moved to. It is required if any policy archives
+	// documents.
+	ArchiveFolder string `hcl:"archive_folder,optional"`
+
+	// NoticePeriod is the duration (e.g., "336h") before a policy is applied
+	// that document owners are notified, so they can update the document or
+	// opt it out. Defaults to 14 days.
+	NoticePeriod string `hcl:"notice_period,optional"`
+
+	// Policy defines a retention policy.
+	Policy []*RetentionPolicy `hcl:"policy,block"`
+}
+
+// Retention policy actions.
+const (
+	// RetentionActionArchive moves documents to the archive folder and marks
+	// them as archived.
+	RetentionActionArchive = "archive"
+
+	// RetentionActionFlag marks documents as flagged for review.
+	RetentionActionFlag = "flag"
+)
+
+// RetentionPolicy is a retention policy that applies to documents that haven't
+// been modified for a duration and match all of its filters.
+type RetentionPolicy struct {
+	// Name is the name of the policy.
+	Name string `hcl:"name,label"`
+
+	// Action is the action to take on matching documents ("archive" or
+	// "flag").
+	Action string `hcl:"action"`
+
+	// Age is the duration (e.g., "17520h" for 2 years) since a document was
+	// last modified after which the policy applies.
+	Age string `hcl:"age"`
+
+	// DocumentTypes filters by document type names. All document types match
+	// if empty.
+	DocumentTypes []string `hcl:"document_types,optional"`
+
+	// Products filters by product names. All products match if empty.
+	Products []string `hcl:"products,optional"`
+
+	// Statuses filters by document status (e.g., "Obsolete", "WIP"). All
+	// statuses match if empty.
+	Statuses []string `hcl:"statuses,optional"`
+}
+
 // Server contains the configuration for the Hermes server.
 type Server struct {
 	// Addr is the address to bind to for listening.
 	Addr string `hcl:"addr,optional"`
+
+	// HealthCheckTimeout is the timeout (e.g., "5s") for each dependency check
+	// performed by the readiness endpoint. Defaults to "5s".
+	HealthCheckTimeout string `hcl:"health_check_timeout,optional"`
+}
+
+// StaleDrafts configures nudging the owners of stale drafts, which is done by
+// the indexer.
+type StaleDrafts struct {
+	// Threshold is the duration (e.g., "2160h") since a draft was last modified
+	// after which it is stale. Defaults to 90 days.
+	Threshold string `hcl:"threshold,optional"`
+
+	// NudgeInterval is the duration (e.g., "720h") between emails to the owner
+	// of a stale draft, and between stale draft reports to admins. Defaults to
+	// 30 days.
+	NudgeInterval string `hcl:"nudge_interval,optional"`
 }
 
 // NewConfig parses an HCL configuration file and returns the Hermes config.
 func NewConfig(filename string) (*Config, error) {
 	c := &Config{
 		Algolia:         &algolia.Config{},
+		Analytics:       &Analytics{},
+		APITokens:       &APITokens{},
 		Email:           &Email{},
 		FeatureFlags:    &FeatureFlags{},
 		GoogleWorkspace: &GoogleWorkspace{},
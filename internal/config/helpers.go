@@ -2,8 +2,237 @@ package config
 
 import (
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/docnumber"
 )
 
+const (
+	// defaultAPITokenDefaultTTL is the default lifetime of API tokens that are
+	// created without an explicit expiration.
+	defaultAPITokenDefaultTTL = 30 * 24 * time.Hour
+
+	// defaultAPITokenMaxTTL is the default maximum lifetime of API tokens.
+	defaultAPITokenMaxTTL = 90 * 24 * time.Hour
+
+	// defaultDraftsTrashRetentionPeriod is the default duration that deleted
+	// drafts can be restored before they are permanently deleted.
+	defaultDraftsTrashRetentionPeriod = 30 * 24 * time.Hour
+
+	// defaultHealthCheckTimeout is the default timeout for each dependency check
+	// performed by the readiness endpoint.
+	defaultHealthCheckTimeout = 5 * time.Second
+
+	// defaultIndexerMaxLag is the default maximum duration since the last
+	// completed full index before the indexer is reported as unhealthy.
+	defaultIndexerMaxLag = time.Hour
+
+	// defaultRetentionNoticePeriod is the default duration before a retention
+	// policy is applied that document owners are notified.
+	defaultRetentionNoticePeriod = 14 * 24 * time.Hour
+
+	// defaultStaleDraftsThreshold is the default duration since a draft was last
+	// modified after which it is stale.
+	defaultStaleDraftsThreshold = 90 * 24 * time.Hour
+
+	// defaultStaleDraftsNudgeInterval is the default duration between emails to
+	// the owner of a stale draft.
+	defaultStaleDraftsNudgeInterval = 30 * 24 * time.Hour
+
+	// defaultViewDeduplicationWindow is the default duration during which
+	// repeated views of a document by the same user are only counted once.
+	defaultViewDeduplicationWindow = 30 * time.Minute
+)
+
+// APITokenDefaultTTL returns the configured lifetime of API tokens that are
+// created without an explicit expiration, or the default if not configured.
+func (c *Config) APITokenDefaultTTL() (time.Duration, error) {
+	if c.APITokens == nil || c.APITokens.DefaultTTL == "" {
+		return defaultAPITokenDefaultTTL, nil
+	}
+
+	d, err := time.ParseDuration(c.APITokens.DefaultTTL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid api_tokens default_ttl: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid api_tokens default_ttl: must be positive")
+	}
+
+	return d, nil
+}
+
+// APITokenMaxTTL returns the configured maximum lifetime of API tokens, or the
+// default if not configured.
+func (c *Config) APITokenMaxTTL() (time.Duration, error) {
+	if c.APITokens == nil || c.APITokens.MaxTTL == "" {
+		return defaultAPITokenMaxTTL, nil
+	}
+
+	d, err := time.ParseDuration(c.APITokens.MaxTTL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid api_tokens max_ttl: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid api_tokens max_ttl: must be positive")
+	}
+
+	return d, nil
+}
+
+// DraftsTrashRetentionPeriod returns the configured duration that deleted
+// drafts can be restored before they are permanently deleted, or the default
+// if not configured.
+func (c *Config) DraftsTrashRetentionPeriod() (time.Duration, error) {
+	if c.DraftsTrash == nil || c.DraftsTrash.RetentionPeriod == "" {
+		return defaultDraftsTrashRetentionPeriod, nil
+	}
+
+	d, err := time.ParseDuration(c.DraftsTrash.RetentionPeriod)
+	if err != nil {
+		return 0, fmt.Errorf("invalid drafts_trash retention_period: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf(
+			"invalid drafts_trash retention_period: must be positive")
+	}
+
+	return d, nil
+}
+
+// NumberingScheme returns the numbering scheme of document type docType.
+func (c *Config) NumberingScheme(docType string) (docnumber.Scheme, error) {
+	if c.DocumentTypes == nil {
+		return docnumber.Scheme{}, fmt.Errorf("document type not found: %s", docType)
+	}
+	var dt *DocumentType
+	for _, v := range c.DocumentTypes.DocumentType {
+		if v.Name == docType {
+			dt = v
+			break
+		}
+	}
+	if dt == nil {
+		return docnumber.Scheme{}, fmt.Errorf("document type not found: %s", docType)
+	}
+
+	s := docnumber.DefaultScheme(dt.Name)
+	if dt.Numbering == nil {
+		return s, nil
+	}
+	s.Format = numberingFormat(dt)
+	if dt.Numbering.Padding != nil {
+		s.Padding = *dt.Numbering.Padding
+	}
+
+	// Find the document types that share the sequence.
+	if seq := sequenceName(dt); seq != dt.Name {
+		s.DocumentTypes = nil
+		for _, v := range c.DocumentTypes.DocumentType {
+			if sequenceName(v) != seq {
+				continue
+			}
+			// Document types that share a sequence must all restart numbers each
+			// year, or not.
+			if strings.Contains(numberingFormat(v), "{year}") != s.Yearly() {
+				return docnumber.Scheme{}, fmt.Errorf(
+					"invalid numbering for document type %s: document types in "+
+						"sequence %q must all include {year} in their format, or not",
+					dt.Name, seq)
+			}
+			s.DocumentTypes = append(s.DocumentTypes, v.Name)
+		}
+	}
+
+	if err := s.Validate(); err != nil {
+		return docnumber.Scheme{}, fmt.Errorf(
+			"invalid numbering for document type %s: %w", dt.Name, err)
+	}
+
+	return s, nil
+}
+
+// numberingFormat returns the format of document numbers of document type dt.
+func numberingFormat(dt *DocumentType) string {
+	if dt.Numbering != nil && dt.Numbering.Format != "" {
+		return dt.Numbering.Format
+	}
+	return docnumber.DefaultFormat
+}
+
+// sequenceName returns the name of the sequence of document numbers of
+// document type dt.
+func sequenceName(dt *DocumentType) string {
+	if dt.Numbering != nil && dt.Numbering.Sequence != "" {
+		return dt.Numbering.Sequence
+	}
+	return dt.Name
+}
+
+// IsAdmin returns true if the user with the provided email address is a Hermes
+// admin.
+func (c *Config) IsAdmin(email string) bool {
+	for _, a := range c.Admins {
+		if strings.EqualFold(a, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServiceAccount returns the service account with the provided name, or nil if
+// it is not configured.
+func (c *Config) ServiceAccount(name string) *ServiceAccount {
+	if c.APITokens == nil {
+		return nil
+	}
+	for _, sa := range c.APITokens.ServiceAccounts {
+		if sa.Name == name {
+			return sa
+		}
+	}
+	return nil
+}
+
+// HealthCheckTimeout returns the configured timeout for each readiness
+// dependency check, or the default if not configured.
+func (c *Config) HealthCheckTimeout() (time.Duration, error) {
+	if c.Server == nil || c.Server.HealthCheckTimeout == "" {
+		return defaultHealthCheckTimeout, nil
+	}
+
+	d, err := time.ParseDuration(c.Server.HealthCheckTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid server health_check_timeout: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf(
+			"invalid server health_check_timeout: must be positive")
+	}
+
+	return d, nil
+}
+
+// IndexerMaxLag returns the configured maximum duration since the last
+// completed full index before the indexer is reported as unhealthy, or the
+// default if not configured.
+func (c *Config) IndexerMaxLag() (time.Duration, error) {
+	if c.Indexer == nil || c.Indexer.MaxLag == "" {
+		return defaultIndexerMaxLag, nil
+	}
+
+	d, err := time.ParseDuration(c.Indexer.MaxLag)
+	if err != nil {
+		return 0, fmt.Errorf("invalid indexer max_lag: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid indexer max_lag: must be positive")
+	}
+
+	return d, nil
+}
+
 // ValidateFeatureFlags validates the feature flags defined in the config.
 func ValidateFeatureFlags(flags []*FeatureFlag) error {
 	for _, f := range flags {
@@ -19,3 +248,125 @@ func ValidateFeatureFlags(flags []*FeatureFlag) error {
 	}
 	return nil
 }
+
+// RetentionNoticePeriod returns the configured duration before a retention
+// policy is applied that document owners are notified, or the default if not
+// configured.
+func (c *Config) RetentionNoticePeriod() (time.Duration, error) {
+	if c.Retention == nil || c.Retention.NoticePeriod == "" {
+		return defaultRetentionNoticePeriod, nil
+	}
+
+	d, err := time.ParseDuration(c.Retention.NoticePeriod)
+	if err != nil {
+		return 0, fmt.Errorf("invalid retention notice_period: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid retention notice_period: must be positive")
+	}
+
+	return d, nil
+}
+
+// MinAge returns the duration since a document was last modified after which
+// the retention policy applies.
+func (p *RetentionPolicy) MinAge() (time.Duration, error) {
+	d, err := time.ParseDuration(p.Age)
+	if err != nil {
+		return 0, fmt.Errorf("invalid age for retention policy %q: %w",
+			p.Name, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf(
+			"invalid age for retention policy %q: must be positive", p.Name)
+	}
+
+	return d, nil
+}
+
+// StaleDraftsThreshold returns the configured duration since a draft was last
+// modified after which it is stale, or the default if not configured.
+func (c *Config) StaleDraftsThreshold() (time.Duration, error) {
+	if c.StaleDrafts == nil || c.StaleDrafts.Threshold == "" {
+		return defaultStaleDraftsThreshold, nil
+	}
+
+	d, err := time.ParseDuration(c.StaleDrafts.Threshold)
+	if err != nil {
+		return 0, fmt.Errorf("invalid stale_drafts threshold: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid stale_drafts threshold: must be positive")
+	}
+
+	return d, nil
+}
+
+// StaleDraftsNudgeInterval returns the configured duration between emails to
+// the owner of a stale draft, or the default if not configured.
+func (c *Config) StaleDraftsNudgeInterval() (time.Duration, error) {
+	if c.StaleDrafts == nil || c.StaleDrafts.NudgeInterval == "" {
+		return defaultStaleDraftsNudgeInterval, nil
+	}
+
+	d, err := time.ParseDuration(c.StaleDrafts.NudgeInterval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid stale_drafts nudge_interval: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf(
+			"invalid stale_drafts nudge_interval: must be positive")
+	}
+
+	return d, nil
+}
+
+// ValidateRetention validates retention policies.
+func ValidateRetention(r *Retention) error {
+	names := make(map[string]struct{})
+	for _, p := range r.Policy {
+		if _, ok := names[p.Name]; ok {
+			return fmt.Errorf("duplicate retention policy %q", p.Name)
+		}
+		names[p.Name] = struct{}{}
+
+		switch p.Action {
+		case RetentionActionArchive:
+			if r.ArchiveFolder == "" {
+				return fmt.Errorf(
+					"retention policy %q archives documents but archive_folder isn't set",
+					p.Name)
+			}
+		case RetentionActionFlag:
+		default:
+			return fmt.Errorf("invalid action %q for retention policy %q",
+				p.Action, p.Name)
+		}
+
+		if _, err := p.MinAge(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ViewDeduplicationWindow returns the configured document view deduplication
+// window, or the default if not configured.
+func (c *Config) ViewDeduplicationWindow() (time.Duration, error) {
+	if c.Analytics == nil || c.Analytics.ViewDeduplicationWindow == "" {
+		return defaultViewDeduplicationWindow, nil
+	}
+
+	d, err := time.ParseDuration(c.Analytics.ViewDeduplicationWindow)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"invalid analytics view_deduplication_window: %w", err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf(
+			"invalid analytics view_deduplication_window: must not be negative")
+	}
+
+	return d, nil
+}
@@ -0,0 +1,34 @@
+package datadog
+
+import (
+	"fmt"
+
+	"github.com/DataDog/datadog-go/v5/statsd"
+)
+
+// NewMetricsClient creates a client for sending metrics to the DogStatsD server
+// of the Datadog agent. The address of the agent is set with the DD_AGENT_HOST
+// and DD_DOGSTATSD_PORT (or DD_DOGSTATSD_URL) environment variables. Metric
+// names are prefixed with the service name.
+func NewMetricsClient(cfg *Config) (*statsd.Client, error) {
+	opts := []statsd.Option{
+		statsd.WithNamespace(cfg.Service + "."),
+	}
+
+	var tags []string
+	if cfg.Env != "" {
+		tags = append(tags, "env:"+cfg.Env)
+	}
+	if cfg.ServiceVersion != "" {
+		tags = append(tags, "version:"+cfg.ServiceVersion)
+	}
+	if len(tags) > 0 {
+		opts = append(opts, statsd.WithTags(tags))
+	}
+
+	c, err := statsd.New("", opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating DogStatsD client: %w", err)
+	}
+	return c, nil
+}
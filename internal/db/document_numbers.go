@@ -0,0 +1,49 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// SetDocumentNumberSequences records the numbering sequences of the document
+// types in cfg for numbered documents that don't have a recorded sequence.
+func SetDocumentNumberSequences(db *gorm.DB, cfg *config.Config) error {
+	if cfg.DocumentTypes == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, dt := range cfg.DocumentTypes.DocumentType {
+		scheme, err := cfg.NumberingScheme(dt.Name)
+		if err != nil {
+			return fmt.Errorf("error getting numbering scheme: %w", err)
+		}
+		if seen[scheme.Sequence()] {
+			continue
+		}
+		seen[scheme.Sequence()] = true
+
+		if err := models.SetDocumentNumberSequences(
+			db, scheme.DocumentTypes); err != nil {
+			return fmt.Errorf(
+				"error setting document number sequence %q: %w",
+				scheme.Sequence(), err)
+		}
+	}
+
+	return nil
+}
+
+// CreateDocumentNumberIndex records the numbering sequences of documents and
+// creates the unique index of document numbers per sequence. It returns
+// models.ErrDuplicateDocumentNumbers if documents have duplicate numbers, which
+// must be repaired with the "operator repair-doc-numbers" command.
+func CreateDocumentNumberIndex(db *gorm.DB, cfg *config.Config) error {
+	if err := SetDocumentNumberSequences(db, cfg); err != nil {
+		return err
+	}
+	return models.CreateDocumentNumberIndex(db)
+}
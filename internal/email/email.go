@@ -62,6 +62,68 @@ type ReviewRequestedEmailData struct {
 	Product             string
 }
 
+type RetentionNoticeEmailData struct {
+	Action              string
+	ActionDate          string
+	BaseURL             string
+	CurrentYear         int
+	DocumentShortName   string
+	DocumentStatus      string
+	DocumentStatusClass string
+	DocumentTitle       string
+	DocumentType        string
+	DocumentURL         string
+	PolicyName          string
+	Product             string
+}
+
+type SavedSearchAlertEmailData struct {
+	BaseURL             string
+	CurrentYear         int
+	DocumentOwner       string
+	DocumentShortName   string
+	DocumentStatus      string
+	DocumentStatusClass string
+	DocumentTitle       string
+	DocumentType        string
+	DocumentURL         string
+	Product             string
+	SavedSearchName     string
+}
+
+type StaleDraftNudgeEmailData struct {
+	BaseURL       string
+	CurrentYear   int
+	DeleteURL     string
+	DocumentTitle string
+	DocumentType  string
+	DocumentURL   string
+	KeepURL       string
+	ModifiedDate  string
+	Product       string
+	PublishURL    string
+}
+
+type StaleDraftsReportEmailData struct {
+	BaseURL     string
+	CurrentYear int
+	Products    []StaleDraftsReportProduct
+	Threshold   string
+	Total       int
+}
+
+type StaleDraftsReportProduct struct {
+	Name   string
+	Drafts []StaleDraftsReportDraft
+}
+
+type StaleDraftsReportDraft struct {
+	ModifiedDate string
+	Owner        string
+	Title        string
+	URL          string
+}
+
 type SubscriberDocumentPublishedEmailData struct {
 	BaseURL           string
 	CurrentYear       int
@@ -237,6 +299,193 @@ func SendReviewRequestedEmail(
 	return err
 }
 
+func SendRetentionNoticeEmail(
+	d RetentionNoticeEmailData,
+	to []string,
+	from string,
+	s *gw.Service,
+) error {
+	// Validate data.
+	if err := validation.ValidateStruct(&d,
+		validation.Field(&d.Action, validation.Required),
+		validation.Field(&d.ActionDate, validation.Required),
+		validation.Field(&d.BaseURL, validation.Required),
+		validation.Field(&d.DocumentStatus, validation.Required),
+		validation.Field(&d.DocumentTitle, validation.Required),
+		validation.Field(&d.DocumentType, validation.Required),
+		validation.Field(&d.DocumentURL, validation.Required),
+		validation.Field(&d.PolicyName, validation.Required),
+		validation.Field(&d.Product, validation.Required),
+	); err != nil {
+		return fmt.Errorf("error validating email data: %w", err)
+	}
+
+	var body bytes.Buffer
+	tmpl, err := template.ParseFS(tmplFS, "templates/retention-notice.html")
+	if err != nil {
+		return fmt.Errorf("error parsing template: %w", err)
+	}
+
+	// Set current year.
+	d.CurrentYear = time.Now().Year()
+
+	// Set status class.
+	d.DocumentStatusClass = dasherizeStatus(d.DocumentStatus)
+
+	if err := tmpl.Execute(&body, d); err != nil {
+		return fmt.Errorf("error executing template: %w", err)
+	}
+
+	subject := fmt.Sprintf("Your document will be %s: %s",
+		d.Action, d.DocumentTitle)
+	if d.DocumentShortName != "" {
+		subject = fmt.Sprintf("Your document will be %s: [%s] %s",
+			d.Action, d.DocumentShortName, d.DocumentTitle)
+	}
+	_, err = s.SendEmail(
+		to,
+		from,
+		subject,
+		body.String(),
+	)
+	return err
+}
+
+func SendSavedSearchAlertEmail(
+	d SavedSearchAlertEmailData,
+	to []string,
+	from string,
+	s *gw.Service,
+) error {
+	// Validate data.
+	if err := validation.ValidateStruct(&d,
+		validation.Field(&d.BaseURL, validation.Required),
+		validation.Field(&d.DocumentOwner, validation.Required),
+		validation.Field(&d.DocumentStatus, validation.Required),
+		validation.Field(&d.DocumentTitle, validation.Required),
+		validation.Field(&d.DocumentType, validation.Required),
+		validation.Field(&d.DocumentURL, validation.Required),
+		validation.Field(&d.Product, validation.Required),
+		validation.Field(&d.SavedSearchName, validation.Required),
+	); err != nil {
+		return fmt.Errorf("error validating email data: %w", err)
+	}
+
+	var body bytes.Buffer
+	tmpl, err := template.ParseFS(tmplFS, "templates/saved-search-alert.html")
+	if err != nil {
+		return fmt.Errorf("error parsing template: %w", err)
+	}
+
+	// Set current year.
+	d.CurrentYear = time.Now().Year()
+
+	// Set status class.
+	d.DocumentStatusClass = dasherizeStatus(d.DocumentStatus)
+
+	if err := tmpl.Execute(&body, d); err != nil {
+		return fmt.Errorf("error executing template: %w", err)
+	}
+
+	_, err = s.SendEmail(
+		to,
+		from,
+		fmt.Sprintf("Saved search \"%s\": [%s] %s",
+			d.SavedSearchName,
+			d.DocumentShortName,
+			d.DocumentTitle,
+		),
+		body.String(),
+	)
+	return err
+}
+
+func SendStaleDraftNudgeEmail(
+	d StaleDraftNudgeEmailData,
+	to []string,
+	from string,
+	s *gw.Service,
+) error {
+	// Validate data.
+	if err := validation.ValidateStruct(&d,
+		validation.Field(&d.BaseURL, validation.Required),
+		validation.Field(&d.DeleteURL, validation.Required),
+		validation.Field(&d.DocumentTitle, validation.Required),
+		validation.Field(&d.DocumentType, validation.Required),
+		validation.Field(&d.DocumentURL, validation.Required),
+		validation.Field(&d.KeepURL, validation.Required),
+		validation.Field(&d.ModifiedDate, validation.Required),
+		validation.Field(&d.Product, validation.Required),
+		validation.Field(&d.PublishURL, validation.Required),
+	); err != nil {
+		return fmt.Errorf("error validating email data: %w", err)
+	}
+
+	var body bytes.Buffer
+	tmpl, err := template.ParseFS(tmplFS, "templates/stale-draft-nudge.html")
+	if err != nil {
+		return fmt.Errorf("error parsing template: %w", err)
+	}
+
+	// Set current year.
+	d.CurrentYear = time.Now().Year()
+
+	if err := tmpl.Execute(&body, d); err != nil {
+		return fmt.Errorf("error executing template: %w", err)
+	}
+
+	_, err = s.SendEmail(
+		to,
+		from,
+		fmt.Sprintf("Keep, publish, or delete your draft: %s", d.DocumentTitle),
+		body.String(),
+	)
+	return err
+}
+
+func SendStaleDraftsReportEmail(
+	d StaleDraftsReportEmailData,
+	to []string,
+	from string,
+	s *gw.Service,
+) error {
+	// Validate data.
+	if err := validation.ValidateStruct(&d,
+		validation.Field(&d.BaseURL, validation.Required),
+		validation.Field(&d.Products, validation.Required),
+		validation.Field(&d.Threshold, validation.Required),
+	); err != nil {
+		return fmt.Errorf("error validating email data: %w", err)
+	}
+
+	var body bytes.Buffer
+	tmpl, err := template.ParseFS(tmplFS, "templates/stale-drafts-report.html")
+	if err != nil {
+		return fmt.Errorf("error parsing template: %w", err)
+	}
+
+	// Set current year.
+	d.CurrentYear = time.Now().Year()
+
+	// Set total number of drafts.
+	d.Total = 0
+	for _, p := range d.Products {
+		d.Total += len(p.Drafts)
+	}
+
+	if err := tmpl.Execute(&body, d); err != nil {
+		return fmt.Errorf("error executing template: %w", err)
+	}
+
+	_, err = s.SendEmail(
+		to,
+		from,
+		fmt.Sprintf("Stale drafts report: %d drafts", d.Total),
+		body.String(),
+	)
+	return err
+}
+
 func SendSubscriberDocumentPublishedEmail(
 	d SubscriberDocumentPublishedEmailData,
 	to []string,
@@ -0,0 +1,10 @@
+// Package embeddings computes vector embeddings of document content for
+// semantic search.
+//
+// Embeddings are computed by a pluggable Provider. The "local" provider is a
+// CPU-only feature hashing model that runs in process and needs no network
+// access or model files, which makes it suitable for offline use. The "openai"
+// provider calls an OpenAI-compatible embeddings API, which can be a hosted
+// service or a model served locally (e.g., by Ollama) for better semantic
+// matching.
+package embeddings
@@ -0,0 +1,147 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// DefaultMaxDistance is the default maximum cosine distance of documents
+	// that are returned by semantic search.
+	DefaultMaxDistance = 0.5
+
+	// LocalProviderType is the provider type for the local feature hashing
+	// model.
+	LocalProviderType = "local"
+
+	// OpenAIProviderType is the provider type for OpenAI-compatible embeddings
+	// APIs.
+	OpenAIProviderType = "openai"
+
+	// chunkWords is the number of words in a chunk of content that is embedded.
+	chunkWords = 200
+
+	// chunkOverlapWords is the number of words that consecutive chunks share, so
+	// text that spans a chunk boundary is embedded together.
+	chunkOverlapWords = 20
+)
+
+// Config is the configuration for computing embeddings.
+type Config struct {
+	// APIKey is the API key for the "openai" provider, if required.
+	APIKey string `hcl:"api_key,optional"`
+
+	// Dimensions is the number of dimensions of embeddings. Defaults to 512 for
+	// the "local" provider. For the "openai" provider, it is sent with requests
+	// if set, for models that support shortening embeddings.
+	Dimensions int `hcl:"dimensions,optional"`
+
+	// Enabled enables computing embeddings in the indexer, finding similar
+	// documents, and hybrid search.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// MaxDistance is the maximum cosine distance (between 0 and 2) of documents
+	// returned by semantic search. Suitable values depend on the model. Defaults
+	// to 0.5.
+	MaxDistance float64 `hcl:"max_distance,optional"`
+
+	// Model is the name of the model for the "openai" provider (e.g.,
+	// "text-embedding-3-small" or "nomic-embed-text").
+	Model string `hcl:"model,optional"`
+
+	// Provider is the embeddings provider: "local" (the default) or "openai".
+	Provider string `hcl:"provider,optional"`
+
+	// URL is the base URL of the API for the "openai" provider. Defaults to
+	// "https://api.openai.com/v1".
+	URL string `hcl:"url,optional"`
+}
+
+// Provider computes embeddings.
+type Provider interface {
+	// Embed returns an embedding for each text.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+
+	// Model identifies the model and its configuration. Embeddings computed by
+	// different models aren't comparable.
+	Model() string
+}
+
+// New returns a new embeddings provider.
+func New(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case "", LocalProviderType:
+		return NewLocalProvider(cfg.Dimensions)
+	case OpenAIProviderType:
+		return NewOpenAIProvider(cfg)
+	default:
+		return nil, fmt.Errorf("invalid provider %q", cfg.Provider)
+	}
+}
+
+// MaxDistanceOrDefault returns the maximum cosine distance of documents
+// returned by semantic search.
+func (c Config) MaxDistanceOrDefault() float64 {
+	if c.MaxDistance <= 0 {
+		return DefaultMaxDistance
+	}
+	return c.MaxDistance
+}
+
+// Chunk splits text into chunks of words to embed.
+func Chunk(text string) []string {
+	return chunk(text, chunkWords, chunkOverlapWords)
+}
+
+// chunk splits text into chunks of at most size words, where consecutive
+// chunks share overlap words.
+func chunk(text string, size, overlap int) []string {
+	words := strings.Fields(text)
+	var chunks []string
+	for start := 0; start < len(words); start += size - overlap {
+		end := start + size
+		if end > len(words) {
+			end = len(words)
+		}
+		chunks = append(chunks, strings.Join(words[start:end], " "))
+		if end == len(words) {
+			break
+		}
+	}
+	return chunks
+}
+
+// Mean returns the normalized mean of embeddings, which represents all of
+// them (e.g., the chunks of a document).
+func Mean(embeddings [][]float32) []float32 {
+	if len(embeddings) == 0 {
+		return nil
+	}
+	mean := make([]float32, len(embeddings[0]))
+	for _, e := range embeddings {
+		for i := range mean {
+			if i < len(e) {
+				mean[i] += e[i]
+			}
+		}
+	}
+	normalize(mean)
+	return mean
+}
+
+// normalize scales v to unit length, in place.
+func normalize(v []float32) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range v {
+		v[i] /= norm
+	}
+}
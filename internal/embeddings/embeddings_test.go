@@ -0,0 +1,150 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cosine returns the cosine similarity of normalized embeddings.
+func cosine(a, b []float32) float32 {
+	var s float32
+	for i := range a {
+		s += a[i] * b[i]
+	}
+	return s
+}
+
+func TestChunk(t *testing.T) {
+	cases := map[string]struct {
+		text    string
+		size    int
+		overlap int
+		want    []string
+	}{
+		"empty": {
+			text:    "  ",
+			size:    3,
+			overlap: 1,
+		},
+		"one chunk": {
+			text:    "a b\nc",
+			size:    3,
+			overlap: 1,
+			want:    []string{"a b c"},
+		},
+		"overlapping chunks": {
+			text:    "a b c d e f g",
+			size:    3,
+			overlap: 1,
+			want:    []string{"a b c", "c d e", "e f g"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, chunk(c.text, c.size, c.overlap))
+		})
+	}
+}
+
+func TestMean(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(Mean(nil))
+	assert.InDeltaSlice([]float32{0.6, 0.8},
+		Mean([][]float32{{0.6, 0}, {0, 0.8}}), 0.0001)
+}
+
+func TestLocalProvider(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	p, err := NewLocalProvider(0)
+	require.NoError(err)
+	assert.Equal("local-v1-512", p.Model())
+
+	embs, err := p.Embed(context.Background(), []string{
+		"Deploying services to Kubernetes clusters",
+		"A deployment strategy for Kubernetes services",
+		"Quarterly marketing budget review",
+		"Deploying services to Kubernetes clusters",
+	})
+	require.NoError(err)
+	require.Len(embs, 4)
+	for _, e := range embs {
+		assert.Len(e, 512)
+		assert.InDelta(1, cosine(e, e), 0.0001)
+	}
+
+	// Embeddings are deterministic.
+	assert.Equal(embs[0], embs[3])
+
+	// Related text is more similar than unrelated text.
+	assert.Greater(cosine(embs[0], embs[1]), cosine(embs[0], embs[2]))
+
+	_, err = NewLocalProvider(-1)
+	assert.Error(err)
+}
+
+func TestOpenAIProvider(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	var gotReq openAIEmbeddingsRequest
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal("/v1/embeddings", r.URL.Path)
+			assert.Equal("Bearer key", r.Header.Get("Authorization"))
+			require.NoError(json.NewDecoder(r.Body).Decode(&gotReq))
+
+			// Return embeddings out of order.
+			w.Write([]byte(`{"data": [
+				{"index": 1, "embedding": [0, 2]},
+				{"index": 0, "embedding": [3, 4]}
+			]}`))
+		}))
+	defer srv.Close()
+
+	p, err := New(Config{
+		APIKey:     "key",
+		Dimensions: 2,
+		Model:      "test-model",
+		Provider:   OpenAIProviderType,
+		URL:        srv.URL + "/v1/",
+	})
+	require.NoError(err)
+	assert.Equal("openai-test-model-2", p.Model())
+
+	embs, err := p.Embed(context.Background(), []string{"a", "b"})
+	require.NoError(err)
+	assert.Equal(openAIEmbeddingsRequest{
+		Dimensions: 2,
+		Input:      []string{"a", "b"},
+		Model:      "test-model",
+	}, gotReq)
+	assert.Equal([][]float32{{0.6, 0.8}, {0, 1}}, embs)
+}
+
+func TestOpenAIProviderError(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad model", http.StatusBadRequest)
+		}))
+	defer srv.Close()
+
+	p, err := NewOpenAIProvider(Config{Model: "test-model", URL: srv.URL})
+	require.NoError(err)
+	_, err = p.Embed(context.Background(), []string{"a"})
+	require.Error(err)
+	assert.True(strings.Contains(err.Error(), "bad model"))
+
+	_, err = NewOpenAIProvider(Config{})
+	assert.Error(err)
+}
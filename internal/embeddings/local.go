@@ -0,0 +1,110 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// defaultLocalDimensions is the default number of dimensions of embeddings
+	// computed by the local provider.
+	defaultLocalDimensions = 512
+
+	// localModelVersion is the version of the local model, which must be
+	// incremented when changes make embeddings incompatible.
+	localModelVersion = 1
+)
+
+// stopWords are common English words that are ignored by the local provider.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "has": true, "in": true,
+	"is": true, "it": true, "of": true, "on": true, "or": true, "that": true,
+	"the": true, "this": true, "to": true, "was": true, "we": true,
+	"will": true, "with": true,
+}
+
+// LocalProvider computes embeddings using feature hashing of words, word
+// pairs, and character trigrams. It runs on the CPU in process and doesn't
+// need network access or model files. Character trigrams match different
+// forms of the same word (e.g., "deploy" and "deployment"), but unlike a
+// learned model, it doesn't match synonyms.
+type LocalProvider struct {
+	dimensions int
+}
+
+// NewLocalProvider returns a new local provider that computes embeddings with
+// dimensions dimensions (or the default, if 0).
+func NewLocalProvider(dimensions int) (*LocalProvider, error) {
+	if dimensions == 0 {
+		dimensions = defaultLocalDimensions
+	}
+	if dimensions < 0 {
+		return nil, fmt.Errorf("dimensions must be positive")
+	}
+	return &LocalProvider{dimensions: dimensions}, nil
+}
+
+// Embed implements Provider.
+func (p *LocalProvider) Embed(
+	ctx context.Context, texts []string) ([][]float32, error) {
+	res := make([][]float32, len(texts))
+	for i, t := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		res[i] = p.embed(t)
+	}
+	return res, nil
+}
+
+// Model implements Provider.
+func (p *LocalProvider) Model() string {
+	return fmt.Sprintf("local-v%d-%d", localModelVersion, p.dimensions)
+}
+
+// embed computes the embedding of text.
+func (p *LocalProvider) embed(text string) []float32 {
+	counts := map[string]int{}
+	var prev string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		if stopWords[w] {
+			prev = ""
+			continue
+		}
+		counts["w:"+w]++
+		if prev != "" {
+			counts["b:"+prev+" "+w]++
+		}
+		prev = w
+
+		// Character trigrams of the word with boundary markers.
+		rs := []rune("<" + w + ">")
+		for i := 0; i+3 <= len(rs); i++ {
+			counts["c:"+string(rs[i:i+3])]++
+		}
+	}
+
+	v := make([]float32, p.dimensions)
+	for f, n := range counts {
+		h := fnv.New64a()
+		h.Write([]byte(f))
+		sum := h.Sum64()
+		// Use a bit of the hash as the sign so collisions tend to cancel out.
+		sign := float32(1)
+		if sum&(1<<63) != 0 {
+			sign = -1
+		}
+		// Dampen repeated features.
+		v[sum%uint64(p.dimensions)] += sign * float32(1+math.Log(float64(n)))
+	}
+	normalize(v)
+
+	return v
+}
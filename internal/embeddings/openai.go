@@ -0,0 +1,143 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultOpenAIURL is the default base URL of the OpenAI API.
+	defaultOpenAIURL = "https://api.openai.com/v1"
+
+	// openAIBatchSize is the maximum number of texts embedded per request.
+	openAIBatchSize = 64
+)
+
+// OpenAIProvider computes embeddings using an OpenAI-compatible embeddings
+// API.
+type OpenAIProvider struct {
+	apiKey     string
+	client     *http.Client
+	dimensions int
+	model      string
+	url        string
+}
+
+type openAIEmbeddingsRequest struct {
+	Dimensions int      `json:"dimensions,omitempty"`
+	Input      []string `json:"input"`
+	Model      string   `json:"model"`
+}
+
+type openAIEmbeddingsResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+		Index     int       `json:"index"`
+	} `json:"data"`
+}
+
+// NewOpenAIProvider returns a new provider for an OpenAI-compatible embeddings
+// API.
+func NewOpenAIProvider(cfg Config) (*OpenAIProvider, error) {
+	if cfg.Model == "" {
+		return nil, errors.New("model is required for the openai provider")
+	}
+	url := cfg.URL
+	if url == "" {
+		url = defaultOpenAIURL
+	}
+	return &OpenAIProvider{
+		apiKey:     cfg.APIKey,
+		client:     &http.Client{Timeout: 60 * time.Second},
+		dimensions: cfg.Dimensions,
+		model:      cfg.Model,
+		url:        strings.TrimSuffix(url, "/"),
+	}, nil
+}
+
+// Embed implements Provider.
+func (p *OpenAIProvider) Embed(
+	ctx context.Context, texts []string) ([][]float32, error) {
+	var res [][]float32
+	for start := 0; start < len(texts); start += openAIBatchSize {
+		end := start + openAIBatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+		embs, err := p.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, embs...)
+	}
+	return res, nil
+}
+
+// Model implements Provider.
+func (p *OpenAIProvider) Model() string {
+	if p.dimensions > 0 {
+		return fmt.Sprintf("openai-%s-%d", p.model, p.dimensions)
+	}
+	return "openai-" + p.model
+}
+
+// embedBatch embeds texts in one request.
+func (p *OpenAIProvider) embedBatch(
+	ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(openAIEmbeddingsRequest{
+		Dimensions: p.dimensions,
+		Input:      texts,
+		Model:      p.model,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, p.url+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting embeddings: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("error requesting embeddings: %s: %s",
+			resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	var er openAIEmbeddingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&er); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+	res := make([][]float32, len(texts))
+	for _, d := range er.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("invalid embedding index %d", d.Index)
+		}
+		res[d.Index] = d.Embedding
+	}
+	for i, e := range res {
+		if len(e) == 0 {
+			return nil, fmt.Errorf("missing embedding for input %d", i)
+		}
+		normalize(e)
+	}
+
+	return res, nil
+}
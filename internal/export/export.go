@@ -0,0 +1,365 @@
+// Package export exports documents with their Hermes metadata, for offline
+// archives and legal holds.
+package export
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// Format is the format of an exported document.
+type Format string
+
+const (
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "md"
+	FormatPDF      Format = "pdf"
+)
+
+// ParseFormat parses an export format.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatHTML, FormatMarkdown, FormatPDF:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid export format: %q", s)
+	}
+}
+
+// MIMEType returns the MIME type of documents exported in the format.
+func (f Format) MIMEType() string {
+	switch f {
+	case FormatHTML:
+		return "text/html"
+	case FormatMarkdown:
+		return "text/markdown"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return ""
+	}
+}
+
+// Metadata is the Hermes metadata of an exported document.
+type Metadata struct {
+	ID               string            `yaml:"id"`
+	Title            string            `yaml:"title"`
+	DocType          string            `yaml:"docType"`
+	DocNumber        string            `yaml:"docNumber"`
+	Product          string            `yaml:"product"`
+	Status           string            `yaml:"status"`
+	Owner            string            `yaml:"owner,omitempty"`
+	Contributors     []string          `yaml:"contributors,omitempty"`
+	Approvers        []string          `yaml:"approvers,omitempty"`
+	ApproverGroups   []string          `yaml:"approverGroups,omitempty"`
+	Approvals        []Approval        `yaml:"approvals,omitempty"`
+	Summary          string            `yaml:"summary,omitempty"`
+	Created          time.Time         `yaml:"created,omitempty"`
+	Modified         time.Time         `yaml:"modified,omitempty"`
+	URL              string            `yaml:"url"`
+	RelatedResources []RelatedResource `yaml:"relatedResources,omitempty"`
+	Exported         time.Time         `yaml:"exported"`
+}
+
+// Approval is a review of a document by an approver.
+type Approval struct {
+	Approver string `yaml:"approver"`
+
+	// Status is "approved" or "changes requested".
+	Status string `yaml:"status"`
+
+	// Time is the time of the review.
+	Time time.Time `yaml:"time"`
+}
+
+// RelatedResource is a resource related to a document.
+type RelatedResource struct {
+	Title string `yaml:"title"`
+	URL   string `yaml:"url"`
+
+	// DocNumber is the document number, if the resource is a Hermes document.
+	DocNumber string `yaml:"docNumber,omitempty"`
+}
+
+// File is an exported document.
+type File struct {
+	// Name is the file name of the exported document.
+	Name string
+
+	// Content is the exported document, with its metadata as front matter (for
+	// formats that support it).
+	Content []byte
+
+	// Format is the format of the exported document.
+	Format Format
+
+	// Metadata is the Hermes metadata of the document.
+	Metadata Metadata
+}
+
+// NewMetadata returns the Hermes metadata of document model.
+func NewMetadata(
+	db *gorm.DB, model models.Document, baseURL string) (Metadata, error) {
+	var reviews models.DocumentReviews
+	if err := reviews.Find(db, models.DocumentReview{
+		Document: models.Document{
+			GoogleFileID: model.GoogleFileID,
+		},
+	}); err != nil {
+		return Metadata{}, fmt.Errorf("error getting reviews: %w", err)
+	}
+	var groupReviews models.DocumentGroupReviews
+	if err := groupReviews.Find(db, models.DocumentGroupReview{
+		Document: models.Document{
+			GoogleFileID: model.GoogleFileID,
+		},
+	}); err != nil {
+		return Metadata{}, fmt.Errorf("error getting group reviews: %w", err)
+	}
+	doc, err := document.NewFromDatabaseModel(model, reviews, groupReviews)
+	if err != nil {
+		return Metadata{}, fmt.Errorf(
+			"error converting database model to document: %w", err)
+	}
+
+	md := Metadata{
+		ID:             doc.ObjectID,
+		Title:          doc.Title,
+		DocType:        doc.DocType,
+		DocNumber:      doc.DocNumber,
+		Product:        doc.Product,
+		Status:         doc.Status,
+		Contributors:   doc.Contributors,
+		Approvers:      doc.Approvers,
+		ApproverGroups: doc.ApproverGroups,
+		Summary:        doc.Summary,
+		Modified:       model.DocumentModifiedAt.UTC(),
+		URL:            documentURL(baseURL, doc.ObjectID, model),
+		Exported:       time.Now().UTC(),
+	}
+	if !model.DocumentCreatedAt.IsZero() {
+		md.Created = model.DocumentCreatedAt.UTC()
+	}
+	if len(doc.Owners) > 0 {
+		md.Owner = doc.Owners[0]
+	}
+
+	// Approvals.
+	for _, r := range reviews {
+		var status string
+		switch r.Status {
+		case models.ApprovedDocumentReviewStatus:
+			status = "approved"
+		case models.ChangesRequestedDocumentReviewStatus:
+			status = "changes requested"
+		default:
+			continue
+		}
+		md.Approvals = append(md.Approvals, Approval{
+			Approver: r.User.EmailAddress,
+			Status:   status,
+			Time:     r.UpdatedAt.UTC(),
+		})
+	}
+
+	// Related resources.
+	elrrs, hdrrs, err := model.GetRelatedResources(db)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("error getting related resources: %w", err)
+	}
+	for _, rr := range elrrs {
+		md.RelatedResources = append(md.RelatedResources, RelatedResource{
+			Title: rr.Name,
+			URL:   rr.URL,
+		})
+	}
+	for _, rr := range hdrrs {
+		rd := models.Document{
+			GoogleFileID: rr.Document.GoogleFileID,
+		}
+		if err := rd.Get(db); err != nil {
+			return Metadata{}, fmt.Errorf("error getting related document: %w", err)
+		}
+		md.RelatedResources = append(md.RelatedResources, RelatedResource{
+			Title:     rd.Title,
+			URL:       documentURL(baseURL, rd.GoogleFileID, rd),
+			DocNumber: rd.DocNumber(),
+		})
+	}
+
+	return md, nil
+}
+
+// Document exports document model from Google Workspace in format f, with its
+// Hermes metadata as front matter.
+func Document(
+	db *gorm.DB,
+	s *gw.Service,
+	model models.Document,
+	f Format,
+	baseURL string,
+) (File, error) {
+	md, err := NewMetadata(db, model, baseURL)
+	if err != nil {
+		return File{}, err
+	}
+
+	content, err := s.ExportFile(model.GoogleFileID, f.MIMEType())
+	if err != nil {
+		return File{}, fmt.Errorf("error exporting document: %w", err)
+	}
+	content, err = WithFrontMatter(content, md, f)
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{
+		Name:     FileName(md, f),
+		Content:  content,
+		Format:   f,
+		Metadata: md,
+	}, nil
+}
+
+// WithFrontMatter returns exported document content with metadata md as YAML
+// front matter. Markdown documents start with the front matter between "---"
+// lines, and HTML documents start with it in a comment. PDF documents can't
+// contain front matter, so their content is returned unchanged.
+func WithFrontMatter(content []byte, md Metadata, f Format) ([]byte, error) {
+	fm, err := yaml.Marshal(md)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling metadata: %w", err)
+	}
+
+	var b bytes.Buffer
+	switch f {
+	case FormatMarkdown:
+		b.WriteString("---\n")
+		b.Write(fm)
+		b.WriteString("---\n\n")
+	case FormatHTML:
+		// Make sure the metadata doesn't end the comment early.
+		fm = bytes.ReplaceAll(fm, []byte("-->"), []byte("-- >"))
+		b.WriteString("<!--\n---\n")
+		b.Write(fm)
+		b.WriteString("---\n-->\n")
+	}
+	b.Write(content)
+
+	return b.Bytes(), nil
+}
+
+// fileNameRE matches characters that aren't allowed in exported file names.
+var fileNameRE = regexp.MustCompile(`[^\w .\-]+`)
+
+// FileName returns the file name of a document exported in format f, which is
+// its document number (or ID, for drafts) and title.
+func FileName(md Metadata, f Format) string {
+	name := md.DocNumber
+	if name == "" || strings.HasSuffix(name, "-???") {
+		name = md.ID
+	}
+	if md.Title != "" {
+		name += " " + md.Title
+	}
+	return SanitizeFileName(name) + "." + string(f)
+}
+
+// SanitizeFileName replaces characters that aren't allowed in exported file
+// names and truncates long names.
+func SanitizeFileName(name string) string {
+	name = strings.TrimSpace(fileNameRE.ReplaceAllString(name, "_"))
+	if len(name) > 100 {
+		name = strings.TrimSpace(name[:100])
+	}
+	return name
+}
+
+// Archive writes exported documents to a gzipped tarball.
+type Archive struct {
+	tw *tar.Writer
+	zw *gzip.Writer
+}
+
+// NewArchive returns an archive that writes to w.
+func NewArchive(w io.Writer) *Archive {
+	zw := gzip.NewWriter(w)
+	return &Archive{
+		tw: tar.NewWriter(zw),
+		zw: zw,
+	}
+}
+
+// Add adds exported document f to directory dir in the archive. The metadata of
+// documents in formats that can't contain front matter is added in a YAML file
+// next to the document.
+func (a *Archive) Add(dir string, f File) error {
+	modTime := f.Metadata.Modified
+	if modTime.IsZero() {
+		modTime = f.Metadata.Exported
+	}
+	if err := a.write(path.Join(dir, f.Name), f.Content, modTime); err != nil {
+		return err
+	}
+
+	if f.Format == FormatPDF {
+		fm, err := yaml.Marshal(f.Metadata)
+		if err != nil {
+			return fmt.Errorf("error marshaling metadata: %w", err)
+		}
+		name := strings.TrimSuffix(f.Name, "."+string(f.Format)) + ".yaml"
+		if err := a.write(path.Join(dir, name), fm, modTime); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the archive.
+func (a *Archive) Close() error {
+	if err := a.tw.Close(); err != nil {
+		return fmt.Errorf("error closing tar writer: %w", err)
+	}
+	if err := a.zw.Close(); err != nil {
+		return fmt.Errorf("error closing gzip writer: %w", err)
+	}
+	return nil
+}
+
+func (a *Archive) write(name string, b []byte, modTime time.Time) error {
+	if err := a.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(b)),
+		ModTime: modTime,
+	}); err != nil {
+		return fmt.Errorf("error writing tar header: %w", err)
+	}
+	if _, err := a.tw.Write(b); err != nil {
+		return fmt.Errorf("error writing file to archive: %w", err)
+	}
+	return nil
+}
+
+// documentURL returns the URL of a document in the Hermes web app.
+func documentURL(baseURL, docID string, model models.Document) string {
+	u := strings.TrimSuffix(baseURL, "/") + "/document/" + docID
+	if model.Status == models.WIPDocumentStatus && !model.Imported {
+		u += "?draft=true"
+	}
+	return u
+}
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMetadata = Metadata{
+	ID:        "abc123",
+	Title:     "My RFC",
+	DocType:   "RFC",
+	DocNumber: "TF-001",
+	Product:   "Terraform",
+	Status:    "Approved",
+	Owner:     "owner@example.com",
+	Approvals: []Approval{
+		{
+			Approver: "approver@example.com",
+			Status:   "approved",
+			Time:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	},
+	URL:      "https://hermes.example.com/document/abc123",
+	Exported: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestWithFrontMatter(t *testing.T) {
+	cases := map[string]struct {
+		format     Format
+		content    string
+		wantPrefix string
+		wantSuffix string
+	}{
+		"markdown": {
+			format:     FormatMarkdown,
+			content:    "# My RFC\n",
+			wantPrefix: "---\nid: abc123\ntitle: My RFC\n",
+			wantSuffix: "---\n\n# My RFC\n",
+		},
+		"html": {
+			format:     FormatHTML,
+			content:    "<html></html>",
+			wantPrefix: "<!--\n---\nid: abc123\n",
+			wantSuffix: "---\n-->\n<html></html>",
+		},
+		"pdf": {
+			format:     FormatPDF,
+			content:    "%PDF-1.4",
+			wantPrefix: "%PDF-1.4",
+			wantSuffix: "%PDF-1.4",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := WithFrontMatter([]byte(c.content), testMetadata, c.format)
+			require.NoError(err)
+			assert.True(bytes.HasPrefix(got, []byte(c.wantPrefix)), string(got))
+			assert.True(bytes.HasSuffix(got, []byte(c.wantSuffix)), string(got))
+			if c.format != FormatPDF {
+				assert.Contains(string(got), "approver: approver@example.com\n")
+				assert.Contains(string(got), "time: 2024-01-02T03:04:05Z\n")
+			}
+		})
+	}
+}
+
+func TestFileName(t *testing.T) {
+	cases := map[string]struct {
+		md   Metadata
+		want string
+	}{
+		"document number": {
+			md:   Metadata{ID: "abc", DocNumber: "TF-001", Title: "My RFC"},
+			want: "TF-001 My RFC.md",
+		},
+		"draft": {
+			md:   Metadata{ID: "abc", DocNumber: "TF-???", Title: "My RFC"},
+			want: "abc My RFC.md",
+		},
+		"unsafe characters": {
+			md:   Metadata{ID: "abc", DocNumber: "TF-002", Title: "A/B: C?"},
+			want: "TF-002 A_B_ C_.md",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, FileName(c.md, FormatMarkdown))
+		})
+	}
+}
+
+func TestArchive(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	var buf bytes.Buffer
+	a := NewArchive(&buf)
+	require.NoError(a.Add("RFC", File{
+		Name:     "TF-001 My RFC.md",
+		Content:  []byte("markdown"),
+		Format:   FormatMarkdown,
+		Metadata: testMetadata,
+	}))
+	require.NoError(a.Add("RFC", File{
+		Name:     "TF-001 My RFC.pdf",
+		Content:  []byte("pdf"),
+		Format:   FormatPDF,
+		Metadata: testMetadata,
+	}))
+	require.NoError(a.Close())
+
+	zr, err := gzip.NewReader(&buf)
+	require.NoError(err)
+	tr := tar.NewReader(zr)
+	got := map[string]string{}
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		b, err := io.ReadAll(tr)
+		require.NoError(err)
+		got[h.Name] = string(b)
+	}
+
+	require.Len(got, 3)
+	assert.Equal("markdown", got["RFC/TF-001 My RFC.md"])
+	assert.Equal("pdf", got["RFC/TF-001 My RFC.pdf"])
+	assert.Contains(got["RFC/TF-001 My RFC.yaml"], "docNumber: TF-001\n")
+}
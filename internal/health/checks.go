@@ -0,0 +1,68 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/hashicorp-forge/hermes/internal/jira"
+	"github.com/hashicorp-forge/hermes/pkg/algolia"
+	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
+	"gorm.io/gorm"
+)
+
+// DatabaseCheck returns a check that pings the database.
+func DatabaseCheck(db *gorm.DB) Check {
+	return Check{
+		Name: "postgres",
+		Func: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return fmt.Errorf("error getting database connection: %w", err)
+			}
+			if err := sqlDB.PingContext(ctx); err != nil {
+				return fmt.Errorf("error pinging database: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// AlgoliaCheck returns a check that performs an empty search on the docs index.
+func AlgoliaCheck(a *algolia.Client) Check {
+	return Check{
+		Name: "algolia",
+		Func: func(ctx context.Context) error {
+			if _, err := a.Docs.Search("", opt.HitsPerPage(0), ctx); err != nil {
+				return fmt.Errorf("error searching docs index: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// GoogleWorkspaceCheck returns a check that verifies the Google Workspace
+// token is valid by getting information about the authenticated Drive user.
+func GoogleWorkspaceCheck(s *gw.Service) Check {
+	return Check{
+		Name: "google_workspace",
+		Func: func(ctx context.Context) error {
+			if _, err := s.Drive.About.Get().
+				Fields("user").
+				Context(ctx).
+				Do(); err != nil {
+				return fmt.Errorf("error getting Drive user: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// JiraCheck returns a check that verifies Jira is reachable with the
+// configured credentials.
+func JiraCheck(s *jira.Service) Check {
+	return Check{
+		Name: "jira",
+		Func: s.Ping,
+	}
+}
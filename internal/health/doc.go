@@ -0,0 +1,3 @@
+// Package health contains logic for checking the health of Hermes and its
+// dependencies.
+package health
@@ -0,0 +1,123 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+// indexerResponse is the response for the indexer health endpoint.
+type indexerResponse struct {
+	Status          string     `json:"status"`
+	Error           string     `json:"error,omitempty"`
+	LastFullIndexAt *time.Time `json:"lastFullIndexAt,omitempty"`
+	LagSeconds      int64      `json:"lagSeconds"`
+	MaxLagSeconds   int64      `json:"maxLagSeconds"`
+}
+
+// LiveHandler responds with the liveness of the service. It doesn't check any
+// dependencies so it only fails if the process can't serve requests.
+func LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Report{
+			Status: StatusOK,
+			Checks: map[string]CheckResult{},
+		})
+	})
+}
+
+// ReadyHandler responds with the readiness of the service, based on the health
+// of its dependencies. It responds with a 503 if any dependency is unhealthy.
+// The response only includes the status of each dependency; errors are logged.
+func ReadyHandler(c *Checker, log hclog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		rep := c.Run(r.Context())
+
+		code := http.StatusOK
+		if rep.Status != StatusOK {
+			code = http.StatusServiceUnavailable
+
+			for name, res := range rep.Checks {
+				if res.Status != StatusOK {
+					log.Warn("readiness check failed",
+						"check", name,
+						"error", res.Error,
+						"duration_ms", res.DurationMs,
+					)
+				}
+			}
+		}
+
+		writeJSON(w, code, rep)
+	})
+}
+
+// IndexerHandler responds with the health of the indexer, based on the time
+// since it last completed a full index. It responds with a 503 if the indexer
+// has never completed a full index or if the lag exceeds maxLag.
+func IndexerHandler(
+	db *gorm.DB, maxLag time.Duration, log hclog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		resp := indexerResponse{
+			Status:        StatusOK,
+			MaxLagSeconds: int64(maxLag.Seconds()),
+		}
+
+		md := models.IndexerMetadata{}
+		if err := md.Get(db.WithContext(r.Context())); err != nil {
+			resp.Status = StatusError
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				resp.Error = "indexer has not completed a full index"
+			} else {
+				log.Error("error getting indexer metadata",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				resp.Error = "error getting indexer metadata"
+			}
+			writeJSON(w, http.StatusServiceUnavailable, resp)
+			return
+		}
+
+		lag := time.Since(md.LastFullIndexAt)
+		resp.LastFullIndexAt = &md.LastFullIndexAt
+		resp.LagSeconds = int64(lag.Seconds())
+
+		code := http.StatusOK
+		if lag > maxLag {
+			resp.Status = StatusError
+			resp.Error = "indexer lag exceeds maximum"
+			code = http.StatusServiceUnavailable
+		}
+
+		writeJSON(w, code, resp)
+	})
+}
+
+// writeJSON writes v as a JSON response with the provided status code.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
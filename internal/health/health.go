@@ -0,0 +1,115 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// StatusOK is the status of a healthy check or report.
+	StatusOK = "ok"
+
+	// StatusError is the status of an unhealthy check or report.
+	StatusError = "error"
+)
+
+// CheckFunc checks the health of a dependency, returning an error if it is
+// unhealthy.
+type CheckFunc func(ctx context.Context) error
+
+// Check is a named dependency health check.
+type Check struct {
+	// Name is the name of the dependency being checked.
+	Name string
+
+	// Func is the function that checks the health of the dependency.
+	Func CheckFunc
+}
+
+// CheckResult is the result of a dependency health check. Only the status is
+// included in responses, as the readiness endpoint is unauthenticated and
+// errors can contain details about dependencies.
+type CheckResult struct {
+	Status     string `json:"status"`
+	Error      string `json:"-"`
+	DurationMs int64  `json:"-"`
+}
+
+// Report is the result of running all dependency health checks.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Checker runs dependency health checks.
+type Checker struct {
+	// Checks are the dependency health checks to run.
+	Checks []Check
+
+	// Timeout is the timeout for each dependency health check.
+	Timeout time.Duration
+}
+
+// Run runs all dependency health checks concurrently and returns the report.
+// The report status is StatusOK only if every check succeeded.
+func (c *Checker) Run(ctx context.Context) Report {
+	rep := Report{
+		Status: StatusOK,
+		Checks: make(map[string]CheckResult, len(c.Checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, chk := range c.Checks {
+		wg.Add(1)
+		go func(chk Check) {
+			defer wg.Done()
+
+			res := c.runCheck(ctx, chk)
+
+			mu.Lock()
+			defer mu.Unlock()
+			rep.Checks[chk.Name] = res
+			if res.Status != StatusOK {
+				rep.Status = StatusError
+			}
+		}(chk)
+	}
+	wg.Wait()
+
+	return rep
+}
+
+// runCheck runs a single dependency health check with the checker's timeout.
+func (c *Checker) runCheck(ctx context.Context, chk Check) CheckResult {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- chk.Func(ctx)
+	}()
+
+	// Don't wait on checks that ignore context cancellation.
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	res := CheckResult{
+		Status:     StatusOK,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		res.Status = StatusError
+		res.Error = err.Error()
+	}
+	return res
+}
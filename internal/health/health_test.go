@@ -0,0 +1,267 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/test"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestCheckerRun(t *testing.T) {
+	okFunc := func(ctx context.Context) error { return nil }
+	errFunc := func(ctx context.Context) error { return errors.New("boom") }
+	slowFunc := func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+
+	cases := map[string]struct {
+		checks         []Check
+		wantStatus     string
+		wantCheckState map[string]string
+		wantErrors     map[string]string
+	}{
+		"all checks pass": {
+			checks: []Check{
+				{Name: "a", Func: okFunc},
+				{Name: "b", Func: okFunc},
+			},
+			wantStatus: StatusOK,
+			wantCheckState: map[string]string{
+				"a": StatusOK,
+				"b": StatusOK,
+			},
+		},
+		"one check fails": {
+			checks: []Check{
+				{Name: "a", Func: okFunc},
+				{Name: "b", Func: errFunc},
+			},
+			wantStatus: StatusError,
+			wantCheckState: map[string]string{
+				"a": StatusOK,
+				"b": StatusError,
+			},
+			wantErrors: map[string]string{
+				"b": "boom",
+			},
+		},
+		"check times out": {
+			checks: []Check{
+				{Name: "slow", Func: slowFunc},
+			},
+			wantStatus: StatusError,
+			wantCheckState: map[string]string{
+				"slow": StatusError,
+			},
+			wantErrors: map[string]string{
+				"slow": context.DeadlineExceeded.Error(),
+			},
+		},
+		"no checks": {
+			wantStatus:     StatusOK,
+			wantCheckState: map[string]string{},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			chkr := &Checker{
+				Checks:  c.checks,
+				Timeout: 50 * time.Millisecond,
+			}
+			rep := chkr.Run(context.Background())
+
+			assert.Equal(c.wantStatus, rep.Status)
+			require.Len(rep.Checks, len(c.wantCheckState))
+			for n, s := range c.wantCheckState {
+				assert.Equal(s, rep.Checks[n].Status, n)
+				assert.Equal(c.wantErrors[n], rep.Checks[n].Error, n)
+			}
+		})
+	}
+}
+
+func TestReadyHandler(t *testing.T) {
+	cases := map[string]struct {
+		method   string
+		checkErr error
+		wantCode int
+	}{
+		"healthy": {
+			method:   "GET",
+			wantCode: http.StatusOK,
+		},
+		"unhealthy": {
+			method:   "GET",
+			checkErr: errors.New("boom"),
+			wantCode: http.StatusServiceUnavailable,
+		},
+		"bad method": {
+			method:   "POST",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			chkr := &Checker{
+				Checks: []Check{
+					{
+						Name: "dep",
+						Func: func(ctx context.Context) error { return c.checkErr },
+					},
+				},
+			}
+			var logBuf bytes.Buffer
+			h := ReadyHandler(chkr, hclog.New(&hclog.LoggerOptions{
+				Output: &logBuf,
+			}))
+
+			req := httptest.NewRequest(c.method, "/health/ready", nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			assert.Equal(c.wantCode, w.Code)
+			if c.wantCode == http.StatusMethodNotAllowed {
+				return
+			}
+
+			body := w.Body.String()
+			var rep Report
+			require.NoError(json.NewDecoder(w.Body).Decode(&rep))
+			require.Contains(rep.Checks, "dep")
+			if c.checkErr != nil {
+				assert.Equal(StatusError, rep.Status)
+				assert.Equal(StatusError, rep.Checks["dep"].Status)
+				// Check errors are logged but not included in the response.
+				assert.NotContains(body, c.checkErr.Error())
+				assert.Contains(logBuf.String(), c.checkErr.Error())
+			} else {
+				assert.Equal(StatusOK, rep.Status)
+			}
+		})
+	}
+}
+
+func TestIndexerHandler(t *testing.T) {
+	t.Run("Method not allowed", func(t *testing.T) {
+		assert := assert.New(t)
+
+		h := IndexerHandler(nil, time.Hour, hclog.NewNullLogger())
+		req := httptest.NewRequest("POST", "/health/indexer", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		assert.Equal(http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		// Nothing listens on port 1, so queries fail.
+		db, err := gorm.Open(postgres.Open(
+			"host=127.0.0.1 port=1 user=hermes dbname=hermes connect_timeout=1"),
+			&gorm.Config{DisableAutomaticPing: true})
+		require.NoError(err)
+
+		h := IndexerHandler(db, time.Hour, hclog.NewNullLogger())
+		req := httptest.NewRequest("GET", "/health/indexer", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		assert.Equal(http.StatusServiceUnavailable, w.Code)
+		var resp indexerResponse
+		require.NoError(json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(StatusError, resp.Status)
+		assert.Equal("error getting indexer metadata", resp.Error)
+		assert.Nil(resp.LastFullIndexAt)
+		assert.EqualValues(3600, resp.MaxLagSeconds)
+	})
+
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	cases := map[string]struct {
+		// lastFullIndexAgo is the duration since the last full index, or nil if
+		// the indexer has never completed a full index.
+		lastFullIndexAgo *time.Duration
+
+		wantCode   int
+		wantStatus string
+		wantError  string
+	}{
+		"never completed a full index": {
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: StatusError,
+			wantError:  "indexer has not completed a full index",
+		},
+		"within maximum lag": {
+			lastFullIndexAgo: durationPtr(10 * time.Minute),
+			wantCode:         http.StatusOK,
+			wantStatus:       StatusOK,
+		},
+		"lag exceeds maximum": {
+			lastFullIndexAgo: durationPtr(2 * time.Hour),
+			wantCode:         http.StatusServiceUnavailable,
+			wantStatus:       StatusError,
+			wantError:        "indexer lag exceeds maximum",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			db, _, err := test.CreateTestDatabase(t, dsn)
+			require.NoError(err)
+			require.NoError(db.AutoMigrate(&models.IndexerMetadata{}))
+			if c.lastFullIndexAgo != nil {
+				md := models.IndexerMetadata{
+					LastFullIndexAt: time.Now().Add(-*c.lastFullIndexAgo),
+				}
+				require.NoError(md.Upsert(db))
+			}
+
+			h := IndexerHandler(db, time.Hour, hclog.NewNullLogger())
+			req := httptest.NewRequest("GET", "/health/indexer", nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			assert.Equal(c.wantCode, w.Code)
+			var resp indexerResponse
+			require.NoError(json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(c.wantStatus, resp.Status)
+			assert.Equal(c.wantError, resp.Error)
+			if c.lastFullIndexAgo != nil {
+				require.NotNil(resp.LastFullIndexAt)
+				assert.InDelta(c.lastFullIndexAgo.Seconds(), resp.LagSeconds, 5)
+			} else {
+				assert.Nil(resp.LastFullIndexAt)
+			}
+		})
+	}
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
@@ -0,0 +1,50 @@
+package indexer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// purgeTrashedDrafts permanently deletes drafts that have been in the trash
+// longer than the drafts trash retention period.
+func purgeTrashedDrafts(idx Indexer) error {
+	db := idx.Database
+	log := idx.Logger
+
+	docs, err := models.GetTrashedDocuments(
+		db, "", time.Now().Add(-idx.DraftsTrashRetention))
+	if err != nil {
+		return fmt.Errorf("error getting trashed documents: %w", err)
+	}
+
+	for _, d := range docs {
+		// Delete document in Google Drive. The database record is kept on errors
+		// so the draft is purged on the next run.
+		if err := idx.GoogleWorkspaceService.DeleteFile(
+			d.GoogleFileID); err != nil {
+			log.Error("error deleting trashed draft in Google Drive",
+				"error", err,
+				"google_file_id", d.GoogleFileID,
+			)
+			continue
+		}
+
+		// Delete document in the database.
+		if err := d.Delete(db); err != nil {
+			log.Error("error deleting trashed draft in database",
+				"error", err,
+				"google_file_id", d.GoogleFileID,
+			)
+			continue
+		}
+
+		log.Info("purged draft from trash",
+			"google_file_id", d.GoogleFileID,
+			"trashed_at", d.TrashedAt,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package indexer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/test"
+	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestPurgeTrashedDrafts(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+	assert, require := assert.New(t), require.New(t)
+
+	db, _, err := test.CreateTestDatabase(t, dsn)
+	require.NoError(err)
+	require.NoError(db.Exec("CREATE EXTENSION IF NOT EXISTS citext;").Error)
+	require.NoError(db.AutoMigrate(models.ModelsToAutoMigrate()...))
+
+	dt := models.DocumentType{
+		Name:     "RFC",
+		LongName: "Request for Comments",
+	}
+	require.NoError(dt.FirstOrCreate(db))
+	p := models.Product{
+		Name:         "Terraform",
+		Abbreviation: "TF",
+	}
+	require.NoError(p.FirstOrCreate(db))
+
+	now := time.Now()
+	for _, d := range []struct {
+		id        string
+		trashedAt time.Time
+	}{
+		{"expired", now.Add(-31 * 24 * time.Hour)},
+		{"expired-drive-error", now.Add(-31 * 24 * time.Hour)},
+		{"restorable", now.Add(-time.Hour)},
+	} {
+		doc := models.Document{
+			GoogleFileID: d.id,
+			DocumentType: models.DocumentType{Name: "RFC"},
+			Owner:        &models.User{EmailAddress: "owner@example.com"},
+			Product:      models.Product{Name: "Terraform"},
+			Status:       models.WIPDocumentStatus,
+		}
+		require.NoError(doc.Create(db))
+		require.NoError(doc.Trash(db, d.trashedAt))
+	}
+
+	// Fake the Google Drive API so deleting one of the expired drafts fails.
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodDelete {
+				http.NotFound(w, r)
+				return
+			}
+			fileID := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			if fileID == "expired-drive-error" {
+				http.Error(w, `{"error":{"code":500,"message":"backend error"}}`,
+					http.StatusInternalServerError)
+				return
+			}
+			deleted = append(deleted, fileID)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+	defer ts.Close()
+	driveSvc, err := drive.NewService(context.Background(),
+		option.WithEndpoint(ts.URL+"/"),
+		option.WithHTTPClient(ts.Client()),
+	)
+	require.NoError(err)
+
+	idx := Indexer{
+		Database:               db,
+		DraftsTrashRetention:   30 * 24 * time.Hour,
+		GoogleWorkspaceService: &gw.Service{Drive: driveSvc},
+		Logger:                 hclog.NewNullLogger(),
+	}
+	require.NoError(purgeTrashedDrafts(idx))
+
+	assert.Equal([]string{"expired"}, deleted)
+
+	// The draft that was deleted in Google Drive is deleted in the database.
+	got := models.Document{GoogleFileID: "expired"}
+	assert.Error(got.Get(db))
+
+	// The draft that failed to be deleted in Google Drive is kept in the
+	// database so it is purged on the next run.
+	got = models.Document{GoogleFileID: "expired-drive-error"}
+	require.NoError(got.Get(db))
+	assert.NotNil(got.TrashedAt)
+
+	// The draft that is still in the retention period is kept.
+	got = models.Document{GoogleFileID: "restorable"}
+	require.NoError(got.Get(db))
+	assert.NotNil(got.TrashedAt)
+}
@@ -0,0 +1,80 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/embeddings"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+const (
+	// embeddingsTimeout is the timeout for computing the embeddings of a
+	// document.
+	embeddingsTimeout = 5 * time.Minute
+)
+
+// saveDocumentEmbeddings computes and saves embeddings of the chunks of a
+// document's sections. Embeddings are only recomputed if the chunks changed,
+// and computing them is canceled when ctx is done.
+func (idx *Indexer) saveDocumentEmbeddings(
+	ctx context.Context, documentID uint, sections []docSection) error {
+	chunks := sectionChunks(sections)
+	model := idx.Embeddings.Model()
+
+	// Skip computing embeddings if the document's chunks haven't changed.
+	var existing models.DocumentEmbeddings
+	if err := existing.Find(idx.Database, documentID, model); err != nil {
+		return fmt.Errorf("error getting existing embeddings: %w", err)
+	}
+	if len(existing) == len(chunks) {
+		unchanged := true
+		for i, e := range existing {
+			if e.Content != chunks[i] {
+				unchanged = false
+				break
+			}
+		}
+		if unchanged {
+			return nil
+		}
+	}
+
+	var embs [][]float32
+	if len(chunks) > 0 {
+		ctx, cancel := context.WithTimeout(ctx, embeddingsTimeout)
+		defer cancel()
+		var err error
+		embs, err = idx.Embeddings.Embed(ctx, chunks)
+		if err != nil {
+			return fmt.Errorf("error computing embeddings: %w", err)
+		}
+	}
+
+	if err := models.ReplaceDocumentEmbeddings(
+		idx.Database, documentID, model, chunks, embs); err != nil {
+		return fmt.Errorf("error saving embeddings: %w", err)
+	}
+
+	return nil
+}
+
+// sectionChunks returns the chunks of doc sections to embed. Chunks don't span
+// sections, and include the section heading for context.
+func sectionChunks(sections []docSection) []string {
+	var chunks []string
+	for _, s := range sections {
+		for _, c := range embeddings.Chunk(s.Content) {
+			if s.Heading != "" {
+				c = s.Heading + "\n" + c
+			}
+			chunks = append(chunks, c)
+		}
+		// Embed headings of empty sections on their own.
+		if s.Content == "" && s.Heading != "" {
+			chunks = append(chunks, s.Heading)
+		}
+	}
+	return chunks
+}
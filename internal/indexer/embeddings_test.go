@@ -0,0 +1,22 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSectionChunks(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{
+		"Intro text",
+		"Background\nSome background",
+		"Empty",
+	}, sectionChunks([]docSection{
+		{Content: "Intro text"},
+		{Heading: "Background", Content: "Some  background"},
+		{Heading: "Empty"},
+	}))
+	assert.Nil(sectionChunks(nil))
+}
@@ -1,21 +1,23 @@
 package indexer
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"os"
+	"sort"
 	"sync"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/embeddings"
 	"github.com/hashicorp-forge/hermes/pkg/algolia"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
 	"github.com/hashicorp-forge/hermes/pkg/links"
 	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp/go-hclog"
+	"google.golang.org/api/drive/v3"
 	"gorm.io/gorm"
 )
 
@@ -23,14 +25,24 @@ const (
 	// loggerName is the name of the logger.
 	loggerName = "indexer"
 
-	// maxContentSize is the maximum size of a document's content in bytes. If the
-	// content is larger than this, it will be trimmed to this length.
+	// maxContentSize is the maximum size of a document's content in bytes in the
+	// docs index. If the content is larger than this, it will be trimmed to this
+	// length (the full content is indexed in the doc sections index).
 	// Note: Algolia currently has a hard limit of 100000 bytes total per record.
 	maxContentSize = 85000
+
+	// documentGracePeriod is how long database queries and API calls for a
+	// document that is being indexed (or having its header refreshed) when the
+	// indexer is stopped can take before they are canceled.
+	documentGracePeriod = 2 * time.Minute
 )
 
 // Indexer contains the indexer configuration.
 type Indexer struct {
+	// Admins are the email addresses of admins, who are sent reports of stale
+	// drafts.
+	Admins []string
+
 	// AlgoliaClient is the Algolia client.
 	AlgoliaClient *algolia.Client
 
@@ -51,6 +63,19 @@ type Indexer struct {
 	// documents to index.
 	DraftsFolderID string
 
+	// DraftsTrashRetention is the duration that deleted drafts are kept in the
+	// trash before they are purged. Drafts in the trash are only purged if it is
+	// set.
+	DraftsTrashRetention time.Duration
+
+	// EmailFromAddress is the email address to send emails from. Saved search
+	// alerts are only evaluated and sent if it is set.
+	EmailFromAddress string
+
+	// Embeddings computes embeddings of published documents' content for
+	// semantic search, if set.
+	Embeddings embeddings.Provider
+
 	// GoogleWorkspaceService is the Google Workspace service.
 	GoogleWorkspaceService *gw.Service
 
@@ -61,6 +86,22 @@ type Indexer struct {
 	// simultaneously indexed.
 	MaxParallelDocuments int
 
+	// Retention configures retention policies, which are applied if it is set.
+	Retention *config.Retention
+
+	// RetentionNoticePeriod is the duration before a retention policy is applied
+	// that document owners are notified.
+	RetentionNoticePeriod time.Duration
+
+	// StaleDraftsNudgeInterval is the duration between emails to the owner of a
+	// stale draft, and between stale draft reports to admins.
+	StaleDraftsNudgeInterval time.Duration
+
+	// StaleDraftsThreshold is the duration since a draft was last modified after
+	// which it is stale. Owners of stale drafts are only nudged if it is set and
+	// email is enabled.
+	StaleDraftsThreshold time.Duration
+
 	// UpdateDocumentHeaders updates published document headers, if true.
 	UpdateDocumentHeaders bool
 
@@ -115,6 +156,13 @@ func (idx *Indexer) validate() error {
 	)
 }
 
+// WithAdmins sets the email addresses of admins.
+func WithAdmins(a []string) IndexerOption {
+	return func(i *Indexer) {
+		i.Admins = a
+	}
+}
+
 // WithAlgoliaClient sets the Algolia client.
 func WithAlgoliaClient(a *algolia.Client) IndexerOption {
 	return func(i *Indexer) {
@@ -157,6 +205,28 @@ func WithDraftsFolderID(d string) IndexerOption {
 	}
 }
 
+// WithDraftsTrashRetention sets the duration that deleted drafts are kept in
+// the trash before they are purged.
+func WithDraftsTrashRetention(d time.Duration) IndexerOption {
+	return func(i *Indexer) {
+		i.DraftsTrashRetention = d
+	}
+}
+
+// WithEmailFromAddress sets the email address to send emails from.
+func WithEmailFromAddress(e string) IndexerOption {
+	return func(i *Indexer) {
+		i.EmailFromAddress = e
+	}
+}
+
+// WithEmbeddings sets the embeddings provider.
+func WithEmbeddings(p embeddings.Provider) IndexerOption {
+	return func(i *Indexer) {
+		i.Embeddings = p
+	}
+}
+
 // WithGoogleWorkspaceService sets the Google Workspace service.
 func WithGoogleWorkspaceService(g *gw.Service) IndexerOption {
 	return func(i *Indexer) {
@@ -179,6 +249,37 @@ func WithMaxParallelDocuments(m int) IndexerOption {
 	}
 }
 
+// WithRetention sets the retention policies.
+func WithRetention(r *config.Retention) IndexerOption {
+	return func(i *Indexer) {
+		i.Retention = r
+	}
+}
+
+// WithRetentionNoticePeriod sets the duration before a retention policy is
+// applied that document owners are notified.
+func WithRetentionNoticePeriod(d time.Duration) IndexerOption {
+	return func(i *Indexer) {
+		i.RetentionNoticePeriod = d
+	}
+}
+
+// WithStaleDraftsNudgeInterval sets the duration between emails to the owner
+// of a stale draft.
+func WithStaleDraftsNudgeInterval(d time.Duration) IndexerOption {
+	return func(i *Indexer) {
+		i.StaleDraftsNudgeInterval = d
+	}
+}
+
+// WithStaleDraftsThreshold sets the duration since a draft was last modified
+// after which it is stale.
+func WithStaleDraftsThreshold(d time.Duration) IndexerOption {
+	return func(i *Indexer) {
+		i.StaleDraftsThreshold = d
+	}
+}
+
 // WithUpdateDocumentHeaders sets the boolean to update draft document headers.
 func WithUpdateDocumentHeaders(u bool) IndexerOption {
 	return func(i *Indexer) {
@@ -201,10 +302,10 @@ func WithUseDatabaseForDocumentData(u bool) IndexerOption {
 	}
 }
 
-// Run runs the indexer.
-// TODO: improve error handling.
-func (idx *Indexer) Run() error {
-	algo := idx.AlgoliaClient
+// Run runs the indexer until ctx is done or an error occurs. When ctx is done,
+// the indexer finishes the document it is currently indexing and saves its
+// progress before returning.
+func (idx *Indexer) Run(ctx context.Context) error {
 	db := idx.Database
 	gwSvc := idx.GoogleWorkspaceService
 	log := idx.Logger
@@ -220,10 +321,7 @@ func (idx *Indexer) Run() error {
 				// full index timestamp to the Unix epoch.
 				md.LastFullIndexAt = time.Unix(0, 0).UTC()
 			} else {
-				log.Error("error getting indexer metadata",
-					"error", err,
-				)
-				os.Exit(1)
+				return fmt.Errorf("error getting indexer metadata: %w", err)
 			}
 		}
 
@@ -243,10 +341,8 @@ func (idx *Indexer) Run() error {
 			}
 			if err := fd.Get(db); err != nil && !errors.Is(
 				err, gorm.ErrRecordNotFound) {
-				log.Error("error getting drafts headers folder indexer data",
-					"error", err,
-				)
-				os.Exit(1)
+				return fmt.Errorf(
+					"error getting drafts headers folder indexer data: %w", err)
 			}
 
 			// If the last indexed timestamp doesn't exist, set it to the Unix epoch.
@@ -262,16 +358,18 @@ func (idx *Indexer) Run() error {
 			}
 
 			if err := refreshDocumentHeaders(
+				ctx,
 				*idx,
 				idx.DraftsFolderID,
 				draftsFolderType,
 				safeLastIndexedAt,
 				currentTime,
 			); err != nil {
-				log.Error("error refreshing draft document headers",
-					"error", err,
-				)
-				os.Exit(1)
+				if errors.Is(err, context.Canceled) {
+					log.Info("indexer stopped")
+					return nil
+				}
+				return fmt.Errorf("error refreshing draft document headers: %w", err)
 			}
 
 			// Save last indexed time for the drafts folder (headers).
@@ -303,10 +401,8 @@ func (idx *Indexer) Run() error {
 			}
 			if err := fd.Get(db); err != nil && !errors.Is(
 				err, gorm.ErrRecordNotFound) {
-				log.Error("error getting documents headers folder indexer data",
-					"error", err,
-				)
-				os.Exit(1)
+				return fmt.Errorf(
+					"error getting documents headers folder indexer data: %w", err)
 			}
 
 			// If the last indexed timestamp doesn't exist, set it to the Unix epoch.
@@ -322,16 +418,18 @@ func (idx *Indexer) Run() error {
 			}
 
 			if err := refreshDocumentHeaders(
+				ctx,
 				*idx,
 				idx.DocumentsFolderID,
 				documentsFolderType,
 				safeLastIndexedAt,
 				currentTime,
 			); err != nil {
-				log.Error("error refreshing published document headers",
-					"error", err,
-				)
-				os.Exit(1)
+				if errors.Is(err, context.Canceled) {
+					log.Info("indexer stopped")
+					return nil
+				}
+				return fmt.Errorf("error refreshing published document headers: %w", err)
 			}
 
 			// Save last indexed time for the documents folder (headers).
@@ -353,10 +451,7 @@ func (idx *Indexer) Run() error {
 		}
 		if err := docsFolderData.Get(db); err != nil && !errors.Is(
 			err, gorm.ErrRecordNotFound) {
-			log.Error("error getting documents folder indexer data",
-				"error", err,
-			)
-			os.Exit(1)
+			return fmt.Errorf("error getting documents folder indexer data: %w", err)
 		}
 
 		// If the last indexed timestamp doesn't exist, set it to the Unix epoch.
@@ -378,13 +473,7 @@ func (idx *Indexer) Run() error {
 		docFiles, err := gwSvc.GetUpdatedDocsBetween(
 			idx.DocumentsFolderID, lastIndexedAtStr, currentTimeStr)
 		if err != nil {
-			log.Error("error getting updated document files",
-				"error", err,
-				"folder_id", idx.DocumentsFolderID,
-				"after_time", lastIndexedAtStr,
-				"before_time", currentTimeStr,
-			)
-			os.Exit(1)
+			return fmt.Errorf("error getting updated document files: %w", err)
 		}
 		if len(docFiles) == 0 {
 			log.Info("no new document updates since the last indexed time",
@@ -393,14 +482,26 @@ func (idx *Indexer) Run() error {
 			)
 		}
 
-		for _, file := range docFiles {
-			logError := func(errMsg string, err error) {
-				log.Error(errMsg,
-					"error", err,
-					"google_file_id", file.Id,
+		// Index documents in order of modified time so the folder's last indexed
+		// time is accurate if we stop before indexing all of them.
+		sort.SliceStable(docFiles, func(i, j int) bool {
+			return docFiles[i].ModifiedTime < docFiles[j].ModifiedTime
+		})
+
+		// Documents are indexed with a context that outlives ctx by a grace
+		// period, so the document being indexed when ctx is done can finish.
+		docCtx, cancelDocs := gracefulContext(ctx, documentGracePeriod)
+		var indexErr error
+		for i, file := range docFiles {
+			// Stop indexing documents if the indexer is shutting down.
+			if ctx.Err() != nil {
+				log.Info("stopping indexing documents folder",
 					"folder_id", idx.DocumentsFolderID,
-					"last_indexed_at", lastIndexedAtStr,
+					"remaining_docs", len(docFiles)-i,
 				)
+				docsFolderData.LastIndexedAt = resumeIndexingAt(
+					docsFolderData.LastIndexedAt, docFiles[i])
+				break
 			}
 
 			log.Info("indexing document",
@@ -409,173 +510,312 @@ func (idx *Indexer) Run() error {
 				"last_indexed_at", lastIndexedAtStr,
 			)
 
-			// Get document from database.
-			dbDoc := models.Document{
-				GoogleFileID: file.Id,
+			modifiedTime, err := idx.indexDocument(docCtx, file)
+			if err != nil {
+				indexErr = fmt.Errorf(
+					"error indexing document (%s): %w", file.Id, err)
+				docsFolderData.LastIndexedAt = resumeIndexingAt(
+					docsFolderData.LastIndexedAt, file)
+				break
 			}
-			if err := dbDoc.Get(db); err != nil {
-				logError("error getting document from the database", err)
-				os.Exit(1)
+
+			// Update last indexed time for folder if document modified time is later.
+			if modifiedTime.After(docsFolderData.LastIndexedAt) {
+				docsFolderData.LastIndexedAt = modifiedTime
 			}
 
-			// Get reviews for the document from the database.
-			var reviews models.DocumentReviews
-			if err := reviews.Find(idx.Database, models.DocumentReview{
-				Document: models.Document{
-					GoogleFileID: file.Id,
-				},
-			}); err != nil {
-				log.Error("error getting reviews for document",
+			log.Info("indexed document",
+				"google_file_id", file.Id,
+				"folder_id", idx.DocumentsFolderID,
+			)
+		}
+		cancelDocs()
+
+		// Save last indexed time for the documents folder.
+		if err := docsFolderData.Upsert(db); err != nil {
+			log.Error("error upserting last indexed time for the folder",
+				"error", err,
+				"folder_id", idx.DocumentsFolderID,
+				"last_indexed_at", docsFolderData.LastIndexedAt,
+			)
+		}
+
+		if indexErr != nil {
+			return indexErr
+		}
+		if ctx.Err() != nil {
+			log.Info("indexer stopped")
+			return nil
+		}
+		// Update view counts for documents viewed since the last full index.
+		if err := updateDocumentViewCounts(*idx, md.LastFullIndexAt); err != nil {
+			log.Error("error updating document view counts",
+				"error", err,
+			)
+		}
+
+		// Purge drafts that have been in the trash longer than the retention
+		// period.
+		if idx.DraftsTrashRetention > 0 {
+			if err := purgeTrashedDrafts(*idx); err != nil {
+				log.Error("error purging drafts in the trash",
 					"error", err,
-					"google_file_id", file.Id,
 				)
-				os.Exit(1)
 			}
+		}
 
-			// Get group reviews for the document.
-			var groupReviews models.DocumentGroupReviews
-			if err := groupReviews.Find(idx.Database, models.DocumentGroupReview{
-				Document: models.Document{
-					GoogleFileID: file.Id,
-				},
-			}); err != nil {
-				log.Error("error getting group reviews for document",
+		// Apply retention policies.
+		if idx.Retention != nil {
+			if err := applyRetentionPolicies(*idx); err != nil {
+				log.Error("error applying retention policies",
 					"error", err,
-					"google_file_id", file.Id,
 				)
-				os.Exit(1)
 			}
+		}
 
-			// Parse document modified time.
-			modifiedTime, err := time.Parse(time.RFC3339Nano, file.ModifiedTime)
-			if err != nil {
-				logError("error parsing document modified time", err)
-				os.Exit(1)
+		// Nudge the owners of stale drafts.
+		if idx.StaleDraftsThreshold > 0 && idx.EmailFromAddress != "" {
+			if err := nudgeStaleDrafts(*idx, &md); err != nil {
+				log.Error("error nudging owners of stale drafts",
+					"error", err,
+				)
 			}
+		}
 
-			// Set new modified time for document record.
-			dbDoc.DocumentModifiedAt = modifiedTime
+		// Update the last full index time.
+		md.LastFullIndexAt = runStartedAt.UTC()
+		if err := md.Upsert(db); err != nil {
+			return fmt.Errorf(
+				"error upserting metadata with last full index time: %w", err)
+		}
 
-			// Update document in database.
-			if err := dbDoc.Upsert(db); err != nil {
-				logError("error upserting document", err)
-				os.Exit(1)
-			}
+		log.Info("sleeping for a minute before the next indexing run...")
+		// TODO: make sleep time configurable.
+		select {
+		case <-ctx.Done():
+			log.Info("indexer stopped")
+			return nil
+		case <-time.After(1 * time.Minute):
+		}
+	}
+}
 
-			var doc *document.Document
-			if idx.UseDatabaseForDocumentData {
-				// Convert database record to a document.
-				doc, err = document.NewFromDatabaseModel(dbDoc, reviews, groupReviews)
-				if err != nil {
-					log.Error("error converting database record to document",
-						"error", err,
-						"google_file_id", file.Id,
-					)
-					os.Exit(1)
-				}
-			} else {
-				// Get document object from Algolia.
-				var algoObj map[string]any
-				if err = algo.Docs.GetObject(file.Id, &algoObj); err != nil {
-					logError("error retrieving document object from Algolia", err)
-					os.Exit(1)
-				}
+// indexDocument indexes a published document and returns its modified time.
+// Database queries and API calls are canceled when ctx is done.
+func (idx *Indexer) indexDocument(
+	ctx context.Context, file *drive.File) (time.Time, error) {
+	idx = idx.withContext(ctx)
+	db := idx.Database
 
-				// Convert Algolia object to a document.
-				doc, err = document.NewFromAlgoliaObject(algoObj, idx.DocumentTypes)
-				if err != nil {
-					logError("error converting Algolia object to document", err)
-					os.Exit(1)
-				}
-			}
+	// Get document from database.
+	dbDoc := models.Document{
+		GoogleFileID: file.Id,
+	}
+	if err := dbDoc.Get(db); err != nil {
+		return time.Time{}, fmt.Errorf(
+			"error getting document from the database: %w", err)
+	}
 
-			// Get document content.
-			exp, err := gwSvc.Drive.Files.Export(file.Id, "text/plain").Download()
-			if err != nil {
-				logError("error exporting document", err)
-				os.Exit(1)
-			}
-			content, err := io.ReadAll(exp.Body)
-			if err != nil {
-				logError("error reading exported document", err)
-				os.Exit(1)
-			}
-			// Trim doc content if it is larger than the maximum size.
-			if len(content) > maxContentSize {
-				content = content[:maxContentSize]
-			}
+	// Get reviews for the document from the database.
+	var reviews models.DocumentReviews
+	if err := reviews.Find(db, models.DocumentReview{
+		Document: models.Document{
+			GoogleFileID: file.Id,
+		},
+	}); err != nil {
+		return time.Time{}, fmt.Errorf(
+			"error getting reviews for document: %w", err)
+	}
 
-			// Update document object with content and latest modified time.
-			doc.Content = (string(content))
-			doc.ModifiedTime = modifiedTime.Unix()
+	// Get group reviews for the document.
+	var groupReviews models.DocumentGroupReviews
+	if err := groupReviews.Find(db, models.DocumentGroupReview{
+		Document: models.Document{
+			GoogleFileID: file.Id,
+		},
+	}); err != nil {
+		return time.Time{}, fmt.Errorf(
+			"error getting group reviews for document: %w", err)
+	}
 
-			// Save the document in Algolia.
-			if err := saveDocInAlgolia(*doc, idx.AlgoliaClient); err != nil {
-				return fmt.Errorf("error saving document in Algolia: %w", err)
-			}
+	// Parse document modified time.
+	modifiedTime, err := time.Parse(time.RFC3339Nano, file.ModifiedTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"error parsing document modified time: %w", err)
+	}
 
-			// Update last indexed time for folder if document modified time is later.
-			if modifiedTime.After(docsFolderData.LastIndexedAt) {
-				docsFolderData.LastIndexedAt = modifiedTime
-			}
+	// Set new modified time for document record.
+	dbDoc.DocumentModifiedAt = modifiedTime
 
-			log.Info("indexed document",
+	// Update document in database.
+	if err := dbDoc.Upsert(db); err != nil {
+		return time.Time{}, fmt.Errorf("error upserting document: %w", err)
+	}
+
+	var doc *document.Document
+	if idx.UseDatabaseForDocumentData {
+		// Convert database record to a document.
+		doc, err = document.NewFromDatabaseModel(dbDoc, reviews, groupReviews)
+		if err != nil {
+			return time.Time{}, fmt.Errorf(
+				"error converting database record to document: %w", err)
+		}
+	} else {
+		// Get document object from Algolia.
+		var algoObj map[string]any
+		if err = idx.AlgoliaClient.Docs.GetObject(
+			file.Id, &algoObj, ctx); err != nil {
+			return time.Time{}, fmt.Errorf(
+				"error retrieving document object from Algolia: %w", err)
+		}
+
+		// Convert Algolia object to a document.
+		doc, err = document.NewFromAlgoliaObject(algoObj, idx.DocumentTypes)
+		if err != nil {
+			return time.Time{}, fmt.Errorf(
+				"error converting Algolia object to document: %w", err)
+		}
+	}
+
+	// Get document content, split into heading-scoped sections.
+	gDoc, err := idx.GoogleWorkspaceService.GetDoc(file.Id)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error getting document content: %w", err)
+	}
+	sections := docSections(gDoc, maxSectionContentSize)
+
+	// Update document object with content and latest modified time.
+	doc.Content = docSectionsContent(sections, maxContentSize)
+	doc.ModifiedTime = modifiedTime.Unix()
+
+	// Save the document in Algolia.
+	if err := idx.saveDocInAlgolia(ctx, *doc, dbDoc.ID); err != nil {
+		return time.Time{}, fmt.Errorf(
+			"error saving document in Algolia: %w", err)
+	}
+
+	// Save the document's short link.
+	if err := links.SaveDocumentShortLink(
+		db, file.Id, doc.DocType, doc.DocNumber); err != nil {
+		return time.Time{}, err
+	}
+
+	// Save the document's sections in Algolia, if configured.
+	if idx.AlgoliaClient.DocSections != nil {
+		if err := saveDocSectionsInAlgolia(ctx,
+			*doc, sections, idx.AlgoliaClient.DocSections); err != nil {
+			return time.Time{}, fmt.Errorf(
+				"error saving document sections in Algolia: %w", err)
+		}
+	}
+
+	// Save embeddings of the document's content for semantic search, if
+	// enabled.
+	if idx.Embeddings != nil {
+		if err := idx.saveDocumentEmbeddings(
+			ctx, dbDoc.ID, sections); err != nil {
+			// Don't fail indexing the document if computing embeddings fails.
+			idx.Logger.Error("error saving document embeddings",
+				"error", err,
 				"google_file_id", file.Id,
-				"folder_id", idx.DocumentsFolderID,
 			)
 		}
+	}
 
-		// Save last indexed time for the documents folder.
-		if err := docsFolderData.Upsert(db); err != nil {
-			log.Error("error upserting last indexed time for the folder",
-				"folder_id", idx.DocumentsFolderID,
-				"last_indexed_at", docsFolderData.LastIndexedAt,
+	// Alert users whose saved searches the document started matching, if
+	// emails are enabled.
+	if idx.EmailFromAddress != "" {
+		if err := idx.alertSavedSearches(dbDoc.ID, *doc); err != nil {
+			// Don't fail indexing the document if alerting fails.
+			idx.Logger.Error("error alerting saved searches",
+				"error", err,
+				"google_file_id", file.Id,
 			)
 		}
+	}
 
-		// Update the last full index time.
-		md.LastFullIndexAt = runStartedAt.UTC()
-		if err := md.Upsert(db); err != nil {
-			log.Error("error upserting metadata with last full index time: %w", err)
-			os.Exit(1)
+	return modifiedTime, nil
+}
+
+// withContext returns a shallow copy of the indexer with its database
+// connection and Google Workspace service bound to ctx, so queries and API calls
+// are canceled when ctx is done.
+func (idx *Indexer) withContext(ctx context.Context) *Indexer {
+	idx2 := *idx
+	if idx2.Database != nil {
+		idx2.Database = idx2.Database.WithContext(ctx)
+	}
+	if idx2.GoogleWorkspaceService != nil {
+		idx2.GoogleWorkspaceService = idx2.GoogleWorkspaceService.WithContext(ctx)
+	}
+	return &idx2
+}
+
+// gracefulContext returns a context that is done gracePeriod after ctx is
+// done, or when the returned cancel function is called. It lets work in
+// progress when ctx is done finish, while still canceling it if it takes too
+// long.
+func gracefulContext(ctx context.Context, gracePeriod time.Duration) (
+	context.Context, context.CancelFunc) {
+	gctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-gctx.Done():
+			return
 		}
+		t := time.NewTimer(gracePeriod)
+		defer t.Stop()
+		select {
+		case <-t.C:
+			cancel()
+		case <-gctx.Done():
+		}
+	}()
+	return gctx, cancel
+}
 
-		log.Info("sleeping for a minute before the next indexing run...")
-		// TODO: make sleep time configurable.
-		time.Sleep(1 * time.Minute)
+// resumeIndexingAt returns the last indexed time to save for a folder when
+// indexing stops before file (and any files modified after it) are indexed,
+// so they are indexed in the next run.
+func resumeIndexingAt(lastIndexedAt time.Time, file *drive.File) time.Time {
+	modifiedTime, err := time.Parse(time.RFC3339Nano, file.ModifiedTime)
+	if err != nil {
+		return lastIndexedAt
 	}
+
+	// Files are indexed if their modified time is after the last indexed time.
+	if !modifiedTime.After(lastIndexedAt) {
+		return modifiedTime.Add(-time.Nanosecond)
+	}
+	return lastIndexedAt
 }
 
-// saveDoc saves a document struct and its redirect details in Algolia.
-func saveDocInAlgolia(
+// saveDocInAlgolia saves a document struct, with database ID documentID, in
+// Algolia. The request is canceled when ctx is done.
+func (idx *Indexer) saveDocInAlgolia(
+	ctx context.Context,
 	doc document.Document,
-	algo *algolia.Client,
+	documentID uint,
 ) error {
 	// Convert document to Algolia object.
-	docObj, err := doc.ToAlgoliaObject(true)
+	docObj, err := doc.ToDocsIndexObject(
+		idx.Database.WithContext(ctx), documentID, idx.Logger)
 	if err != nil {
 		return fmt.Errorf(
 			"error converting document to Algolia object: %w", err)
 	}
 
 	// Save document object.
-	res, err := algo.Docs.SaveObject(docObj)
+	res, err := idx.AlgoliaClient.Docs.SaveObject(docObj, ctx)
 	if err != nil {
 		return fmt.Errorf("error saving document: %w", err)
 	}
-	err = res.Wait()
+	err = res.Wait(ctx)
 	if err != nil {
 		return fmt.Errorf("error saving document: %w", err)
 	}
 
-	// Save document redirect details.
-	if doc.DocNumber != "" {
-		err = links.SaveDocumentRedirectDetails(
-			algo, doc.ObjectID, doc.DocType, doc.DocNumber)
-		if err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
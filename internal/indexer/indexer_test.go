@@ -0,0 +1,72 @@
+package indexer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/drive/v3"
+)
+
+func TestResumeIndexingAt(t *testing.T) {
+	lastIndexedAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		modifiedTime string
+		want         time.Time
+	}{
+		"file modified after last indexed time": {
+			modifiedTime: "2023-01-01T12:00:01.000Z",
+			want:         lastIndexedAt,
+		},
+		"file modified at last indexed time": {
+			modifiedTime: "2023-01-01T12:00:00.000Z",
+			want:         lastIndexedAt.Add(-time.Nanosecond),
+		},
+		"file modified before last indexed time": {
+			modifiedTime: "2023-01-01T11:00:00.000Z",
+			want: time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC).
+				Add(-time.Nanosecond),
+		},
+		"bad modified time": {
+			modifiedTime: "bad",
+			want:         lastIndexedAt,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := resumeIndexingAt(lastIndexedAt, &drive.File{
+				ModifiedTime: c.modifiedTime,
+			})
+			assert.True(t, c.want.Equal(got), "want %v, got %v", c.want, got)
+		})
+	}
+}
+
+func TestGracefulContext(t *testing.T) {
+	t.Run("Done after the grace period", func(t *testing.T) {
+		assert := assert.New(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		gctx, gcancel := gracefulContext(ctx, 50*time.Millisecond)
+		defer gcancel()
+
+		cancel()
+		assert.NoError(gctx.Err())
+		select {
+		case <-gctx.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("context wasn't done after the grace period")
+		}
+		assert.ErrorIs(gctx.Err(), context.Canceled)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		assert := assert.New(t)
+		gctx, gcancel := gracefulContext(context.Background(), time.Hour)
+		assert.NoError(gctx.Err())
+		gcancel()
+		assert.ErrorIs(gctx.Err(), context.Canceled)
+	})
+}
@@ -1,8 +1,8 @@
 package indexer
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"sync"
 	"time"
 
@@ -25,8 +25,11 @@ const (
 
 // refreshDocumentHeaders updates the header of any documents in a specified
 // folder that been modified since the last indexer run but inactive in the last
-// 30 minutes (to not disrupt users' editing).
+// 30 minutes (to not disrupt users' editing). Headers that are being refreshed
+// when ctx is done are finished, but no more are started and ctx's error is
+// returned.
 func refreshDocumentHeaders(
+	ctx context.Context,
 	idx Indexer,
 	folderID string,
 	ft folderType,
@@ -119,21 +122,36 @@ func refreshDocumentHeaders(
 	}
 	wg.Add(parallel)
 
-	// Refresh document headers in parallel.
+	// Refresh document headers in parallel. The first error stops the workers
+	// from refreshing any more documents.
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Headers are refreshed with a context that outlives ctx by a grace period,
+	// so headers being refreshed when ctx is done can finish.
+	docCtx, cancelDocs := gracefulContext(ctx, documentGracePeriod)
+	defer cancelDocs()
+	var errOnce sync.Once
+	var refreshErr error
 	for i := 0; i < parallel; i++ {
 		go func() {
-			for {
-				file, ok := <-ch
-				if !ok {
-					wg.Done()
-					return
+			defer wg.Done()
+			for file := range ch {
+				if workerCtx.Err() != nil {
+					continue
 				}
-				refreshDocumentHeader(
+				if err := refreshDocumentHeader(
+					docCtx,
 					idx,
 					file,
 					ft,
 					LastIndexedAt,
-				)
+				); err != nil {
+					errOnce.Do(func() {
+						refreshErr = fmt.Errorf(
+							"error refreshing header for document (%s): %w", file.Id, err)
+						cancel()
+					})
+				}
 			}
 		}()
 	}
@@ -144,17 +162,22 @@ func refreshDocumentHeaders(
 
 	wg.Wait()
 
-	return nil
+	if refreshErr != nil {
+		return refreshErr
+	}
+	return ctx.Err()
 }
 
 // refreshDocumentHeader refreshes the header for a published document.
-// TODO: improve error handling.
+// Database queries and API calls are canceled when ctx is done.
 func refreshDocumentHeader(
+	ctx context.Context,
 	idx Indexer,
 	file *drive.File,
 	ft folderType,
 	lastIndexedAt *safeTime,
-) {
+) error {
+	idx = *idx.withContext(ctx)
 	algo := idx.AlgoliaClient
 	log := idx.Logger
 
@@ -162,15 +185,11 @@ func refreshDocumentHeader(
 	locked, err := hcd.IsLocked(
 		file.Id, idx.Database, idx.GoogleWorkspaceService, log)
 	if err != nil {
-		log.Error("error checking document locked status",
-			"error", err,
-			"google_file_id", file.Id,
-		)
-		os.Exit(1)
+		return fmt.Errorf("error checking document locked status: %w", err)
 	}
 	// Don't continue if document is locked.
 	if locked {
-		return
+		return nil
 	}
 
 	var doc *document.Document
@@ -180,11 +199,7 @@ func refreshDocumentHeader(
 			GoogleFileID: file.Id,
 		}
 		if err := model.Get(idx.Database); err != nil {
-			log.Error("error getting document from database",
-				"error", err,
-				"google_file_id", file.Id,
-			)
-			os.Exit(1)
+			return fmt.Errorf("error getting document from database: %w", err)
 		}
 
 		// Get reviews for the document from the database.
@@ -194,11 +209,7 @@ func refreshDocumentHeader(
 				GoogleFileID: file.Id,
 			},
 		}); err != nil {
-			log.Error("error getting reviews for document",
-				"error", err,
-				"google_file_id", file.Id,
-			)
-			os.Exit(1)
+			return fmt.Errorf("error getting reviews for document: %w", err)
 		}
 
 		// Get group reviews for the document.
@@ -208,59 +219,41 @@ func refreshDocumentHeader(
 				GoogleFileID: file.Id,
 			},
 		}); err != nil {
-			log.Error("error getting group reviews for document",
-				"error", err,
-				"google_file_id", file.Id,
-			)
-			os.Exit(1)
+			return fmt.Errorf(
+				"error getting group reviews for document: %w", err)
 		}
 
 		// Convert database record to a document.
 		doc, err = document.NewFromDatabaseModel(
 			model, reviews, groupReviews)
 		if err != nil {
-			log.Error("error converting database record to document",
-				"error", err,
-				"google_file_id", file.Id,
-			)
-			os.Exit(1)
+			return fmt.Errorf(
+				"error converting database record to document: %w", err)
 		}
 	} else {
 		// Get document object from Algolia.
 		var algoObj map[string]any
 		switch ft {
 		case draftsFolderType:
-			if err = algo.Drafts.GetObject(file.Id, &algoObj); err != nil {
-				log.Error("error getting draft document object from Algolia",
-					"error", err,
-					"google_file_id", file.Id,
-				)
-				os.Exit(1)
+			if err = algo.Drafts.GetObject(file.Id, &algoObj, ctx); err != nil {
+				return fmt.Errorf(
+					"error getting draft document object from Algolia: %w", err)
 			}
 		case documentsFolderType:
-			if err = algo.Docs.GetObject(file.Id, &algoObj); err != nil {
-				log.Error("error getting document object from Algolia",
-					"error", err,
-					"google_file_id", file.Id,
-				)
-				os.Exit(1)
+			if err = algo.Docs.GetObject(file.Id, &algoObj, ctx); err != nil {
+				return fmt.Errorf(
+					"error getting document object from Algolia: %w", err)
 			}
 		default:
-			log.Error("bad folder type",
-				"folder_type", ft,
-			)
-			os.Exit(1)
+			return fmt.Errorf("bad folder type: %v", ft)
 		}
 
 		// Convert Algolia object to a document.
 		doc, err = document.NewFromAlgoliaObject(
 			algoObj, idx.DocumentTypes)
 		if err != nil {
-			log.Error("error converting Algolia object to document",
-				"error", err,
-				"google_file_id", file.Id,
-			)
-			os.Exit(1)
+			return fmt.Errorf(
+				"error converting Algolia object to document: %w", err)
 		}
 	}
 
@@ -274,31 +267,20 @@ func refreshDocumentHeader(
 	// Replace document header.
 	if err := doc.ReplaceHeader(
 		idx.BaseURL, isDraft, idx.GoogleWorkspaceService); err != nil {
-		log.Error("error replacing document header",
-			"error", err,
-			"google_file_id", file.Id,
-		)
-		os.Exit(1)
+		return fmt.Errorf("error replacing document header: %w", err)
 	}
 
 	// Get the file again because we just modified it.
 	file, err = idx.GoogleWorkspaceService.GetFile(file.Id)
 	if err != nil {
-		log.Error("error getting the file after replacing the header",
-			"error", err,
-			"google_file_id", file.Id,
-		)
-		os.Exit(1)
+		return fmt.Errorf(
+			"error getting the file after replacing the header: %w", err)
 	}
 
 	// Parse the modified time of the document.
 	modifiedTime, err := time.Parse(time.RFC3339Nano, file.ModifiedTime)
 	if err != nil {
-		log.Error("error parsing file modified time",
-			"error", err,
-			"google_file_id", file.Id,
-		)
-		os.Exit(1)
+		return fmt.Errorf("error parsing file modified time: %w", err)
 	}
 
 	// Update the last indexed time if this file's modified time is newer.
@@ -311,4 +293,6 @@ func refreshDocumentHeader(
 	log.Info("refreshed document header",
 		"google_file_id", file.Id,
 	)
+
+	return nil
 }
@@ -0,0 +1,281 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/email"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// retentionObject is a partial Algolia document object used to update only the
+// retention state of a document.
+type retentionObject struct {
+	ObjectID         string `json:"objectID"`
+	Archived         bool   `json:"archived,omitempty"`
+	RetentionFlagged bool   `json:"retentionFlagged,omitempty"`
+}
+
+// retentionPolicy is a retention policy with its parsed minimum age.
+type retentionPolicy struct {
+	*config.RetentionPolicy
+	minAge time.Duration
+}
+
+// applyRetentionPolicies notifies the owners of documents that retention
+// policies will soon apply to, and archives or flags documents that retention
+// policies apply to once their owners have been notified for the notice
+// period.
+func applyRetentionPolicies(idx Indexer) error {
+	db := idx.Database
+	log := idx.Logger
+	now := time.Now()
+
+	// Parse policies and find the earliest that any policy could apply, so we
+	// only get documents that a policy could apply to within the notice period.
+	var policies []retentionPolicy
+	var minAge time.Duration
+	for _, p := range idx.Retention.Policy {
+		a, err := p.MinAge()
+		if err != nil {
+			return err
+		}
+		policies = append(policies, retentionPolicy{
+			RetentionPolicy: p,
+			minAge:          a,
+		})
+		if minAge == 0 || a < minAge {
+			minAge = a
+		}
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	docs, err := models.GetRetentionCandidates(
+		db, now.Add(idx.RetentionNoticePeriod-minAge))
+	if err != nil {
+		return fmt.Errorf("error getting retention candidates: %w", err)
+	}
+
+	for _, d := range docs {
+		doc, err := document.NewFromDatabaseModel(
+			d, models.DocumentReviews{}, models.DocumentGroupReviews{})
+		if err != nil {
+			log.Error("error converting database model to document type",
+				"error", err,
+				"google_file_id", d.GoogleFileID,
+			)
+			continue
+		}
+
+		for _, p := range policies {
+			if !retentionPolicyMatches(p.RetentionPolicy, *doc) {
+				continue
+			}
+			// Documents are only flagged once.
+			if p.Action == config.RetentionActionFlag &&
+				d.RetentionFlaggedAt != nil {
+				continue
+			}
+			due := d.DocumentModifiedAt.Add(p.minAge)
+			if due.Add(-idx.RetentionNoticePeriod).After(now) {
+				continue
+			}
+
+			if err := idx.applyRetentionPolicy(p, &d, *doc, due, now); err != nil {
+				log.Error("error applying retention policy",
+					"error", err,
+					"google_file_id", d.GoogleFileID,
+					"policy", p.Name,
+				)
+				continue
+			}
+
+			// No other policies apply to archived documents.
+			if d.ArchivedAt != nil {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyRetentionPolicy applies retention policy p to a document that it is due
+// for at time due. The document's owner is notified first, and the policy's
+// action is taken once the notice period has passed.
+func (idx *Indexer) applyRetentionPolicy(
+	p retentionPolicy,
+	d *models.Document,
+	doc document.Document,
+	due, now time.Time,
+) error {
+	db := idx.Database
+	log := idx.Logger
+
+	// Notify the document owner if they haven't been notified, or if the
+	// document was modified since they were.
+	n := models.DocumentRetentionNotice{
+		DocumentID: d.ID,
+		Policy:     p.Name,
+	}
+	if err := n.Get(db); err != nil &&
+		!errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("error getting retention notice: %w", err)
+	}
+	if n.ActionAt.IsZero() || n.ActionAt.Before(due) {
+		n.ActionAt = retentionActionTime(due, now, idx.RetentionNoticePeriod)
+		if err := idx.sendRetentionNotice(p, doc, n.ActionAt); err != nil {
+			return fmt.Errorf("error sending retention notice: %w", err)
+		}
+		if err := n.Upsert(db); err != nil {
+			return fmt.Errorf("error saving retention notice: %w", err)
+		}
+		log.Info("retention notice sent",
+			"google_file_id", d.GoogleFileID,
+			"policy", p.Name,
+			"action_at", n.ActionAt,
+		)
+		return nil
+	}
+	if now.Before(n.ActionAt) {
+		return nil
+	}
+
+	// Take the policy's action.
+	obj := retentionObject{
+		ObjectID: d.GoogleFileID,
+	}
+	switch p.Action {
+	case config.RetentionActionArchive:
+		if _, err := idx.GoogleWorkspaceService.MoveFile(
+			d.GoogleFileID, idx.Retention.ArchiveFolder); err != nil {
+			return fmt.Errorf("error moving file to archive folder: %w", err)
+		}
+		if err := d.Archive(db, now); err != nil {
+			return fmt.Errorf("error archiving document in database: %w", err)
+		}
+		obj.Archived = true
+	case config.RetentionActionFlag:
+		if err := d.FlagForRetention(db, now); err != nil {
+			return fmt.Errorf("error flagging document in database: %w", err)
+		}
+		obj.RetentionFlagged = true
+	default:
+		return fmt.Errorf("invalid retention policy action %q", p.Action)
+	}
+
+	// Update the document in the search index.
+	var algoIdx *search.Index
+	if d.Status == models.WIPDocumentStatus && !d.Imported {
+		algoIdx = idx.AlgoliaClient.Drafts
+	} else {
+		algoIdx = idx.AlgoliaClient.Docs
+	}
+	res, err := algoIdx.PartialUpdateObject(obj, opt.CreateIfNotExists(false))
+	if err != nil {
+		return fmt.Errorf("error updating document in Algolia: %w", err)
+	}
+	if err := res.Wait(); err != nil {
+		return fmt.Errorf("error updating document in Algolia: %w", err)
+	}
+	if algoIdx == idx.AlgoliaClient.Docs {
+		if err := idx.AlgoliaClient.PartialUpdateDocSections(
+			context.Background(), obj); err != nil {
+			return fmt.Errorf("error updating doc sections in Algolia: %w", err)
+		}
+	}
+
+	log.Info("retention policy applied",
+		"google_file_id", d.GoogleFileID,
+		"policy", p.Name,
+		"action", p.Action,
+	)
+
+	return nil
+}
+
+// sendRetentionNotice emails the owner of a document that retention policy p
+// will apply to it at time actionAt. No email is sent if email isn't enabled
+// or the document has no owner.
+func (idx *Indexer) sendRetentionNotice(
+	p retentionPolicy, doc document.Document, actionAt time.Time) error {
+	if idx.EmailFromAddress == "" || len(doc.Owners) == 0 {
+		return nil
+	}
+
+	docURL, err := documentURL(idx.BaseURL, doc.ObjectID)
+	if err != nil {
+		return err
+	}
+	if doc.Status == "WIP" && doc.AppCreated {
+		docURL += "?draft=true"
+	}
+
+	action := "archived"
+	if p.Action == config.RetentionActionFlag {
+		action = "flagged for review"
+	}
+
+	return email.SendRetentionNoticeEmail(
+		email.RetentionNoticeEmailData{
+			Action:            action,
+			ActionDate:        actionAt.Format("January 2, 2006"),
+			BaseURL:           idx.BaseURL,
+			DocumentShortName: doc.DocNumber,
+			DocumentStatus:    doc.Status,
+			DocumentTitle:     doc.Title,
+			DocumentType:      doc.DocType,
+			DocumentURL:       docURL,
+			PolicyName:        p.Name,
+			Product:           doc.Product,
+		},
+		[]string{doc.Owners[0]},
+		idx.EmailFromAddress,
+		idx.GoogleWorkspaceService,
+	)
+}
+
+// retentionPolicyMatches returns true if a document matches at least one value
+// of each of a retention policy's filters.
+func retentionPolicyMatches(
+	p *config.RetentionPolicy, doc document.Document) bool {
+	return matchesAny(p.DocumentTypes, doc.DocType) &&
+		matchesAny(p.Products, doc.Product) &&
+		matchesAny(p.Statuses, doc.Status)
+}
+
+// matchesAny returns true if filter is empty or any of values are in filter
+// (case-insensitively).
+func matchesAny(filter []string, values ...string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		for _, v := range values {
+			if strings.EqualFold(f, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// retentionActionTime returns the time to take a retention policy's action on a
+// document that it is due for at time due, giving the owner at least the
+// notice period after being notified at time now.
+func retentionActionTime(due, now time.Time, notice time.Duration) time.Time {
+	if earliest := now.Add(notice); due.Before(earliest) {
+		return earliest
+	}
+	return due
+}
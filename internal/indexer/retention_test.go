@@ -0,0 +1,85 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionPolicyMatches(t *testing.T) {
+	doc := document.Document{
+		ObjectID: "abc",
+		DocType:  "RFC",
+		Product:  "Terraform",
+		Status:   "Obsolete",
+	}
+
+	cases := map[string]struct {
+		policy config.RetentionPolicy
+		want   bool
+	}{
+		"no filters": {
+			want: true,
+		},
+		"matching filters": {
+			policy: config.RetentionPolicy{
+				DocumentTypes: []string{"PRD", "RFC"},
+				Products:      []string{"terraform"},
+				Statuses:      []string{"Obsolete"},
+			},
+			want: true,
+		},
+		"non-matching document type": {
+			policy: config.RetentionPolicy{
+				DocumentTypes: []string{"PRD"},
+			},
+			want: false,
+		},
+		"non-matching status": {
+			policy: config.RetentionPolicy{
+				Statuses: []string{"WIP"},
+			},
+			want: false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(c.want, retentionPolicyMatches(&c.policy, doc))
+		})
+	}
+}
+
+func TestRetentionActionTime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notice := 14 * 24 * time.Hour
+
+	cases := map[string]struct {
+		due  time.Time
+		want time.Time
+	}{
+		"due after the notice period": {
+			due:  now.Add(30 * 24 * time.Hour),
+			want: now.Add(30 * 24 * time.Hour),
+		},
+		"due within the notice period": {
+			due:  now.Add(24 * time.Hour),
+			want: now.Add(notice),
+		},
+		"overdue": {
+			due:  now.Add(-365 * 24 * time.Hour),
+			want: now.Add(notice),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(c.want, retentionActionTime(c.due, now, notice))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package indexer
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/email"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// alertSavedSearches evaluates saved searches that have alerts enabled against
+// a published document, and emails the users whose saved searches the document
+// started matching. A document starts matching a saved search if it matches
+// now but didn't when it was last indexed, or if it hasn't been evaluated
+// before because it was published after the search was saved (documents that
+// were already published are recorded with whether they matched when the
+// search is saved).
+func (idx *Indexer) alertSavedSearches(docID uint, doc document.Document) error {
+	db := idx.Database
+	log := idx.Logger
+
+	searches, err := models.GetAlertingSavedSearches(db)
+	if err != nil {
+		return fmt.Errorf("error getting saved searches: %w", err)
+	}
+
+	for _, s := range searches {
+		matched := savedSearchMatches(s, doc)
+		prev, err := s.UpdateMatch(db, docID, matched)
+		if err != nil {
+			return fmt.Errorf("error updating saved search match: %w", err)
+		}
+
+		if !startedMatching(matched, prev) {
+			continue
+		}
+
+		// Don't alert users about their own documents.
+		if isDocumentOwner(doc, s.User.EmailAddress) {
+			continue
+		}
+
+		docURL, err := documentURL(idx.BaseURL, doc.ObjectID)
+		if err != nil {
+			return err
+		}
+		owner := ""
+		if len(doc.Owners) > 0 {
+			owner = doc.Owners[0]
+		}
+		if err := email.SendSavedSearchAlertEmail(
+			email.SavedSearchAlertEmailData{
+				BaseURL:           idx.BaseURL,
+				DocumentOwner:     owner,
+				DocumentShortName: doc.DocNumber,
+				DocumentStatus:    doc.Status,
+				DocumentTitle:     doc.Title,
+				DocumentType:      doc.DocType,
+				DocumentURL:       docURL,
+				Product:           doc.Product,
+				SavedSearchName:   s.Name,
+			},
+			[]string{s.User.EmailAddress},
+			idx.EmailFromAddress,
+			idx.GoogleWorkspaceService,
+		); err != nil {
+			log.Error("error sending saved search alert email",
+				"error", err,
+				"google_file_id", doc.ObjectID,
+				"saved_search_id", s.ID,
+			)
+			continue
+		}
+		log.Info("saved search alert email sent",
+			"google_file_id", doc.ObjectID,
+			"saved_search_id", s.ID,
+		)
+	}
+
+	return nil
+}
+
+// startedMatching returns true if a document started matching a saved search,
+// given whether it matches now and its previous match (nil if it hasn't been
+// evaluated before).
+func startedMatching(matched bool, prev *models.SavedSearchMatch) bool {
+	return matched && (prev == nil || !prev.Matched)
+}
+
+// savedSearchMatches returns true if a document matches a saved search. The
+// query is matched against the document's title, document number, summary,
+// product, tags, and content.
+func savedSearchMatches(s models.SavedSearch, doc document.Document) bool {
+	return s.Matches(models.SavedSearchDocument{
+		DocType: doc.DocType,
+		Owners:  doc.Owners,
+		Product: doc.Product,
+		Status:  doc.Status,
+		Text: append([]string{
+			doc.Title,
+			doc.DocNumber,
+			doc.Summary,
+			doc.Product,
+			doc.Content,
+		}, doc.Tags...),
+	})
+}
+
+// isDocumentOwner returns true if the user with email address userEmail is an
+// owner of a document.
+func isDocumentOwner(doc document.Document, userEmail string) bool {
+	for _, o := range doc.Owners {
+		if strings.EqualFold(o, userEmail) {
+			return true
+		}
+	}
+	return false
+}
+
+// documentURL returns the URL of a document in Hermes.
+func documentURL(baseURL, docID string) (string, error) {
+	docURL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing base URL: %w", err)
+	}
+	docURL.Path = path.Join(docURL.Path, "document", docID)
+	return strings.TrimRight(docURL.String(), "/"), nil
+}
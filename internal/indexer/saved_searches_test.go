@@ -0,0 +1,132 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func TestSavedSearchMatches(t *testing.T) {
+	doc := document.Document{
+		ObjectID:  "abc",
+		Title:     "Terraform Stacks",
+		DocNumber: "TF-123",
+		DocType:   "RFC",
+		Product:   "Terraform",
+		Status:    "In-Review",
+		Owners:    []string{"owner@example.com"},
+		Summary:   "A proposal for stacks.",
+		Content:   "Stacks let you deploy many configurations together.",
+		Tags:      []string{"infrastructure"},
+	}
+
+	cases := map[string]struct {
+		query   string
+		filters models.SavedSearchFilters
+		want    bool
+	}{
+		"no query or filters": {
+			want: true,
+		},
+		"matching filters": {
+			filters: models.SavedSearchFilters{
+				DocTypes: []string{"PRD", "RFC"},
+				Products: []string{"terraform"},
+				Statuses: []string{"In-Review"},
+				Owners:   []string{"owner@example.com"},
+			},
+			want: true,
+		},
+		"non-matching status": {
+			filters: models.SavedSearchFilters{
+				Statuses: []string{"Approved"},
+			},
+			want: false,
+		},
+		"non-matching owner": {
+			filters: models.SavedSearchFilters{
+				Owners: []string{"someone@example.com"},
+			},
+			want: false,
+		},
+		"query words in title and content": {
+			query: "STACKS configurations",
+			want:  true,
+		},
+		"query word in tags": {
+			query: "infrastructure",
+			want:  true,
+		},
+		"query word not found": {
+			query: "stacks vault",
+			want:  false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := models.SavedSearch{
+				Query: c.query,
+				Filters: datatypes.JSONType[models.SavedSearchFilters]{
+					Data: c.filters,
+				},
+			}
+			assert.Equal(t, c.want, savedSearchMatches(s, doc))
+		})
+	}
+}
+
+func TestDocumentURL(t *testing.T) {
+	cases := map[string]string{
+		"https://hermes.example.com":        "https://hermes.example.com/document/abc",
+		"https://hermes.example.com/":       "https://hermes.example.com/document/abc",
+		"https://example.com/hermes/prefix": "https://example.com/hermes/prefix/document/abc",
+	}
+
+	for baseURL, want := range cases {
+		got, err := documentURL(baseURL, "abc")
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, baseURL)
+	}
+}
+
+func TestStartedMatching(t *testing.T) {
+	cases := map[string]struct {
+		matched bool
+		prev    *models.SavedSearchMatch
+		want    bool
+	}{
+		"draft published after the search was saved": {
+			matched: true,
+			want:    true,
+		},
+		"document published before the search was saved": {
+			matched: true,
+			prev:    &models.SavedSearchMatch{Matched: true},
+			want:    false,
+		},
+		"document changed to match": {
+			matched: true,
+			prev:    &models.SavedSearchMatch{Matched: false},
+			want:    true,
+		},
+		"document doesn't match": {
+			matched: false,
+			want:    false,
+		},
+		"document stopped matching": {
+			matched: false,
+			prev:    &models.SavedSearchMatch{Matched: true},
+			want:    false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, startedMatching(c.matched, c.prev))
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"google.golang.org/api/docs/v1"
+)
+
+const (
+	// maxSectionContentSize is the maximum size of the content of a doc section
+	// record in bytes. Sections with more content are split into multiple
+	// records, which keeps records well under Algolia's record size limit
+	// (including document metadata) and keeps snippets relevant.
+	maxSectionContentSize = 8000
+)
+
+// docSection is a heading-scoped section of a document's content.
+type docSection struct {
+	// Heading is the text of the section's heading. It is empty for content
+	// before the first heading.
+	Heading string
+
+	// HeadingID is the Google Docs ID of the section's heading, which can be
+	// used to link to the section (e.g., "#heading=h.abc123").
+	HeadingID string
+
+	// Content is the text content of the section, excluding the heading.
+	Content string
+}
+
+// docSectionRecord is an Algolia record for a doc section.
+type docSectionRecord struct {
+	ObjectID     string   `json:"objectID"`
+	DocID        string   `json:"docID"`
+	SectionIndex int      `json:"sectionIndex"`
+	Heading      string   `json:"heading,omitempty"`
+	HeadingID    string   `json:"headingID,omitempty"`
+	Content      string   `json:"content,omitempty"`
+	Title        string   `json:"title,omitempty"`
+	DocNumber    string   `json:"docNumber,omitempty"`
+	DocType      string   `json:"docType,omitempty"`
+	Owners       []string `json:"owners,omitempty"`
+	Product      string   `json:"product,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	Summary      string   `json:"summary,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	CreatedTime  int64    `json:"createdTime,omitempty"`
+	ModifiedTime int64    `json:"modifiedTime,omitempty"`
+	ViewCount    int64    `json:"viewCount,omitempty"`
+}
+
+// isHeadingStyle returns true if a Google Docs named paragraph style is a
+// heading.
+func isHeadingStyle(s string) bool {
+	return s == "TITLE" || strings.HasPrefix(s, "HEADING_")
+}
+
+// docSections splits the content of a Google Doc into heading-scoped sections.
+// Sections with content larger than maxSize bytes are split into multiple
+// sections with the same heading.
+func docSections(d *docs.Document, maxSize int) []docSection {
+	type rawSection struct {
+		heading, headingID string
+		paragraphs         []string
+	}
+	raw := []*rawSection{{}}
+
+	var walk func(elems []*docs.StructuralElement)
+	walk = func(elems []*docs.StructuralElement) {
+		for _, e := range elems {
+			switch {
+			case e.Paragraph != nil:
+				var b strings.Builder
+				for _, pe := range e.Paragraph.Elements {
+					if pe.TextRun != nil {
+						b.WriteString(pe.TextRun.Content)
+					}
+				}
+				text := strings.TrimSpace(
+					// Google Docs uses vertical tabs for line breaks within a
+					// paragraph.
+					strings.ReplaceAll(b.String(), "\v", "\n"))
+
+				ps := e.Paragraph.ParagraphStyle
+				if ps != nil && isHeadingStyle(ps.NamedStyleType) && text != "" {
+					raw = append(raw, &rawSection{
+						heading:   text,
+						headingID: ps.HeadingId,
+					})
+					continue
+				}
+				if text != "" {
+					cur := raw[len(raw)-1]
+					cur.paragraphs = append(cur.paragraphs, text)
+				}
+
+			case e.Table != nil:
+				for _, row := range e.Table.TableRows {
+					for _, cell := range row.TableCells {
+						walk(cell.Content)
+					}
+				}
+			}
+			// Tables of contents are skipped because they duplicate headings.
+		}
+	}
+	if d.Body != nil {
+		walk(d.Body.Content)
+	}
+
+	var sections []docSection
+	for _, rs := range raw {
+		// Skip empty content before the first heading.
+		if rs.heading == "" && len(rs.paragraphs) == 0 {
+			continue
+		}
+		chunks := splitParagraphs(rs.paragraphs, maxSize)
+		if len(chunks) == 0 {
+			chunks = []string{""}
+		}
+		for _, c := range chunks {
+			sections = append(sections, docSection{
+				Heading:   rs.heading,
+				HeadingID: rs.headingID,
+				Content:   c,
+			})
+		}
+	}
+
+	return sections
+}
+
+// splitParagraphs joins paragraphs with newlines into chunks of at most
+// maxSize bytes, splitting between paragraphs when possible.
+func splitParagraphs(paragraphs []string, maxSize int) []string {
+	var (
+		chunks []string
+		b      strings.Builder
+	)
+	flush := func() {
+		if b.Len() > 0 {
+			chunks = append(chunks, b.String())
+			b.Reset()
+		}
+	}
+	for _, p := range paragraphs {
+		if b.Len() > 0 && b.Len()+1+len(p) > maxSize {
+			flush()
+		}
+		// Split paragraphs that are too large on their own.
+		for len(p) > maxSize {
+			flush()
+			n := truncateIndex(p, maxSize)
+			chunks = append(chunks, strings.TrimSpace(p[:n]))
+			p = strings.TrimSpace(p[n:])
+		}
+		if p == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(p)
+	}
+	flush()
+
+	return chunks
+}
+
+// truncateIndex returns the index to truncate s at so it is at most maxSize
+// bytes, preferring a whitespace boundary and never splitting a UTF-8
+// character.
+func truncateIndex(s string, maxSize int) int {
+	if len(s) <= maxSize {
+		return len(s)
+	}
+	n := maxSize
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	if i := strings.LastIndexAny(s[:n], " \n\t"); i > 0 {
+		return i
+	}
+	return n
+}
+
+// docSectionsContent returns the plain text content of doc sections, truncated
+// to at most maxSize bytes.
+func docSectionsContent(sections []docSection, maxSize int) string {
+	var b strings.Builder
+	for _, s := range sections {
+		for _, t := range []string{s.Heading, s.Content} {
+			if t == "" {
+				continue
+			}
+			if b.Len() > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(t)
+		}
+	}
+	content := b.String()
+
+	return content[:truncateIndex(content, maxSize)]
+}
+
+// saveDocSectionsInAlgolia saves a record for each section of a document in
+// the doc sections index, and deletes the document's records for sections
+// that no longer exist. Requests are canceled when ctx is done.
+func saveDocSectionsInAlgolia(
+	ctx context.Context,
+	doc document.Document,
+	sections []docSection,
+	idx *search.Index,
+) error {
+	var records []docSectionRecord
+	for i, s := range sections {
+		records = append(records, docSectionRecord{
+			ObjectID:     fmt.Sprintf("%s-%d", doc.ObjectID, i),
+			DocID:        doc.ObjectID,
+			SectionIndex: i,
+			Heading:      s.Heading,
+			HeadingID:    s.HeadingID,
+			Content:      s.Content,
+			Title:        doc.Title,
+			DocNumber:    doc.DocNumber,
+			DocType:      doc.DocType,
+			Owners:       doc.Owners,
+			Product:      doc.Product,
+			Status:       doc.Status,
+			Summary:      doc.Summary,
+			Tags:         doc.Tags,
+			CreatedTime:  doc.CreatedTime,
+			ModifiedTime: doc.ModifiedTime,
+			ViewCount:    doc.ViewCount,
+		})
+	}
+
+	// Save section records.
+	if len(records) > 0 {
+		res, err := idx.SaveObjects(records, ctx)
+		if err != nil {
+			return fmt.Errorf("error saving doc sections: %w", err)
+		}
+		if err := res.Wait(ctx); err != nil {
+			return fmt.Errorf("error saving doc sections: %w", err)
+		}
+	}
+
+	// Delete records for sections past the end of the document, which were
+	// saved when the document had more sections.
+	delRes, err := idx.DeleteBy(opt.Filters(fmt.Sprintf(
+		`docID:"%s" AND sectionIndex >= %d`, doc.ObjectID, len(records))), ctx)
+	if err != nil {
+		return fmt.Errorf("error deleting old doc sections: %w", err)
+	}
+	if err := delRes.Wait(ctx); err != nil {
+		return fmt.Errorf("error deleting old doc sections: %w", err)
+	}
+
+	return nil
+}
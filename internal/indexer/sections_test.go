@@ -0,0 +1,156 @@
+package indexer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/docs/v1"
+)
+
+func TestDocSections(t *testing.T) {
+	paragraph := func(style, headingID string, texts ...string) *docs.StructuralElement {
+		p := &docs.Paragraph{
+			ParagraphStyle: &docs.ParagraphStyle{
+				NamedStyleType: style,
+				HeadingId:      headingID,
+			},
+		}
+		for _, t := range texts {
+			p.Elements = append(p.Elements, &docs.ParagraphElement{
+				TextRun: &docs.TextRun{Content: t},
+			})
+		}
+		return &docs.StructuralElement{Paragraph: p}
+	}
+
+	cases := map[string]struct {
+		content []*docs.StructuralElement
+		maxSize int
+		want    []docSection
+	}{
+		"no content": {
+			maxSize: 100,
+		},
+		"content before the first heading": {
+			content: []*docs.StructuralElement{
+				paragraph("TITLE", "h.title", "RFC Title\n"),
+				paragraph("NORMAL_TEXT", "", "Intro\n"),
+			},
+			maxSize: 100,
+			want: []docSection{
+				{Heading: "RFC Title", HeadingID: "h.title", Content: "Intro"},
+			},
+		},
+		"headings and tables": {
+			content: []*docs.StructuralElement{
+				{Table: &docs.Table{
+					TableRows: []*docs.TableRow{{
+						TableCells: []*docs.TableCell{{
+							Content: []*docs.StructuralElement{
+								paragraph("NORMAL_TEXT", "", "Status: ", "Approved\n"),
+							},
+						}},
+					}},
+				}},
+				paragraph("HEADING_1", "h.bg", "Background\n"),
+				paragraph("NORMAL_TEXT", "", "Line one\vline two\n"),
+				paragraph("NORMAL_TEXT", "", "\n"),
+				paragraph("NORMAL_TEXT", "", "More\n"),
+				paragraph("HEADING_2", "h.empty", "Empty\n"),
+				paragraph("HEADING_2", "h.blank", "\n"),
+				paragraph("NORMAL_TEXT", "", "After blank heading\n"),
+			},
+			maxSize: 100,
+			want: []docSection{
+				{Content: "Status: Approved"},
+				{
+					Heading:   "Background",
+					HeadingID: "h.bg",
+					Content:   "Line one\nline two\nMore",
+				},
+				{
+					Heading:   "Empty",
+					HeadingID: "h.empty",
+					Content:   "After blank heading",
+				},
+			},
+		},
+		"large section": {
+			content: []*docs.StructuralElement{
+				paragraph("HEADING_1", "h.big", "Big\n"),
+				paragraph("NORMAL_TEXT", "", "aaaa\n"),
+				paragraph("NORMAL_TEXT", "", "bbbb\n"),
+				paragraph("NORMAL_TEXT", "", "cccc dddd eeee\n"),
+			},
+			maxSize: 10,
+			want: []docSection{
+				{Heading: "Big", HeadingID: "h.big", Content: "aaaa\nbbbb"},
+				{Heading: "Big", HeadingID: "h.big", Content: "cccc dddd"},
+				{Heading: "Big", HeadingID: "h.big", Content: "eeee"},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := docSections(&docs.Document{
+				Body: &docs.Body{Content: c.content},
+			}, c.maxSize)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestTruncateIndex(t *testing.T) {
+	cases := map[string]struct {
+		s       string
+		maxSize int
+		want    int
+	}{
+		"shorter than max size": {
+			s:       "abc",
+			maxSize: 5,
+			want:    3,
+		},
+		"whitespace boundary": {
+			s:       "abc defgh",
+			maxSize: 6,
+			want:    3,
+		},
+		"no whitespace": {
+			s:       "abcdefgh",
+			maxSize: 5,
+			want:    5,
+		},
+		"multibyte character": {
+			// "é" is two bytes.
+			s:       "aéé",
+			maxSize: 4,
+			want:    3,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, truncateIndex(c.s, c.maxSize))
+		})
+	}
+}
+
+func TestDocSectionsContent(t *testing.T) {
+	assert := assert.New(t)
+
+	sections := []docSection{
+		{Content: "Intro"},
+		{Heading: "Background", Content: "Some background"},
+		{Heading: "Empty"},
+	}
+	assert.Equal("Intro\nBackground\nSome background\nEmpty",
+		docSectionsContent(sections, 100))
+
+	got := docSectionsContent(sections, 20)
+	assert.Equal("Intro\nBackground", got)
+	assert.LessOrEqual(len(got), 20)
+	assert.False(strings.HasSuffix(got, "\n"))
+}
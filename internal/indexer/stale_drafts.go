@@ -0,0 +1,199 @@
+package indexer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/email"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// nudgeStaleDrafts emails the owners of stale drafts, at most once per nudge
+// interval, asking them to keep, publish, or delete their drafts. It also
+// emails admins a report of stale drafts per product once per nudge interval,
+// and sets the time of the report in the indexer metadata.
+func nudgeStaleDrafts(idx Indexer, md *models.IndexerMetadata) error {
+	db := idx.Database
+	log := idx.Logger
+	now := time.Now()
+
+	docs, err := models.GetStaleDrafts(
+		db, "", now.Add(-idx.StaleDraftsThreshold))
+	if err != nil {
+		return fmt.Errorf("error getting stale drafts: %w", err)
+	}
+
+	for _, d := range docs {
+		if d.Owner == nil {
+			continue
+		}
+
+		// Don't nudge owners more than once per nudge interval.
+		n := models.StaleDraftNudge{
+			DocumentID: d.ID,
+		}
+		if err := n.Get(db); err != nil &&
+			!errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("error getting stale draft nudge: %w", err)
+		}
+		if !n.SentAt.IsZero() &&
+			n.SentAt.After(now.Add(-idx.StaleDraftsNudgeInterval)) {
+			continue
+		}
+
+		if err := idx.sendStaleDraftNudge(d, &n); err != nil {
+			log.Error("error sending stale draft nudge",
+				"error", err,
+				"google_file_id", d.GoogleFileID,
+			)
+			continue
+		}
+		n.SentAt = now
+		if err := n.Upsert(db); err != nil {
+			return fmt.Errorf("error saving stale draft nudge: %w", err)
+		}
+		log.Info("stale draft nudge sent",
+			"google_file_id", d.GoogleFileID,
+		)
+	}
+
+	// Send report to admins.
+	if len(docs) == 0 || len(idx.Admins) == 0 ||
+		md.LastStaleDraftsReportAt.After(
+			now.Add(-idx.StaleDraftsNudgeInterval)) {
+		return nil
+	}
+	data, err := newStaleDraftsReport(idx.BaseURL, docs)
+	if err != nil {
+		return err
+	}
+	data.Threshold = fmt.Sprintf("%d days",
+		int(idx.StaleDraftsThreshold.Hours()/24))
+	if err := email.SendStaleDraftsReportEmail(
+		data,
+		idx.Admins,
+		idx.EmailFromAddress,
+		idx.GoogleWorkspaceService,
+	); err != nil {
+		return fmt.Errorf("error sending stale drafts report: %w", err)
+	}
+	md.LastStaleDraftsReportAt = now.UTC()
+	log.Info("stale drafts report sent",
+		"num_drafts", len(docs),
+	)
+
+	return nil
+}
+
+// sendStaleDraftNudge emails the owner of stale draft d with links to keep,
+// publish, or delete it, and sets a new token for the links in nudge n.
+func (idx *Indexer) sendStaleDraftNudge(
+	d models.Document, n *models.StaleDraftNudge) error {
+	token, err := newStaleDraftNudgeToken()
+	if err != nil {
+		return err
+	}
+	n.Token = token
+
+	docURL, err := documentURL(idx.BaseURL, d.GoogleFileID)
+	if err != nil {
+		return err
+	}
+	actionURLs := make(map[string]string)
+	for _, a := range []string{"keep", "publish", "delete"} {
+		u, err := staleDraftActionURL(idx.BaseURL, d.GoogleFileID, a, token)
+		if err != nil {
+			return err
+		}
+		actionURLs[a] = u
+	}
+
+	title := d.Title
+	if title == "" {
+		title = "Untitled"
+	}
+	return email.SendStaleDraftNudgeEmail(
+		email.StaleDraftNudgeEmailData{
+			BaseURL:       idx.BaseURL,
+			DeleteURL:     actionURLs["delete"],
+			DocumentTitle: title,
+			DocumentType:  d.DocumentType.Name,
+			DocumentURL:   docURL + "?draft=true",
+			KeepURL:       actionURLs["keep"],
+			ModifiedDate:  d.DocumentModifiedAt.Format("January 2, 2006"),
+			Product:       d.Product.Name,
+			PublishURL:    actionURLs["publish"],
+		},
+		[]string{d.Owner.EmailAddress},
+		idx.EmailFromAddress,
+		idx.GoogleWorkspaceService,
+	)
+}
+
+// newStaleDraftsReport returns report email data for stale drafts docs,
+// grouped by product in alphabetical order.
+func newStaleDraftsReport(
+	baseURL string, docs []models.Document) (email.StaleDraftsReportEmailData, error) {
+	byProduct := make(map[string][]email.StaleDraftsReportDraft)
+	for _, d := range docs {
+		docURL, err := documentURL(baseURL, d.GoogleFileID)
+		if err != nil {
+			return email.StaleDraftsReportEmailData{}, err
+		}
+		owner := ""
+		if d.Owner != nil {
+			owner = d.Owner.EmailAddress
+		}
+		byProduct[d.Product.Name] = append(byProduct[d.Product.Name],
+			email.StaleDraftsReportDraft{
+				ModifiedDate: d.DocumentModifiedAt.Format("January 2, 2006"),
+				Owner:        owner,
+				Title:        d.Title,
+				URL:          docURL + "?draft=true",
+			})
+	}
+
+	data := email.StaleDraftsReportEmailData{
+		BaseURL: baseURL,
+	}
+	for name, drafts := range byProduct {
+		data.Products = append(data.Products, email.StaleDraftsReportProduct{
+			Name:   name,
+			Drafts: drafts,
+		})
+	}
+	sort.Slice(data.Products, func(i, j int) bool {
+		return data.Products[i].Name < data.Products[j].Name
+	})
+
+	return data, nil
+}
+
+// staleDraftActionURL returns the URL of the web app page that confirms an
+// action for a stale draft.
+func staleDraftActionURL(baseURL, docID, action, token string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing base URL: %w", err)
+	}
+	u.Path = path.Join(u.Path, "stale-drafts", docID, action)
+	u.RawQuery = url.Values{"token": []string{token}}.Encode()
+	return u.String(), nil
+}
+
+// newStaleDraftNudgeToken returns a random token for the actions in a stale
+// draft nudge email.
+func newStaleDraftNudgeToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
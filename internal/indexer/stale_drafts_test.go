@@ -0,0 +1,74 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleDraftActionURL(t *testing.T) {
+	cases := map[string]struct {
+		baseURL string
+		want    string
+	}{
+		"base URL": {
+			baseURL: "https://hermes.example.com",
+			want:    "https://hermes.example.com/stale-drafts/abc/keep?token=xyz",
+		},
+		"base URL with path": {
+			baseURL: "https://example.com/hermes/",
+			want:    "https://example.com/hermes/stale-drafts/abc/keep?token=xyz",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := staleDraftActionURL(c.baseURL, "abc", "keep", "xyz")
+			require.NoError(err)
+			assert.Equal(c.want, got)
+		})
+	}
+}
+
+func TestNewStaleDraftsReport(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	modified := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	docs := []models.Document{
+		{
+			GoogleFileID:       "a",
+			Title:              "A",
+			DocumentModifiedAt: modified,
+			Owner:              &models.User{EmailAddress: "a@example.com"},
+			Product:            models.Product{Name: "Vault"},
+		},
+		{
+			GoogleFileID:       "b",
+			Title:              "B",
+			DocumentModifiedAt: modified,
+			Product:            models.Product{Name: "Terraform"},
+		},
+		{
+			GoogleFileID:       "c",
+			Title:              "C",
+			DocumentModifiedAt: modified,
+			Product:            models.Product{Name: "Vault"},
+		},
+	}
+
+	got, err := newStaleDraftsReport("https://hermes.example.com", docs)
+	require.NoError(err)
+	require.Len(got.Products, 2)
+	assert.Equal("Terraform", got.Products[0].Name)
+	assert.Equal("Vault", got.Products[1].Name)
+	require.Len(got.Products[1].Drafts, 2)
+	assert.Equal("A", got.Products[1].Drafts[0].Title)
+	assert.Equal("a@example.com", got.Products[1].Drafts[0].Owner)
+	assert.Equal("January 2, 2024", got.Products[1].Drafts[0].ModifiedDate)
+	assert.Equal("https://hermes.example.com/document/a?draft=true",
+		got.Products[1].Drafts[0].URL)
+}
@@ -0,0 +1,78 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// documentViewCountObject is a partial Algolia document object used to update
+// only the view count of a document.
+type documentViewCountObject struct {
+	ObjectID  string `json:"objectID"`
+	ViewCount int64  `json:"viewCount"`
+}
+
+// updateDocumentViewCounts updates the view counts in the docs index for all
+// published documents that have been viewed since the provided time.
+func updateDocumentViewCounts(idx Indexer, since time.Time) error {
+	counts, err := models.GetDocumentViewCountsSince(idx.Database, since)
+	if err != nil {
+		return fmt.Errorf("error getting document view counts: %w", err)
+	}
+
+	var objs []documentViewCountObject
+	for _, c := range counts {
+		doc := models.Document{
+			Model: gorm.Model{
+				ID: c.DocumentID,
+			},
+		}
+		if err := doc.Get(idx.Database); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return fmt.Errorf("error getting document: %w", err)
+		}
+
+		// Drafts aren't in the docs index.
+		if doc.Status == models.WIPDocumentStatus && !doc.Imported {
+			continue
+		}
+
+		objs = append(objs, documentViewCountObject{
+			ObjectID:  doc.GoogleFileID,
+			ViewCount: c.Views,
+		})
+	}
+	if len(objs) == 0 {
+		return nil
+	}
+
+	res, err := idx.AlgoliaClient.Docs.PartialUpdateObjects(
+		objs, opt.CreateIfNotExists(false))
+	if err != nil {
+		return fmt.Errorf("error updating document view counts: %w", err)
+	}
+	if err := res.Wait(); err != nil {
+		return fmt.Errorf("error updating document view counts: %w", err)
+	}
+	for _, obj := range objs {
+		if err := idx.AlgoliaClient.PartialUpdateDocSections(
+			context.Background(), obj); err != nil {
+			return fmt.Errorf(
+				"error updating doc sections view counts: %w", err)
+		}
+	}
+
+	idx.Logger.Info("updated document view counts",
+		"num_docs", len(objs),
+	)
+
+	return nil
+}
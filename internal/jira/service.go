@@ -1,9 +1,12 @@
 package jira
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"path"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/hashicorp-forge/hermes/internal/config"
@@ -46,6 +49,34 @@ func NewService(cfg config.Jira) (*Service, error) {
 	}, nil
 }
 
+// Ping verifies that the Jira instance is reachable and that the configured
+// credentials are valid.
+func (s *Service) Ping(ctx context.Context) error {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return fmt.Errorf("error parsing Jira URL: %w", err)
+	}
+	u.Path = path.Join(u.Path, "rest/api/3/myself")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error creating HTTP request: %w", err)
+	}
+	req.SetBasicAuth(s.User, s.APIToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
 // validate validates the service configuration.
 func validate(cfg config.Jira) error {
 	return validation.ValidateStruct(&cfg,
@@ -0,0 +1,8 @@
+// Package ratelimit implements rate limiting of HTTP requests per user and per
+// route.
+//
+// Requests are counted in fixed time windows, keyed by the authenticated
+// user's email address and the route. Counters are kept in memory, or in
+// PostgreSQL so limits hold across server replicas. Requests over the limit
+// receive a 429 (Too Many Requests) response with a Retry-After header.
+package ratelimit
@@ -0,0 +1,236 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// defaultPeriod is the default duration of a rate limit window.
+	defaultPeriod = time.Minute
+
+	// MemoryStoreType is the store type for counters kept in memory.
+	MemoryStoreType = "memory"
+
+	// PostgresStoreType is the store type for counters kept in PostgreSQL.
+	PostgresStoreType = "postgres"
+)
+
+// Config is the configuration for rate limiting.
+type Config struct {
+	// Default is the budget for routes that don't have a route budget. If not
+	// set, only routes with a route budget are rate limited.
+	Default *Budget `hcl:"default,block"`
+
+	// Disabled disables rate limiting.
+	Disabled bool `hcl:"disabled,optional"`
+
+	// Routes are the budgets for specific routes.
+	Routes []*RouteBudget `hcl:"route,block"`
+
+	// Store is where request counters are kept: "memory" (the default) or
+	// "postgres". Use "postgres" to share limits across server replicas.
+	Store string `hcl:"store,optional"`
+}
+
+// Budget is a rate limit budget.
+type Budget struct {
+	// Period is the duration (e.g., "1m") of the rate limit window. Defaults to
+	// "1m".
+	Period string `hcl:"period,optional"`
+
+	// Requests is the number of requests allowed per user in the period.
+	Requests int `hcl:"requests"`
+}
+
+// RouteBudget is a rate limit budget for a route.
+type RouteBudget struct {
+	// Pattern is the route pattern (e.g., "/api/v2/people"), which must match
+	// the pattern the route is registered with. See Limiter.UnusedRoutes.
+	Pattern string `hcl:"pattern,label"`
+
+	// Period is the duration (e.g., "1m") of the rate limit window. Defaults to
+	// "1m".
+	Period string `hcl:"period,optional"`
+
+	// Requests is the number of requests allowed per user in the period.
+	Requests int `hcl:"requests"`
+}
+
+// budget is a parsed rate limit budget.
+type budget struct {
+	period   time.Duration
+	requests int
+}
+
+// Limiter rate limits requests.
+type Limiter struct {
+	// Limited writes the response for rate limited requests, after the rate
+	// limit headers are set. Defaults to a plain text 429 response.
+	Limited http.Handler
+
+	def    *budget
+	log    hclog.Logger
+	routes map[string]budget
+	store  Store
+
+	// limited are the route patterns that Limit was called with.
+	limited map[string]bool
+
+	// now returns the current time, and is overridden in tests.
+	now func() time.Time
+}
+
+// New returns a new Limiter that keeps request counters in store.
+func New(cfg Config, store Store, log hclog.Logger) (*Limiter, error) {
+	l := &Limiter{
+		Limited: http.HandlerFunc(tooManyRequests),
+		limited: map[string]bool{},
+		log:     log,
+		routes:  map[string]budget{},
+		store:   store,
+		now:     time.Now,
+	}
+
+	if cfg.Default != nil {
+		b, err := parseBudget(cfg.Default.Requests, cfg.Default.Period)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default budget: %w", err)
+		}
+		l.def = &b
+	}
+	for _, r := range cfg.Routes {
+		if _, ok := l.routes[r.Pattern]; ok {
+			return nil, fmt.Errorf("duplicate budget for route %q", r.Pattern)
+		}
+		b, err := parseBudget(r.Requests, r.Period)
+		if err != nil {
+			return nil, fmt.Errorf("invalid budget for route %q: %w", r.Pattern, err)
+		}
+		l.routes[r.Pattern] = b
+	}
+
+	return l, nil
+}
+
+// parseBudget parses a budget.
+func parseBudget(requests int, period string) (budget, error) {
+	b := budget{
+		period:   defaultPeriod,
+		requests: requests,
+	}
+	if requests <= 0 {
+		return b, fmt.Errorf("requests must be positive")
+	}
+	if period != "" {
+		d, err := time.ParseDuration(period)
+		if err != nil {
+			return b, fmt.Errorf("invalid period: %w", err)
+		}
+		if d < time.Second {
+			return b, fmt.Errorf("period must be at least 1s")
+		}
+		b.period = d
+	}
+	return b, nil
+}
+
+// Limit is middleware that rate limits requests to the route registered with
+// pattern. It must run after authentication so requests are limited per user.
+func (l *Limiter) Limit(pattern string, next http.Handler) http.Handler {
+	l.limited[pattern] = true
+
+	b, ok := l.routes[pattern]
+	if !ok {
+		if l.def == nil {
+			return next
+		}
+		b = *l.def
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := l.now()
+		windowStart := now.Truncate(b.period)
+		windowEnd := windowStart.Add(b.period)
+		key := requestKey(r) + " " + pattern
+
+		count, err := l.store.Increment(r.Context(), key, windowStart, windowEnd)
+		if err != nil {
+			// Allow the request if the counter is unavailable, so rate limiting
+			// doesn't cause an outage.
+			l.log.Error("error incrementing rate limit counter",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		reset := int(math.Ceil(windowEnd.Sub(now).Seconds()))
+		if reset < 1 {
+			reset = 1
+		}
+		remaining := b.requests - count
+		if remaining < 0 {
+			remaining = 0
+		}
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(b.requests))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.Itoa(reset))
+
+		if count > b.requests {
+			l.log.Warn("rate limit exceeded",
+				"key", key,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			w.Header().Set("Retry-After", strconv.Itoa(reset))
+			l.Limited.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// UnusedRoutes returns the sorted patterns of route budgets that Limit hasn't
+// been called with, which usually means that they don't match the pattern of
+// any registered route. It should be called after all routes are registered.
+func (l *Limiter) UnusedRoutes() []string {
+	var unused []string
+	for p := range l.routes {
+		if !l.limited[p] {
+			unused = append(unused, p)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// requestKey returns the key that identifies who made a request: the
+// authenticated user's email address, or the client IP address if the request
+// isn't authenticated.
+func requestKey(r *http.Request) string {
+	if email, ok := r.Context().Value("userEmail").(string); ok && email != "" {
+		return email
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// tooManyRequests writes a plain text response for a rate limited request.
+func tooManyRequests(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, http.StatusText(http.StatusTooManyRequests),
+		http.StatusTooManyRequests)
+}
@@ -0,0 +1,239 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errStore is a Store that always returns an error.
+type errStore struct{}
+
+func (errStore) Increment(
+	context.Context, string, time.Time, time.Time) (int, error) {
+	return 0, errors.New("unavailable")
+}
+
+func TestNew(t *testing.T) {
+	cases := map[string]struct {
+		cfg     Config
+		wantErr bool
+	}{
+		"empty": {},
+		"valid": {
+			cfg: Config{
+				Default: &Budget{Requests: 100},
+				Routes: []*RouteBudget{
+					{Pattern: "/api/v2/people", Requests: 10, Period: "10s"},
+				},
+			},
+		},
+		"zero requests": {
+			cfg:     Config{Default: &Budget{Requests: 0}},
+			wantErr: true,
+		},
+		"bad period": {
+			cfg: Config{Routes: []*RouteBudget{
+				{Pattern: "/api/v2/people", Requests: 10, Period: "soon"},
+			}},
+			wantErr: true,
+		},
+		"short period": {
+			cfg: Config{Routes: []*RouteBudget{
+				{Pattern: "/api/v2/people", Requests: 10, Period: "1ms"},
+			}},
+			wantErr: true,
+		},
+		"duplicate route": {
+			cfg: Config{Routes: []*RouteBudget{
+				{Pattern: "/api/v2/people", Requests: 10},
+				{Pattern: "/api/v2/people", Requests: 20},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(c.cfg, NewMemoryStore(), hclog.NewNullLogger())
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLimit(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	newRequest := func(email string) *http.Request {
+		r := httptest.NewRequest("GET", "/api/v2/people", nil)
+		return r.WithContext(
+			context.WithValue(r.Context(), "userEmail", email))
+	}
+
+	t.Run("limits requests per user", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		l, err := New(Config{
+			Routes: []*RouteBudget{
+				{Pattern: "/api/v2/people", Requests: 2, Period: "1m"},
+			},
+		}, NewMemoryStore(), hclog.NewNullLogger())
+		require.NoError(err)
+		now := time.Date(2023, 1, 1, 0, 0, 15, 0, time.UTC)
+		l.now = func() time.Time { return now }
+		h := l.Limit("/api/v2/people", ok)
+
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, newRequest("a@example.com"))
+			assert.Equal(http.StatusOK, w.Code)
+			assert.Equal("2", w.Header().Get("X-RateLimit-Limit"))
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, newRequest("a@example.com"))
+		assert.Equal(http.StatusTooManyRequests, w.Code)
+		assert.Equal("45", w.Header().Get("Retry-After"))
+		assert.Equal("0", w.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal("Too Many Requests\n", w.Body.String())
+
+		// Other users have their own budget.
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, newRequest("b@example.com"))
+		assert.Equal(http.StatusOK, w.Code)
+		assert.Equal("1", w.Header().Get("X-RateLimit-Remaining"))
+
+		// The budget resets in the next window.
+		now = now.Add(45 * time.Second)
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, newRequest("a@example.com"))
+		assert.Equal(http.StatusOK, w.Code)
+	})
+
+	t.Run("uses the default budget for other routes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		l, err := New(Config{
+			Default: &Budget{Requests: 1},
+		}, NewMemoryStore(), hclog.NewNullLogger())
+		require.NoError(err)
+		h := l.Limit("/api/v2/products", ok)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, newRequest("a@example.com"))
+		assert.Equal(http.StatusOK, w.Code)
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, newRequest("a@example.com"))
+		assert.Equal(http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("writes limited responses with the Limited handler", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		l, err := New(Config{
+			Default: &Budget{Requests: 1},
+		}, NewMemoryStore(), hclog.NewNullLogger())
+		require.NoError(err)
+		l.Limited = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+		h := l.Limit("/api/v2/people", ok)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, newRequest("a@example.com"))
+		assert.Equal(http.StatusOK, w.Code)
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, newRequest("a@example.com"))
+		assert.Equal(http.StatusTeapot, w.Code)
+		assert.NotEmpty(w.Header().Get("Retry-After"))
+	})
+
+	t.Run("doesn't limit routes without a budget", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		l, err := New(Config{}, NewMemoryStore(), hclog.NewNullLogger())
+		require.NoError(err)
+		h := l.Limit("/api/v2/products", ok)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, newRequest("a@example.com"))
+		assert.Equal(http.StatusOK, w.Code)
+		assert.Empty(w.Header().Get("X-RateLimit-Limit"))
+	})
+
+	t.Run("allows requests if the store fails", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		l, err := New(Config{
+			Default: &Budget{Requests: 1},
+		}, errStore{}, hclog.NewNullLogger())
+		require.NoError(err)
+		h := l.Limit("/api/v2/people", ok)
+
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, newRequest("a@example.com"))
+			assert.Equal(http.StatusOK, w.Code)
+		}
+	})
+}
+
+func TestUnusedRoutes(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	l, err := New(Config{
+		Default: &Budget{Requests: 100},
+		Routes: []*RouteBudget{
+			{Pattern: "/api/v2/people", Requests: 10},
+			{Pattern: "/api/v2/typo", Requests: 10},
+			{Pattern: "/api/v2/groups", Requests: 10},
+		},
+	}, NewMemoryStore(), hclog.NewNullLogger())
+	require.NoError(err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	l.Limit("/api/v2/people", next)
+	l.Limit("/api/v2/products", next)
+	assert.Equal([]string{"/api/v2/groups", "/api/v2/typo"}, l.UnusedRoutes())
+
+	l.Limit("/api/v2/groups", next)
+	l.Limit("/api/v2/typo", next)
+	assert.Empty(l.UnusedRoutes())
+}
+
+func TestMemoryStore(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	s := NewMemoryStore()
+	ctx := context.Background()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+
+	n, err := s.Increment(ctx, "a", start, end)
+	require.NoError(err)
+	assert.Equal(1, n)
+	n, err = s.Increment(ctx, "a", start, end)
+	require.NoError(err)
+	assert.Equal(2, n)
+
+	// The next window deletes the expired counter and starts a new one.
+	n, err = s.Increment(ctx, "b", end, end.Add(time.Minute))
+	require.NoError(err)
+	assert.Equal(1, n)
+	assert.Len(s.counters, 1)
+	n, err = s.Increment(ctx, "a", end, end.Add(time.Minute))
+	require.NoError(err)
+	assert.Equal(1, n)
+}
@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// sweepInterval is how often expired counters are deleted.
+const sweepInterval = time.Minute
+
+// Store keeps rate limit counters.
+type Store interface {
+	// Increment increments the counter for key in the window starting at
+	// windowStart and ending at windowEnd, and returns the new count.
+	Increment(
+		ctx context.Context, key string, windowStart, windowEnd time.Time,
+	) (int, error)
+}
+
+// MemoryStore is a Store that keeps counters in memory, so limits apply per
+// server replica.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*memoryCounter
+	lastSweep time.Time
+}
+
+// memoryCounter is a counter kept in memory.
+type memoryCounter struct {
+	count       int
+	windowStart time.Time
+	windowEnd   time.Time
+}
+
+// NewMemoryStore returns a new MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters: map[string]*memoryCounter{},
+	}
+}
+
+// Increment implements Store.
+func (s *MemoryStore) Increment(
+	_ context.Context, key string, windowStart, windowEnd time.Time,
+) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Delete expired counters periodically so memory doesn't grow unbounded.
+	if windowStart.Sub(s.lastSweep) >= sweepInterval {
+		for k, c := range s.counters {
+			if !c.windowEnd.After(windowStart) {
+				delete(s.counters, k)
+			}
+		}
+		s.lastSweep = windowStart
+	}
+
+	c, ok := s.counters[key]
+	if !ok || !c.windowStart.Equal(windowStart) {
+		c = &memoryCounter{
+			windowStart: windowStart,
+			windowEnd:   windowEnd,
+		}
+		s.counters[key] = c
+	}
+	c.count++
+
+	return c.count, nil
+}
+
+// PostgresStore is a Store that keeps counters in PostgreSQL, so limits are
+// shared across server replicas.
+type PostgresStore struct {
+	db *gorm.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewPostgresStore returns a new PostgresStore.
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{
+		db: db,
+	}
+}
+
+// Increment implements Store.
+func (s *PostgresStore) Increment(
+	ctx context.Context, key string, windowStart, windowEnd time.Time,
+) (int, error) {
+	db := s.db.WithContext(ctx)
+
+	// Delete expired counters periodically.
+	s.mu.Lock()
+	sweep := windowStart.Sub(s.lastSweep) >= sweepInterval
+	if sweep {
+		s.lastSweep = windowStart
+	}
+	s.mu.Unlock()
+	if sweep {
+		if err := models.DeleteExpiredRateLimitCounters(
+			db, windowStart); err != nil {
+			return 0, err
+		}
+	}
+
+	c := models.RateLimitCounter{
+		Key:         key,
+		WindowStart: windowStart,
+		ExpiresAt:   windowEnd,
+	}
+	if err := c.Increment(db); err != nil {
+		return 0, err
+	}
+
+	return c.Count, nil
+}
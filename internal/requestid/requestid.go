@@ -0,0 +1,55 @@
+// Package requestid contains logic for assigning IDs to HTTP requests so they
+// can be correlated across logs and API responses.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+)
+
+// Header is the HTTP header used to pass request IDs.
+const Header = "X-Request-ID"
+
+// contextKey is the type for the request ID context key.
+type contextKey struct{}
+
+// validRequestID matches request IDs that are accepted from clients.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// Middleware assigns an ID to every request, stores it in the request context,
+// and sets it in the response header. A valid ID provided by the client (or a
+// load balancer) in the request header is reused.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if !validRequestID.MatchString(id) {
+			id = New()
+		}
+
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// New generates a new random request ID.
+func New() string {
+	b := make([]byte, 16)
+	// crypto/rand.Read doesn't return an error in practice.
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// NewContext returns a copy of ctx with the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or an empty string if
+// there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -0,0 +1,53 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		headerID string
+		wantID   string
+	}{
+		"no request ID header": {},
+		"valid request ID header": {
+			headerID: "abc-123",
+			wantID:   "abc-123",
+		},
+		"invalid request ID header": {
+			headerID: "bad id\n",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var ctxID string
+			h := Middleware(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					ctxID = FromContext(r.Context())
+				}))
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.headerID != "" {
+				req.Header.Set(Header, c.headerID)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			assert.NotEmpty(ctxID)
+			assert.Equal(ctxID, w.Header().Get(Header))
+			if c.wantID != "" {
+				assert.Equal(c.wantID, ctxID)
+			} else {
+				assert.NotEqual(c.headerID, ctxID)
+				assert.Len(ctxID, 32)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package server
 
 import (
+	"context"
+
 	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/embeddings"
 	"github.com/hashicorp-forge/hermes/internal/jira"
+	"github.com/hashicorp-forge/hermes/internal/requestid"
 	"github.com/hashicorp-forge/hermes/pkg/algolia"
 	gw "github.com/hashicorp-forge/hermes/pkg/googleworkspace"
 	"github.com/hashicorp/go-hclog"
@@ -23,6 +27,10 @@ type Server struct {
 	// DB is the database for the server.
 	DB *gorm.DB
 
+	// Embeddings computes embeddings for semantic search. It is nil if
+	// embeddings aren't enabled.
+	Embeddings embeddings.Provider
+
 	// GWService is the Google Workspace service for the server.
 	GWService *gw.Service
 
@@ -31,4 +39,37 @@ type Server struct {
 
 	// Logger is the logger for the server.
 	Logger hclog.Logger
+
+	// ctx is the context the server is bound to by WithContext.
+	ctx context.Context
+}
+
+// WithContext returns a shallow copy of the server with its database
+// connection and Google Workspace service bound to ctx, so queries and API
+// calls are canceled when ctx is done, and its logger including the request ID
+// from ctx, if any. Algolia calls are bound to ctx by passing Context() as an
+// option. Handlers bind the request context, and work that continues after a
+// response is sent should rebind to a context that outlives the request.
+func (s Server) WithContext(ctx context.Context) Server {
+	s.ctx = ctx
+	if s.DB != nil {
+		s.DB = s.DB.WithContext(ctx)
+	}
+	if s.GWService != nil {
+		s.GWService = s.GWService.WithContext(ctx)
+	}
+	if id := requestid.FromContext(ctx); id != "" && s.Logger != nil {
+		s.Logger = s.Logger.With("request_id", id)
+	}
+	return s
+}
+
+// Context returns the context the server is bound to, or the background
+// context if it isn't bound to one. It can be passed as an option to Algolia
+// calls (e.g., "srv.AlgoWrite.Docs.SaveObject(obj, srv.Context())").
+func (s Server) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
 }
@@ -0,0 +1,397 @@
+// Package site builds a static HTML site of exported documents, for read-only
+// documentation sites.
+package site
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/export"
+)
+
+//go:embed templates/*
+var tmplFS embed.FS
+
+var tmpl = template.Must(template.New("").Funcs(template.FuncMap{
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("Jan 2, 2006")
+	},
+	"join": strings.Join,
+}).ParseFS(tmplFS, "templates/*.html"))
+
+// Page is a document to add to a site.
+type Page struct {
+	// Metadata is the Hermes metadata of the document.
+	Metadata export.Metadata
+
+	// Content is the document exported from Google Workspace as HTML, without
+	// front matter.
+	Content []byte
+
+	// ShortLinks are the short link paths of the document without the "/l"
+	// prefix (e.g., "/rfc/lab-001").
+	ShortLinks []string
+}
+
+// Site is a static HTML site of documents. Each document is rendered with its
+// Hermes metadata, with index pages per product and document type, and short
+// link pages that redirect to documents under the same "/l" paths as Hermes.
+type Site struct {
+	// BasePath is the URL path that the site is served from (e.g., "/docs/").
+	BasePath string
+
+	// Title is the title of the site.
+	Title string
+
+	pages []*page
+}
+
+// page is a document page of a site.
+type page struct {
+	Page
+
+	// URL is the URL of the page.
+	URL string
+}
+
+// group is a group of document pages.
+type group struct {
+	Name string
+	URL  string
+	Docs []*page
+}
+
+// pageData is the data of a site page.
+type pageData struct {
+	Site      *Site
+	PageTitle string
+}
+
+// indexData is the data of the site index page.
+type indexData struct {
+	pageData
+
+	Total    int
+	Products []*group
+	DocTypes []*group
+}
+
+// listData is the data of a product or document type index page.
+type listData struct {
+	pageData
+
+	Sections []*group
+}
+
+// documentData is the data of a document page.
+type documentData struct {
+	Site       *Site
+	Doc        export.Metadata
+	DocTypeURL string
+	ProductURL string
+	Related    []export.RelatedResource
+	ShortLinks []shortLink
+}
+
+// shortLink is a short link of a document.
+type shortLink struct {
+	Path string
+	URL  string
+}
+
+// docIDRE matches valid document IDs.
+var docIDRE = regexp.MustCompile(`^[0-9A-Za-z_\-]+$`)
+
+// shortLinkPathRE matches valid short link paths.
+var shortLinkPathRE = regexp.MustCompile(`^(/[a-z0-9][a-z0-9_\-]*)+$`)
+
+// New returns a new site with title served from path basePath.
+func New(title, basePath string) *Site {
+	if !strings.HasPrefix(basePath, "/") {
+		basePath = "/" + basePath
+	}
+	if !strings.HasSuffix(basePath, "/") {
+		basePath += "/"
+	}
+	return &Site{
+		BasePath: basePath,
+		Title:    title,
+	}
+}
+
+// Add adds document page p to the site.
+func (s *Site) Add(p Page) error {
+	if !docIDRE.MatchString(p.Metadata.ID) {
+		return fmt.Errorf("invalid document ID: %q", p.Metadata.ID)
+	}
+	for _, l := range p.ShortLinks {
+		if !shortLinkPathRE.MatchString(l) {
+			return fmt.Errorf("invalid short link path: %q", l)
+		}
+	}
+
+	s.pages = append(s.pages, &page{
+		Page: p,
+		URL:  s.URL(path.Join("documents", p.Metadata.ID)),
+	})
+	return nil
+}
+
+// Build writes the site to directory dir.
+func (s *Site) Build(dir string) error {
+	sort.SliceStable(s.pages, func(i, j int) bool {
+		if s.pages[i].Metadata.DocNumber != s.pages[j].Metadata.DocNumber {
+			return s.pages[i].Metadata.DocNumber < s.pages[j].Metadata.DocNumber
+		}
+		return s.pages[i].Metadata.Title < s.pages[j].Metadata.Title
+	})
+
+	products := s.groups("products", func(p *page) string {
+		return p.Metadata.Product
+	})
+	docTypes := s.groups("doc-types", func(p *page) string {
+		return p.Metadata.DocType
+	})
+
+	// Build index page.
+	if err := s.render(dir, "", "index.html", indexData{
+		pageData: pageData{Site: s, PageTitle: "Home"},
+		Total:    len(s.pages),
+		Products: products,
+		DocTypes: docTypes,
+	}); err != nil {
+		return err
+	}
+
+	// Build product index pages, with a section per document type.
+	for _, g := range products {
+		if err := s.render(dir, relPath(s, g.URL), "list.html", listData{
+			pageData: pageData{Site: s, PageTitle: g.Name},
+			Sections: sections(g.Docs, docTypes, func(p *page) string {
+				return p.Metadata.DocType
+			}),
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Build document type index pages, with a section per product.
+	for _, g := range docTypes {
+		if err := s.render(dir, relPath(s, g.URL), "list.html", listData{
+			pageData: pageData{Site: s, PageTitle: g.Name},
+			Sections: sections(g.Docs, products, func(p *page) string {
+				return p.Metadata.Product
+			}),
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Build document pages and short link pages.
+	byDocNumber := map[string]*page{}
+	for _, p := range s.pages {
+		if n := p.Metadata.DocNumber; n != "" && !strings.HasSuffix(n, "-???") {
+			byDocNumber[n] = p
+		}
+	}
+	shortLinks := map[string]bool{}
+	for _, p := range s.pages {
+		data := documentData{
+			Site:       s,
+			Doc:        p.Metadata,
+			DocTypeURL: s.URL(path.Join("doc-types", slug(p.Metadata.DocType))),
+			ProductURL: s.URL(path.Join("products", slug(p.Metadata.Product))),
+		}
+
+		// Link to related documents on the site, if they are published to it.
+		for _, rr := range p.Metadata.RelatedResources {
+			if rp, ok := byDocNumber[rr.DocNumber]; ok && rr.DocNumber != "" {
+				rr.URL = rp.URL
+			}
+			data.Related = append(data.Related, rr)
+		}
+
+		for _, l := range p.ShortLinks {
+			// Short link paths are unique in Hermes, but skip duplicates just in
+			// case.
+			if shortLinks[l] {
+				continue
+			}
+			shortLinks[l] = true
+
+			u := s.URL(path.Join("l", l))
+			data.ShortLinks = append(data.ShortLinks, shortLink{
+				Path: l,
+				URL:  u,
+			})
+			if err := s.render(dir, relPath(s, u), "redirect.html", struct {
+				URL string
+			}{
+				URL: p.URL,
+			}); err != nil {
+				return err
+			}
+		}
+
+		var head, header bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&head, "document-head", data); err != nil {
+			return fmt.Errorf("error executing document head template: %w", err)
+		}
+		if err := tmpl.ExecuteTemplate(
+			&header, "document-header", data); err != nil {
+			return fmt.Errorf("error executing document header template: %w", err)
+		}
+		if err := writeFile(
+			dir,
+			path.Join(relPath(s, p.URL), "index.html"),
+			injectDocument(p.Content, head.Bytes(), header.Bytes()),
+		); err != nil {
+			return err
+		}
+	}
+
+	// Write stylesheet.
+	css, err := tmplFS.ReadFile("templates/style.css")
+	if err != nil {
+		return fmt.Errorf("error reading stylesheet: %w", err)
+	}
+	return writeFile(dir, "style.css", css)
+}
+
+// URL returns the URL of the page at path p on the site.
+func (s *Site) URL(p string) string {
+	if p = strings.Trim(p, "/"); p == "" {
+		return s.BasePath
+	}
+	return s.BasePath + p + "/"
+}
+
+// groups groups the site's pages by the key returned by f, in alphabetical
+// order. Index pages of the groups are under directory dir.
+func (s *Site) groups(dir string, f func(*page) string) []*group {
+	byKey := map[string]*group{}
+	var groups []*group
+	for _, p := range s.pages {
+		k := f(p)
+		g, ok := byKey[k]
+		if !ok {
+			g = &group{
+				Name: k,
+				URL:  s.URL(path.Join(dir, slug(k))),
+			}
+			byKey[k] = g
+			groups = append(groups, g)
+		}
+		g.Docs = append(g.Docs, p)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// render renders template name with data to the index page of directory rel
+// under dir.
+func (s *Site) render(dir, rel, name string, data any) error {
+	var b bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&b, name, data); err != nil {
+		return fmt.Errorf("error executing template %q: %w", name, err)
+	}
+	return writeFile(dir, path.Join(rel, "index.html"), b.Bytes())
+}
+
+// sections groups pages docs into sections by the key returned by f, in the
+// order of groups all, which link to the index page of their key.
+func sections(docs []*page, all []*group, f func(*page) string) []*group {
+	var sections []*group
+	for _, g := range all {
+		section := &group{
+			Name: g.Name,
+			URL:  g.URL,
+		}
+		for _, p := range docs {
+			if f(p) == g.Name {
+				section.Docs = append(section.Docs, p)
+			}
+		}
+		if len(section.Docs) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// injectDocument returns HTML document content with head added to the end of
+// its head element and header added to the start of its body element.
+func injectDocument(content, head, header []byte) []byte {
+	lower := bytes.ToLower(content)
+	headEnd := bytes.Index(lower, []byte("</head>"))
+	bodyStart := bytes.Index(lower, []byte("<body"))
+	if bodyStart >= 0 {
+		if i := bytes.IndexByte(lower[bodyStart:], '>'); i >= 0 {
+			bodyStart += i + 1
+		} else {
+			bodyStart = -1
+		}
+	}
+	if headEnd < 0 || bodyStart < 0 || headEnd > bodyStart {
+		// Wrap content that isn't a full HTML document.
+		var b bytes.Buffer
+		b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n")
+		b.Write(head)
+		b.WriteString("</head>\n<body class=\"hermes-site\">\n")
+		b.Write(header)
+		b.Write(content)
+		b.WriteString("\n</body>\n</html>\n")
+		return b.Bytes()
+	}
+
+	var b bytes.Buffer
+	b.Write(content[:headEnd])
+	b.Write(head)
+	b.Write(content[headEnd:bodyStart])
+	b.Write(header)
+	b.Write(content[bodyStart:])
+	return b.Bytes()
+}
+
+// relPath returns the path of URL u relative to the site's base path.
+func relPath(s *Site, u string) string {
+	return strings.TrimPrefix(u, s.BasePath)
+}
+
+// slugRE matches characters that aren't allowed in slugs.
+var slugRE = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slug returns the URL path segment for name.
+func slug(name string) string {
+	s := strings.Trim(slugRE.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// writeFile writes file name (a slash-separated path) under directory dir.
+func writeFile(dir, name string, b []byte) error {
+	p := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+	if err := os.WriteFile(p, b, 0644); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	dir := t.TempDir()
+
+	s := New("Docs", "docs")
+	require.NoError(s.Add(Page{
+		Metadata: export.Metadata{
+			ID:        "doc1",
+			Title:     "Vault HA",
+			DocType:   "RFC",
+			DocNumber: "VLT-001",
+			Product:   "Vault",
+			Status:    "Approved",
+			Owner:     "owner@example.com",
+			Approvals: []export.Approval{
+				{
+					Approver: "approver@example.com",
+					Status:   "approved",
+					Time:     time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			RelatedResources: []export.RelatedResource{
+				{
+					Title:     "Vault Storage",
+					URL:       "https://hermes.example.com/document/doc2",
+					DocNumber: "VLT-002",
+				},
+				{
+					Title:     "Not Published",
+					URL:       "https://hermes.example.com/document/doc3",
+					DocNumber: "VLT-003",
+				},
+				{
+					Title: "External",
+					URL:   "https://example.com",
+				},
+			},
+		},
+		Content: []byte(
+			"<html><head><style>p{}</style></head>" +
+				"<body class=\"c1\"><p>Content</p></body></html>"),
+		ShortLinks: []string{"/rfc/vlt-001", "/vault-ha"},
+	}))
+	require.NoError(s.Add(Page{
+		Metadata: export.Metadata{
+			ID:        "doc2",
+			Title:     "Vault Storage",
+			DocType:   "PRD",
+			DocNumber: "VLT-002",
+			Product:   "Vault",
+			Status:    "Approved",
+		},
+		Content:    []byte("<p>Storage</p>"),
+		ShortLinks: []string{"/prd/vlt-002"},
+	}))
+	require.NoError(s.Build(dir))
+
+	read := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		require.NoError(err)
+		return string(b)
+	}
+
+	// Index pages.
+	index := read("index.html")
+	assert.Contains(index, `<a href="/docs/products/vault/">Vault</a> (2)`)
+	assert.Contains(index, `<a href="/docs/doc-types/rfc/">RFC</a> (1)`)
+	assert.Contains(index, `<a href="/docs/doc-types/prd/">PRD</a> (1)`)
+	product := read("products/vault/index.html")
+	assert.Contains(product, `<a href="/docs/documents/doc1/">Vault HA</a>`)
+	assert.Contains(product, `<a href="/docs/documents/doc2/">Vault Storage</a>`)
+	assert.Contains(read("doc-types/rfc/index.html"),
+		`<a href="/docs/documents/doc1/">Vault HA</a>`)
+
+	// Document pages.
+	doc := read("documents/doc1/index.html")
+	assert.Contains(doc, `<style>p{}</style><meta charset="utf-8">`)
+	assert.Contains(doc, "<title>VLT-001: Vault HA | Docs</title>")
+	assert.Contains(doc, `<body class="c1"><nav class="hermes-site-nav">`)
+	assert.Contains(doc, "<p>Content</p></body></html>")
+	assert.Contains(doc, "approver@example.com (approved, Jan 2, 2023)")
+	assert.Contains(doc,
+		`<a href="/docs/documents/doc2/">VLT-002: Vault Storage</a>`)
+	assert.Contains(doc,
+		`<a href="https://hermes.example.com/document/doc3">VLT-003: Not Published</a>`)
+	assert.Contains(doc, `<a href="/docs/l/vault-ha/">/l/vault-ha</a>`)
+	doc = read("documents/doc2/index.html")
+	assert.Contains(doc, "<!DOCTYPE html>")
+	assert.Contains(doc, "<p>Storage</p>")
+
+	// Short link pages.
+	assert.Contains(read("l/rfc/vlt-001/index.html"),
+		`<meta http-equiv="refresh" content="0; url=/docs/documents/doc1/">`)
+	assert.Contains(read("l/vault-ha/index.html"), "/docs/documents/doc1/")
+	assert.Contains(read("l/prd/vlt-002/index.html"), "/docs/documents/doc2/")
+
+	assert.NotEmpty(read("style.css"))
+}
+
+func TestAdd(t *testing.T) {
+	cases := map[string]struct {
+		page      Page
+		shouldErr bool
+	}{
+		"good": {
+			page: Page{
+				Metadata:   export.Metadata{ID: "doc1"},
+				ShortLinks: []string{"/rfc/lab-001", "/alias"},
+			},
+		},
+		"missing ID": {
+			page:      Page{},
+			shouldErr: true,
+		},
+		"short link path with dot segments": {
+			page: Page{
+				Metadata:   export.Metadata{ID: "doc1"},
+				ShortLinks: []string{"/../../etc"},
+			},
+			shouldErr: true,
+		},
+		"short link path without leading slash": {
+			page: Page{
+				Metadata:   export.Metadata{ID: "doc1"},
+				ShortLinks: []string{"rfc/lab-001"},
+			},
+			shouldErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := New("Docs", "/").Add(c.page)
+			if c.shouldErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("vault", slug("Vault"))
+	assert.Equal("terraform-cloud", slug(" Terraform Cloud! "))
+	assert.Equal("unknown", slug("!!"))
+}
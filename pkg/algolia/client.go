@@ -32,6 +32,11 @@ type Client struct {
 	// by ascending modified time.
 	DocsModifiedTimeAsc *search.Index
 
+	// DocSections is an Algolia index for searching the content of published
+	// documents, with a record for each heading-scoped section of a document.
+	// It is nil if a doc sections index name isn't configured.
+	DocSections *search.Index
+
 	// Drafts is an Algolia index for storing metadata for draft documents.
 	Drafts *search.Index
 
@@ -74,6 +79,11 @@ type Config struct {
 	// metadata.
 	DocsIndexName string `hcl:"docs_index_name,optional"`
 
+	// DocSectionsIndexName is the name of the Algolia index for storing the
+	// content of published documents as heading-scoped sections. Document
+	// content is only indexed in sections if it is set.
+	DocSectionsIndexName string `hcl:"doc_sections_index_name,optional"`
+
 	// DraftsIndexName is the name of the Algolia index for storing draft
 	// documents' metadata.
 	DraftsIndexName string `hcl:"drafts_index_name,optional"`
@@ -146,6 +156,10 @@ func New(cfg *Config) (*Client, error) {
 		SnippetEllipsisText: opt.SnippetEllipsisText("..."),
 
 		// Ranking
+		// Boost frequently viewed documents when relevance is otherwise equal.
+		CustomRanking: opt.CustomRanking(
+			"desc(viewCount)",
+		),
 		Replicas: opt.Replicas(
 			cfg.DocsIndexName+"_createdTime_asc",
 			cfg.DocsIndexName+"_createdTime_desc",
@@ -173,6 +187,52 @@ func New(cfg *Config) (*Client, error) {
 		return nil, err
 	}
 
+	// Configure the doc sections index, if configured.
+	if cfg.DocSectionsIndexName != "" {
+		c.DocSections = a.InitIndex(cfg.DocSectionsIndexName)
+		err = configureMainIndex(cfg.DocSectionsIndexName, c.DocSections,
+			search.Settings{
+				// Attributes
+				AttributesForFaceting: opt.AttributesForFaceting(
+					"filterOnly(docID)",
+					"docType",
+					"searchable(owners)",
+					"searchable(product)",
+					"status",
+					"searchable(tags)",
+				),
+				// Return only the most relevant section of each document.
+				AttributeForDistinct: opt.AttributeForDistinct("docID"),
+				Distinct:             opt.DistinctOf(1),
+				SearchableAttributes: opt.SearchableAttributes(
+					"title",
+					"docNumber",
+					"heading",
+					"content",
+					"summary",
+				),
+
+				// Highlighting/snippeting
+				AttributesToSnippet: opt.AttributesToSnippet(
+					"content:30",
+				),
+				HighlightPostTag:    opt.HighlightPostTag("</mark>"),
+				HighlightPreTag:     opt.HighlightPreTag(`<mark>`),
+				SnippetEllipsisText: opt.SnippetEllipsisText("..."),
+
+				// Ranking
+				// Boost frequently viewed documents when relevance is otherwise
+				// equal, then prefer earlier sections.
+				CustomRanking: opt.CustomRanking(
+					"desc(viewCount)",
+					"asc(sectionIndex)",
+				),
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Configure the drafts index.
 	err = configureMainIndex(cfg.DraftsIndexName, c.Drafts, search.Settings{
 		// Attributes
@@ -213,6 +273,18 @@ func New(cfg *Config) (*Client, error) {
 		return nil, err
 	}
 
+	// Configure the projects index.
+	err = configureMainIndex(cfg.ProjectsIndexName, c.Projects, search.Settings{
+		// Attributes
+		AttributesForFaceting: opt.AttributesForFaceting(
+			"searchable(creator)",
+			"status",
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
 
@@ -332,6 +404,9 @@ func NewSearchClient(cfg *Config) (*Client, error) {
 	c.DocsCreatedTimeDesc = a.InitIndex(cfg.DocsIndexName + "_createdTime_desc")
 	c.DocsModifiedTimeDesc = a.InitIndex(cfg.DocsIndexName + "_modifiedTime_desc")
 	c.DocsModifiedTimeAsc = a.InitIndex(cfg.DocsIndexName + "_modifiedTime_asc")
+	if cfg.DocSectionsIndexName != "" {
+		c.DocSections = a.InitIndex(cfg.DocSectionsIndexName)
+	}
 	c.Drafts = a.InitIndex(cfg.DraftsIndexName)
 	c.DraftsCreatedTimeAsc = a.InitIndex(cfg.DraftsIndexName + "_createdTime_asc")
 	c.DraftsCreatedTimeDesc = a.InitIndex(cfg.DraftsIndexName + "_createdTime_desc")
@@ -0,0 +1,160 @@
+package algolia
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+)
+
+// docSectionAttributes are the attributes of document objects in the docs index
+// that are copied to each of the document's records in the doc sections index.
+var docSectionAttributes = []string{
+	"archived",
+	"createdTime",
+	"docNumber",
+	"docType",
+	"modifiedTime",
+	"owners",
+	"product",
+	"retentionFlagged",
+	"status",
+	"summary",
+	"tags",
+	"title",
+	"viewCount",
+}
+
+// UpdateDocSections updates the records of a document in the doc sections
+// index with the attributes of docObj, the document's object saved in the docs
+// index, so searching the doc sections index filters and returns the current
+// attributes of the document. Attributes that aren't in docObj are cleared.
+// It does nothing if the doc sections index isn't configured, or if the
+// document doesn't have records (section records are created by the indexer).
+func (c *Client) UpdateDocSections(ctx context.Context, docObj any) error {
+	return c.updateDocSections(ctx, docObj, false)
+}
+
+// PartialUpdateDocSections is like UpdateDocSections, but only updates the
+// attributes in obj, a partial update of the document's object in the docs
+// index.
+func (c *Client) PartialUpdateDocSections(ctx context.Context, obj any) error {
+	return c.updateDocSections(ctx, obj, true)
+}
+
+// DeleteDocSections deletes the records of the document with object ID docID
+// in the doc sections index, if it is configured.
+func (c *Client) DeleteDocSections(ctx context.Context, docID string) error {
+	if c.DocSections == nil {
+		return nil
+	}
+
+	res, err := c.DocSections.DeleteBy(
+		opt.Filters(docSectionsFilter(docID)), ctx)
+	if err != nil {
+		return fmt.Errorf("error deleting doc sections: %w", err)
+	}
+	if err := res.Wait(ctx); err != nil {
+		return fmt.Errorf("error deleting doc sections: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) updateDocSections(
+	ctx context.Context, obj any, partial bool) error {
+	if c.DocSections == nil {
+		return nil
+	}
+
+	// Convert object to a map.
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("error marshaling document object: %w", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return fmt.Errorf("error unmarshaling document object: %w", err)
+	}
+	docID, _ := m["objectID"].(string)
+	if docID == "" {
+		return errors.New("document object ID is required")
+	}
+
+	attrs := docSectionsUpdate(m, partial)
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	// Get the object IDs of the document's records.
+	it, err := c.DocSections.BrowseObjects(
+		opt.Filters(docSectionsFilter(docID)),
+		opt.AttributesToRetrieve("objectID"),
+		ctx,
+	)
+	if err != nil {
+		return fmt.Errorf("error browsing doc sections: %w", err)
+	}
+	var objs []map[string]any
+	for {
+		hit, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("error browsing doc sections: %w", err)
+		}
+		h, ok := hit.(map[string]any)
+		if !ok {
+			continue
+		}
+		id, _ := h["objectID"].(string)
+		if id == "" {
+			continue
+		}
+
+		o := map[string]any{"objectID": id}
+		for k, v := range attrs {
+			o[k] = v
+		}
+		objs = append(objs, o)
+	}
+	if len(objs) == 0 {
+		return nil
+	}
+
+	// Update the records.
+	res, err := c.DocSections.PartialUpdateObjects(
+		objs, opt.CreateIfNotExists(false), ctx)
+	if err != nil {
+		return fmt.Errorf("error updating doc sections: %w", err)
+	}
+	if err := res.Wait(ctx); err != nil {
+		return fmt.Errorf("error updating doc sections: %w", err)
+	}
+
+	return nil
+}
+
+// docSectionsUpdate returns the attributes of document object docObj to update
+// in the document's doc section records. If partial is false, attributes that
+// aren't in docObj are cleared.
+func docSectionsUpdate(docObj map[string]any, partial bool) map[string]any {
+	attrs := map[string]any{}
+	for _, a := range docSectionAttributes {
+		if v, ok := docObj[a]; ok {
+			attrs[a] = v
+		} else if !partial {
+			attrs[a] = nil
+		}
+	}
+	return attrs
+}
+
+// docSectionsFilter returns the Algolia filter for the doc section records of
+// the document with object ID docID.
+func docSectionsFilter(docID string) string {
+	return fmt.Sprintf(`docID:"%s"`, docID)
+}
@@ -1,25 +1,53 @@
 package algolia
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
 )
 
+// proxyIndexesPath is the path prefix of Algolia API requests for indexes.
+const proxyIndexesPath = "/1/indexes/"
+
 // AlgoliaProxyHandler proxies Algolia API requests from the Hermes frontend.
+// Only requests for the indexes that the frontend searches (documents and
+// projects) are proxied. Drafts are only searched with the search API, which
+// only returns drafts to their owners and contributors.
 func AlgoliaProxyHandler(
 	c *Client, cfg *Config, log hclog.Logger) http.Handler {
+	indexes := proxyIndexes(cfg)
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			log.Error("error reading search request", "error", err)
+			http.Error(w, "Error executing search request",
+				http.StatusInternalServerError)
+			return
+		}
+		if !proxyRequestAllowed(r.Method, r.URL.Path, body, indexes) {
+			log.Warn("forbidden search request",
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
 		// Create HTTP request.
 		url := fmt.Sprintf("https://%s-dsn.algolia.net%s?%s",
 			c.Docs.GetAppID(), r.URL.Path, r.URL.RawQuery)
 		client := &http.Client{
 			Timeout: time.Second * 10,
 		}
-		req, err := http.NewRequest(r.Method, url, r.Body)
+		req, err := http.NewRequest(r.Method, url, bytes.NewReader(body))
 		if err != nil {
 			log.Error("error executing search request", "error", err)
 			http.Error(w, "Error executing search request",
@@ -56,3 +84,76 @@ func AlgoliaProxyHandler(
 		w.Write(respBody)
 	})
 }
+
+// proxyIndexes returns the names of the indexes that can be requested with the
+// Algolia proxy.
+func proxyIndexes(cfg *Config) map[string]bool {
+	indexes := map[string]bool{}
+	for _, name := range []string{
+		cfg.DocsIndexName,
+		cfg.DocsIndexName + "_createdTime_asc",
+		cfg.DocsIndexName + "_createdTime_desc",
+		cfg.DocsIndexName + "_modifiedTime_desc",
+		cfg.DocsIndexName + "_modifiedTime_asc",
+		cfg.ProjectsIndexName,
+	} {
+		if name != "" {
+			indexes[name] = true
+		}
+	}
+	return indexes
+}
+
+// proxyRequestAllowed returns true if an Algolia API request with method,
+// path, and body only reads from indexes.
+func proxyRequestAllowed(
+	method, path string, body []byte, indexes map[string]bool) bool {
+	p := strings.TrimPrefix(path, proxyIndexesPath)
+	if p == path {
+		return false
+	}
+	name, rest, _ := strings.Cut(p, "/")
+	name, err := url.PathUnescape(name)
+	if err != nil {
+		return false
+	}
+
+	// Queries of multiple indexes ("/1/indexes/*/queries").
+	if name == "*" {
+		if method != http.MethodPost || rest != "queries" {
+			return false
+		}
+		var req struct {
+			Requests []struct {
+				IndexName string `json:"indexName"`
+			} `json:"requests"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil ||
+			len(req.Requests) == 0 {
+			return false
+		}
+		for _, q := range req.Requests {
+			if !indexes[q.IndexName] {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !indexes[name] {
+		return false
+	}
+	switch method {
+	case http.MethodGet:
+		// Getting objects ("/1/indexes/{index}/{objectID}") and searches.
+		return rest != "" && rest != "settings"
+	case http.MethodPost:
+		// Searches ("/1/indexes/{index}/query") and searches for facet values
+		// ("/1/indexes/{index}/facets/{facet}/query").
+		return rest == "query" ||
+			(strings.HasPrefix(rest, "facets/") &&
+				strings.HasSuffix(rest, "/query"))
+	default:
+		return false
+	}
+}
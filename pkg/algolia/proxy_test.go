@@ -0,0 +1,112 @@
+package algolia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyRequestAllowed(t *testing.T) {
+	indexes := proxyIndexes(&Config{
+		DocsIndexName:     "docs",
+		DraftsIndexName:   "drafts",
+		ProjectsIndexName: "projects",
+	})
+
+	cases := map[string]struct {
+		method string
+		path   string
+		body   string
+		want   bool
+	}{
+		"search docs": {
+			method: "POST",
+			path:   "/1/indexes/docs/query",
+			want:   true,
+		},
+		"search docs replica": {
+			method: "POST",
+			path:   "/1/indexes/docs_createdTime_desc/query",
+			want:   true,
+		},
+		"search projects": {
+			method: "POST",
+			path:   "/1/indexes/projects/query",
+			want:   true,
+		},
+		"search facet values": {
+			method: "POST",
+			path:   "/1/indexes/docs/facets/product/query",
+			want:   true,
+		},
+		"get doc": {
+			method: "GET",
+			path:   "/1/indexes/docs/abc",
+			want:   true,
+		},
+		"search drafts": {
+			method: "POST",
+			path:   "/1/indexes/drafts/query",
+		},
+		"get draft": {
+			method: "GET",
+			path:   "/1/indexes/drafts/abc",
+		},
+		"search drafts replica": {
+			method: "POST",
+			path:   "/1/indexes/drafts_modifiedTime_desc/query",
+		},
+		"get docs settings": {
+			method: "GET",
+			path:   "/1/indexes/docs/settings",
+		},
+		"delete doc": {
+			method: "DELETE",
+			path:   "/1/indexes/docs/abc",
+		},
+		"list indexes": {
+			method: "GET",
+			path:   "/1/indexes/",
+		},
+		"other path": {
+			method: "GET",
+			path:   "/1/keys",
+		},
+		"multiple queries": {
+			method: "POST",
+			path:   "/1/indexes/*/queries",
+			body: `{"requests":[{"indexName":"docs","params":"query=a"},` +
+				`{"indexName":"projects","params":"query=a"}]}`,
+			want: true,
+		},
+		"multiple queries with escaped path": {
+			method: "POST",
+			path:   "/1/indexes/%2A/queries",
+			body:   `{"requests":[{"indexName":"docs","params":"query=a"}]}`,
+			want:   true,
+		},
+		"multiple queries including drafts": {
+			method: "POST",
+			path:   "/1/indexes/*/queries",
+			body: `{"requests":[{"indexName":"docs","params":"query=a"},` +
+				`{"indexName":"drafts","params":"query=a"}]}`,
+		},
+		"multiple queries without requests": {
+			method: "POST",
+			path:   "/1/indexes/*/queries",
+			body:   `{}`,
+		},
+		"get multiple objects": {
+			method: "POST",
+			path:   "/1/indexes/*/objects",
+			body:   `{"requests":[{"indexName":"drafts","objectID":"abc"}]}`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want,
+				proxyRequestAllowed(c.method, c.path, []byte(c.body), indexes))
+		})
+	}
+}
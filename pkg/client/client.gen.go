@@ -0,0 +1,1610 @@
+// Code generated by gen.go from the OpenAPI specification. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// APIToken is an API token (without the token itself).
+type APIToken struct {
+	CreatedTime  int64    `json:"createdTime"`
+	ExpiresTime  int64    `json:"expiresTime"`
+	ID           int      `json:"id"`
+	Kind         string   `json:"kind"`
+	LastUsedTime int64    `json:"lastUsedTime,omitempty"`
+	Name         string   `json:"name"`
+	RevokedTime  int64    `json:"revokedTime,omitempty"`
+	Scopes       []string `json:"scopes"`
+	// Name of the service account (service account tokens only).
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Beginning of the token, to help identify it.
+	TokenPrefix string `json:"tokenPrefix"`
+}
+
+type AnalyticsRequest struct {
+	DocumentID  string  `json:"document_id"`
+	ProductName *string `json:"product_name,omitempty"`
+}
+
+type AnalyticsResponse struct {
+	// True if a new document view was recorded.
+	Recorded bool `json:"recorded,omitempty"`
+}
+
+type CustomField struct {
+	// Value of the custom field (a string or an array of strings).
+	Value       any    `json:"Value,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	Name        string `json:"name"`
+	// Type of the custom field ("PEOPLE", "PERSON", or "STRING").
+	Type string `json:"type"`
+}
+
+// Document is a document. Values of custom fields are included as additional
+// properties.
+type Document struct {
+	MetaTags       []string `json:"_tags,omitempty"`
+	AppCreated     bool     `json:"appCreated,omitempty"`
+	ApprovedBy     []string `json:"approvedBy,omitempty"`
+	ApproverGroups []string `json:"approverGroups,omitempty"`
+	Approvers      []string `json:"approvers,omitempty"`
+	// True if the document was archived by a retention policy.
+	Archived             bool              `json:"archived,omitempty"`
+	ChangesRequestedBy   []string          `json:"changesRequestedBy,omitempty"`
+	Content              string            `json:"content,omitempty"`
+	Contributors         []string          `json:"contributors,omitempty"`
+	Created              string            `json:"created,omitempty"`
+	CreatedTime          int64             `json:"createdTime,omitempty"`
+	CustomEditableFields map[string]any    `json:"customEditableFields,omitempty"`
+	CustomFields         []CustomField     `json:"customFields,omitempty"`
+	DocNumber            string            `json:"docNumber,omitempty"`
+	DocType              string            `json:"docType,omitempty"`
+	FileRevisions        map[string]string `json:"fileRevisions,omitempty"`
+	LinkedDocs           []string          `json:"linkedDocs,omitempty"`
+	Locked               bool              `json:"locked,omitempty"`
+	ModifiedTime         int64             `json:"modifiedTime,omitempty"`
+	// Google file ID of the document.
+	ObjectID    string   `json:"objectID,omitempty"`
+	OwnerPhotos []string `json:"ownerPhotos,omitempty"`
+	Owners      []string `json:"owners,omitempty"`
+	Product     string   `json:"product,omitempty"`
+	// True if the document was flagged for review by a retention policy.
+	RetentionFlagged bool     `json:"retentionFlagged,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	Summary          string   `json:"summary,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+	ThumbnailLink    string   `json:"thumbnailLink,omitempty"`
+	Title            string   `json:"title,omitempty"`
+	ViewCount        int64    `json:"viewCount,omitempty"`
+}
+
+type DocumentAnalytics struct {
+	ProductUniqueViewersLastWeek int64 `json:"productUniqueViewersLastWeek,omitempty"`
+	ProductViewsLastWeek         int64 `json:"productViewsLastWeek,omitempty"`
+	UniqueViewers                int64 `json:"uniqueViewers,omitempty"`
+	UniqueViewersLastWeek        int64 `json:"uniqueViewersLastWeek,omitempty"`
+	Views                        int64 `json:"views,omitempty"`
+	ViewsLastWeek                int64 `json:"viewsLastWeek,omitempty"`
+}
+
+// DocumentMovePostRequest is a request to move a published document to
+// another product.
+type DocumentMovePostRequest struct {
+	// Name of the product to move the document to.
+	Product string `json:"product"`
+}
+
+// DocumentMoveResponse is the result of moving a document to another
+// product.
+type DocumentMoveResponse struct {
+	// Document number assigned in the new product.
+	DocNumber string `json:"docNumber"`
+	// Document number in the previous product, which keeps redirecting to the
+	// document.
+	PreviousDocNumber string `json:"previousDocNumber"`
+	// Name of the product the document was moved to.
+	Product string `json:"product"`
+}
+
+type DocumentPatchRequest struct {
+	ApproverGroups []string      `json:"approverGroups,omitempty"`
+	Approvers      []string      `json:"approvers,omitempty"`
+	Contributors   []string      `json:"contributors,omitempty"`
+	CustomFields   []CustomField `json:"customFields,omitempty"`
+	Owners         []string      `json:"owners,omitempty"`
+	Status         *string       `json:"status,omitempty"`
+	Summary        *string       `json:"summary,omitempty"`
+	// Tag names. Tags are normalized to lowercase letters, numbers, and hyphens
+	// (e.g., "Cloud Platform" becomes "cloud-platform"). At most 10 tags are
+	// allowed.
+	Tags  []string `json:"tags,omitempty"`
+	Title *string  `json:"title,omitempty"`
+}
+
+// DocumentRetentionPutRequest is a request to update a document's retention
+// settings.
+type DocumentRetentionPutRequest struct {
+	// Opts the document out of retention policies, if true.
+	OptOut bool `json:"optOut"`
+}
+
+// DocumentRetentionResponse is the retention state of a document.
+type DocumentRetentionResponse struct {
+	// Time the document was archived by a retention policy, in Unix time.
+	ArchivedTime int64 `json:"archivedTime,omitempty"`
+	// Time the document was flagged for review by a retention policy, in Unix
+	// time.
+	FlaggedTime int64 `json:"flaggedTime,omitempty"`
+	// Retention policies that the document's owner was notified will apply to
+	// the document.
+	Notices []RetentionNotice `json:"notices"`
+	// True if the document is opted out of retention policies.
+	OptOut bool `json:"optOut"`
+}
+
+type DocumentType struct {
+	// Google file ID of the document type template.
+	Template     string                    `json:"Template,omitempty"`
+	Checks       []DocumentTypeCheck       `json:"checks,omitempty"`
+	CustomFields []DocumentTypeCustomField `json:"customFields,omitempty"`
+	Description  string                    `json:"description,omitempty"`
+	FlightIcon   string                    `json:"flightIcon,omitempty"`
+	LongName     string                    `json:"longName,omitempty"`
+	MoreInfoLink DocumentTypeLink          `json:"moreInfoLink,omitempty"`
+	Name         string                    `json:"name,omitempty"`
+}
+
+type DocumentTypeCheck struct {
+	HelperText string             `json:"helperText,omitempty"`
+	Label      string             `json:"label,omitempty"`
+	Links      []DocumentTypeLink `json:"links,omitempty"`
+}
+
+type DocumentTypeCustomField struct {
+	Name     string `json:"name,omitempty"`
+	ReadOnly bool   `json:"readOnly,omitempty"`
+	Type     string `json:"type,omitempty"`
+}
+
+type DocumentTypeLink struct {
+	Text string `json:"text,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type DraftShareable struct {
+	IsShareable bool `json:"isShareable"`
+}
+
+type DraftsPatchRequest struct {
+	ApproverGroups []string      `json:"approverGroups,omitempty"`
+	Approvers      []string      `json:"approvers,omitempty"`
+	Contributors   []string      `json:"contributors,omitempty"`
+	CustomFields   []CustomField `json:"customFields,omitempty"`
+	Owners         []string      `json:"owners,omitempty"`
+	Product        *string       `json:"product,omitempty"`
+	Summary        *string       `json:"summary,omitempty"`
+	// Tag names. Tags are normalized to lowercase letters, numbers, and hyphens
+	// (e.g., "Cloud Platform" becomes "cloud-platform"). At most 10 tags are
+	// allowed.
+	Tags  []string `json:"tags,omitempty"`
+	Title *string  `json:"title,omitempty"`
+}
+
+// DraftsPriorArtRequest is a draft to find prior art for.
+type DraftsPriorArtRequest struct {
+	// Product of the draft. Documents for the same product rank higher.
+	Product *string `json:"product,omitempty"`
+	// Summary of the draft.
+	Summary *string `json:"summary,omitempty"`
+	// Title of the draft.
+	Title string `json:"title"`
+}
+
+// DraftsPriorArtResponse is existing documents and projects that are likely
+// related to a draft.
+type DraftsPriorArtResponse struct {
+	// Documents (not drafts) that are likely related to the draft, most related
+	// first.
+	Documents []PriorArtDocument `json:"documents"`
+	// Projects that are likely related to the draft, most related first.
+	Projects []PriorArtProject `json:"projects"`
+}
+
+type DraftsRequest struct {
+	Contributors        []string `json:"contributors,omitempty"`
+	DocType             string   `json:"docType"`
+	Product             string   `json:"product"`
+	ProductAbbreviation *string  `json:"productAbbreviation,omitempty"`
+	Summary             *string  `json:"summary,omitempty"`
+	Tags                []string `json:"tags,omitempty"`
+	Title               string   `json:"title"`
+}
+
+type DraftsResponse struct {
+	ID string `json:"id"`
+}
+
+// ErrorResponse is an error response returned by all API endpoints.
+type ErrorResponse struct {
+	// Stable, machine-readable error code.
+	Code string `json:"code"`
+	// Additional information about the error.
+	Details map[string]any `json:"details,omitempty"`
+	// Human-readable error message.
+	Message string `json:"message"`
+	// ID of the request, for correlating with server logs.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+type ExternalLink struct {
+	Name      string `json:"name"`
+	SortOrder int    `json:"sortOrder"`
+	URL       string `json:"url"`
+}
+
+type Group struct {
+	Email string `json:"email,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+type GroupsPostRequest struct {
+	Query *string `json:"query,omitempty"`
+}
+
+type JiraIssue struct {
+	Assignee       string `json:"assignee,omitempty"`
+	AssigneeAvatar string `json:"assigneeAvatar,omitempty"`
+	IssueType      string `json:"issueType,omitempty"`
+	IssueTypeImage string `json:"issueTypeImage,omitempty"`
+	Key            string `json:"key,omitempty"`
+	Priority       string `json:"priority,omitempty"`
+	PriorityImage  string `json:"priorityImage,omitempty"`
+	Project        string `json:"project,omitempty"`
+	Reporter       string `json:"reporter,omitempty"`
+	Status         string `json:"status,omitempty"`
+	Summary        string `json:"summary,omitempty"`
+	URL            string `json:"url,omitempty"`
+}
+
+type JiraIssuePickerIssue struct {
+	IssueTypeImage string `json:"issueTypeImage,omitempty"`
+	Key            string `json:"key,omitempty"`
+	Summary        string `json:"summary,omitempty"`
+	URL            string `json:"url,omitempty"`
+}
+
+type Me struct {
+	Email         string `json:"email,omitempty"`
+	FamilyName    string `json:"family_name,omitempty"`
+	GivenName     string `json:"given_name,omitempty"`
+	HD            string `json:"hd,omitempty"`
+	ID            string `json:"id,omitempty"`
+	Locale        string `json:"locale,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Picture       string `json:"picture,omitempty"`
+	VerifiedEmail bool   `json:"verified_email,omitempty"`
+}
+
+// MeSavedSearchPatchRequest is fields to update. Only provided fields are
+// updated.
+type MeSavedSearchPatchRequest struct {
+	AlertsEnabled *bool    `json:"alertsEnabled,omitempty"`
+	DocTypes      []string `json:"docTypes,omitempty"`
+	Name          *string  `json:"name,omitempty"`
+	Owners        []string `json:"owners,omitempty"`
+	Products      []string `json:"products,omitempty"`
+	Query         *string  `json:"query,omitempty"`
+	Statuses      []string `json:"statuses,omitempty"`
+}
+
+type MeSavedSearchesPostRequest struct {
+	// Email the user when a published document starts matching the saved search.
+	AlertsEnabled *bool `json:"alertsEnabled,omitempty"`
+	// Document types to match (any).
+	DocTypes []string `json:"docTypes,omitempty"`
+	Name     string   `json:"name"`
+	// Owner email addresses to match (any).
+	Owners []string `json:"owners,omitempty"`
+	// Products to match (any).
+	Products []string `json:"products,omitempty"`
+	// Search text. Documents match if every word appears in them.
+	Query *string `json:"query,omitempty"`
+	// Document statuses to match (any).
+	Statuses []string `json:"statuses,omitempty"`
+}
+
+type MeSubscriptionsPostRequest struct {
+	Subscriptions []string `json:"subscriptions"`
+}
+
+type MeTagSubscriptionsPostRequest struct {
+	// Tag names.
+	Subscriptions []string `json:"subscriptions"`
+}
+
+type MeTokensPostRequest struct {
+	// Time (Unix seconds) that the token expires. Defaults to the configured
+	// default token lifetime.
+	ExpiresTime *int64 `json:"expiresTime,omitempty"`
+	Name        string `json:"name"`
+	// Scopes formatted as "resource:access" (e.g., "documents:read"). Resources
+	// are documents, drafts, me, people, products, projects, or * (all). Access
+	// is read or write (which includes read).
+	Scopes []string `json:"scopes"`
+	// Name of a service account that the user owns to create the token for. If
+	// not set, a personal access token is created.
+	ServiceAccount *string `json:"serviceAccount,omitempty"`
+}
+
+// MeTokensPostResponse is a created API token, including the token itself.
+type MeTokensPostResponse struct {
+	CreatedTime    int64    `json:"createdTime"`
+	ExpiresTime    int64    `json:"expiresTime"`
+	ID             int      `json:"id"`
+	Kind           string   `json:"kind"`
+	LastUsedTime   int64    `json:"lastUsedTime,omitempty"`
+	Name           string   `json:"name"`
+	RevokedTime    int64    `json:"revokedTime,omitempty"`
+	Scopes         []string `json:"scopes"`
+	ServiceAccount string   `json:"serviceAccount,omitempty"`
+	// The API token. It is only returned when the token is created.
+	Token       string `json:"token"`
+	TokenPrefix string `json:"tokenPrefix"`
+}
+
+type MostViewedDoc struct {
+	DocNumber     string `json:"docNumber,omitempty"`
+	DocType       string `json:"docType,omitempty"`
+	ID            string `json:"id,omitempty"`
+	Product       string `json:"product,omitempty"`
+	Title         string `json:"title,omitempty"`
+	UniqueViewers int64  `json:"uniqueViewers,omitempty"`
+	Views         int64  `json:"views,omitempty"`
+}
+
+type PeopleDataRequest struct {
+	Query *string `json:"query,omitempty"`
+}
+
+// Person is a person from the Google Workspace directory.
+type Person struct {
+	EmailAddresses []map[string]any `json:"emailAddresses,omitempty"`
+	Etag           string           `json:"etag,omitempty"`
+	Names          []map[string]any `json:"names,omitempty"`
+	Photos         []map[string]any `json:"photos,omitempty"`
+	ResourceName   string           `json:"resourceName,omitempty"`
+}
+
+// PriorArtDocument is a document that is likely related to a draft.
+type PriorArtDocument struct {
+	DocNumber string   `json:"docNumber"`
+	DocType   string   `json:"docType"`
+	ID        string   `json:"id"`
+	Owners    []string `json:"owners"`
+	Product   string   `json:"product"`
+	// Why the document is likely related to the draft.
+	Reasons []string `json:"reasons"`
+	// How likely the document is related to the draft, between 0 and 1.
+	Score  float64 `json:"score"`
+	Status string  `json:"status"`
+	Title  string  `json:"title"`
+}
+
+// PriorArtProject is a project that is likely related to a draft.
+type PriorArtProject struct {
+	ID int `json:"id"`
+	// Why the project is likely related to the draft.
+	Reasons []string `json:"reasons"`
+	// How likely the project is related to the draft, between 0 and 1.
+	Score  float64 `json:"score"`
+	Status string  `json:"status"`
+	Title  string  `json:"title"`
+}
+
+type ProductData struct {
+	Abbreviation   string                        `json:"abbreviation,omitempty"`
+	PerDocTypeData map[string]ProductDocTypeData `json:"perDocTypeData,omitempty"`
+}
+
+type ProductDocTypeData struct {
+	FolderID        string `json:"folderID,omitempty"`
+	LatestDocNumber int    `json:"latestDocNumber,omitempty"`
+}
+
+type Project struct {
+	CreatedTime  int64    `json:"createdTime,omitempty"`
+	Creator      string   `json:"creator,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	ID           int      `json:"id,omitempty"`
+	JiraIssueID  string   `json:"jiraIssueID,omitempty"`
+	ModifiedTime int64    `json:"modifiedTime,omitempty"`
+	Products     []string `json:"products,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Title        string   `json:"title,omitempty"`
+}
+
+type ProjectPatchRequest struct {
+	Description *string `json:"description,omitempty"`
+	JiraIssueID *string `json:"jiraIssueID,omitempty"`
+	Status      *string `json:"status,omitempty"`
+	// Tag names. Tags are normalized to lowercase letters, numbers, and hyphens
+	// (e.g., "Cloud Platform" becomes "cloud-platform"). At most 10 tags are
+	// allowed.
+	Tags  []string `json:"tags,omitempty"`
+	Title *string  `json:"title,omitempty"`
+}
+
+type ProjectRelatedHermesDocument struct {
+	CreatedTime    int64    `json:"createdTime,omitempty"`
+	DocumentNumber string   `json:"documentNumber,omitempty"`
+	DocumentType   string   `json:"documentType,omitempty"`
+	GoogleFileID   string   `json:"googleFileID,omitempty"`
+	ModifiedTime   int64    `json:"modifiedTime,omitempty"`
+	Owners         []string `json:"owners,omitempty"`
+	Product        string   `json:"product,omitempty"`
+	SortOrder      int      `json:"sortOrder,omitempty"`
+	Status         string   `json:"status,omitempty"`
+	Summary        string   `json:"summary,omitempty"`
+	Title          string   `json:"title,omitempty"`
+}
+
+type ProjectRelatedResources struct {
+	ExternalLinks   []ExternalLink                 `json:"externalLinks,omitempty"`
+	HermesDocuments []ProjectRelatedHermesDocument `json:"hermesDocuments,omitempty"`
+}
+
+type ProjectsGetResponse struct {
+	NumPages int       `json:"numPages,omitempty"`
+	Page     int       `json:"page,omitempty"`
+	Projects []Project `json:"projects,omitempty"`
+}
+
+type ProjectsPostRequest struct {
+	Description *string `json:"description,omitempty"`
+	JiraIssueID *string `json:"jiraIssueID,omitempty"`
+	Title       string  `json:"title"`
+}
+
+type ProjectsPostResponse struct {
+	ID int `json:"id"`
+}
+
+type RecentlyViewedDoc struct {
+	ID         string `json:"id,omitempty"`
+	IsDraft    bool   `json:"isDraft,omitempty"`
+	ViewedTime int64  `json:"viewedTime,omitempty"`
+}
+
+type RecentlyViewedProject struct {
+	ID         int   `json:"id,omitempty"`
+	ViewedTime int64 `json:"viewedTime,omitempty"`
+}
+
+type RelatedHermesDocument struct {
+	DocumentNumber string `json:"documentNumber,omitempty"`
+	DocumentType   string `json:"documentType,omitempty"`
+	GoogleFileID   string `json:"googleFileID,omitempty"`
+	SortOrder      int    `json:"sortOrder,omitempty"`
+	Title          string `json:"title,omitempty"`
+}
+
+type RelatedHermesDocumentReference struct {
+	GoogleFileID string `json:"googleFileID"`
+	SortOrder    int    `json:"sortOrder"`
+}
+
+type RelatedResources struct {
+	ExternalLinks   []ExternalLink          `json:"externalLinks,omitempty"`
+	HermesDocuments []RelatedHermesDocument `json:"hermesDocuments,omitempty"`
+}
+
+type RelatedResourcesPutRequest struct {
+	ExternalLinks   []ExternalLink                   `json:"externalLinks,omitempty"`
+	HermesDocuments []RelatedHermesDocumentReference `json:"hermesDocuments,omitempty"`
+}
+
+// RetentionNotice is a notice that a retention policy will apply to a
+// document.
+type RetentionNotice struct {
+	// Action of the retention policy ("archive" or "flag"), or empty if the
+	// policy no longer exists.
+	Action string `json:"action"`
+	// Time the retention policy will apply to the document, in Unix time.
+	ActionTime int64 `json:"actionTime"`
+	// Name of the retention policy.
+	Policy string `json:"policy"`
+}
+
+// SavedSearch is a saved search of published documents.
+type SavedSearch struct {
+	// Whether the user is emailed when a published document starts matching the
+	// saved search.
+	AlertsEnabled bool     `json:"alertsEnabled"`
+	CreatedTime   int64    `json:"createdTime"`
+	DocTypes      []string `json:"docTypes"`
+	ID            int      `json:"id"`
+	ModifiedTime  int64    `json:"modifiedTime"`
+	Name          string   `json:"name"`
+	Owners        []string `json:"owners"`
+	Products      []string `json:"products"`
+	// Search text.
+	Query    string   `json:"query"`
+	Statuses []string `json:"statuses"`
+}
+
+// SearchHit is a search result.
+type SearchHit struct {
+	CreatedTime int64  `json:"createdTime,omitempty"`
+	DocNumber   string `json:"docNumber,omitempty"`
+	DocType     string `json:"docType,omitempty"`
+	// Attribute values that matched the query, with matches wrapped in <mark>
+	// tags.
+	Highlights   map[string]string `json:"highlights,omitempty"`
+	ModifiedTime int64             `json:"modifiedTime,omitempty"`
+	// Google file ID of the document, or ID of the project.
+	ObjectID string   `json:"objectID"`
+	Owners   []string `json:"owners,omitempty"`
+	Product  string   `json:"product,omitempty"`
+	// The section of a document that best matched a search query.
+	Section SearchHitSection `json:"section,omitempty"`
+	// Excerpt of matching document content, with matches wrapped in <mark> tags.
+	Snippet string `json:"snippet,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Title   string `json:"title"`
+	Type    string `json:"type"`
+}
+
+// SearchHitSection is the section of a document that best matched a search
+// query.
+type SearchHitSection struct {
+	// Heading of the section. Empty for content before the first heading.
+	Heading string `json:"heading"`
+	// Link to the section heading in the Google Doc.
+	URL string `json:"url,omitempty"`
+}
+
+// SearchRequest is a search of documents, drafts, and projects.
+type SearchRequest struct {
+	// Filter to results created at or after a Unix time.
+	CreatedAfter *int64 `json:"createdAfter,omitempty"`
+	// Filter to results created at or before a Unix time.
+	CreatedBefore *int64 `json:"createdBefore,omitempty"`
+	// Filter by document type. Projects are not searched if set.
+	DocTypes []string `json:"docTypes,omitempty"`
+	// Results per page (up to 100). Defaults to 20.
+	HitsPerPage *int `json:"hitsPerPage,omitempty"`
+	// Search mode. Hybrid search also returns documents with content that is
+	// semantically similar to the query, and requires sorting by relevance.
+	// Defaults to keyword.
+	Mode *string `json:"mode,omitempty"`
+	// Filter to results modified at or after a Unix time.
+	ModifiedAfter *int64 `json:"modifiedAfter,omitempty"`
+	// Filter to results modified at or before a Unix time.
+	ModifiedBefore *int64 `json:"modifiedBefore,omitempty"`
+	// Filter by owner (or project creator) email address.
+	Owners []string `json:"owners,omitempty"`
+	// Page of results, starting at 0.
+	Page *int `json:"page,omitempty"`
+	// Filter by product. Projects are not searched if set.
+	Products []string `json:"products,omitempty"`
+	// Search text.
+	Query *string `json:"query,omitempty"`
+	// Sort order. Defaults to relevance.
+	SortBy *string `json:"sortBy,omitempty"`
+	// Filter by status.
+	Statuses []string `json:"statuses,omitempty"`
+	// Types of results to search. Defaults to all types.
+	Types []string `json:"types,omitempty"`
+}
+
+// SearchResponse is the results of a search.
+type SearchResponse struct {
+	// Counts of results by facet and value.
+	Facets      map[string]map[string]int `json:"facets"`
+	Hits        []SearchHit               `json:"hits"`
+	HitsPerPage int                       `json:"hitsPerPage"`
+	NbHits      int                       `json:"nbHits"`
+	NbPages     int                       `json:"nbPages"`
+	Page        int                       `json:"page"`
+}
+
+// SearchResults is the results of an Algolia search.
+type SearchResults struct {
+	Facets      map[string]any `json:"facets,omitempty"`
+	Hits        []Document     `json:"hits,omitempty"`
+	HitsPerPage int            `json:"hitsPerPage,omitempty"`
+	NbHits      int            `json:"nbHits,omitempty"`
+	NbPages     int            `json:"nbPages,omitempty"`
+	Page        int            `json:"page,omitempty"`
+}
+
+// ShortLink is a short link that redirects to a document.
+type ShortLink struct {
+	// True if the short link was defined by a user.
+	Alias bool `json:"alias"`
+	// Email address of the user that created an alias.
+	CreatedBy string `json:"createdBy,omitempty"`
+	// Time the short link was created, in Unix time.
+	CreatedTime int64 `json:"createdTime"`
+	// True if the short link is for the document's current number. Short links
+	// for previous numbers keep redirecting to the document.
+	Current bool `json:"current"`
+	// Number of times the short link has been followed.
+	HitCount int64 `json:"hitCount"`
+	// Time the short link was last followed, in Unix time.
+	LastHitTime int64 `json:"lastHitTime,omitempty"`
+	// Path of the short link without the "/l" prefix (e.g., "/rfc/lab-001").
+	Path string `json:"path"`
+	// Full URL of the short link.
+	URL string `json:"url"`
+}
+
+// ShortLinkPostRequest is a request to create an alias short link.
+type ShortLinkPostRequest struct {
+	// Alias of the short link (e.g., "vault-ha" for "/l/vault-ha"). Can only
+	// contain lowercase letters, numbers, and hyphens.
+	Alias string `json:"alias"`
+}
+
+// SimilarDocument is a document that is similar to another document.
+type SimilarDocument struct {
+	DocNumber string   `json:"docNumber"`
+	DocType   string   `json:"docType"`
+	ID        string   `json:"id"`
+	Owners    []string `json:"owners"`
+	Product   string   `json:"product"`
+	// Cosine similarity of the documents' content, between -1 and 1 (most
+	// similar).
+	Score  float64 `json:"score"`
+	Status string  `json:"status"`
+	Title  string  `json:"title"`
+}
+
+// StaleDraft is a draft that hasn't been modified within the stale drafts
+// threshold.
+type StaleDraft struct {
+	DocType string `json:"docType"`
+	ID      string `json:"id"`
+	// Time the draft was last modified, in Unix time.
+	ModifiedTime int    `json:"modifiedTime"`
+	Owner        string `json:"owner"`
+	Product      string `json:"product"`
+	Title        string `json:"title"`
+}
+
+// StaleDraftActionRequest is a request to take an action on a stale draft.
+type StaleDraftActionRequest struct {
+	// Token from the latest email about the stale draft, when the action is
+	// confirmed from a link in the email. Each token can only be used once.
+	Token *string `json:"token,omitempty"`
+}
+
+// StaleDraftsProduct is the stale drafts for a product.
+type StaleDraftsProduct struct {
+	// Stale drafts, least recently modified first.
+	Drafts  []StaleDraft `json:"drafts"`
+	Product string       `json:"product"`
+}
+
+// StaleDraftsResponse is a report of stale drafts per product.
+type StaleDraftsResponse struct {
+	// Products with stale drafts, in alphabetical order.
+	Products []StaleDraftsProduct `json:"products"`
+	// Number of days since a draft was last modified after which it is stale.
+	ThresholdDays int `json:"thresholdDays"`
+	// Total number of stale drafts.
+	Total int `json:"total"`
+}
+
+type Tag struct {
+	// Number of documents and projects with the tag.
+	Count int    `json:"count"`
+	Name  string `json:"name"`
+}
+
+type TagMergeRequest struct {
+	// Name of the tag to merge the tag into. It is created if it doesn't exist.
+	Into string `json:"into"`
+}
+
+type TagPatchRequest struct {
+	// New name of the tag.
+	Name string `json:"name"`
+}
+
+// TrashedDraft is a deleted draft in the trash that can still be restored.
+type TrashedDraft struct {
+	DocType string `json:"docType"`
+	ID      string `json:"id"`
+	Product string `json:"product"`
+	// Time the draft will be permanently deleted, in Unix time.
+	PurgeTime int    `json:"purgeTime"`
+	Title     string `json:"title"`
+	// Time the draft was moved to the trash, in Unix time.
+	TrashedTime int `json:"trashedTime"`
+}
+
+// ActOnStaleDraft calls POST /api/v2/me/stale-drafts/{id}/{action} to take
+// an action on a stale draft (owners only).
+func (c *Client) ActOnStaleDraft(ctx context.Context, id string, action string, body *StaleDraftActionRequest) error {
+	path := fmt.Sprintf("/api/v2/me/stale-drafts/%s/%s", url.PathEscape(id), url.PathEscape(action))
+	var query url.Values
+	return c.do(ctx, http.MethodPost, path, query, body, nil)
+}
+
+// ApproveDocument calls POST /api/v2/approvals/{id} to approve a document.
+func (c *Client) ApproveDocument(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/api/v2/approvals/%s", url.PathEscape(id))
+	var query url.Values
+	return c.do(ctx, http.MethodPost, path, query, nil, nil)
+}
+
+// CreateAPIToken calls POST /api/v2/me/tokens to create an API token.
+func (c *Client) CreateAPIToken(ctx context.Context, body *MeTokensPostRequest) (*MeTokensPostResponse, error) {
+	path := "/api/v2/me/tokens"
+	var query url.Values
+	out := new(MeTokensPostResponse)
+	if err := c.do(ctx, http.MethodPost, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateDocumentShortLink calls POST /api/v2/documents/{id}/short-links to
+// create an alias short link (e.g., "/l/vault-ha") for a document (owners
+// and contributors only).
+func (c *Client) CreateDocumentShortLink(ctx context.Context, id string, body *ShortLinkPostRequest) (*ShortLink, error) {
+	path := fmt.Sprintf("/api/v2/documents/%s/short-links", url.PathEscape(id))
+	var query url.Values
+	out := new(ShortLink)
+	if err := c.do(ctx, http.MethodPost, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateDraft calls POST /api/v2/drafts to create a draft document.
+func (c *Client) CreateDraft(ctx context.Context, body *DraftsRequest) (*DraftsResponse, error) {
+	path := "/api/v2/drafts"
+	var query url.Values
+	out := new(DraftsResponse)
+	if err := c.do(ctx, http.MethodPost, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateProject calls POST /api/v2/projects to create a project.
+func (c *Client) CreateProject(ctx context.Context, body *ProjectsPostRequest) (*ProjectsPostResponse, error) {
+	path := "/api/v2/projects"
+	var query url.Values
+	out := new(ProjectsPostResponse)
+	if err := c.do(ctx, http.MethodPost, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateReview calls POST /api/v2/reviews/{id} to publish a draft document
+// for review.
+func (c *Client) CreateReview(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/api/v2/reviews/%s", url.PathEscape(id))
+	var query url.Values
+	return c.do(ctx, http.MethodPost, path, query, nil, nil)
+}
+
+// CreateSavedSearch calls POST /api/v2/me/saved-searches to create a saved
+// search.
+func (c *Client) CreateSavedSearch(ctx context.Context, body *MeSavedSearchesPostRequest) (*SavedSearch, error) {
+	path := "/api/v2/me/saved-searches"
+	var query url.Values
+	out := new(SavedSearch)
+	if err := c.do(ctx, http.MethodPost, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteDocumentShortLink calls DELETE
+// /api/v2/documents/{id}/short-links/{alias} to delete an alias short link
+// of a document (document owner and alias creator only).
+func (c *Client) DeleteDocumentShortLink(ctx context.Context, id string, alias string) error {
+	path := fmt.Sprintf("/api/v2/documents/%s/short-links/%s", url.PathEscape(id), url.PathEscape(alias))
+	var query url.Values
+	return c.do(ctx, http.MethodDelete, path, query, nil, nil)
+}
+
+// DeleteDraft calls DELETE /api/v2/drafts/{id} to delete a draft document
+// (owners only). If a drafts trash is configured, the draft is moved to the
+// trash and can be restored until it is purged after the retention period.
+func (c *Client) DeleteDraft(ctx context.Context, id string) (*DraftsResponse, error) {
+	path := fmt.Sprintf("/api/v2/drafts/%s", url.PathEscape(id))
+	var query url.Values
+	out := new(DraftsResponse)
+	if err := c.do(ctx, http.MethodDelete, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteSavedSearch calls DELETE /api/v2/me/saved-searches/{id} to delete a
+// saved search.
+func (c *Client) DeleteSavedSearch(ctx context.Context, id int) error {
+	path := fmt.Sprintf("/api/v2/me/saved-searches/%s", fmt.Sprint(id))
+	var query url.Values
+	return c.do(ctx, http.MethodDelete, path, query, nil, nil)
+}
+
+// ExportDocumentParams contains the query parameters of ExportDocument.
+type ExportDocumentParams struct {
+	// Export format (default "md").
+	Format string
+}
+
+// ExportDocument calls GET /api/v2/documents/{id}/export to export a
+// published document with its Hermes metadata.
+func (c *Client) ExportDocument(ctx context.Context, id string, params *ExportDocumentParams) error {
+	path := fmt.Sprintf("/api/v2/documents/%s/export", url.PathEscape(id))
+	var query url.Values
+	if params != nil {
+		query = url.Values{}
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+	}
+	return c.do(ctx, http.MethodGet, path, query, nil, nil)
+}
+
+// ExportDraftParams contains the query parameters of ExportDraft.
+type ExportDraftParams struct {
+	// Export format (default "md").
+	Format string
+}
+
+// ExportDraft calls GET /api/v2/drafts/{id}/export to export a draft
+// document with its Hermes metadata.
+func (c *Client) ExportDraft(ctx context.Context, id string, params *ExportDraftParams) error {
+	path := fmt.Sprintf("/api/v2/drafts/%s/export", url.PathEscape(id))
+	var query url.Values
+	if params != nil {
+		query = url.Values{}
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+	}
+	return c.do(ctx, http.MethodGet, path, query, nil, nil)
+}
+
+// FindDraftPriorArt calls POST /api/v2/drafts/prior-art to find existing
+// documents and projects that are likely related to a draft before it is
+// created (by title similarity and content search), to avoid duplicate
+// documents. API token results are limited to the token's scopes.
+func (c *Client) FindDraftPriorArt(ctx context.Context, body *DraftsPriorArtRequest) (*DraftsPriorArtResponse, error) {
+	path := "/api/v2/drafts/prior-art"
+	var query url.Values
+	out := new(DraftsPriorArtResponse)
+	if err := c.do(ctx, http.MethodPost, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDocument calls GET /api/v2/documents/{id} to get a published document.
+func (c *Client) GetDocument(ctx context.Context, id string) (*Document, error) {
+	path := fmt.Sprintf("/api/v2/documents/%s", url.PathEscape(id))
+	var query url.Values
+	out := new(Document)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDocumentAnalytics calls GET /api/v2/documents/{id}/analytics to get
+// view analytics for a document (owners only).
+func (c *Client) GetDocumentAnalytics(ctx context.Context, id string) (*DocumentAnalytics, error) {
+	path := fmt.Sprintf("/api/v2/documents/%s/analytics", url.PathEscape(id))
+	var query url.Values
+	out := new(DocumentAnalytics)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDocumentRelatedResources calls GET
+// /api/v2/documents/{id}/related-resources to get related resources of a
+// document.
+func (c *Client) GetDocumentRelatedResources(ctx context.Context, id string) (*RelatedResources, error) {
+	path := fmt.Sprintf("/api/v2/documents/%s/related-resources", url.PathEscape(id))
+	var query url.Values
+	out := new(RelatedResources)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDocumentRetention calls GET /api/v2/documents/{id}/retention to get the
+// retention state of a document.
+func (c *Client) GetDocumentRetention(ctx context.Context, id string) (*DocumentRetentionResponse, error) {
+	path := fmt.Sprintf("/api/v2/documents/%s/retention", url.PathEscape(id))
+	var query url.Values
+	out := new(DocumentRetentionResponse)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDraft calls GET /api/v2/drafts/{id} to get a draft document.
+func (c *Client) GetDraft(ctx context.Context, id string) (*Document, error) {
+	path := fmt.Sprintf("/api/v2/drafts/%s", url.PathEscape(id))
+	var query url.Values
+	out := new(Document)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDraftRelatedResources calls GET /api/v2/drafts/{id}/related-resources
+// to get related resources of a draft document.
+func (c *Client) GetDraftRelatedResources(ctx context.Context, id string) (*RelatedResources, error) {
+	path := fmt.Sprintf("/api/v2/drafts/%s/related-resources", url.PathEscape(id))
+	var query url.Values
+	out := new(RelatedResources)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDraftRetention calls GET /api/v2/drafts/{id}/retention to get the
+// retention state of a draft document.
+func (c *Client) GetDraftRetention(ctx context.Context, id string) (*DocumentRetentionResponse, error) {
+	path := fmt.Sprintf("/api/v2/drafts/%s/retention", url.PathEscape(id))
+	var query url.Values
+	out := new(DocumentRetentionResponse)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDraftShareable calls GET /api/v2/drafts/{id}/shareable to get if a
+// draft document is shareable.
+func (c *Client) GetDraftShareable(ctx context.Context, id string) (*DraftShareable, error) {
+	path := fmt.Sprintf("/api/v2/drafts/%s/shareable", url.PathEscape(id))
+	var query url.Values
+	out := new(DraftShareable)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetJiraIssue calls GET /api/v2/jira/issues/{key} to get a Jira issue.
+func (c *Client) GetJiraIssue(ctx context.Context, key string) (*JiraIssue, error) {
+	path := fmt.Sprintf("/api/v2/jira/issues/%s", url.PathEscape(key))
+	var query url.Values
+	out := new(JiraIssue)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMe calls GET /api/v2/me to get the authenticated user.
+func (c *Client) GetMe(ctx context.Context) (*Me, error) {
+	path := "/api/v2/me"
+	var query url.Values
+	out := new(Me)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetPeopleParams contains the query parameters of GetPeople.
+type GetPeopleParams struct {
+	// Comma-separated email addresses.
+	Emails string
+}
+
+// GetPeople calls GET /api/v2/people to get people by email address.
+func (c *Client) GetPeople(ctx context.Context, params *GetPeopleParams) ([]Person, error) {
+	path := "/api/v2/people"
+	var query url.Values
+	if params != nil {
+		query = url.Values{}
+		query.Set("emails", params.Emails)
+	}
+	var out []Person
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetProject calls GET /api/v2/projects/{id} to get a project.
+func (c *Client) GetProject(ctx context.Context, id int) (*Project, error) {
+	path := fmt.Sprintf("/api/v2/projects/%s", fmt.Sprint(id))
+	var query url.Values
+	out := new(Project)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetProjectRelatedResources calls GET
+// /api/v2/projects/{id}/related-resources to get related resources of a
+// project.
+func (c *Client) GetProjectRelatedResources(ctx context.Context, id int) (*ProjectRelatedResources, error) {
+	path := fmt.Sprintf("/api/v2/projects/%s/related-resources", fmt.Sprint(id))
+	var query url.Values
+	out := new(ProjectRelatedResources)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSavedSearch calls GET /api/v2/me/saved-searches/{id} to get a saved
+// search.
+func (c *Client) GetSavedSearch(ctx context.Context, id int) (*SavedSearch, error) {
+	path := fmt.Sprintf("/api/v2/me/saved-searches/%s", fmt.Sprint(id))
+	var query url.Values
+	out := new(SavedSearch)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetStaleDraftsReport calls GET /api/v2/stale-drafts to get a report of
+// stale drafts per product (admins only).
+func (c *Client) GetStaleDraftsReport(ctx context.Context) (*StaleDraftsResponse, error) {
+	path := "/api/v2/stale-drafts"
+	var query url.Values
+	out := new(StaleDraftsResponse)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAPITokens calls GET /api/v2/me/tokens to list API tokens created by
+// the user.
+func (c *Client) ListAPITokens(ctx context.Context) ([]APIToken, error) {
+	path := "/api/v2/me/tokens"
+	var query url.Values
+	var out []APIToken
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListDocumentShortLinks calls GET /api/v2/documents/{id}/short-links to
+// list short links that redirect to a document, including links for its
+// previous numbers and user-defined aliases.
+func (c *Client) ListDocumentShortLinks(ctx context.Context, id string) ([]ShortLink, error) {
+	path := fmt.Sprintf("/api/v2/documents/%s/short-links", url.PathEscape(id))
+	var query url.Values
+	var out []ShortLink
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListDocumentTypes calls GET /api/v2/document-types to list document types.
+func (c *Client) ListDocumentTypes(ctx context.Context) ([]DocumentType, error) {
+	path := "/api/v2/document-types"
+	var query url.Values
+	var out []DocumentType
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListDraftsParams contains the query parameters of ListDrafts.
+type ListDraftsParams struct {
+	// Comma-separated facet filters (e.g., "docType:RFC").
+	FacetFilters string
+	// Comma-separated facets to return.
+	Facets string
+	// Number of results per page.
+	HitsPerPage int
+	// Maximum number of values per facet.
+	MaxValuesPerFacet int
+	// Page number (starting at 0).
+	Page int
+	// Sort order by created time.
+	SortBy string
+}
+
+// ListDrafts calls GET /api/v2/drafts to list the user's draft documents.
+func (c *Client) ListDrafts(ctx context.Context, params *ListDraftsParams) (*SearchResults, error) {
+	path := "/api/v2/drafts"
+	var query url.Values
+	if params != nil {
+		query = url.Values{}
+		if params.FacetFilters != "" {
+			query.Set("facetFilters", params.FacetFilters)
+		}
+		if params.Facets != "" {
+			query.Set("facets", params.Facets)
+		}
+		query.Set("hitsPerPage", strconv.Itoa(params.HitsPerPage))
+		query.Set("maxValuesPerFacet", strconv.Itoa(params.MaxValuesPerFacet))
+		query.Set("page", strconv.Itoa(params.Page))
+		if params.SortBy != "" {
+			query.Set("sortBy", params.SortBy)
+		}
+	}
+	out := new(SearchResults)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMostViewedDocsParams contains the query parameters of
+// ListMostViewedDocs.
+type ListMostViewedDocsParams struct {
+	// Maximum number of documents to return (default 10).
+	Limit int
+}
+
+// ListMostViewedDocs calls GET /api/v2/most-viewed-docs to list the most
+// viewed published documents of the last week.
+func (c *Client) ListMostViewedDocs(ctx context.Context, params *ListMostViewedDocsParams) ([]MostViewedDoc, error) {
+	path := "/api/v2/most-viewed-docs"
+	var query url.Values
+	if params != nil {
+		query = url.Values{}
+		if params.Limit != 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	var out []MostViewedDoc
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListProducts calls GET /api/v2/products to list products.
+func (c *Client) ListProducts(ctx context.Context) (map[string]ProductData, error) {
+	path := "/api/v2/products"
+	var query url.Values
+	var out map[string]ProductData
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListProjectsParams contains the query parameters of ListProjects.
+type ListProjectsParams struct {
+	// Number of results per page.
+	HitsPerPage int
+	// Page number (starting at 1).
+	Page int
+	// Filter by status.
+	Status string
+	// Filter by title.
+	Title string
+}
+
+// ListProjects calls GET /api/v2/projects to list projects.
+func (c *Client) ListProjects(ctx context.Context, params *ListProjectsParams) (*ProjectsGetResponse, error) {
+	path := "/api/v2/projects"
+	var query url.Values
+	if params != nil {
+		query = url.Values{}
+		if params.HitsPerPage != 0 {
+			query.Set("hitsPerPage", strconv.Itoa(params.HitsPerPage))
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.Title != "" {
+			query.Set("title", params.Title)
+		}
+	}
+	out := new(ProjectsGetResponse)
+	if err := c.do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListRecentlyViewedDocs calls GET /api/v2/me/recently-viewed-docs to list
+// the user's recently viewed documents.
+func (c *Client) ListRecentlyViewedDocs(ctx context.Context) ([]RecentlyViewedDoc, error) {
+	path := "/api/v2/me/recently-viewed-docs"
+	var query url.Values
+	var out []RecentlyViewedDoc
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListRecentlyViewedProjects calls GET /api/v2/me/recently-viewed-projects
+// to list the user's recently viewed projects.
+func (c *Client) ListRecentlyViewedProjects(ctx context.Context) ([]RecentlyViewedProject, error) {
+	path := "/api/v2/me/recently-viewed-projects"
+	var query url.Values
+	var out []RecentlyViewedProject
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSavedSearches calls GET /api/v2/me/saved-searches to list the user's
+// saved searches.
+func (c *Client) ListSavedSearches(ctx context.Context) ([]SavedSearch, error) {
+	path := "/api/v2/me/saved-searches"
+	var query url.Values
+	var out []SavedSearch
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSimilarDocumentsParams contains the query parameters of
+// ListSimilarDocuments.
+type ListSimilarDocumentsParams struct {
+	// Maximum number of documents to return (default 10).
+	Limit int
+}
+
+// ListSimilarDocuments calls GET /api/v2/documents/{id}/similar to list
+// published documents with content that is semantically similar to a
+// document. Requires embeddings to be enabled.
+func (c *Client) ListSimilarDocuments(ctx context.Context, id string, params *ListSimilarDocumentsParams) ([]SimilarDocument, error) {
+	path := fmt.Sprintf("/api/v2/documents/%s/similar", url.PathEscape(id))
+	var query url.Values
+	if params != nil {
+		query = url.Values{}
+		if params.Limit != 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	var out []SimilarDocument
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListStaleDrafts calls GET /api/v2/me/stale-drafts to list the user's stale
+// drafts, least recently modified first.
+func (c *Client) ListStaleDrafts(ctx context.Context) ([]StaleDraft, error) {
+	path := "/api/v2/me/stale-drafts"
+	var query url.Values
+	var out []StaleDraft
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSubscriptions calls GET /api/v2/me/subscriptions to list the user's
+// product subscriptions.
+func (c *Client) ListSubscriptions(ctx context.Context) ([]string, error) {
+	path := "/api/v2/me/subscriptions"
+	var query url.Values
+	var out []string
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListTagSubscriptions calls GET /api/v2/me/tag-subscriptions to list the
+// user's tag subscriptions.
+func (c *Client) ListTagSubscriptions(ctx context.Context) ([]string, error) {
+	path := "/api/v2/me/tag-subscriptions"
+	var query url.Values
+	var out []string
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListTagsParams contains the query parameters of ListTags.
+type ListTagsParams struct {
+	// Prefix of tag names to return.
+	Q string
+	// Maximum number of tags to return. Defaults to 10.
+	Limit int
+}
+
+// ListTags calls GET /api/v2/tags to list tags for autocomplete, ordered by
+// the number of documents and projects with them.
+func (c *Client) ListTags(ctx context.Context, params *ListTagsParams) ([]Tag, error) {
+	path := "/api/v2/tags"
+	var query url.Values
+	if params != nil {
+		query = url.Values{}
+		if params.Q != "" {
+			query.Set("q", params.Q)
+		}
+		if params.Limit != 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	var out []Tag
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListTrashedDrafts calls GET /api/v2/drafts/trash to list the user's
+// deleted drafts that can still be restored, most recently deleted first.
+func (c *Client) ListTrashedDrafts(ctx context.Context) ([]TrashedDraft, error) {
+	path := "/api/v2/drafts/trash"
+	var query url.Values
+	var out []TrashedDraft
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MergeTag calls POST /api/v2/tags/{name}/merge to merge a tag into another
+// tag, moving its documents, projects, and subscribers. Only admins can
+// merge tags.
+func (c *Client) MergeTag(ctx context.Context, name string, body *TagMergeRequest) error {
+	path := fmt.Sprintf("/api/v2/tags/%s/merge", url.PathEscape(name))
+	var query url.Values
+	return c.do(ctx, http.MethodPost, path, query, body, nil)
+}
+
+// MoveDocument calls POST /api/v2/documents/{id}/move to move a published
+// document to another product (owners and admins only). The document is
+// assigned a new number in the product, and its previous number keeps
+// redirecting to it.
+func (c *Client) MoveDocument(ctx context.Context, id string, body *DocumentMovePostRequest) (*DocumentMoveResponse, error) {
+	path := fmt.Sprintf("/api/v2/documents/%s/move", url.PathEscape(id))
+	var query url.Values
+	out := new(DocumentMoveResponse)
+	if err := c.do(ctx, http.MethodPost, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PatchDocument calls PATCH /api/v2/documents/{id} to update a published
+// document.
+func (c *Client) PatchDocument(ctx context.Context, id string, body *DocumentPatchRequest) error {
+	path := fmt.Sprintf("/api/v2/documents/%s", url.PathEscape(id))
+	var query url.Values
+	return c.do(ctx, http.MethodPatch, path, query, body, nil)
+}
+
+// PatchDraft calls PATCH /api/v2/drafts/{id} to update a draft document.
+func (c *Client) PatchDraft(ctx context.Context, id string, body *DraftsPatchRequest) error {
+	path := fmt.Sprintf("/api/v2/drafts/%s", url.PathEscape(id))
+	var query url.Values
+	return c.do(ctx, http.MethodPatch, path, query, body, nil)
+}
+
+// PatchProject calls PATCH /api/v2/projects/{id} to update a project.
+func (c *Client) PatchProject(ctx context.Context, id int, body *ProjectPatchRequest) error {
+	path := fmt.Sprintf("/api/v2/projects/%s", fmt.Sprint(id))
+	var query url.Values
+	return c.do(ctx, http.MethodPatch, path, query, body, nil)
+}
+
+// PutDocumentRelatedResources calls PUT
+// /api/v2/documents/{id}/related-resources to replace related resources of a
+// document.
+func (c *Client) PutDocumentRelatedResources(ctx context.Context, id string, body *RelatedResourcesPutRequest) error {
+	path := fmt.Sprintf("/api/v2/documents/%s/related-resources", url.PathEscape(id))
+	var query url.Values
+	return c.do(ctx, http.MethodPut, path, query, body, nil)
+}
+
+// PutDocumentRetention calls PUT /api/v2/documents/{id}/retention to opt a
+// document in or out of retention policies (owners only).
+func (c *Client) PutDocumentRetention(ctx context.Context, id string, body *DocumentRetentionPutRequest) (*DocumentRetentionResponse, error) {
+	path := fmt.Sprintf("/api/v2/documents/%s/retention", url.PathEscape(id))
+	var query url.Values
+	out := new(DocumentRetentionResponse)
+	if err := c.do(ctx, http.MethodPut, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PutDraftRelatedResources calls PUT /api/v2/drafts/{id}/related-resources
+// to replace related resources of a draft document.
+func (c *Client) PutDraftRelatedResources(ctx context.Context, id string, body *RelatedResourcesPutRequest) error {
+	path := fmt.Sprintf("/api/v2/drafts/%s/related-resources", url.PathEscape(id))
+	var query url.Values
+	return c.do(ctx, http.MethodPut, path, query, body, nil)
+}
+
+// PutDraftRetention calls PUT /api/v2/drafts/{id}/retention to opt a draft
+// document in or out of retention policies (owners only).
+func (c *Client) PutDraftRetention(ctx context.Context, id string, body *DocumentRetentionPutRequest) (*DocumentRetentionResponse, error) {
+	path := fmt.Sprintf("/api/v2/drafts/%s/retention", url.PathEscape(id))
+	var query url.Values
+	out := new(DocumentRetentionResponse)
+	if err := c.do(ctx, http.MethodPut, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PutDraftShareable calls PUT /api/v2/drafts/{id}/shareable to set if a
+// draft document is shareable.
+func (c *Client) PutDraftShareable(ctx context.Context, id string, body *DraftShareable) error {
+	path := fmt.Sprintf("/api/v2/drafts/%s/shareable", url.PathEscape(id))
+	var query url.Values
+	return c.do(ctx, http.MethodPut, path, query, body, nil)
+}
+
+// PutProjectRelatedResources calls PUT
+// /api/v2/projects/{id}/related-resources to replace related resources of a
+// project.
+func (c *Client) PutProjectRelatedResources(ctx context.Context, id int, body *RelatedResourcesPutRequest) error {
+	path := fmt.Sprintf("/api/v2/projects/%s/related-resources", fmt.Sprint(id))
+	var query url.Values
+	return c.do(ctx, http.MethodPut, path, query, body, nil)
+}
+
+// RecordDocumentView calls POST /api/v2/web/analytics to record a document
+// view.
+func (c *Client) RecordDocumentView(ctx context.Context, body *AnalyticsRequest) (*AnalyticsResponse, error) {
+	path := "/api/v2/web/analytics"
+	var query url.Values
+	out := new(AnalyticsResponse)
+	if err := c.do(ctx, http.MethodPost, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RenameTag calls PATCH /api/v2/tags/{name} to rename a tag. Only admins can
+// rename tags.
+func (c *Client) RenameTag(ctx context.Context, name string, body *TagPatchRequest) error {
+	path := fmt.Sprintf("/api/v2/tags/%s", url.PathEscape(name))
+	var query url.Values
+	return c.do(ctx, http.MethodPatch, path, query, body, nil)
+}
+
+// RequestDocumentChanges calls DELETE /api/v2/approvals/{id} to request
+// changes of a document.
+func (c *Client) RequestDocumentChanges(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/api/v2/approvals/%s", url.PathEscape(id))
+	var query url.Values
+	return c.do(ctx, http.MethodDelete, path, query, nil, nil)
+}
+
+// RestoreDraft calls POST /api/v2/drafts/{id}/restore to restore a deleted
+// draft document from the trash (owners only).
+func (c *Client) RestoreDraft(ctx context.Context, id string) (*DraftsResponse, error) {
+	path := fmt.Sprintf("/api/v2/drafts/%s/restore", url.PathEscape(id))
+	var query url.Values
+	out := new(DraftsResponse)
+	if err := c.do(ctx, http.MethodPost, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RevokeAPIToken calls DELETE /api/v2/me/tokens/{id} to revoke an API token.
+func (c *Client) RevokeAPIToken(ctx context.Context, id int) error {
+	path := fmt.Sprintf("/api/v2/me/tokens/%s", fmt.Sprint(id))
+	var query url.Values
+	return c.do(ctx, http.MethodDelete, path, query, nil, nil)
+}
+
+// Search calls POST /api/v2/search to search documents, drafts, and
+// projects. Drafts are only returned to their owners and contributors
+// (drafts shared with a link aren't returned to other users), and API token
+// results are limited to the token's scopes.
+func (c *Client) Search(ctx context.Context, body *SearchRequest) (*SearchResponse, error) {
+	path := "/api/v2/search"
+	var query url.Values
+	out := new(SearchResponse)
+	if err := c.do(ctx, http.MethodPost, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SearchGroups calls POST /api/v2/groups to search Google groups.
+func (c *Client) SearchGroups(ctx context.Context, body *GroupsPostRequest) ([]Group, error) {
+	path := "/api/v2/groups"
+	var query url.Values
+	var out []Group
+	if err := c.do(ctx, http.MethodPost, path, query, body, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SearchJiraIssuesParams contains the query parameters of SearchJiraIssues.
+type SearchJiraIssuesParams struct {
+	// Search query.
+	Query string
+}
+
+// SearchJiraIssues calls GET /api/v2/jira/issue/picker to search Jira
+// issues.
+func (c *Client) SearchJiraIssues(ctx context.Context, params *SearchJiraIssuesParams) ([]JiraIssuePickerIssue, error) {
+	path := "/api/v2/jira/issue/picker"
+	var query url.Values
+	if params != nil {
+		query = url.Values{}
+		query.Set("query", params.Query)
+	}
+	var out []JiraIssuePickerIssue
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SearchPeople calls POST /api/v2/people to search people.
+func (c *Client) SearchPeople(ctx context.Context, body *PeopleDataRequest) ([]Person, error) {
+	path := "/api/v2/people"
+	var query url.Values
+	var out []Person
+	if err := c.do(ctx, http.MethodPost, path, query, body, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SetSubscriptions calls POST /api/v2/me/subscriptions to replace the user's
+// product subscriptions.
+func (c *Client) SetSubscriptions(ctx context.Context, body *MeSubscriptionsPostRequest) error {
+	path := "/api/v2/me/subscriptions"
+	var query url.Values
+	return c.do(ctx, http.MethodPost, path, query, body, nil)
+}
+
+// SetTagSubscriptions calls POST /api/v2/me/tag-subscriptions to replace the
+// user's tag subscriptions. Subscribers are emailed when documents with the
+// tags are published.
+func (c *Client) SetTagSubscriptions(ctx context.Context, body *MeTagSubscriptionsPostRequest) error {
+	path := "/api/v2/me/tag-subscriptions"
+	var query url.Values
+	return c.do(ctx, http.MethodPost, path, query, body, nil)
+}
+
+// UpdateSavedSearch calls PATCH /api/v2/me/saved-searches/{id} to update a
+// saved search.
+func (c *Client) UpdateSavedSearch(ctx context.Context, id int, body *MeSavedSearchPatchRequest) (*SavedSearch, error) {
+	path := fmt.Sprintf("/api/v2/me/saved-searches/%s", fmt.Sprint(id))
+	var query url.Values
+	out := new(SavedSearch)
+	if err := c.do(ctx, http.MethodPatch, path, query, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,174 @@
+// Package client is a Go client for the Hermes v2 API.
+//
+// Types and methods for API operations are generated from the OpenAPI
+// specification of the API (internal/api/v2/openapi.json) into client.gen.go.
+package client
+
+//go:generate go run gen.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client is a client for the Hermes v2 API.
+type Client struct {
+	baseURL     *url.URL
+	httpClient  *http.Client
+	accessToken string
+	apiToken    string
+	userAgent   string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to make requests. By default,
+// http.DefaultClient is used.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithGoogleAccessToken sets a Google OAuth access token that is sent with
+// every request to authenticate the user.
+func WithGoogleAccessToken(token string) Option {
+	return func(c *Client) {
+		c.accessToken = token
+	}
+}
+
+// WithAPIToken sets a Hermes-issued API token (personal access token or
+// service account token) that is sent with every request to authenticate.
+func WithAPIToken(token string) Option {
+	return func(c *Client) {
+		c.apiToken = token
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// New returns a new client for the Hermes server at baseURL (e.g.,
+// "https://hermes.example.com").
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("base URL must be absolute: %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		userAgent:  "hermes-go-client",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// APIError is an error returned by the Hermes API.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Response is the decoded error response. It is nil if the response body
+	// was not a valid error response (e.g., for errors returned by a proxy).
+	Response *ErrorResponse
+
+	// Body is the raw response body.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Response != nil {
+		msg := fmt.Sprintf("hermes: %d %s: %s",
+			e.StatusCode, e.Response.Code, e.Response.Message)
+		if e.Response.RequestID != "" {
+			msg += fmt.Sprintf(" (request ID: %s)", e.Response.RequestID)
+		}
+		return msg
+	}
+	return fmt.Sprintf("hermes: %d %s",
+		e.StatusCode, strings.TrimSpace(string(e.Body)))
+}
+
+// do makes a request to the API. If body is not nil, it is encoded as the JSON
+// request body. If out is not nil, the JSON response body is decoded into it.
+func (c *Client) do(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	body, out any,
+) error {
+	u := *c.baseURL
+	u.Path = c.baseURL.Path + path
+	u.RawQuery = query.Encode()
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error encoding request body: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	}
+	if c.accessToken != "" {
+		req.Header.Set("Hermes-Google-Access-Token", c.accessToken)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		apiErr.Body, _ = io.ReadAll(resp.Body)
+		var errResp ErrorResponse
+		if err := json.Unmarshal(apiErr.Body, &errResp); err == nil &&
+			errResp.Code != "" {
+			apiErr.Response = &errResp
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response body: %w", err)
+	}
+	return nil
+}
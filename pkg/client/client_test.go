@@ -0,0 +1,177 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/client/internal/codegen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedClientIsUpToDate(t *testing.T) {
+	require := require.New(t)
+
+	spec, err := os.ReadFile("../../internal/api/v2/openapi.json")
+	require.NoError(err)
+	want, err := codegen.Generate(spec, "client")
+	require.NoError(err)
+	got, err := os.ReadFile("client.gen.go")
+	require.NoError(err)
+
+	require.True(bytes.Equal(want, got),
+		"client.gen.go is out of date, run \"go generate ./pkg/client\"")
+}
+
+func TestClient(t *testing.T) {
+	cases := map[string]struct {
+		handler func(t *testing.T, w http.ResponseWriter, r *http.Request)
+		call    func(c *Client) (any, error)
+
+		want        any
+		wantErr     bool
+		wantAPIErr  *APIError
+		wantErrText string
+	}{
+		"get with path parameter": {
+			handler: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/v2/projects/7", r.URL.Path)
+				w.Write([]byte(`{"id":7,"title":"Test","status":"active"}`))
+			},
+			call: func(c *Client) (any, error) {
+				return c.GetProject(context.Background(), 7)
+			},
+			want: &Project{ID: 7, Title: "Test", Status: "active"},
+		},
+		"get with query parameters": {
+			handler: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v2/drafts", r.URL.Path)
+				assert.Equal(t,
+					"hitsPerPage=12&maxValuesPerFacet=1&page=0&sortBy=dateDesc",
+					r.URL.RawQuery)
+				w.Write([]byte(`{"hits":[{"objectID":"abc","title":"Draft"}]}`))
+			},
+			call: func(c *Client) (any, error) {
+				return c.ListDrafts(context.Background(), &ListDraftsParams{
+					HitsPerPage:       12,
+					MaxValuesPerFacet: 1,
+					SortBy:            "dateDesc",
+				})
+			},
+			want: &SearchResults{
+				Hits: []Document{{ObjectID: "abc", Title: "Draft"}},
+			},
+		},
+		"post with body": {
+			handler: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				var req ProjectsPostRequest
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "Test", req.Title)
+				assert.Nil(t, req.Description)
+				w.Write([]byte(`{"id":1}`))
+			},
+			call: func(c *Client) (any, error) {
+				return c.CreateProject(context.Background(),
+					&ProjectsPostRequest{Title: "Test"})
+			},
+			want: &ProjectsPostResponse{ID: 1},
+		},
+		"no response body": {
+			handler: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPatch, r.Method)
+				assert.Equal(t, "/api/v2/drafts/abc", r.URL.Path)
+				w.WriteHeader(http.StatusOK)
+			},
+			call: func(c *Client) (any, error) {
+				title := "New title"
+				return nil, c.PatchDraft(context.Background(), "abc",
+					&DraftsPatchRequest{Title: &title})
+			},
+		},
+		"error response": {
+			handler: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code":"document_not_found",` +
+					`"message":"Document not found","request_id":"abc123"}`))
+			},
+			call: func(c *Client) (any, error) {
+				return c.GetDocument(context.Background(), "abc")
+			},
+			wantErr: true,
+			wantAPIErr: &APIError{
+				StatusCode: http.StatusNotFound,
+				Response: &ErrorResponse{
+					Code:      "document_not_found",
+					Message:   "Document not found",
+					RequestID: "abc123",
+				},
+			},
+			wantErrText: "hermes: 404 document_not_found: Document not found " +
+				"(request ID: abc123)",
+		},
+		"non-JSON error response": {
+			handler: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+				w.Write([]byte("Bad Gateway\n"))
+			},
+			call: func(c *Client) (any, error) {
+				return c.GetMe(context.Background())
+			},
+			wantErr: true,
+			wantAPIErr: &APIError{
+				StatusCode: http.StatusBadGateway,
+			},
+			wantErrText: "hermes: 502 Bad Gateway",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			ts := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal("token", r.Header.Get("Hermes-Google-Access-Token"))
+					c.handler(t, w, r)
+				}))
+			defer ts.Close()
+
+			cl, err := New(ts.URL+"/", WithGoogleAccessToken("token"))
+			require.NoError(err)
+
+			got, err := c.call(cl)
+			if c.wantErr {
+				require.Error(err)
+				var apiErr *APIError
+				require.True(errors.As(err, &apiErr))
+				assert.Equal(c.wantAPIErr.StatusCode, apiErr.StatusCode)
+				assert.Equal(c.wantAPIErr.Response, apiErr.Response)
+				assert.Equal(c.wantErrText, err.Error())
+				return
+			}
+			require.NoError(err)
+			if c.want != nil {
+				assert.Equal(c.want, got)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("hermes.example.com")
+	assert.Error(t, err)
+
+	c, err := New("https://hermes.example.com/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://hermes.example.com", c.baseURL.String())
+}
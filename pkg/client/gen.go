@@ -0,0 +1,28 @@
+//go:build ignore
+
+// This program generates client.gen.go from the OpenAPI specification of the
+// v2 API. Run it with "go generate ./pkg/client".
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/hashicorp-forge/hermes/pkg/client/internal/codegen"
+)
+
+func main() {
+	spec, err := os.ReadFile("../../internal/api/v2/openapi.json")
+	if err != nil {
+		log.Fatalf("error reading spec: %v", err)
+	}
+
+	src, err := codegen.Generate(spec, "client")
+	if err != nil {
+		log.Fatalf("error generating client: %v", err)
+	}
+
+	if err := os.WriteFile("client.gen.go", src, 0644); err != nil {
+		log.Fatalf("error writing client: %v", err)
+	}
+}
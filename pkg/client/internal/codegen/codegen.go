@@ -0,0 +1,562 @@
+// Package codegen generates the Hermes API client from the OpenAPI
+// specification of the v2 API.
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Generate generates Go source code for package pkg from an OpenAPI
+// specification. The generated code contains a type for each schema in the
+// specification and a Client method for each operation.
+func Generate(spec []byte, pkg string) ([]byte, error) {
+	var s Spec
+	if err := json.Unmarshal(spec, &s); err != nil {
+		return nil, fmt.Errorf("error parsing spec: %w", err)
+	}
+
+	g := &generator{
+		spec:    &s,
+		imports: map[string]bool{},
+	}
+	if err := g.genSchemas(); err != nil {
+		return nil, err
+	}
+	if err := g.genOperations(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by gen.go from the OpenAPI specification. " +
+		"DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		out.WriteString("import (\n")
+		for _, imp := range sortedKeys(g.imports) {
+			fmt.Fprintf(&out, "%q\n", imp)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w\n%s",
+			err, out.Bytes())
+	}
+	return src, nil
+}
+
+// Spec is the subset of an OpenAPI specification that is used to generate the
+// client.
+type Spec struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Parameters map[string]*Parameter `json:"parameters"`
+		Schemas    map[string]*Schema    `json:"schemas"`
+	} `json:"components"`
+}
+
+// Schema is an OpenAPI schema object.
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *Schema            `json:"items"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+
+	// GoName overrides the name of the generated struct field.
+	GoName string `json:"x-go-name"`
+}
+
+// Parameter is an OpenAPI parameter object.
+type Parameter struct {
+	Ref         string  `json:"$ref"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// Operation is an OpenAPI operation object.
+type Operation struct {
+	OperationID string       `json:"operationId"`
+	Summary     string       `json:"summary"`
+	Parameters  []*Parameter `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema *Schema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema *Schema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+type generator struct {
+	spec    *Spec
+	buf     bytes.Buffer
+	imports map[string]bool
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) genSchemas() error {
+	for _, name := range sortedKeys(g.spec.Components.Schemas) {
+		s := g.spec.Components.Schemas[name]
+		if s.Description != "" {
+			writeComment(&g.buf, name,
+				"is "+strings.ToLower(s.Description[:1])+s.Description[1:])
+		}
+
+		if s.Type != "object" || len(s.Properties) == 0 {
+			t, err := g.goType(s)
+			if err != nil {
+				return fmt.Errorf("schema %q: %w", name, err)
+			}
+			g.printf("type %s %s\n\n", name, t)
+			continue
+		}
+
+		required := map[string]bool{}
+		for _, r := range s.Required {
+			required[r] = true
+		}
+		// Optional scalar fields of request types are pointers so that zero
+		// values can be sent.
+		isRequest := strings.HasSuffix(name, "Request")
+
+		g.printf("type %s struct {\n", name)
+		for _, prop := range sortedKeys(s.Properties) {
+			ps := g.resolve(s.Properties[prop])
+			t, err := g.goType(s.Properties[prop])
+			if err != nil {
+				return fmt.Errorf("schema %q, property %q: %w", name, prop, err)
+			}
+			tag := prop
+			if !required[prop] {
+				tag += ",omitempty"
+				if isRequest && isScalar(ps) {
+					t = "*" + t
+				}
+			}
+			fieldName := s.Properties[prop].GoName
+			if fieldName == "" {
+				fieldName = goName(prop)
+			}
+			if ps.Description != "" {
+				writeComment(&g.buf, "", ps.Description)
+			}
+			g.printf("%s %s `json:%q`\n", fieldName, t, tag)
+		}
+		g.printf("}\n\n")
+	}
+	return nil
+}
+
+type operation struct {
+	method, path string
+	params       []*Parameter
+	*Operation
+}
+
+func (g *generator) genOperations() error {
+	var ops []operation
+	for path, item := range g.spec.Paths {
+		var pathParams []*Parameter
+		if raw, ok := item["parameters"]; ok {
+			if err := json.Unmarshal(raw, &pathParams); err != nil {
+				return fmt.Errorf("path %q: error parsing parameters: %w", path, err)
+			}
+		}
+		for method, raw := range item {
+			if method == "parameters" {
+				continue
+			}
+			op := &Operation{}
+			if err := json.Unmarshal(raw, op); err != nil {
+				return fmt.Errorf("%s %s: error parsing operation: %w",
+					method, path, err)
+			}
+			if op.OperationID == "" {
+				return fmt.Errorf("%s %s: missing operation ID", method, path)
+			}
+			var params []*Parameter
+			for _, p := range append(append([]*Parameter{}, pathParams...),
+				op.Parameters...) {
+				params = append(params, g.resolveParam(p))
+			}
+			ops = append(ops, operation{
+				method:    strings.ToUpper(method),
+				path:      path,
+				params:    params,
+				Operation: op,
+			})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		return ops[i].OperationID < ops[j].OperationID
+	})
+
+	for _, op := range ops {
+		if err := g.genOperation(op); err != nil {
+			return fmt.Errorf("operation %q: %w", op.OperationID, err)
+		}
+	}
+	return nil
+}
+
+func (g *generator) genOperation(op operation) error {
+	name := goName(op.OperationID)
+
+	var pathParams, queryParams []*Parameter
+	for _, p := range op.params {
+		switch p.In {
+		case "path":
+			pathParams = append(pathParams, p)
+		case "query":
+			queryParams = append(queryParams, p)
+		default:
+			return fmt.Errorf("unsupported parameter location %q", p.In)
+		}
+	}
+
+	// Query parameters type.
+	if len(queryParams) > 0 {
+		writeComment(&g.buf, name+"Params",
+			"contains the query parameters of "+name+".")
+		g.printf("type %sParams struct {\n", name)
+		for _, p := range queryParams {
+			t, err := g.goType(p.Schema)
+			if err != nil {
+				return err
+			}
+			if p.Description != "" {
+				writeComment(&g.buf, "", p.Description)
+			}
+			g.printf("%s %s\n", goName(p.Name), t)
+		}
+		g.printf("}\n\n")
+	}
+
+	// Method signature.
+	args := []string{"ctx context.Context"}
+	for _, p := range pathParams {
+		t, err := g.goType(p.Schema)
+		if err != nil {
+			return err
+		}
+		args = append(args, fmt.Sprintf("%s %s", lowerFirst(goName(p.Name)), t))
+	}
+	if len(queryParams) > 0 {
+		args = append(args, fmt.Sprintf("params *%sParams", name))
+	}
+	if op.RequestBody != nil {
+		body, ok := op.RequestBody.Content["application/json"]
+		if !ok {
+			return fmt.Errorf("unsupported request body content type")
+		}
+		t, err := g.goType(body.Schema)
+		if err != nil {
+			return err
+		}
+		args = append(args, "body *"+t)
+	}
+
+	var result string
+	if resp, ok := op.Responses["200"]; ok {
+		if c, ok := resp.Content["application/json"]; ok {
+			t, err := g.goType(c.Schema)
+			if err != nil {
+				return err
+			}
+			if c.Schema.Ref != "" {
+				t = "*" + t
+			}
+			result = t
+		}
+	}
+
+	summary := strings.TrimSuffix(op.Summary, ".")
+	if summary != "" {
+		summary = strings.ToLower(summary[:1]) + summary[1:]
+	}
+	g.imports["context"] = true
+	g.imports["net/http"] = true
+	g.imports["net/url"] = true
+	writeComment(&g.buf, name,
+		fmt.Sprintf("calls %s %s to %s.", op.method, op.path, summary))
+	if result != "" {
+		g.printf("func (c *Client) %s(%s) (%s, error) {\n",
+			name, strings.Join(args, ", "), result)
+	} else {
+		g.printf("func (c *Client) %s(%s) error {\n",
+			name, strings.Join(args, ", "))
+	}
+
+	// Path.
+	path := op.path
+	var pathArgs []string
+	for _, p := range pathParams {
+		path = strings.Replace(path, "{"+p.Name+"}", "%s", 1)
+		arg := lowerFirst(goName(p.Name))
+		if g.resolve(p.Schema).Type != "string" {
+			arg = fmt.Sprintf("fmt.Sprint(%s)", arg)
+		} else {
+			arg = fmt.Sprintf("url.PathEscape(%s)", arg)
+		}
+		pathArgs = append(pathArgs, arg)
+	}
+	if len(pathArgs) > 0 {
+		g.imports["fmt"] = true
+		g.printf("path := fmt.Sprintf(%q, %s)\n", path, strings.Join(pathArgs, ", "))
+	} else {
+		g.printf("path := %q\n", path)
+	}
+
+	// Query.
+	g.printf("var query url.Values\n")
+	if len(queryParams) > 0 {
+		g.printf("if params != nil {\nquery = url.Values{}\n")
+		for _, p := range queryParams {
+			field := "params." + goName(p.Name)
+			var val, zero string
+			switch g.resolve(p.Schema).Type {
+			case "integer":
+				g.imports["strconv"] = true
+				val, zero = fmt.Sprintf("strconv.Itoa(%s)", field), "0"
+			case "boolean":
+				g.imports["strconv"] = true
+				val, zero = fmt.Sprintf("strconv.FormatBool(%s)", field), "false"
+			default:
+				val, zero = field, `""`
+			}
+			if p.Required {
+				g.printf("query.Set(%q, %s)\n", p.Name, val)
+			} else {
+				g.printf("if %s != %s {\nquery.Set(%q, %s)\n}\n",
+					field, zero, p.Name, val)
+			}
+		}
+		g.printf("}\n")
+	}
+
+	body := "nil"
+	if op.RequestBody != nil {
+		body = "body"
+	}
+	method := "http.Method" + string(op.method[0]) + strings.ToLower(op.method[1:])
+	if result != "" {
+		out := "&out"
+		if strings.HasPrefix(result, "*") {
+			g.printf("out := new(%s)\n", result[1:])
+			out = "out"
+		} else {
+			g.printf("var out %s\n", result)
+		}
+		g.printf("if err := c.do(ctx, %s, path, query, %s, %s); err != nil {\n",
+			method, body, out)
+		g.printf("return nil, err\n}\nreturn out, nil\n}\n\n")
+	} else {
+		g.printf("return c.do(ctx, %s, path, query, %s, nil)\n}\n\n", method, body)
+	}
+	return nil
+}
+
+// goType returns the Go type for a schema.
+func (g *generator) goType(s *Schema) (string, error) {
+	if s == nil {
+		return "any", nil
+	}
+	if s.Ref != "" {
+		name, err := refName(s.Ref, "schemas")
+		if err != nil {
+			return "", err
+		}
+		if _, ok := g.spec.Components.Schemas[name]; !ok {
+			return "", fmt.Errorf("unknown schema %q", name)
+		}
+		return name, nil
+	}
+
+	switch s.Type {
+	case "string":
+		return "string", nil
+	case "boolean":
+		return "bool", nil
+	case "integer":
+		if s.Format == "int64" {
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "array":
+		t, err := g.goType(s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + t, nil
+	case "object":
+		if len(s.Properties) > 0 {
+			return "", fmt.Errorf("nested object schemas are not supported")
+		}
+		ap := bytes.TrimSpace(s.AdditionalProperties)
+		if len(ap) > 0 && ap[0] == '{' {
+			var as Schema
+			if err := json.Unmarshal(ap, &as); err != nil {
+				return "", err
+			}
+			t, err := g.goType(&as)
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + t, nil
+		}
+		return "map[string]any", nil
+	case "":
+		return "any", nil
+	default:
+		return "", fmt.Errorf("unsupported type %q", s.Type)
+	}
+}
+
+// resolve returns the schema referenced by s, or s if it is not a reference.
+func (g *generator) resolve(s *Schema) *Schema {
+	if s == nil || s.Ref == "" {
+		return s
+	}
+	name, err := refName(s.Ref, "schemas")
+	if err != nil {
+		return s
+	}
+	if rs, ok := g.spec.Components.Schemas[name]; ok {
+		return g.resolve(rs)
+	}
+	return s
+}
+
+// resolveParam returns the parameter referenced by p, or p if it is not a
+// reference.
+func (g *generator) resolveParam(p *Parameter) *Parameter {
+	if p.Ref == "" {
+		return p
+	}
+	name, err := refName(p.Ref, "parameters")
+	if err != nil {
+		return p
+	}
+	if rp, ok := g.spec.Components.Parameters[name]; ok {
+		return rp
+	}
+	return p
+}
+
+func refName(ref, component string) (string, error) {
+	prefix := "#/components/" + component + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("unsupported reference %q", ref)
+	}
+	return strings.TrimPrefix(ref, prefix), nil
+}
+
+func isScalar(s *Schema) bool {
+	if s == nil {
+		return false
+	}
+	switch s.Type {
+	case "string", "boolean", "integer", "number":
+		return true
+	}
+	return false
+}
+
+// initialisms are words that are written in all caps in Go names.
+var initialisms = map[string]bool{
+	"Id":  true,
+	"Url": true,
+	"Hd":  true,
+}
+
+// goName converts a JSON name (e.g., "docNumber", "verified_email",
+// "objectID") to an exported Go name.
+func goName(s string) string {
+	var words []string
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		// Split camel case words.
+		start := 0
+		runes := []rune(part)
+		for i := 1; i < len(runes); i++ {
+			if unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1]) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(runes[start:]))
+	}
+
+	var b strings.Builder
+	for _, w := range words {
+		w = strings.ToUpper(w[:1]) + w[1:]
+		if initialisms[w] {
+			w = strings.ToUpper(w)
+		}
+		b.WriteString(w)
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	// Lower the whole name if it is an initialism (e.g., "ID" -> "id").
+	if strings.ToUpper(s) == s {
+		return strings.ToLower(s)
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func writeComment(buf *bytes.Buffer, name, text string) {
+	if text == "" {
+		return
+	}
+	if name != "" {
+		text = name + " " + strings.ToLower(text[:1]) + text[1:]
+	}
+	const width = 77
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > width && line != "//" {
+			buf.WriteString(line + "\n")
+			line = "//"
+		}
+		line += " " + word
+	}
+	buf.WriteString(line + "\n")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,25 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoName(t *testing.T) {
+	cases := map[string]string{
+		"docNumber":      "DocNumber",
+		"objectID":       "ObjectID",
+		"googleFileID":   "GoogleFileID",
+		"verified_email": "VerifiedEmail",
+		"request_id":     "RequestID",
+		"url":            "URL",
+		"Template":       "Template",
+		"hd":             "HD",
+		"getDraft":       "GetDraft",
+	}
+
+	for in, want := range cases {
+		assert.Equal(t, want, goName(in), in)
+	}
+}
@@ -0,0 +1,196 @@
+// Package docnumber formats and assigns document numbers (e.g., "TF-123") with
+// configurable numbering schemes.
+package docnumber
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultFormat is the default format of document numbers.
+	DefaultFormat = "{product}-{number}"
+
+	// DefaultPadding is the default minimum number of digits of document
+	// numbers.
+	DefaultPadding = 3
+
+	// maxPadding is the maximum number of digits of document numbers that
+	// numbers can be padded to.
+	maxPadding = 10
+)
+
+// placeholderRE matches placeholders in formats.
+var placeholderRE = regexp.MustCompile(`\{[^}]*\}`)
+
+// Scheme is a document numbering scheme.
+type Scheme struct {
+	// Format is the format of document numbers. "{product}" is replaced with the
+	// product abbreviation, "{doc_type}" with the document type, "{year}" with
+	// the year the number was assigned, and "{number}" with the number in the
+	// sequence.
+	Format string
+
+	// Padding is the minimum number of digits of the number in the sequence,
+	// which is padded with leading zeros.
+	Padding int
+
+	// DocumentTypes are the document types that share the sequence of numbers
+	// within a product. Numbers restart each year if Format contains "{year}".
+	DocumentTypes []string
+}
+
+// Number is a document number.
+type Number struct {
+	// Number is the number in the sequence.
+	Number int
+
+	// Year is the year the number was assigned, for schemes with numbers that
+	// restart each year (zero otherwise).
+	Year int
+}
+
+// DefaultScheme returns the default numbering scheme for document type
+// docType, which numbers documents per product and document type (e.g.,
+// "TF-123").
+func DefaultScheme(docType string) Scheme {
+	return Scheme{
+		Format:        DefaultFormat,
+		Padding:       DefaultPadding,
+		DocumentTypes: []string{docType},
+	}
+}
+
+// Draft returns the document number of a draft in a product with abbreviation
+// product, which doesn't have a number yet (e.g., "TF-???").
+func Draft(product string) string {
+	return fmt.Sprintf("%s-???", product)
+}
+
+// Validate validates the scheme.
+func (s Scheme) Validate() error {
+	if !strings.Contains(s.Format, "{number}") {
+		return fmt.Errorf("format must contain {number}")
+	}
+	for _, p := range placeholderRE.FindAllString(s.Format, -1) {
+		switch p {
+		case "{product}", "{doc_type}", "{year}", "{number}":
+		default:
+			return fmt.Errorf("unknown placeholder in format: %s", p)
+		}
+	}
+	if s.Padding < 0 || s.Padding > maxPadding {
+		return fmt.Errorf("padding must be between 0 and %d", maxPadding)
+	}
+	if len(s.DocumentTypes) == 0 {
+		return fmt.Errorf("at least one document type is required")
+	}
+	return nil
+}
+
+// Yearly returns true if numbers restart each year.
+func (s Scheme) Yearly() bool {
+	return strings.Contains(s.Format, "{year}")
+}
+
+// Sequence returns the key of the sequence of numbers of the scheme.
+func (s Scheme) Sequence() string {
+	return models.DocumentNumberSequenceKey(s.DocumentTypes)
+}
+
+// String returns document number n for a document of type docType in a
+// product with abbreviation product.
+func (s Scheme) String(product, docType string, n Number) string {
+	return strings.NewReplacer(
+		"{product}", product,
+		"{doc_type}", docType,
+		"{year}", strconv.Itoa(n.Year),
+		"{number}", fmt.Sprintf("%0*d", s.Padding, n.Number),
+	).Replace(s.Format)
+}
+
+// Parse parses document number str of a document of type docType in a product
+// with abbreviation product, and returns false if it doesn't match the scheme.
+// Product abbreviations and document types are matched case-insensitively.
+func (s Scheme) Parse(str, product, docType string) (Number, bool) {
+	var (
+		b      strings.Builder
+		groups []string
+	)
+	b.WriteString("(?i)^")
+	last := 0
+	for _, loc := range placeholderRE.FindAllStringIndex(s.Format, -1) {
+		b.WriteString(regexp.QuoteMeta(s.Format[last:loc[0]]))
+		switch p := s.Format[loc[0]:loc[1]]; p {
+		case "{product}":
+			b.WriteString(regexp.QuoteMeta(product))
+		case "{doc_type}":
+			b.WriteString(regexp.QuoteMeta(docType))
+		case "{year}":
+			b.WriteString(`([0-9]{4})`)
+			groups = append(groups, p)
+		case "{number}":
+			b.WriteString(`([0-9]+)`)
+			groups = append(groups, p)
+		default:
+			return Number{}, false
+		}
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(s.Format[last:]))
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return Number{}, false
+	}
+	m := re.FindStringSubmatch(strings.TrimSpace(str))
+	if m == nil {
+		return Number{}, false
+	}
+
+	var n Number
+	for i, g := range groups {
+		v, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return Number{}, false
+		}
+		switch g {
+		case "{year}":
+			n.Year = v
+		case "{number}":
+			n.Number = v
+		}
+	}
+	if n.Number == 0 {
+		return Number{}, false
+	}
+	return n, true
+}
+
+// Next assigns the next document number in scheme s to a document in product
+// productName published at time t, and returns it. The sequence is locked until
+// the transaction of db is committed or rolled back, so the document should be
+// saved with the number in the same transaction.
+func Next(
+	db *gorm.DB, s Scheme, productName string, t time.Time) (Number, error) {
+	var n Number
+	if s.Yearly() {
+		n.Year = t.Year()
+	}
+
+	next, err := models.NextDocumentNumber(
+		db, productName, s.DocumentTypes, n.Year)
+	if err != nil {
+		return Number{}, fmt.Errorf("error assigning document number: %w", err)
+	}
+	n.Number = next
+
+	return n, nil
+}
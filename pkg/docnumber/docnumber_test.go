@@ -0,0 +1,175 @@
+package docnumber
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemeString(t *testing.T) {
+	cases := map[string]struct {
+		scheme Scheme
+		number Number
+		want   string
+	}{
+		"default": {
+			scheme: DefaultScheme("RFC"),
+			number: Number{Number: 14},
+			want:   "TF-014",
+		},
+		"default with more digits than padding": {
+			scheme: DefaultScheme("RFC"),
+			number: Number{Number: 1234},
+			want:   "TF-1234",
+		},
+		"per-year": {
+			scheme: Scheme{
+				Format:  "{product}-{year}-{number}",
+				Padding: 3,
+			},
+			number: Number{Number: 14, Year: 2026},
+			want:   "TF-2026-014",
+		},
+		"document type prefix without padding": {
+			scheme: Scheme{
+				Format: "{doc_type}-{product}-{number}",
+			},
+			number: Number{Number: 7},
+			want:   "RFC-TF-7",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, c.scheme.String("TF", "RFC", c.number))
+		})
+	}
+}
+
+func TestSchemeParse(t *testing.T) {
+	cases := map[string]struct {
+		scheme Scheme
+		str    string
+		want   Number
+		wantOK bool
+	}{
+		"default": {
+			scheme: DefaultScheme("RFC"),
+			str:    "TF-014",
+			want:   Number{Number: 14},
+			wantOK: true,
+		},
+		"default without padding": {
+			scheme: DefaultScheme("RFC"),
+			str:    "tf-14",
+			want:   Number{Number: 14},
+			wantOK: true,
+		},
+		"default with other product": {
+			scheme: DefaultScheme("RFC"),
+			str:    "VLT-014",
+		},
+		"draft": {
+			scheme: DefaultScheme("RFC"),
+			str:    "TF-???",
+		},
+		"per-year": {
+			scheme: Scheme{
+				Format:  "{product}-{year}-{number}",
+				Padding: 3,
+			},
+			str:    "TF-2026-014",
+			want:   Number{Number: 14, Year: 2026},
+			wantOK: true,
+		},
+		"per-year without year": {
+			scheme: Scheme{
+				Format:  "{product}-{year}-{number}",
+				Padding: 3,
+			},
+			str: "TF-014",
+		},
+		"document type prefix": {
+			scheme: Scheme{
+				Format: "{doc_type}-{product}-{number}",
+			},
+			str:    "RFC-TF-7",
+			want:   Number{Number: 7},
+			wantOK: true,
+		},
+		"format with regexp characters": {
+			scheme: Scheme{
+				Format: "{product}.{number}",
+			},
+			str: "TF-7",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, ok := c.scheme.Parse(c.str, "TF", "RFC")
+			assert.Equal(c.wantOK, ok)
+			assert.Equal(c.want, got)
+		})
+	}
+}
+
+func TestSchemeValidate(t *testing.T) {
+	cases := map[string]struct {
+		scheme    Scheme
+		shouldErr bool
+	}{
+		"default": {
+			scheme: DefaultScheme("RFC"),
+		},
+		"all placeholders": {
+			scheme: Scheme{
+				Format:        "{doc_type}-{product}-{year}-{number}",
+				DocumentTypes: []string{"RFC"},
+			},
+		},
+		"missing number": {
+			scheme: Scheme{
+				Format:        "{product}-{year}",
+				Padding:       3,
+				DocumentTypes: []string{"RFC"},
+			},
+			shouldErr: true,
+		},
+		"unknown placeholder": {
+			scheme: Scheme{
+				Format:        "{team}-{number}",
+				Padding:       3,
+				DocumentTypes: []string{"RFC"},
+			},
+			shouldErr: true,
+		},
+		"negative padding": {
+			scheme: Scheme{
+				Format:        DefaultFormat,
+				Padding:       -1,
+				DocumentTypes: []string{"RFC"},
+			},
+			shouldErr: true,
+		},
+		"missing document types": {
+			scheme: Scheme{
+				Format:  DefaultFormat,
+				Padding: 3,
+			},
+			shouldErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.scheme.Validate()
+			if c.shouldErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -12,8 +12,10 @@ import (
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/helpers"
 	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
 	"github.com/iancoleman/strcase"
 	"github.com/mitchellh/mapstructure"
+	"gorm.io/gorm"
 )
 
 type Document struct {
@@ -48,6 +50,9 @@ type Document struct {
 	// approvals are requested for the document.
 	ApproverGroups []string `json:"approverGroups,omitempty"`
 
+	// Archived is true if the document was archived by a retention policy.
+	Archived bool `json:"archived,omitempty"`
+
 	// ChangesRequestedBy is a slice of email address strings for users that have
 	// requested changes for the document.
 	ChangesRequestedBy []string `json:"changesRequestedBy,omitempty"`
@@ -102,6 +107,10 @@ type Document struct {
 	// Product is the product or area that the document relates to.
 	Product string `json:"product,omitempty"`
 
+	// RetentionFlagged is true if the document was flagged for review by a
+	// retention policy.
+	RetentionFlagged bool `json:"retentionFlagged,omitempty"`
+
 	// Summary is a summary of the document.
 	Summary string `json:"summary,omitempty"`
 
@@ -115,6 +124,10 @@ type Document struct {
 
 	// ThumbnailLink is a URL string for the document thumbnail image.
 	ThumbnailLink string `json:"thumbnailLink,omitempty"`
+
+	// ViewCount is the total number of (deduplicated) views of the document. It
+	// is used to boost search ranking, and set by ToDocsIndexObject.
+	ViewCount int64 `json:"viewCount"`
 }
 
 type CustomDocTypeField struct {
@@ -254,15 +267,14 @@ func NewFromDatabaseModel(
 	doc.DocType = model.DocumentType.Name
 
 	// DocNumber.
-	doc.DocNumber = fmt.Sprintf(
-		"%s-%03d", model.Product.Abbreviation, model.DocumentNumber)
-	if model.DocumentNumber == 0 {
-		doc.DocNumber = fmt.Sprintf("%s-???", model.Product.Abbreviation)
-	}
+	doc.DocNumber = model.DocNumber()
 
 	// AppCreated.
 	doc.AppCreated = !model.Imported
 
+	// Archived.
+	doc.Archived = model.ArchivedAt != nil
+
 	// ApprovedBy, Approvers, ChangesRequestedBy.
 	var approvedBy, approvers, changesRequestedBy []string
 	for _, r := range reviews {
@@ -370,11 +382,21 @@ func NewFromDatabaseModel(
 	// Product.
 	doc.Product = model.Product.Name
 
+	// RetentionFlagged.
+	doc.RetentionFlagged = model.RetentionFlaggedAt != nil
+
 	// Summary.
 	if model.Summary != nil {
 		doc.Summary = *model.Summary
 	}
 
+	// Tags.
+	var tags []string
+	for _, t := range model.Tags {
+		tags = append(tags, t.Name)
+	}
+	doc.Tags = tags
+
 	// Status.
 	var status string
 	switch model.Status {
@@ -425,6 +447,33 @@ func (d Document) ToAlgoliaObject(
 	return obj, nil
 }
 
+// ToDocsIndexObject converts a document to a document Algolia object for the
+// docs index, with the total view count of the document with database ID
+// documentID in database db. View counts aren't stored in document records, so
+// objects saved in the docs index are converted with this method to not reset
+// them. If the view count can't be retrieved, the error is logged and the
+// object doesn't include it.
+func (d Document) ToDocsIndexObject(
+	db *gorm.DB, documentID uint, log hclog.Logger) (map[string]any, error) {
+	viewCount, viewCountErr := models.GetDocumentViewCount(db, documentID)
+	if viewCountErr != nil {
+		log.Error("error getting document view count",
+			"error", viewCountErr,
+			"google_file_id", d.ObjectID,
+		)
+	}
+	d.ViewCount = viewCount
+
+	obj, err := d.ToAlgoliaObject(true)
+	if err != nil {
+		return nil, err
+	}
+	if viewCountErr != nil {
+		delete(obj, "viewCount")
+	}
+	return obj, nil
+}
+
 // ToDatabaseModels converts a document to a document and document reviews
 // database records.
 func (d Document) ToDatabaseModels(
@@ -454,14 +503,40 @@ func (d Document) ToDatabaseModels(
 		return doc, reviews, fmt.Errorf("document type not found: %s", d.DocType)
 	}
 
-	// DocumentNumber.
-	splitDocNum := strings.Split(d.DocNumber, "-")
-	if len(splitDocNum) == 2 {
-		docNumInt, err := strconv.Atoi(splitDocNum[1])
-		if err == nil {
+	// DocumentNumber and DocumentNumberYear, parsed with the numbering scheme of
+	// the document type. Numbers that don't match the scheme (e.g., assigned
+	// with a previous scheme) use the last part of the document number. Numbered
+	// documents are in the sequence of the scheme.
+	cfg := config.Config{
+		DocumentTypes: &config.DocumentTypes{DocumentType: docTypes},
+	}
+	scheme, err := cfg.NumberingScheme(d.DocType)
+	if err != nil {
+		return doc, reviews, fmt.Errorf(
+			"error getting document numbering scheme: %w", err)
+	}
+	productAbbreviation := ""
+	for _, p := range products {
+		if p.Name == d.Product {
+			productAbbreviation = p.Abbreviation
+			break
+		}
+	}
+	if n, ok := scheme.Parse(
+		d.DocNumber, productAbbreviation, d.DocType); ok {
+		doc.DocumentNumber = n.Number
+		doc.DocumentNumberYear = n.Year
+		doc.FormattedDocumentNumber = d.DocNumber
+	} else if splitDocNum := strings.Split(d.DocNumber, "-"); len(splitDocNum) >= 2 {
+		docNumInt, err := strconv.Atoi(splitDocNum[len(splitDocNum)-1])
+		if err == nil && docNumInt > 0 {
 			doc.DocumentNumber = docNumInt
+			doc.FormattedDocumentNumber = d.DocNumber
 		}
 	}
+	if doc.DocumentNumber > 0 {
+		doc.DocumentNumberSequence = scheme.Sequence()
+	}
 
 	// Imported.
 	doc.Imported = !d.AppCreated
@@ -93,7 +93,9 @@ func (doc *Document) ReplaceHeader(
 				},
 			},
 		}
-		_, err = s.Docs.Documents.BatchUpdate(doc.ObjectID, req).Do()
+		_, err = s.Docs.Documents.BatchUpdate(doc.ObjectID, req).
+			Context(s.Context()).
+			Do()
 		if err != nil {
 			return fmt.Errorf("error deleting existing header: %w", err)
 		}
@@ -119,7 +121,9 @@ func (doc *Document) ReplaceHeader(
 			},
 		},
 	}
-	_, err = s.Docs.Documents.BatchUpdate(doc.ObjectID, req).Do()
+	_, err = s.Docs.Documents.BatchUpdate(doc.ObjectID, req).
+		Context(s.Context()).
+		Do()
 	if err != nil {
 		return fmt.Errorf("error inserting header table: %w", err)
 	}
@@ -382,7 +386,9 @@ func (doc *Document) ReplaceHeader(
 			},
 		},
 	}
-	_, err = s.Docs.Documents.BatchUpdate(doc.ObjectID, req).Do()
+	_, err = s.Docs.Documents.BatchUpdate(doc.ObjectID, req).
+		Context(s.Context()).
+		Do()
 	if err != nil {
 		return fmt.Errorf("error applying formatting to header table: %w", err)
 	}
@@ -768,6 +774,7 @@ func (doc *Document) ReplaceHeader(
 	_, err = s.Docs.Documents.BatchUpdate(doc.ObjectID,
 		&docs.BatchUpdateDocumentRequest{
 			Requests: reqs}).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error populating table: %w", err)
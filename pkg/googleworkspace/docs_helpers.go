@@ -15,7 +15,7 @@ func (s *Service) GetDoc(id string) (*docs.Document, error) {
 	)
 
 	op := func() error {
-		d, err = s.Docs.Documents.Get(id).Do()
+		d, err = s.Docs.Documents.Get(id).Context(s.Context()).Do()
 		if err != nil {
 			return fmt.Errorf("error getting document: %w", err)
 		}
@@ -91,6 +91,7 @@ func (s *Service) ReplaceText(id string, r map[string]string) error {
 	}
 
 	_, err := s.Docs.Documents.BatchUpdate(id, req).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error executing document batch update: %w", err)
@@ -2,6 +2,7 @@ package googleworkspace
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/cenkalti/backoff/v4"
@@ -25,6 +26,7 @@ func (s *Service) CopyFile(
 	resp, err := s.Drive.Files.Copy(fileID, f).
 		Fields("*").
 		SupportsAllDrives(true).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return nil, fmt.Errorf("error copying file: %w", err)
@@ -53,6 +55,7 @@ func (s *Service) CreateFolder(
 	resp, err := s.Drive.Files.Create(f).
 		Fields("id,mimeType,name,parents").
 		SupportsAllDrives(true).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return nil, err
@@ -90,6 +93,7 @@ func (s *Service) CreateShortcut(
 	resp, err := s.Drive.Files.Create(f).
 		Fields("id,mimeType,name,parents,shortcutDetails").
 		SupportsAllDrives(true).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return nil, err
@@ -98,6 +102,36 @@ func (s *Service) CreateShortcut(
 	return resp, nil
 }
 
+// ExportFile exports a Google Workspace file (e.g., a Google Doc) to the
+// format of the provided MIME type, and returns the exported content.
+func (s *Service) ExportFile(fileID, mimeType string) ([]byte, error) {
+	var b []byte
+
+	op := func() error {
+		resp, err := s.Drive.Files.Export(fileID, mimeType).
+			Context(s.Context()).
+			Download()
+		if err != nil {
+			return fmt.Errorf("error exporting file: %w", err)
+		}
+		defer resp.Body.Close()
+
+		b, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("error reading exported file: %w", err)
+		}
+
+		return nil
+	}
+
+	boErr := backoff.RetryNotify(op, defaultBackoff(), backoffNotify)
+	if boErr != nil {
+		return nil, boErr
+	}
+
+	return b, nil
+}
+
 // GetDocs returns all docs in a Google Drive folder.
 func (s *Service) GetDocs(folderID string) ([]*drive.File, error) {
 	return s.GetFiles(folderID, "application/vnd.google-apps.document")
@@ -114,6 +148,7 @@ func (s *Service) GetFile(fileID string) (*drive.File, error) {
 		resp, err = s.Drive.Files.Get(fileID).
 			Fields(fileFields).
 			SupportsAllDrives(true).
+			Context(s.Context()).
 			Do()
 		if err != nil {
 			return fmt.Errorf("error getting file: %w", err)
@@ -139,6 +174,17 @@ func (s *Service) GetFiles(folderID, mimeType string) ([]*drive.File, error) {
 	return s.ListFiles(folderID, query)
 }
 
+// GetShortcuts returns all shortcuts to a target file in a Google Drive folder.
+func (s *Service) GetShortcuts(
+	folderID, targetFileID string) ([]*drive.File, error) {
+	query := fmt.Sprintf("'%s' in parents"+
+		" and mimeType = 'application/vnd.google-apps.shortcut'"+
+		" and shortcutDetails.targetId = '%s'"+
+		" and trashed = false",
+		folderID, targetFileID)
+	return s.ListFiles(folderID, query)
+}
+
 // GetDocs returns all folders in a Google Drive folder.
 func (s *Service) GetFolders(folderID string) ([]*drive.File, error) {
 	return s.GetFiles(folderID, "application/vnd.google-apps.folder")
@@ -237,6 +283,7 @@ func (s *Service) KeepRevisionForever(
 		KeepForever: true,
 	}).
 		Fields("keepForever").
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return nil, err
@@ -252,6 +299,7 @@ func (s *Service) UpdateKeepRevisionForever(
 		KeepForever: keepForever,
 	}).
 		Fields("keepForever").
+		Context(s.Context()).
 		Do()
 
 	return err
@@ -273,7 +321,7 @@ func (s *Service) ListFiles(folderID, query string) ([]*drive.File, error) {
 			if nextPageToken != "" {
 				call = call.PageToken(nextPageToken)
 			}
-			resp, err := call.Do()
+			resp, err := call.Context(s.Context()).Do()
 			if err != nil {
 				return fmt.Errorf("error listing files: %w", err)
 			}
@@ -308,7 +356,7 @@ func (s *Service) ListRevisions(fileID string) ([]*drive.Revision, error) {
 		if nextPageToken != "" {
 			call = call.PageToken(nextPageToken)
 		}
-		resp, err := call.Do()
+		resp, err := call.Context(s.Context()).Do()
 		if err != nil {
 			return nil, err
 		}
@@ -339,6 +387,7 @@ func (s *Service) MoveFile(fileID, destFolder string) (*drive.File, error) {
 		RemoveParents(strings.Join(f.Parents[:], ",")).
 		Fields("parents").
 		SupportsAllDrives(true).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return nil, fmt.Errorf("error updating file: %w", err)
@@ -352,6 +401,7 @@ func (s *Service) RenameFile(fileID, newName string) error {
 		Name: newName,
 	}).
 		SupportsAllDrives(true).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error updating file: %w", err)
@@ -370,6 +420,7 @@ func (s *Service) ShareFile(
 			Type:         "user",
 		}).
 		SupportsAllDrives(true).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error updating file permissions: %w", err)
@@ -388,6 +439,7 @@ func (s *Service) ShareFileWithDomain(
 			Type:   "domain",
 		}).
 		SupportsAllDrives(true).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error updating file permissions: %w", err)
@@ -408,7 +460,7 @@ func (s *Service) ListPermissions(fileID string) ([]*drive.Permission, error) {
 		if nextPageToken != "" {
 			call = call.PageToken(nextPageToken)
 		}
-		resp, err := call.Do()
+		resp, err := call.Context(s.Context()).Do()
 		if err != nil {
 			return nil, err
 		}
@@ -427,6 +479,7 @@ func (s *Service) ListPermissions(fileID string) ([]*drive.Permission, error) {
 func (s *Service) DeleteFile(fileID string) error {
 	err := s.Drive.Files.Delete(fileID).
 		SupportsAllDrives(true).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error deleting file: %w", err)
@@ -439,6 +492,7 @@ func (s *Service) DeletePermission(
 	fileID, permissionID string) error {
 	err := s.Drive.Permissions.Delete(fileID, permissionID).
 		SupportsAllDrives(true).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error deleting permission: %w", err)
@@ -17,7 +17,9 @@ func (s *Service) SendEmail(to []string, from, subject, body string) (*gmail.Mes
 		Raw: base64.URLEncoding.EncodeToString([]byte(email)),
 	}
 
-	resp, err := s.Gmail.Users.Messages.Send("me", msg).Do()
+	resp, err := s.Gmail.Users.Messages.Send("me", msg).
+		Context(s.Context()).
+		Do()
 	if err != nil {
 		return nil, fmt.Errorf("error sending email: %w", err)
 	}
@@ -12,6 +12,7 @@ func (s *Service) ValidateAccessToken(
 	resp, err := s.OAuth2.Tokeninfo().
 		AccessToken(accessToken).
 		Fields("*").
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return nil, err
@@ -20,7 +20,7 @@ func (s *Service) SearchPeople(
 	)
 
 	op := func() error {
-		resp, err = call.Do()
+		resp, err = call.Context(s.Context()).Do()
 		if err != nil {
 			return fmt.Errorf("error searching people directory: %w", err)
 		}
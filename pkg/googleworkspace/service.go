@@ -31,6 +31,29 @@ type Service struct {
 	Gmail          *gmail.Service
 	OAuth2         *oauth2api.Service
 	People         *people.PeopleService
+
+	// ctx is the context of API calls made by the service's helpers.
+	ctx context.Context
+}
+
+// WithContext returns a shallow copy of the service that makes API calls with
+// ctx, so they are canceled when ctx is done.
+func (s *Service) WithContext(ctx context.Context) *Service {
+	if s == nil {
+		return nil
+	}
+	s2 := *s
+	s2.ctx = ctx
+	return &s2
+}
+
+// Context returns the context of API calls made by the service, which is the
+// background context unless the service was created with WithContext.
+func (s *Service) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
 }
 
 // Config is the configuration for interacting with Google Workspace using a
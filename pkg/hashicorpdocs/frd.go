@@ -84,14 +84,14 @@ func NewFRD(f *drive.File, s *gw.Service, allFolders []string) (*FRD, error) {
 	}
 	r.ModifiedTime = mt.Unix()
 
-	doc, err := s.Docs.Documents.Get(f.Id).Do()
+	doc, err := s.Docs.Documents.Get(f.Id).Context(s.Context()).Do()
 	if err != nil {
 		return nil, fmt.Errorf("error getting doc: %w: id=\"%s\"", err, f.Id)
 	}
 
 	// Assume the name of the parent folder is the FRD Product.
 	parent, err := s.Drive.Files.Get(f.Parents[0]).
-		SupportsAllDrives(true).Fields("name").Do()
+		SupportsAllDrives(true).Fields("name").Context(s.Context()).Do()
 	if err != nil {
 		return nil, fmt.Errorf("error getting parent folder file: %w", err)
 	}
@@ -115,6 +115,7 @@ func NewFRD(f *drive.File, s *gw.Service, allFolders []string) (*FRD, error) {
 		resp, err := s.People.SearchDirectoryPeople().Query(o).
 			ReadMask("photos").
 			Sources("DIRECTORY_SOURCE_TYPE_DOMAIN_PROFILE").
+			Context(s.Context()).
 			Do()
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -131,7 +132,9 @@ func NewFRD(f *drive.File, s *gw.Service, allFolders []string) (*FRD, error) {
 	}
 
 	// Get doc content.
-	resp, err := s.Drive.Files.Export(f.Id, "text/plain").Download()
+	resp, err := s.Drive.Files.Export(f.Id, "text/plain").
+		Context(s.Context()).
+		Download()
 	if err != nil {
 		return nil, fmt.Errorf("error exporting doc: %w: id=\"%s\"", err, f.Id)
 	}
@@ -302,7 +305,7 @@ func (r *FRD) parseFRDTitle(s string) {
 	// Handle `[FRD] CSL-123: Some FRD Title` case.
 	// Also handles different types of "[FRD]" identifiers like "[Meta FRD]",
 	// "[Mini-FRD]", etc.
-	re := regexp.MustCompile(`\[.*FRD\] (?P<DocID>[A-Z]+-[0-9xX#?]+): (?P<Title>.+)`)
+	re := regexp.MustCompile(`\[.*FRD\] (?P<DocID>[A-Z]+(?:-[A-Z0-9]+)*-[0-9xX#?]+): (?P<Title>.+)`)
 	matches := re.FindStringSubmatch(s)
 	if len(matches) > 1 {
 		r.DocNumber = matches[1]
@@ -87,7 +87,7 @@ func NewPRD(f *drive.File, s *gw.Service, allFolders []string) (*PRD, error) {
 
 	// Assume the name of the parent folder is the PRD Product.
 	parent, err := s.Drive.Files.Get(f.Parents[0]).
-		SupportsAllDrives(true).Fields("name").Do()
+		SupportsAllDrives(true).Fields("name").Context(s.Context()).Do()
 	if err != nil {
 		return nil, fmt.Errorf("error getting parent folder file: %w", err)
 	}
@@ -124,7 +124,9 @@ func NewPRD(f *drive.File, s *gw.Service, allFolders []string) (*PRD, error) {
 	}
 
 	// Get doc content.
-	resp, err := s.Drive.Files.Export(f.Id, "text/plain").Download()
+	resp, err := s.Drive.Files.Export(f.Id, "text/plain").
+		Context(s.Context()).
+		Download()
 	if err != nil {
 		return nil, fmt.Errorf("error exporting doc: %w: id=\"%s\"", err, f.Id)
 	}
@@ -282,7 +284,7 @@ func (r *PRD) parsePRDTitle(s string) {
 	// Handle `[PRD] CSL-123: Some PRD Title` case.
 	// Also handles different types of "[PRD]" identifiers like "[Meta PRD]",
 	// "[Mini-PRD]", etc.
-	re := regexp.MustCompile(`\[.*PRD\] (?P<DocID>[A-Z]+-[0-9xX#?]+): (?P<Title>.+)`)
+	re := regexp.MustCompile(`\[.*PRD\] (?P<DocID>[A-Z]+(?:-[A-Z0-9]+)*-[0-9xX#?]+): (?P<Title>.+)`)
 	matches := re.FindStringSubmatch(s)
 	if len(matches) > 1 {
 		r.DocNumber = matches[1]
@@ -92,7 +92,9 @@ func (doc *PRD) ReplaceHeader(fileID, baseURL string, isDraft bool, s *gw.Servic
 				},
 			},
 		}
-		_, err = s.Docs.Documents.BatchUpdate(fileID, req).Do()
+		_, err = s.Docs.Documents.BatchUpdate(fileID, req).
+			Context(s.Context()).
+			Do()
 		if err != nil {
 			return fmt.Errorf("error deleting existing header: %w", err)
 		}
@@ -112,7 +114,7 @@ func (doc *PRD) ReplaceHeader(fileID, baseURL string, isDraft bool, s *gw.Servic
 			},
 		},
 	}
-	_, err = s.Docs.Documents.BatchUpdate(fileID, req).Do()
+	_, err = s.Docs.Documents.BatchUpdate(fileID, req).Context(s.Context()).Do()
 	if err != nil {
 		return fmt.Errorf("error inserting header table: %w", err)
 	}
@@ -384,7 +386,7 @@ func (doc *PRD) ReplaceHeader(fileID, baseURL string, isDraft bool, s *gw.Servic
 			},
 		},
 	}
-	_, err = s.Docs.Documents.BatchUpdate(fileID, req).Do()
+	_, err = s.Docs.Documents.BatchUpdate(fileID, req).Context(s.Context()).Do()
 	if err != nil {
 		return fmt.Errorf("error applying formatting to header table: %w", err)
 	}
@@ -726,6 +728,7 @@ func (doc *PRD) ReplaceHeader(fileID, baseURL string, isDraft bool, s *gw.Servic
 	_, err = s.Docs.Documents.BatchUpdate(fileID,
 		&docs.BatchUpdateDocumentRequest{
 			Requests: reqs}).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error populating table: %w", err)
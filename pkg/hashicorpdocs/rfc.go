@@ -108,7 +108,7 @@ func NewRFC(f *drive.File, s *gw.Service, allFolders []string) (*RFC, error) {
 
 	// Assume the name of the parent folder is the RFC Product.
 	parent, err := s.Drive.Files.Get(f.Parents[0]).
-		SupportsAllDrives(true).Fields("name").Do()
+		SupportsAllDrives(true).Fields("name").Context(s.Context()).Do()
 	if err != nil {
 		return nil, fmt.Errorf("error getting parent folder file: %w", err)
 	}
@@ -145,7 +145,9 @@ func NewRFC(f *drive.File, s *gw.Service, allFolders []string) (*RFC, error) {
 	}
 
 	// Get doc content.
-	resp, err := s.Drive.Files.Export(f.Id, "text/plain").Download()
+	resp, err := s.Drive.Files.Export(f.Id, "text/plain").
+		Context(s.Context()).
+		Download()
 	if err != nil {
 		return nil, fmt.Errorf("error exporting doc: %w: id=\"%s\"", err, f.Id)
 	}
@@ -330,7 +332,7 @@ func (r *RFC) parseRFCSummary(b *docs.Body) {
 // should be the name of the Google Drive file).
 func (r *RFC) parseRFCTitle(s string) {
 	// Handle `[RFC-123]: Some RFC Title` case.
-	re := regexp.MustCompile(`\[(?P<DocID>[A-Z]+-[0-9xX#?]+)\] (?P<Title>.+)`)
+	re := regexp.MustCompile(`\[(?P<DocID>[A-Z]+(?:-[A-Z0-9]+)*-[0-9xX#?]+)\] (?P<Title>.+)`)
 	matches := re.FindStringSubmatch(s)
 	if len(matches) > 1 {
 		r.DocNumber = matches[1]
@@ -339,7 +341,7 @@ func (r *RFC) parseRFCTitle(s string) {
 	}
 
 	// Handle `RFC-123: Some RFC Title` case.
-	re = regexp.MustCompile(`(?P<DocID>[A-Z]+-[0-9xX#?]+): (?P<Title>.+)`)
+	re = regexp.MustCompile(`(?P<DocID>[A-Z]+(?:-[A-Z0-9]+)*-[0-9xX#?]+): (?P<Title>.+)`)
 	matches = re.FindStringSubmatch(s)
 	if len(matches) > 1 {
 		r.DocNumber = matches[1]
@@ -348,7 +350,7 @@ func (r *RFC) parseRFCTitle(s string) {
 	}
 
 	// Handle `RFC-123 - Some RFC Title` case.
-	re = regexp.MustCompile(`(?P<DocID>[A-Z]+-[0-9xX#?]+) - (?P<Title>.+)`)
+	re = regexp.MustCompile(`(?P<DocID>[A-Z]+(?:-[A-Z0-9]+)*-[0-9xX#?]+) - (?P<Title>.+)`)
 	matches = re.FindStringSubmatch(s)
 	if len(matches) > 1 {
 		r.DocNumber = matches[1]
@@ -357,7 +359,7 @@ func (r *RFC) parseRFCTitle(s string) {
 	}
 
 	// Handle `RFC-123 Some RFC Title` case.
-	re = regexp.MustCompile(`(?P<DocID>[A-Z]+-[0-9xX#?]+) (?P<Title>.+)`)
+	re = regexp.MustCompile(`(?P<DocID>[A-Z]+(?:-[A-Z0-9]+)*-[0-9xX#?]+) (?P<Title>.+)`)
 	matches = re.FindStringSubmatch(s)
 	if len(matches) > 1 {
 		r.DocNumber = matches[1]
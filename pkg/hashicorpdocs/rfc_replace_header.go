@@ -95,7 +95,9 @@ func (doc *RFC) ReplaceHeader(fileID, baseURL string, isDraft bool, s *gw.Servic
 				},
 			},
 		}
-		_, err = s.Docs.Documents.BatchUpdate(fileID, req).Do()
+		_, err = s.Docs.Documents.BatchUpdate(fileID, req).
+			Context(s.Context()).
+			Do()
 		if err != nil {
 			return fmt.Errorf("error deleting existing header: %w", err)
 		}
@@ -115,7 +117,7 @@ func (doc *RFC) ReplaceHeader(fileID, baseURL string, isDraft bool, s *gw.Servic
 			},
 		},
 	}
-	_, err = s.Docs.Documents.BatchUpdate(fileID, req).Do()
+	_, err = s.Docs.Documents.BatchUpdate(fileID, req).Context(s.Context()).Do()
 	if err != nil {
 		return fmt.Errorf("error inserting header table: %w", err)
 	}
@@ -387,7 +389,7 @@ func (doc *RFC) ReplaceHeader(fileID, baseURL string, isDraft bool, s *gw.Servic
 			},
 		},
 	}
-	_, err = s.Docs.Documents.BatchUpdate(fileID, req).Do()
+	_, err = s.Docs.Documents.BatchUpdate(fileID, req).Context(s.Context()).Do()
 	if err != nil {
 		return fmt.Errorf("error applying formatting to header table: %w", err)
 	}
@@ -728,6 +730,7 @@ func (doc *RFC) ReplaceHeader(fileID, baseURL string, isDraft bool, s *gw.Servic
 	_, err = s.Docs.Documents.BatchUpdate(fileID,
 		&docs.BatchUpdateDocumentRequest{
 			Requests: reqs}).
+		Context(s.Context()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error populating table: %w", err)
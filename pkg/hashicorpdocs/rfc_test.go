@@ -337,3 +337,60 @@ func TestParseParagraphWithEmails(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRFCTitle(t *testing.T) {
+	tests := []struct {
+		testName      string
+		s             string
+		wantDocNumber string
+		wantTitle     string
+	}{
+		{
+			"Brackets",
+			"[TF-123] Some RFC Title",
+			"TF-123",
+			"Some RFC Title",
+		},
+		{
+			"Colon",
+			"TF-123: Some RFC Title",
+			"TF-123",
+			"Some RFC Title",
+		},
+		{
+			"Draft",
+			"[TF-???] Some RFC Title",
+			"TF-???",
+			"Some RFC Title",
+		},
+		{
+			"Per-year number",
+			"[TF-2024-007] Some RFC Title",
+			"TF-2024-007",
+			"Some RFC Title",
+		},
+		{
+			"Document type prefix",
+			"RFC-TF-007 - Some RFC Title",
+			"RFC-TF-007",
+			"Some RFC Title",
+		},
+		{
+			"No document number",
+			"Some RFC Title",
+			"",
+			"Some RFC Title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := &RFC{}
+			r.parseRFCTitle(tt.s)
+			assert.Equal(tt.wantDocNumber, r.DocNumber)
+			assert.Equal(tt.wantTitle, r.Title)
+		})
+	}
+}
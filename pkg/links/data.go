@@ -4,58 +4,53 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/hashicorp-forge/hermes/pkg/algolia"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
 )
 
-// DeleteDocumentRedirectDetails deletes document redirect details from Algolia.
-func DeleteDocumentRedirectDetails(
-	algo *algolia.Client, id string, docType string, docNumString string) error {
+// DeleteDocumentShortLink deletes the short link for a document's number from
+// the database (e.g., when publishing the document is reverted).
+func DeleteDocumentShortLink(
+	db *gorm.DB, id string, docType string, docNumString string) error {
 
 	if docNumString != "" && docType != "" {
-		objectID := getObjectID(docType, docNumString)
-		res, err := algo.Links.DeleteObject(objectID)
-		if err != nil {
-			return fmt.Errorf("error deleting redirect link details: %w", err)
-		}
-		err = res.Wait()
-		if err != nil {
-			return fmt.Errorf("error deleting redirect link details: %w", err)
+		if err := models.DeleteDocumentNumberShortLink(
+			db, id, DocumentShortLinkPath(docType, docNumString),
+		); err != nil {
+			return fmt.Errorf("error deleting short link: %w", err)
 		}
 	}
 
 	return nil
 }
 
-// SaveDocumentRedirectDetails saves the short path of the document as the key
-// and the document ID as the value in Algolia.
-func SaveDocumentRedirectDetails(
-	algo *algolia.Client, id string, docType string, docNumString string) error {
-
-	var ld LinkData
+// SaveDocumentShortLink saves the short link for a document's number in the
+// database. Short links for the document's previous numbers are kept, so they
+// keep redirecting to the document.
+func SaveDocumentShortLink(
+	db *gorm.DB, id string, docType string, docNumString string) error {
 
-	// Save redirect details when document number {product-abbreviation}-{docnumber} is set
+	// Save short link when document number {product-abbreviation}-{docnumber}
+	// is set.
 	if docNumString != "" {
-		// Save object id as /doctype/{product_abbreviation-docnumber}. Eg. /rfc/lab-001
-		ld.ObjectID = getObjectID(docType, docNumString)
-		// Save id of the document
-		ld.DocumentID = id
-		res, err := algo.Links.SaveObject(&ld)
-		if err != nil {
-			return fmt.Errorf("error saving redirect link details: %w", err)
+		l := models.ShortLink{
+			Path: DocumentShortLinkPath(docType, docNumString),
+			Document: models.Document{
+				GoogleFileID: id,
+			},
 		}
-		err = res.Wait()
-		if err != nil {
-			return fmt.Errorf("error saving redirect link details: %w", err)
+		if err := l.Upsert(db); err != nil {
+			return fmt.Errorf("error saving short link: %w", err)
 		}
 	}
 
 	return nil
 }
 
-// getObjectID builds the ID for a document redirect details object in Algolia.
-// Object ID's format is: /doctype/{product_abbreviation-docnumber}
+// DocumentShortLinkPath builds the short link path for a document number,
+// without the "/l" prefix. The format is: /doctype/{product_abbreviation-docnumber}
 // (e.g., "/rfc/lab-001").
-func getObjectID(docType, docNumString string) string {
+func DocumentShortLinkPath(docType, docNumString string) string {
 	return fmt.Sprintf(
 		"/%s/%s",
 		strings.ToLower(docType),
@@ -1,20 +1,36 @@
 package links
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/errs"
 	"github.com/hashicorp-forge/hermes/pkg/algolia"
+	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
 )
 
-// sharedRFCsFolderURL is the Google Drive URL to the shared RFCs folder
-const sharedRFCsFolderURL = "https://drive.google.com/drive/folders/0AJA7q1x_uaLUUk9PVA"
+// Config configures short links.
+type Config struct {
+	// StaticRedirects are short links that redirect to fixed URLs (e.g., a
+	// shared folder of documents).
+	StaticRedirects []*StaticRedirect `hcl:"static_redirect,block"`
+}
+
+// StaticRedirect is a short link that redirects to a fixed URL.
+type StaticRedirect struct {
+	// Path is the path of the short link without the "/l" prefix (e.g.,
+	// "/rfc").
+	Path string `hcl:"path"`
 
-// sharedPRDsFolderURL is the Google Drive URL to the shared PRDs folder
-const sharedPRDsFolderURL = "https://drive.google.com/drive/folders/0AJvQodV_kfUeUk9PVA"
+	// URL is the URL that the short link redirects to.
+	URL string `hcl:"url"`
+}
 
+// LinkData is a short link saved in Algolia by earlier versions of Hermes.
 type LinkData struct {
 	// ObjectID is the short link path
 	ObjectID string `json:"objectID,omitempty"`
@@ -22,8 +38,16 @@ type LinkData struct {
 	DocumentID string `json:"documentID,omitempty"`
 }
 
-// RedirectHandler handles redirects from Hashilinks
-func RedirectHandler(algo *algolia.Client, algoCfg *algolia.Config, log hclog.Logger) http.Handler {
+// RedirectHandler handles redirects from short links. Short links are looked
+// up in the configured static redirects, then in the database, and then in
+// the Algolia links index for short links saved by earlier versions of Hermes.
+func RedirectHandler(
+	algo *algolia.Client,
+	algoCfg *algolia.Config,
+	db *gorm.DB,
+	cfg *Config,
+	log hclog.Logger,
+) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Only allow GET requests.
 		if r.Method != http.MethodGet {
@@ -31,16 +55,6 @@ func RedirectHandler(algo *algolia.Client, algoCfg *algolia.Config, log hclog.Lo
 			return
 		}
 
-		// Check if the path matches exact paths such as "/l/rfc", "/l/rfc/", "/l/prd"
-		// or "/l/prd/". If so, redirect to the appropriate Google Drive shared
-		// folder URL. This is to support short link paths that existed in Hashilinks.
-		// Eg. https://go.hashi.co/rfc, https://go.hashi.co/prd.
-		redirectURL := matchStaticPathRedirects(r.URL.Path)
-		if redirectURL != "" {
-			http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
-			return
-		}
-
 		// Parse url path and validate
 		p, err := parseAndValidatePath(r.URL.Path)
 		if err != nil {
@@ -49,35 +63,108 @@ func RedirectHandler(algo *algolia.Client, algoCfg *algolia.Config, log hclog.Lo
 			return
 		}
 
-		// Get document associated with the short link path from Algolia
-		ld := LinkData{
-			ObjectID: p,
+		// Check if the path matches a static redirect (e.g., "/l/rfc" for a
+		// shared folder of RFCs).
+		if redirectURL := cfg.StaticRedirectURL(p); redirectURL != "" {
+			http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+			return
 		}
 
-		err = algo.Links.GetObject(p, &ld)
-		if err != nil {
-			log.Error("error getting redirect link from algolia", "error", err, "id", p)
-			http.Error(w, "Error getting redirect link", http.StatusInternalServerError)
+		// Get short link from the database.
+		l := models.ShortLink{
+			Path: p,
+		}
+		if err := l.Get(db); err != nil &&
+			!errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error("error getting short link from database",
+				"error", err, "short_path", p)
+			http.Error(w, "Error getting redirect link",
+				http.StatusInternalServerError)
 			return
 		}
 
-		// Redirect request
-		if ld.DocumentID != "" {
-			redirectPath := fmt.Sprintf("/document/%s", ld.DocumentID)
-			log.Info("document id for short link found",
-				"short_path", p,
-				"document_id", ld.DocumentID,
-				"redirect_path", redirectPath,
-			)
-			http.Redirect(w, r, redirectPath, http.StatusTemporaryRedirect)
+		docID := l.Document.GoogleFileID
+		if l.ID == 0 {
+			// Fall back to short links saved in Algolia.
+			docID, err = getAlgoliaRedirect(algo, p)
+			if err != nil {
+				log.Error("error getting redirect link from algolia",
+					"error", err, "id", p)
+				http.Error(w, "Error getting redirect link",
+					http.StatusInternalServerError)
+				return
+			}
+
+			// Save the short link in the database so it's found there next
+			// time.
+			if docID != "" {
+				l = models.ShortLink{
+					Path: p,
+					Document: models.Document{
+						GoogleFileID: docID,
+					},
+				}
+				if err := l.Upsert(db); err != nil {
+					log.Warn("error saving short link from algolia in database",
+						"error", err,
+						"short_path", p,
+						"document_id", docID,
+					)
+				}
+			}
+		}
+
+		// The short link doesn't exist or its document was deleted.
+		if docID == "" {
+			http.Error(w, "Short link not found", http.StatusNotFound)
+			return
 		}
+
+		// Record hit.
+		if l.ID != 0 {
+			if err := l.RecordHit(db); err != nil {
+				log.Warn("error recording short link hit",
+					"error", err, "short_path", p)
+			}
+		}
+
+		// Redirect request
+		redirectPath := fmt.Sprintf("/document/%s", docID)
+		log.Info("document id for short link found",
+			"short_path", p,
+			"document_id", docID,
+			"redirect_path", redirectPath,
+		)
+		http.Redirect(w, r, redirectPath, http.StatusTemporaryRedirect)
 	})
 }
 
+// getAlgoliaRedirect returns the ID of the document that a short link path
+// redirects to in the Algolia links index, or an empty string if the short
+// link doesn't exist.
+func getAlgoliaRedirect(algo *algolia.Client, p string) (string, error) {
+	// Only document number short links (e.g., "/rfc/lab-001") were saved in
+	// Algolia.
+	if algo == nil || algo.Links == nil || strings.Count(p, "/") != 2 {
+		return "", nil
+	}
+
+	ld := LinkData{
+		ObjectID: p,
+	}
+	if err := algo.Links.GetObject(p, &ld); err != nil {
+		if _, ok := errs.IsAlgoliaErrWithCode(err, http.StatusNotFound); ok {
+			return "", nil
+		}
+		return "", err
+	}
+	return ld.DocumentID, nil
+}
+
 // parseAndValidatePath parses the short URL that is requested on "/l"
-// route that has the format /l/doctype/product-docnumber and validates
-// that the path has only two fields and removes the "/l" prefix to help
-// get a valid short URL key to perform a look up in Algolia
+// route that has the format /l/doctype/product-docnumber or /l/alias and
+// validates that the path has one or two fields and removes the "/l" prefix
+// to help get a valid short link path to perform a look up.
 func parseAndValidatePath(p string) (string, error) {
 	// Remove redirect url path "/l"
 	p = strings.TrimPrefix(p, "/l")
@@ -89,29 +176,37 @@ func parseAndValidatePath(p string) (string, error) {
 		// Only append non-empty values, this remove
 		// any empty strings in the slice
 		if v != "" {
-			resultPath = append(resultPath, v)
+			resultPath = append(resultPath, strings.ToLower(v))
 		}
 	}
-	// Check if there are only two fields in the resultPath slice
-	// Eg. The path /rfc/lab-001 will have ["rfc", "lab-001"]
-	// otherwise, the path is invalid
-	if len(resultPath) != 2 {
+	// Check if there are one or two fields in the resultPath slice
+	// Eg. The path /rfc/lab-001 will have ["rfc", "lab-001"] and the path
+	// /vault-ha will have ["vault-ha"], otherwise, the path is invalid
+	if len(resultPath) < 1 || len(resultPath) > 2 {
 		return "", fmt.Errorf("invalid url path")
 	}
 
-	return fmt.Sprintf("/%s/%s",
-		strings.ToLower(resultPath[0]), strings.ToLower(resultPath[1])), nil
+	return "/" + strings.Join(resultPath, "/"), nil
 }
 
-// matchStaticPathRedirects matches the URL path provided
-// with string paths and returns the appropriate
-// redirect URL
-func matchStaticPathRedirects(p string) string {
-	switch {
-	case p == "/l/rfc" || p == "/l/rfc/":
-		return sharedRFCsFolderURL
-	case p == "/l/prd" || p == "/l/prd/":
-		return sharedPRDsFolderURL
+// StaticRedirectURL returns the URL that the static redirect with short link
+// path p (without the "/l" prefix) redirects to, or an empty string if there
+// is no such static redirect.
+func (c *Config) StaticRedirectURL(p string) string {
+	if c == nil {
+		return ""
+	}
+	p = normalizePath(p)
+	for _, sr := range c.StaticRedirects {
+		if p == normalizePath(sr.Path) {
+			return sr.URL
+		}
 	}
 	return ""
 }
+
+// normalizePath returns a lowercase short link path with a leading slash and
+// without a trailing slash.
+func normalizePath(p string) string {
+	return "/" + strings.Trim(strings.ToLower(strings.TrimSpace(p)), "/")
+}
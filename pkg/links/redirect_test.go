@@ -0,0 +1,73 @@
+package links
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAndValidatePath(t *testing.T) {
+	cases := map[string]struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		"document number": {
+			path: "/l/RFC/LAB-001",
+			want: "/rfc/lab-001",
+		},
+		"trailing slash": {
+			path: "/l/rfc/lab-001/",
+			want: "/rfc/lab-001",
+		},
+		"alias": {
+			path: "/l/Vault-HA",
+			want: "/vault-ha",
+		},
+		"empty": {
+			path:    "/l/",
+			wantErr: true,
+		},
+		"too many fields": {
+			path:    "/l/rfc/lab-001/extra",
+			wantErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, err := parseAndValidatePath(c.path)
+			if c.wantErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+				assert.Equal(c.want, got)
+			}
+		})
+	}
+}
+
+func TestStaticRedirectURL(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := &Config{
+		StaticRedirects: []*StaticRedirect{
+			{
+				Path: "/RFC/",
+				URL:  "https://example.com/rfcs",
+			},
+			{
+				Path: "prd",
+				URL:  "https://example.com/prds",
+			},
+		},
+	}
+	assert.Equal("https://example.com/rfcs", cfg.StaticRedirectURL("/rfc"))
+	assert.Equal("https://example.com/prds", cfg.StaticRedirectURL("/prd/"))
+	assert.Empty(cfg.StaticRedirectURL("/rfc/lab-001"))
+
+	var nilCfg *Config
+	assert.Empty(nilCfg.StaticRedirectURL("/rfc"))
+}
@@ -0,0 +1,211 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+)
+
+// APIToken is a model for a Hermes-issued API token used for machine access.
+// Only a hash of the token is stored.
+type APIToken struct {
+	gorm.Model
+
+	// Name is a user-provided name to identify the token.
+	Name string `gorm:"not null"`
+
+	// Kind is the kind of token.
+	Kind APITokenKind `gorm:"default:null;not null"`
+
+	// TokenHash is the SHA-256 hash (hex-encoded) of the token.
+	TokenHash string `gorm:"default:null;not null;uniqueIndex"`
+
+	// TokenPrefix is the beginning of the token, which is displayed to help
+	// users identify their tokens.
+	TokenPrefix string `gorm:"not null"`
+
+	// Scopes is a space-separated list of scopes (e.g., "documents:read
+	// drafts:write") granted to the token.
+	Scopes string `gorm:"not null"`
+
+	// ServiceAccount is the name of the service account that the token
+	// authenticates as. It is only set for service account tokens.
+	ServiceAccount string
+
+	// User is the user that created the token. Personal access tokens
+	// authenticate as this user.
+	User   User
+	UserID uint `gorm:"index;not null"`
+
+	// ExpiresAt is the time that the token expires.
+	ExpiresAt time.Time `gorm:"not null"`
+
+	// LastUsedAt is the time that the token was last used to authenticate a
+	// request.
+	LastUsedAt *time.Time
+
+	// RevokedAt is the time that the token was revoked.
+	RevokedAt *time.Time
+}
+
+// APITokenKind is the kind of an API token.
+type APITokenKind string
+
+const (
+	// PersonalAPITokenKind is a personal access token, which authenticates as
+	// the user that created it.
+	PersonalAPITokenKind APITokenKind = "personal"
+
+	// ServiceAccountAPITokenKind is a service account token, which
+	// authenticates as a service account.
+	ServiceAccountAPITokenKind APITokenKind = "service_account"
+)
+
+// ScopeList returns the scopes of the token.
+func (t *APIToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+// IsActive returns true if the token has not expired or been revoked at time
+// now.
+func (t *APIToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// Create creates an API token in database db. Required fields in the
+// receiver:
+//   - Name
+//   - Kind
+//   - TokenHash
+//   - Scopes
+//   - User email address
+//   - ExpiresAt
+func (t *APIToken) Create(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(t,
+		validation.Field(&t.Name, validation.Required),
+		validation.Field(&t.Kind, validation.Required,
+			validation.In(PersonalAPITokenKind, ServiceAccountAPITokenKind)),
+		validation.Field(&t.TokenHash, validation.Required),
+		validation.Field(&t.Scopes, validation.Required),
+		validation.Field(&t.ServiceAccount,
+			validation.When(t.Kind == ServiceAccountAPITokenKind,
+				validation.Required).Else(validation.Empty)),
+		validation.Field(&t.ExpiresAt, validation.Required),
+	); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(&t.User,
+		validation.Field(&t.User.EmailAddress, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Find or create user.
+		if err := t.User.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error finding or creating user: %w", err)
+		}
+		t.UserID = t.User.ID
+
+		return tx.
+			Omit("User").
+			Create(&t).
+			Error
+	})
+}
+
+// GetByHash gets an API token by its hash from database db, and assigns it
+// to the receiver.
+func (t *APIToken) GetByHash(db *gorm.DB, hash string) error {
+	// Validate required fields.
+	if err := validation.Validate(hash, validation.Required); err != nil {
+		return err
+	}
+
+	return db.
+		Preload("User").
+		Where(APIToken{TokenHash: hash}).
+		First(&t).
+		Error
+}
+
+// Revoke revokes the API token with the ID in the receiver, if it was created
+// by the user with the email address in the receiver. It returns
+// gorm.ErrRecordNotFound if no such token exists.
+func (t *APIToken) Revoke(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(t,
+		validation.Field(&t.ID, validation.Required),
+	); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(&t.User,
+		validation.Field(&t.User.EmailAddress, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Joins("JOIN users ON users.id = api_tokens.user_id").
+			Where("users.email_address = ?", t.User.EmailAddress).
+			Preload("User").
+			First(&t, t.ID).
+			Error; err != nil {
+			return err
+		}
+
+		if t.RevokedAt != nil {
+			return nil
+		}
+		now := time.Now().UTC()
+		t.RevokedAt = &now
+		return tx.
+			Model(&t).
+			UpdateColumn("revoked_at", now).
+			Error
+	})
+}
+
+// UpdateLastUsedAt sets the last used time of the API token with the ID in
+// the receiver.
+func (t *APIToken) UpdateLastUsedAt(db *gorm.DB, at time.Time) error {
+	// Validate required fields.
+	if err := validation.Validate(t.ID, validation.Required); err != nil {
+		return err
+	}
+
+	t.LastUsedAt = &at
+	return db.
+		Model(&APIToken{}).
+		Where("id = ?", t.ID).
+		UpdateColumn("last_used_at", at).
+		Error
+}
+
+// GetUserAPITokens gets all API tokens created by the user with email address
+// userEmail, ordered by creation time (newest first).
+func GetUserAPITokens(db *gorm.DB, userEmail string) ([]APIToken, error) {
+	if err := validation.Validate(userEmail, validation.Required); err != nil {
+		return nil, err
+	}
+
+	var tokens []APIToken
+	err := db.
+		Joins("JOIN users ON users.id = api_tokens.user_id").
+		Where("users.email_address = ?", userEmail).
+		Preload("User").
+		Order("api_tokens.created_at DESC").
+		Find(&tokens).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return tokens, err
+}
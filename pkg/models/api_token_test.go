@@ -0,0 +1,105 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAPITokenModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	t.Run("Create, get, and revoke", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		var tok APIToken
+		t.Run("Create a personal access token", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			tok = APIToken{
+				Name:        "token1",
+				Kind:        PersonalAPITokenKind,
+				TokenHash:   "hash1",
+				TokenPrefix: "hermes_pat_abcd1234",
+				Scopes:      "documents:read drafts:write",
+				User: User{
+					EmailAddress: "a@a.com",
+				},
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+			require.NoError(tok.Create(db))
+			require.NotZero(tok.ID)
+		})
+
+		t.Run("Create a service account token without a service account",
+			func(t *testing.T) {
+				_, require := assert.New(t), require.New(t)
+				sat := APIToken{
+					Name:        "token2",
+					Kind:        ServiceAccountAPITokenKind,
+					TokenHash:   "hash2",
+					TokenPrefix: "hermes_sat_abcd1234",
+					Scopes:      "documents:read",
+					User: User{
+						EmailAddress: "a@a.com",
+					},
+					ExpiresAt: time.Now().Add(time.Hour),
+				}
+				require.Error(sat.Create(db))
+			})
+
+		t.Run("Get the token by hash", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			var got APIToken
+			require.NoError(got.GetByHash(db, "hash1"))
+			assert.Equal(tok.ID, got.ID)
+			assert.Equal("a@a.com", got.User.EmailAddress)
+			assert.Equal([]string{"documents:read", "drafts:write"},
+				got.ScopeList())
+			assert.True(got.IsActive(time.Now()))
+			assert.False(got.IsActive(time.Now().Add(2 * time.Hour)))
+		})
+
+		t.Run("Get user tokens", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			toks, err := GetUserAPITokens(db, "a@a.com")
+			require.NoError(err)
+			require.Len(toks, 1)
+			assert.Equal(tok.ID, toks[0].ID)
+
+			toks, err = GetUserAPITokens(db, "b@b.com")
+			require.NoError(err)
+			assert.Empty(toks)
+		})
+
+		t.Run("Revoke the token as another user", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			rt := APIToken{
+				Model: gorm.Model{ID: tok.ID},
+				User:  User{EmailAddress: "b@b.com"},
+			}
+			require.ErrorIs(rt.Revoke(db), gorm.ErrRecordNotFound)
+		})
+
+		t.Run("Revoke the token", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			rt := APIToken{
+				Model: gorm.Model{ID: tok.ID},
+				User:  User{EmailAddress: "a@a.com"},
+			}
+			require.NoError(rt.Revoke(db))
+
+			var got APIToken
+			require.NoError(got.GetByHash(db, "hash1"))
+			require.NotNil(got.RevokedAt)
+			assert.False(got.IsActive(time.Now()))
+		})
+	})
+}
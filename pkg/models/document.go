@@ -21,6 +21,10 @@ type Document struct {
 	// document.
 	Approvers []*User `gorm:"many2many:document_reviews;"`
 
+	// ArchivedAt is the time the document was archived by a retention policy,
+	// or nil if it isn't archived.
+	ArchivedAt *time.Time `gorm:"index"`
+
 	// ApproverGroups is the list of groups whose approval is requested for the
 	// document.
 	ApproverGroups []*Group `gorm:"many2many:document_group_reviews;"`
@@ -42,6 +46,22 @@ type Document struct {
 	// (e.g., "TF-123").
 	DocumentNumber int `gorm:"index:latest_product_number"`
 
+	// DocumentNumberYear is the year the document number was assigned, for
+	// numbering schemes with numbers that restart each year (zero otherwise).
+	DocumentNumberYear int
+
+	// DocumentNumberSequence is the key of the numbering sequence the document
+	// number was assigned in (see DocumentNumberSequenceKey). It is empty for
+	// documents numbered before sequences were recorded, until it is set with
+	// SetDocumentNumberSequences.
+	DocumentNumberSequence string
+
+	// FormattedDocumentNumber is the document number formatted with the
+	// numbering scheme of the document type when it was assigned (e.g.,
+	// "TF-2024-007"). It is empty for documents numbered before numbering
+	// schemes were configurable, which use the default format.
+	FormattedDocumentNumber string
+
 	// DocumentType is the document type.
 	DocumentType   DocumentType
 	DocumentTypeID uint
@@ -66,6 +86,18 @@ type Document struct {
 	// RelatedResources are the related resources for the document.
 	RelatedResources []*DocumentRelatedResource
 
+	// RetentionFlaggedAt is the time the document was flagged by a retention
+	// policy, or nil if it isn't flagged.
+	RetentionFlaggedAt *time.Time
+
+	// RetentionOptOut is true if the document's owner opted it out of retention
+	// policies.
+	RetentionOptOut bool
+
+	// StaleDraftKeptAt is the time the owner of a stale draft chose to keep it.
+	// The draft isn't stale again until the stale threshold has passed since.
+	StaleDraftKeptAt *time.Time
+
 	// Status is the status of the document.
 	Status DocumentStatus
 
@@ -76,9 +108,17 @@ type Document struct {
 	// Summary is a summary of the document.
 	Summary *string
 
+	// Tags are the tags of the document. They are managed using ReplaceTags.
+	Tags []*Tag `gorm:"many2many:document_tags;"`
+
 	// Title is the title of the document. It only contains the title, and not the
 	// product abbreviation, document number, or document type.
 	Title string
+
+	// TrashedAt is the time the document (a draft) was moved to the trash, or
+	// nil if it isn't in the trash. Documents in the trash can be restored until
+	// they are purged.
+	TrashedAt *time.Time `gorm:"index"`
 }
 
 // Documents is a slice of documents.
@@ -96,6 +136,36 @@ const (
 	ObsoleteDocumentStatus
 )
 
+// String returns the name of the document status (e.g., "In-Review").
+func (s DocumentStatus) String() string {
+	switch s {
+	case WIPDocumentStatus:
+		return "WIP"
+	case InReviewDocumentStatus:
+		return "In-Review"
+	case ApprovedDocumentStatus:
+		return "Approved"
+	case ObsoleteDocumentStatus:
+		return "Obsolete"
+	default:
+		return ""
+	}
+}
+
+// DocNumber returns the formatted document number (e.g., "TF-123"), or the
+// product abbreviation with "-???" (e.g., "TF-???") if the document doesn't
+// have a number.
+func (d Document) DocNumber() string {
+	switch {
+	case d.DocumentNumber == 0:
+		return fmt.Sprintf("%s-???", d.Product.Abbreviation)
+	case d.FormattedDocumentNumber != "":
+		return d.FormattedDocumentNumber
+	default:
+		return fmt.Sprintf("%s-%03d", d.Product.Abbreviation, d.DocumentNumber)
+	}
+}
+
 // BeforeSave is a hook used to find associations before saving.
 func (d *Document) BeforeSave(tx *gorm.DB) error {
 	if err := d.getAssociations(tx); err != nil {
@@ -172,6 +242,200 @@ func (d *Document) Delete(db *gorm.DB) error {
 		Error
 }
 
+// documentStateColumns are the columns for a document's trash and retention
+// state, which aren't updated by Upsert.
+var documentStateColumns = []string{
+	"archived_at",
+	"retention_flagged_at",
+	"retention_opt_out",
+	"stale_draft_kept_at",
+	"trashed_at",
+}
+
+// Archive marks the document with the Google file ID in the receiver as
+// archived at time t.
+func (d *Document) Archive(db *gorm.DB, t time.Time) error {
+	if err := d.updateStateColumn(db, "archived_at", t); err != nil {
+		return err
+	}
+	d.ArchivedAt = &t
+
+	return nil
+}
+
+// FlagForRetention marks the document with the Google file ID in the receiver
+// as flagged by a retention policy at time t.
+func (d *Document) FlagForRetention(db *gorm.DB, t time.Time) error {
+	if err := d.updateStateColumn(db, "retention_flagged_at", t); err != nil {
+		return err
+	}
+	d.RetentionFlaggedAt = &t
+
+	return nil
+}
+
+// KeepStaleDraft marks the draft with the Google file ID in the receiver as
+// kept by its owner at time t.
+func (d *Document) KeepStaleDraft(db *gorm.DB, t time.Time) error {
+	if err := d.updateStateColumn(db, "stale_draft_kept_at", t); err != nil {
+		return err
+	}
+	d.StaleDraftKeptAt = &t
+
+	return nil
+}
+
+// SetRetentionOptOut sets if the document with the Google file ID in the
+// receiver is opted out of retention policies.
+func (d *Document) SetRetentionOptOut(db *gorm.DB, optOut bool) error {
+	if err := d.updateStateColumn(db, "retention_opt_out", optOut); err != nil {
+		return err
+	}
+	d.RetentionOptOut = optOut
+
+	return nil
+}
+
+// updateStateColumn updates a trash or retention state column of the document
+// with the Google file ID in the receiver.
+func (d *Document) updateStateColumn(
+	db *gorm.DB, column string, value any) error {
+	if err := validation.ValidateStruct(d,
+		validation.Field(&d.GoogleFileID, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.
+		Model(&Document{}).
+		Where(Document{GoogleFileID: d.GoogleFileID}).
+		UpdateColumn(column, value).
+		Error
+}
+
+// Trash marks the document with the Google file ID in the receiver as moved to
+// the trash at time t.
+func (d *Document) Trash(db *gorm.DB, t time.Time) error {
+	if err := d.updateStateColumn(db, "trashed_at", t); err != nil {
+		return err
+	}
+	d.TrashedAt = &t
+
+	return nil
+}
+
+// Restore marks the document with the Google file ID in the receiver as no
+// longer in the trash.
+func (d *Document) Restore(db *gorm.DB) error {
+	if err := d.updateStateColumn(db, "trashed_at", nil); err != nil {
+		return err
+	}
+	d.TrashedAt = nil
+
+	return nil
+}
+
+// GetTrashedDocuments gets all documents in the trash that are owned by the
+// user with email address ownerEmail (or all owners, if empty) and were moved
+// to the trash before time before, most recently trashed first.
+func GetTrashedDocuments(
+	db *gorm.DB, ownerEmail string, before time.Time) ([]Document, error) {
+	q := db.
+		Where("documents.trashed_at IS NOT NULL").
+		Where("documents.trashed_at < ?", before)
+	if ownerEmail != "" {
+		q = q.
+			Joins("JOIN users ON users.id = documents.owner_id").
+			Where("users.email_address = ?", ownerEmail)
+	}
+
+	var docs []Document
+	err := q.
+		Preload(clause.Associations).
+		Order("documents.trashed_at DESC").
+		Find(&docs).
+		Error
+	return docs, err
+}
+
+// GetStaleDrafts gets all drafts (not in the trash or archived) that are owned
+// by the user with email address ownerEmail (or all owners, if empty), and
+// were last modified and kept before time before, least recently modified
+// first.
+func GetStaleDrafts(
+	db *gorm.DB, ownerEmail string, before time.Time) ([]Document, error) {
+	q := db.
+		Where("documents.status = ? AND NOT documents.imported",
+			WIPDocumentStatus).
+		Where("documents.trashed_at IS NULL AND documents.archived_at IS NULL").
+		Where("documents.document_modified_at < ?", before).
+		Where("(documents.stale_draft_kept_at IS NULL OR "+
+			"documents.stale_draft_kept_at < ?)", before)
+	if ownerEmail != "" {
+		q = q.
+			Joins("JOIN users ON users.id = documents.owner_id").
+			Where("users.email_address = ?", ownerEmail)
+	}
+
+	var docs []Document
+	err := q.
+		Preload(clause.Associations).
+		Order("documents.document_modified_at").
+		Find(&docs).
+		Error
+	return docs, err
+}
+
+// DocumentFilter filters documents by document type, product, and status. Empty
+// fields match all documents.
+type DocumentFilter struct {
+	DocumentTypes []string
+	Products      []string
+	Statuses      []DocumentStatus
+}
+
+// GetDocuments gets all documents (not in the trash) that match filter f,
+// ordered by product, document type, and document number.
+func GetDocuments(db *gorm.DB, f DocumentFilter) ([]Document, error) {
+	q := db.
+		Joins("JOIN document_types ON document_types.id = documents.document_type_id").
+		Joins("JOIN products ON products.id = documents.product_id").
+		Where("documents.trashed_at IS NULL")
+	if len(f.DocumentTypes) > 0 {
+		q = q.Where("document_types.name IN ?", f.DocumentTypes)
+	}
+	if len(f.Products) > 0 {
+		q = q.Where("products.name IN ?", f.Products)
+	}
+	if len(f.Statuses) > 0 {
+		q = q.Where("documents.status IN ?", f.Statuses)
+	}
+
+	var docs []Document
+	err := q.
+		Preload(clause.Associations).
+		Order("products.name, document_types.name, documents.document_number").
+		Find(&docs).
+		Error
+	return docs, err
+}
+
+// GetRetentionCandidates gets all documents that can be archived or flagged by
+// retention policies (not in the trash, archived, or opted out) and were last
+// modified before time before, least recently modified first.
+func GetRetentionCandidates(db *gorm.DB, before time.Time) ([]Document, error) {
+	var docs []Document
+	err := db.
+		Where("trashed_at IS NULL AND archived_at IS NULL").
+		Where("NOT retention_opt_out").
+		Where("document_modified_at < ?", before).
+		Preload(clause.Associations).
+		Order("document_modified_at").
+		Find(&docs).
+		Error
+	return docs, err
+}
+
 // Find finds all documents from database db with the provided query, and
 // assigns them to the receiver.
 func (d *Documents) Find(
@@ -230,6 +494,119 @@ func (d *Document) Get(db *gorm.DB) error {
 	return nil
 }
 
+// GetDocumentByNumber gets the document with document number number for a
+// document type and product.
+func GetDocumentByNumber(db *gorm.DB,
+	documentTypeName, productName string, number int) (Document, error) {
+	// Validate required fields.
+	if err := validation.Validate(db, validation.Required); err != nil {
+		return Document{}, err
+	}
+	if err := validation.Validate(documentTypeName, validation.Required); err != nil {
+		return Document{}, err
+	}
+	if err := validation.Validate(productName, validation.Required); err != nil {
+		return Document{}, err
+	}
+	if err := validation.Validate(number, validation.Required); err != nil {
+		return Document{}, err
+	}
+
+	var d Document
+	if err := db.
+		Joins("JOIN document_types ON document_types.id = documents.document_type_id").
+		Joins("JOIN products ON products.id = documents.product_id").
+		Where("document_types.name = ? AND products.name = ?",
+			documentTypeName, productName).
+		Where("documents.document_number = ?", number).
+		Preload(clause.Associations).
+		First(&d).
+		Error; err != nil {
+		return Document{}, err
+	}
+
+	return d, nil
+}
+
+// GetDocumentBySequenceNumber gets the document with number in the sequence
+// shared by document types documentTypeNames in a product. If year is not zero,
+// only numbers assigned in that year are considered.
+func GetDocumentBySequenceNumber(db *gorm.DB, productName string,
+	documentTypeNames []string, year, number int) (Document, error) {
+	// Validate required fields.
+	if err := validation.Validate(db, validation.Required); err != nil {
+		return Document{}, err
+	}
+	if err := validation.Validate(productName, validation.Required); err != nil {
+		return Document{}, err
+	}
+	if err := validation.Validate(
+		documentTypeNames, validation.Required); err != nil {
+		return Document{}, err
+	}
+	if err := validation.Validate(number, validation.Required); err != nil {
+		return Document{}, err
+	}
+
+	q := db.
+		Joins("JOIN document_types ON document_types.id = documents.document_type_id").
+		Joins("JOIN products ON products.id = documents.product_id").
+		Where("products.name = ?", productName).
+		Where("document_types.name IN ?", documentTypeNames).
+		Where("documents.document_number = ?", number)
+	if year != 0 {
+		q = q.Where("documents.document_number_year = ?", year)
+	}
+
+	var d Document
+	if err := q.
+		Preload(clause.Associations).
+		First(&d).
+		Error; err != nil {
+		return Document{}, err
+	}
+
+	return d, nil
+}
+
+// GetLatestDocumentNumber gets the latest document number in the sequence
+// shared by document types documentTypeNames in a product. If year is not
+// zero, only numbers assigned in that year are considered.
+func GetLatestDocumentNumber(db *gorm.DB,
+	productName string, documentTypeNames []string, year int) (int, error) {
+	// Validate required fields.
+	if err := validation.Validate(db, validation.Required); err != nil {
+		return 0, err
+	}
+	if err := validation.Validate(productName, validation.Required); err != nil {
+		return 0, err
+	}
+	if err := validation.Validate(
+		documentTypeNames, validation.Required); err != nil {
+		return 0, err
+	}
+
+	q := db.
+		Model(&Document{}).
+		Joins("JOIN document_types ON document_types.id = documents.document_type_id").
+		Joins("JOIN products ON products.id = documents.product_id").
+		Where("products.name = ?", productName).
+		Where("document_types.name IN ?", documentTypeNames)
+	if year != 0 {
+		q = q.Where("documents.document_number_year = ?", year)
+	}
+
+	var latest int
+	if err := q.
+		Select("COALESCE(MAX(documents.document_number), 0)").
+		Scan(&latest).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return latest, nil
+}
+
 // GetLatestProductNumber gets the latest document number for a product.
 func GetLatestProductNumber(db *gorm.DB,
 	documentTypeName, productName string) (int, error) {
@@ -405,6 +782,56 @@ func (d *Document) ReplaceRelatedResources(
 	return nil
 }
 
+// ReplaceTags replaces the tags of document d with the tags with the provided
+// names, creating tags that don't exist. Names should be normalized using
+// NormalizeTagNames.
+func (d *Document) ReplaceTags(db *gorm.DB, names []string) error {
+	if err := validation.ValidateStruct(d,
+		validation.Field(
+			&d.ID,
+			validation.When(d.GoogleFileID == "",
+				validation.Required.Error("either ID or GoogleFileID is required"),
+			),
+		),
+		validation.Field(
+			&d.GoogleFileID,
+			validation.When(d.ID == 0,
+				validation.Required.Error("either ID or GoogleFileID is required"),
+			),
+		),
+	); err != nil {
+		return err
+	}
+
+	// Get document ID if not known.
+	if d.ID == 0 {
+		doc := &Document{
+			GoogleFileID: d.GoogleFileID,
+		}
+		if err := doc.Get(db); err != nil {
+			return fmt.Errorf("error getting document: %w", err)
+		}
+		d.ID = doc.ID
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		tags, err := findOrCreateTags(tx, names)
+		if err != nil {
+			return err
+		}
+		d.Tags = make([]*Tag, len(tags))
+		for i := range tags {
+			d.Tags[i] = &tags[i]
+		}
+
+		return tx.
+			Session(&gorm.Session{SkipHooks: true}).
+			Model(&d).
+			Association("Tags").
+			Replace(d.Tags)
+	})
+}
+
 // GetRelatedResources returns typed related resources for document d.
 func (d *Document) GetRelatedResources(db *gorm.DB) (
 	elrrs []DocumentRelatedResourceExternalLink,
@@ -499,7 +926,10 @@ func (d *Document) Upsert(db *gorm.DB) error {
 			Model(&d).
 			Where(Document{GoogleFileID: d.GoogleFileID}).
 			Select("*").
-			Omit(clause.Associations). // We manage associations in the BeforeSave hook.
+			// We manage associations in the BeforeSave hook, and trash and
+			// retention columns with their own methods.
+			Omit(append([]string{clause.Associations},
+				documentStateColumns...)...).
 			Assign(*d).
 			FirstOrCreate(&d).
 			Error; err != nil {
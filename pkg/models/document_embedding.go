@@ -0,0 +1,205 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+)
+
+// DocumentEmbedding is a model for the embedding of a chunk of a document's
+// content, used for semantic search. It requires the pgvector PostgreSQL
+// extension, so it is migrated separately using MigrateDocumentEmbeddings.
+type DocumentEmbedding struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	// Document is the document that the chunk is from.
+	Document   Document
+	DocumentID uint `gorm:"index;not null"`
+
+	// ChunkIndex is the position of the chunk in the document's content.
+	ChunkIndex int `gorm:"not null"`
+
+	// Content is the text of the chunk.
+	Content string `gorm:"not null"`
+
+	// Embedding is the embedding of the chunk's content.
+	Embedding Vector `gorm:"type:vector;not null"`
+
+	// Model identifies the model that computed the embedding. Embeddings from
+	// different models aren't comparable.
+	Model string `gorm:"index;not null"`
+}
+
+// DocumentEmbeddings is a slice of document embeddings.
+type DocumentEmbeddings []DocumentEmbedding
+
+// DocumentSimilarity is the cosine distance of a document to an embedding.
+type DocumentSimilarity struct {
+	DocumentID   uint
+	GoogleFileID string
+	Distance     float64
+}
+
+// Vector is a pgvector vector.
+type Vector []float32
+
+// Scan implements the sql.Scanner interface.
+func (v *Vector) Scan(src any) error {
+	var s string
+	switch src := src.(type) {
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return fmt.Errorf("unsupported type for vector: %T", src)
+	}
+
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return fmt.Errorf("invalid vector: %q", s)
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	res := Vector{}
+	if s != "" {
+		for _, f := range strings.Split(s, ",") {
+			x, err := strconv.ParseFloat(strings.TrimSpace(f), 32)
+			if err != nil {
+				return fmt.Errorf("invalid vector element %q: %w", f, err)
+			}
+			res = append(res, float32(x))
+		}
+	}
+	*v = res
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (v Vector) Value() (driver.Value, error) {
+	var b strings.Builder
+	b.WriteString("[")
+	for i, x := range v {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(strconv.FormatFloat(float64(x), 'g', -1, 32))
+	}
+	b.WriteString("]")
+	return b.String(), nil
+}
+
+// MigrateDocumentEmbeddings enables the pgvector extension and migrates the
+// document embeddings table in database db.
+func MigrateDocumentEmbeddings(db *gorm.DB) error {
+	if err := db.
+		Exec("CREATE EXTENSION IF NOT EXISTS vector;").
+		Error; err != nil {
+		return fmt.Errorf("error enabling vector extension: %w", err)
+	}
+
+	return db.AutoMigrate(&DocumentEmbedding{})
+}
+
+// Find finds the embeddings of a document computed by a model in database db,
+// ordered by chunk index, and assigns them to the receiver.
+func (e *DocumentEmbeddings) Find(
+	db *gorm.DB, documentID uint, model string) error {
+	if err := validation.Validate(documentID, validation.Required); err != nil {
+		return err
+	}
+
+	return db.
+		Where(DocumentEmbedding{DocumentID: documentID, Model: model}).
+		Order("chunk_index").
+		Find(&e).
+		Error
+}
+
+// ReplaceDocumentEmbeddings replaces all embeddings of a document with the
+// embeddings of its chunks, computed by a model.
+func ReplaceDocumentEmbeddings(
+	db *gorm.DB,
+	documentID uint,
+	model string,
+	chunks []string,
+	embeddings [][]float32,
+) error {
+	if err := validation.Validate(documentID, validation.Required); err != nil {
+		return err
+	}
+	if len(chunks) != len(embeddings) {
+		return errors.New("number of chunks and embeddings must be equal")
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("document_id = ?", documentID).
+			Delete(&DocumentEmbedding{}).
+			Error; err != nil {
+			return fmt.Errorf("error deleting embeddings: %w", err)
+		}
+		if len(chunks) == 0 {
+			return nil
+		}
+
+		var es []DocumentEmbedding
+		for i, c := range chunks {
+			es = append(es, DocumentEmbedding{
+				DocumentID: documentID,
+				ChunkIndex: i,
+				Content:    c,
+				Embedding:  embeddings[i],
+				Model:      model,
+			})
+		}
+		if err := tx.
+			Omit("Document").
+			Create(&es).
+			Error; err != nil {
+			return fmt.Errorf("error creating embeddings: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// SearchDocumentEmbeddings returns up to limit documents with a chunk within
+// maxDistance cosine distance of an embedding computed by a model, ordered by
+// the distance of their closest chunk. The document with ID excludeID (if not
+// 0) is excluded.
+func SearchDocumentEmbeddings(
+	db *gorm.DB,
+	embedding []float32,
+	model string,
+	maxDistance float64,
+	excludeID uint,
+	limit int,
+) ([]DocumentSimilarity, error) {
+	var res []DocumentSimilarity
+	err := db.
+		Model(&DocumentEmbedding{}).
+		Select("document_embeddings.document_id, documents.google_file_id, "+
+			"MIN(document_embeddings.embedding <=> ?) AS distance",
+			Vector(embedding)).
+		Joins("JOIN documents ON documents.id = document_embeddings.document_id").
+		Where("documents.deleted_at IS NULL").
+		Where("document_embeddings.model = ?", model).
+		Where("document_embeddings.document_id != ?", excludeID).
+		Group("document_embeddings.document_id, documents.google_file_id").
+		Having("MIN(document_embeddings.embedding <=> ?) <= ?",
+			Vector(embedding), maxDistance).
+		Order("distance").
+		Limit(limit).
+		Scan(&res).
+		Error
+
+	return res, err
+}
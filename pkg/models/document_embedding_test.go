@@ -0,0 +1,114 @@
+package models
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVector(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	v := Vector{0.5, -1, 0.25}
+	val, err := v.Value()
+	require.NoError(err)
+	assert.Equal("[0.5,-1,0.25]", val)
+
+	var got Vector
+	require.NoError(got.Scan([]byte("[0.5, -1, 0.25]")))
+	assert.Equal(v, got)
+
+	require.NoError(got.Scan("[]"))
+	assert.Equal(Vector{}, got)
+
+	assert.Error(got.Scan("0.5,1"))
+	assert.Error(got.Scan("[a]"))
+	assert.Error(got.Scan(1))
+}
+
+func TestDocumentEmbeddingModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	t.Run("Replace and search embeddings", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		if err := MigrateDocumentEmbeddings(db); err != nil {
+			t.Skipf("pgvector isn't available: %v", err)
+		}
+
+		t.Run("Create a document type", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			dt := DocumentType{
+				Name:     "DT1",
+				LongName: "DocumentType1",
+			}
+			require.NoError(dt.FirstOrCreate(db))
+		})
+
+		t.Run("Create a product", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			p := Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			require.NoError(p.FirstOrCreate(db))
+		})
+
+		var d1, d2 Document
+		t.Run("Create documents with embeddings", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			d1 = Document{
+				GoogleFileID: "fileID1",
+				DocumentType: DocumentType{Name: "DT1"},
+				Product:      Product{Name: "Product1"},
+			}
+			require.NoError(d1.Create(db))
+			d2 = Document{
+				GoogleFileID: "fileID2",
+				DocumentType: DocumentType{Name: "DT1"},
+				Product:      Product{Name: "Product1"},
+			}
+			require.NoError(d2.Create(db))
+
+			require.NoError(ReplaceDocumentEmbeddings(db, d1.ID, "m1",
+				[]string{"a", "b"}, [][]float32{{1, 0}, {0, 1}}))
+			require.NoError(ReplaceDocumentEmbeddings(db, d2.ID, "m1",
+				[]string{"c"}, [][]float32{{0.6, 0.8}}))
+
+			// Replacing embeddings deletes the previous ones.
+			require.NoError(ReplaceDocumentEmbeddings(db, d1.ID, "m1",
+				[]string{"a"}, [][]float32{{1, 0}}))
+			var es DocumentEmbeddings
+			require.NoError(es.Find(db, d1.ID, "m1"))
+			require.Len(es, 1)
+			assert.Equal("a", es[0].Content)
+			assert.Equal(Vector{1, 0}, es[0].Embedding)
+		})
+
+		t.Run("Search embeddings", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			res, err := SearchDocumentEmbeddings(
+				db, []float32{0, 1}, "m1", 2, 0, 10)
+			require.NoError(err)
+			require.Len(res, 2)
+			assert.Equal("fileID2", res[0].GoogleFileID)
+			assert.InDelta(0.2, res[0].Distance, 0.0001)
+
+			res, err = SearchDocumentEmbeddings(
+				db, []float32{0, 1}, "m1", 0.5, d2.ID, 10)
+			require.NoError(err)
+			assert.Empty(res)
+
+			res, err = SearchDocumentEmbeddings(
+				db, []float32{0, 1}, "m2", 2, 0, 10)
+			require.NoError(err)
+			assert.Empty(res)
+		})
+	})
+}
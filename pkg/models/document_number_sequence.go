@@ -0,0 +1,245 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// DocumentNumberIndexName is the name of the unique index of document numbers
+	// per product, numbering sequence, and year.
+	DocumentNumberIndexName = "idx_documents_document_number_sequence"
+
+	// legacyDocumentNumberIndexName is the name of the unique index of document
+	// numbers per product, document type, and year, which is replaced by the
+	// index named DocumentNumberIndexName.
+	legacyDocumentNumberIndexName = "idx_documents_document_number"
+
+	// documentNumberSequenceExpr is the SQL expression of the numbering sequence
+	// of a document. Documents without a recorded sequence are in the sequence
+	// of their document type, like documents numbered with the default scheme.
+	documentNumberSequenceExpr = "COALESCE(NULLIF(document_number_sequence, ''), " +
+		"'document_type_id:' || document_type_id)"
+)
+
+// ErrDuplicateDocumentNumbers is returned when the unique index of document
+// numbers can't be created because documents have duplicate numbers.
+var ErrDuplicateDocumentNumbers = errors.New("duplicate document numbers")
+
+// DocumentNumberSequence is a model for the latest document number assigned in
+// a sequence of document numbers. Rows are locked while document numbers are
+// assigned, so concurrent transactions can't assign the same number.
+type DocumentNumberSequence struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Product   Product
+	ProductID uint `gorm:"primaryKey;autoIncrement:false"`
+
+	// DocumentTypes are the sorted, comma-separated names of the document types
+	// that share the sequence.
+	DocumentTypes string `gorm:"primaryKey"`
+
+	// Year is the year of the sequence, for numbering schemes with numbers that
+	// restart each year (zero otherwise).
+	Year int `gorm:"primaryKey;autoIncrement:false"`
+
+	// LatestDocumentNumber is the latest document number assigned in the
+	// sequence.
+	LatestDocumentNumber int `gorm:"not null"`
+}
+
+// DocumentNumberDuplicate is a document number used by more than one document
+// in the same numbering sequence of a product.
+type DocumentNumberDuplicate struct {
+	ProductID          uint
+	Sequence           string
+	DocumentNumber     int
+	DocumentNumberYear int
+	Count              int
+}
+
+// DocumentNumberSequenceKey returns the key of the numbering sequence shared by
+// document types documentTypeNames: their sorted, comma-separated names.
+func DocumentNumberSequenceKey(documentTypeNames []string) string {
+	dts := append([]string{}, documentTypeNames...)
+	sort.Strings(dts)
+	return strings.Join(dts, ",")
+}
+
+// NumberSequence returns the key of the numbering sequence of the document in
+// the receiver, which matches documentNumberSequenceExpr.
+func (d Document) NumberSequence() string {
+	if d.DocumentNumberSequence != "" {
+		return d.DocumentNumberSequence
+	}
+	return fmt.Sprintf("document_type_id:%d", d.DocumentTypeID)
+}
+
+// NextDocumentNumber assigns the next document number in the sequence shared
+// by document types documentTypeNames in a product, and returns it. If year is
+// not zero, the sequence restarts each year.
+//
+// The sequence is locked until the transaction of db is committed or rolled
+// back, so the document should be saved with the number in the same
+// transaction. Numbers are never lower than the latest number of documents in
+// the sequence, which may have been assigned without the sequence (e.g., by
+// importing documents).
+func NextDocumentNumber(db *gorm.DB,
+	productName string, documentTypeNames []string, year int) (int, error) {
+	// Validate required fields.
+	if err := validation.Validate(db, validation.Required); err != nil {
+		return 0, err
+	}
+	if err := validation.Validate(productName, validation.Required); err != nil {
+		return 0, err
+	}
+	if err := validation.Validate(
+		documentTypeNames, validation.Required); err != nil {
+		return 0, err
+	}
+
+	var next int
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		// Get product.
+		p := Product{
+			Name: productName,
+		}
+		if err := p.Get(tx); err != nil {
+			return fmt.Errorf("error getting product: %w", err)
+		}
+
+		// Create the sequence if it doesn't exist.
+		s := DocumentNumberSequence{
+			ProductID:     p.ID,
+			DocumentTypes: DocumentNumberSequenceKey(documentTypeNames),
+			Year:          year,
+		}
+		if err := tx.
+			Omit(clause.Associations).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&s).
+			Error; err != nil {
+			return fmt.Errorf("error creating document number sequence: %w", err)
+		}
+
+		// Lock the sequence.
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ? AND document_types = ? AND year = ?",
+				s.ProductID, s.DocumentTypes, s.Year).
+			Omit(clause.Associations).
+			First(&s).
+			Error; err != nil {
+			return fmt.Errorf("error locking document number sequence: %w", err)
+		}
+
+		// Get the latest number of documents in the sequence, after the lock is
+		// acquired so documents saved by other transactions that held it are
+		// included.
+		latest, err := GetLatestDocumentNumber(
+			tx, productName, documentTypeNames, year)
+		if err != nil {
+			return fmt.Errorf("error getting latest document number: %w", err)
+		}
+		if s.LatestDocumentNumber > latest {
+			latest = s.LatestDocumentNumber
+		}
+		next = latest + 1
+
+		return tx.
+			Model(&DocumentNumberSequence{}).
+			Where("product_id = ? AND document_types = ? AND year = ?",
+				s.ProductID, s.DocumentTypes, s.Year).
+			Update("latest_document_number", next).
+			Error
+	}); err != nil {
+		return 0, err
+	}
+
+	return next, nil
+}
+
+// SetDocumentNumberSequences records the numbering sequence shared by
+// document types documentTypeNames for their numbered documents that don't
+// have a recorded sequence (because they were numbered before sequences were
+// recorded).
+func SetDocumentNumberSequences(db *gorm.DB, documentTypeNames []string) error {
+	if err := validation.Validate(
+		documentTypeNames, validation.Required); err != nil {
+		return err
+	}
+
+	return db.
+		Model(&Document{}).
+		Where("document_number > 0").
+		Where("COALESCE(document_number_sequence, '') = ''").
+		Where("document_type_id IN (?)",
+			db.Model(&DocumentType{}).
+				Select("id").
+				Where("name IN ?", documentTypeNames),
+		).
+		Update("document_number_sequence",
+			DocumentNumberSequenceKey(documentTypeNames)).
+		Error
+}
+
+// CreateDocumentNumberIndex creates the unique index of document numbers per
+// product, numbering sequence, and year if it doesn't exist, and drops the
+// index of document numbers per document type that it replaces. It returns
+// ErrDuplicateDocumentNumbers if documents have duplicate numbers, which must
+// be repaired before the index can be created.
+func CreateDocumentNumberIndex(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&Document{}, DocumentNumberIndexName) {
+		return nil
+	}
+
+	dups, err := GetDocumentNumberDuplicates(db)
+	if err != nil {
+		return fmt.Errorf("error getting duplicate document numbers: %w", err)
+	}
+	if len(dups) > 0 {
+		return ErrDuplicateDocumentNumbers
+	}
+
+	// Documents without a number have a number of zero.
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE UNIQUE INDEX IF NOT EXISTS %s ON documents "+
+			"(product_id, (%s), document_number_year, document_number) "+
+			"WHERE document_number > 0 AND deleted_at IS NULL",
+		DocumentNumberIndexName, documentNumberSequenceExpr,
+	)).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(fmt.Sprintf(
+		"DROP INDEX IF EXISTS %s", legacyDocumentNumberIndexName)).Error
+}
+
+// GetDocumentNumberDuplicates gets document numbers that are used by more than
+// one document in the same numbering sequence of a product.
+func GetDocumentNumberDuplicates(
+	db *gorm.DB) ([]DocumentNumberDuplicate, error) {
+	var dups []DocumentNumberDuplicate
+	if err := db.
+		Model(&Document{}).
+		Select("product_id, " + documentNumberSequenceExpr + " AS sequence, " +
+			"document_number, document_number_year, COUNT(*) AS count").
+		Where("document_number > 0").
+		Group("product_id, sequence, document_number, document_number_year").
+		Having("COUNT(*) > 1").
+		Order("product_id, sequence, document_number_year, document_number").
+		Scan(&dups).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return dups, nil
+}
@@ -0,0 +1,245 @@
+package models
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestDocumentNumberSequenceModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	t.Run("Assign document numbers", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		t.Run("Create document types and a product", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			for _, name := range []string{"DT1", "DT2"} {
+				dt := DocumentType{
+					Name:     name,
+					LongName: name,
+				}
+				require.NoError(dt.FirstOrCreate(db))
+			}
+			p := Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			require.NoError(p.FirstOrCreate(db))
+		})
+
+		t.Run("Create a document with a number", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			d := Document{
+				GoogleFileID:   "fileID1",
+				DocumentNumber: 3,
+				DocumentType:   DocumentType{Name: "DT1"},
+				Product:        Product{Name: "Product1"},
+				Status:         InReviewDocumentStatus,
+			}
+			require.NoError(d.Create(db))
+		})
+
+		t.Run("Numbers start after the latest document number", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			n, err := NextDocumentNumber(db, "Product1", []string{"DT1"}, 0)
+			require.NoError(err)
+			assert.Equal(4, n)
+
+			// Numbers are assigned even if documents aren't saved with them.
+			n, err = NextDocumentNumber(db, "Product1", []string{"DT1"}, 0)
+			require.NoError(err)
+			assert.Equal(5, n)
+		})
+
+		t.Run("Sequences are per document types and year", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			n, err := NextDocumentNumber(db, "Product1", []string{"DT2"}, 0)
+			require.NoError(err)
+			assert.Equal(1, n)
+
+			n, err = NextDocumentNumber(db, "Product1", []string{"DT2", "DT1"}, 0)
+			require.NoError(err)
+			assert.Equal(4, n)
+
+			n, err = NextDocumentNumber(db, "Product1", []string{"DT1"}, 2024)
+			require.NoError(err)
+			assert.Equal(1, n)
+		})
+
+		t.Run("Rolled back numbers are assigned again", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			tx := db.Begin()
+			n, err := NextDocumentNumber(tx, "Product1", []string{"DT2"}, 0)
+			require.NoError(err)
+			assert.Equal(2, n)
+			require.NoError(tx.Rollback().Error)
+
+			n, err = NextDocumentNumber(db, "Product1", []string{"DT2"}, 0)
+			require.NoError(err)
+			assert.Equal(2, n)
+		})
+
+		t.Run("Concurrent transactions are assigned different numbers",
+			func(t *testing.T) {
+				assert, require := assert.New(t), require.New(t)
+				const count = 10
+				var (
+					mu      sync.Mutex
+					numbers = map[int]bool{}
+					wg      sync.WaitGroup
+				)
+				for i := 0; i < count; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						err := db.Transaction(func(tx *gorm.DB) error {
+							n, err := NextDocumentNumber(
+								tx, "Product1", []string{"DT1"}, 2025)
+							if err != nil {
+								return err
+							}
+							mu.Lock()
+							numbers[n] = true
+							mu.Unlock()
+							return nil
+						})
+						assert.NoError(err)
+					}()
+				}
+				wg.Wait()
+				require.Len(numbers, count)
+				for i := 1; i <= count; i++ {
+					assert.True(numbers[i])
+				}
+			})
+	})
+
+	t.Run("Create document number index", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		t.Run("Create documents with duplicate numbers", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			dt := DocumentType{
+				Name:     "DT1",
+				LongName: "DocumentType1",
+			}
+			require.NoError(dt.FirstOrCreate(db))
+			p := Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			require.NoError(p.FirstOrCreate(db))
+			for _, id := range []string{"fileID1", "fileID2", "fileID3"} {
+				d := Document{
+					GoogleFileID:   id,
+					DocumentNumber: 1,
+					DocumentType:   DocumentType{Name: "DT1"},
+					Product:        Product{Name: "Product1"},
+					Status:         InReviewDocumentStatus,
+				}
+				require.NoError(d.Create(db))
+			}
+			// Drafts don't have numbers.
+			for _, id := range []string{"fileID4", "fileID5"} {
+				d := Document{
+					GoogleFileID: id,
+					DocumentType: DocumentType{Name: "DT1"},
+					Product:      Product{Name: "Product1"},
+					Status:       WIPDocumentStatus,
+				}
+				require.NoError(d.Create(db))
+			}
+		})
+
+		t.Run("Get duplicate numbers", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			dups, err := GetDocumentNumberDuplicates(db)
+			require.NoError(err)
+			require.Len(dups, 1)
+			assert.Equal(1, dups[0].DocumentNumber)
+			assert.Equal(3, dups[0].Count)
+		})
+
+		t.Run("Index isn't created with duplicate numbers", func(t *testing.T) {
+			assert := assert.New(t)
+			assert.ErrorIs(
+				CreateDocumentNumberIndex(db), ErrDuplicateDocumentNumbers)
+			assert.False(
+				db.Migrator().HasIndex(&Document{}, DocumentNumberIndexName))
+		})
+
+		t.Run("Repair duplicate numbers and create index", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			for i, id := range []string{"fileID2", "fileID3"} {
+				d := Document{GoogleFileID: id}
+				require.NoError(d.Get(db))
+				d.DocumentNumber = i + 2
+				require.NoError(d.Upsert(db))
+			}
+			require.NoError(CreateDocumentNumberIndex(db))
+			assert.True(
+				db.Migrator().HasIndex(&Document{}, DocumentNumberIndexName))
+		})
+
+		t.Run("Duplicate numbers are rejected", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			d := Document{GoogleFileID: "fileID4"}
+			require.NoError(d.Get(db))
+			d.DocumentNumber = 1
+			d.Status = InReviewDocumentStatus
+			assert.Error(d.Upsert(db))
+
+			d.DocumentNumber = 4
+			assert.NoError(d.Upsert(db))
+		})
+
+		t.Run("Duplicate numbers in a shared sequence are rejected",
+			func(t *testing.T) {
+				assert, require := assert.New(t), require.New(t)
+				dt := DocumentType{
+					Name:     "DT2",
+					LongName: "DocumentType2",
+				}
+				require.NoError(dt.FirstOrCreate(db))
+
+				// Documents of another document type are in another sequence.
+				d := Document{
+					GoogleFileID:   "fileID6",
+					DocumentNumber: 1,
+					DocumentType:   DocumentType{Name: "DT2"},
+					Product:        Product{Name: "Product1"},
+					Status:         InReviewDocumentStatus,
+				}
+				require.NoError(d.Create(db))
+
+				d = Document{
+					GoogleFileID:           "fileID7",
+					DocumentNumber:         4,
+					DocumentNumberSequence: "DT1,DT2",
+					DocumentType:           DocumentType{Name: "DT2"},
+					Product:                Product{Name: "Product1"},
+					Status:                 InReviewDocumentStatus,
+				}
+				require.NoError(d.Create(db))
+
+				// Recording the sequence of the document with the same number of
+				// the other document type makes it a duplicate.
+				require.NoError(SetDocumentNumberSequences(db, []string{"DT1"}))
+				d = Document{GoogleFileID: "fileID4"}
+				require.NoError(d.Get(db))
+				assert.Equal("DT1", d.DocumentNumberSequence)
+				d.DocumentNumberSequence = "DT1,DT2"
+				assert.Error(d.Upsert(db))
+			})
+	})
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentRetentionNotice is a model for a notice sent to a document's owner
+// that a retention policy will archive or flag the document.
+type DocumentRetentionNotice struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Document   Document
+	DocumentID uint `gorm:"primaryKey"`
+
+	// Policy is the name of the retention policy.
+	Policy string `gorm:"primaryKey"`
+
+	// ActionAt is the time that the retention policy will be applied to the
+	// document, unless it is modified or opted out before then.
+	ActionAt time.Time `gorm:"not null"`
+}
+
+// Get gets the retention notice for the document ID and policy in the
+// receiver from database db, and assigns it back to the receiver.
+func (n *DocumentRetentionNotice) Get(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(n,
+		validation.Field(&n.DocumentID, validation.Required),
+		validation.Field(&n.Policy, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.
+		Where(DocumentRetentionNotice{
+			DocumentID: n.DocumentID,
+			Policy:     n.Policy,
+		}).
+		Omit(clause.Associations).
+		First(&n).
+		Error
+}
+
+// Upsert updates or inserts the retention notice in the receiver into database
+// db.
+func (n *DocumentRetentionNotice) Upsert(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(n,
+		validation.Field(&n.DocumentID, validation.Required),
+		validation.Field(&n.Policy, validation.Required),
+		validation.Field(&n.ActionAt, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "document_id"}, {Name: "policy"}},
+			DoUpdates: clause.AssignmentColumns(
+				[]string{"action_at", "updated_at"}),
+		}).
+		Create(&n).
+		Error
+}
+
+// GetDocumentRetentionNotices gets all retention notices for the document with
+// ID documentID, soonest action first.
+func GetDocumentRetentionNotices(
+	db *gorm.DB, documentID uint) ([]DocumentRetentionNotice, error) {
+	if err := validation.Validate(documentID, validation.Required); err != nil {
+		return nil, err
+	}
+
+	var ns []DocumentRetentionNotice
+	err := db.
+		Where(DocumentRetentionNotice{DocumentID: documentID}).
+		Order("action_at, policy").
+		Find(&ns).
+		Error
+	return ns, err
+}
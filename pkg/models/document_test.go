@@ -1551,4 +1551,468 @@ func TestDocumentReplaceRelatedResources(t *testing.T) {
 			assert.Equal(3, hdrrs[1].RelatedResource.SortOrder)
 		})
 	})
+
+	t.Run("Trash and Restore", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		t.Run("Create documents", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			dt := DocumentType{
+				Name:     "DT1",
+				LongName: "DocumentType1",
+			}
+			require.NoError(dt.FirstOrCreate(db))
+			p := Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			require.NoError(p.FirstOrCreate(db))
+			for _, c := range []struct{ id, owner string }{
+				{"fileID1", "a@a.com"},
+				{"fileID2", "a@a.com"},
+				{"fileID3", "b@b.com"},
+			} {
+				d := Document{
+					GoogleFileID: c.id,
+					DocumentType: DocumentType{Name: "DT1"},
+					Owner:        &User{EmailAddress: c.owner},
+					Product:      Product{Name: "Product1"},
+					Status:       WIPDocumentStatus,
+				}
+				require.NoError(d.Create(db))
+			}
+		})
+
+		now := time.Now()
+		t.Run("Trash documents", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			d := Document{GoogleFileID: "fileID1"}
+			require.NoError(d.Trash(db, now.Add(-48*time.Hour)))
+			d = Document{GoogleFileID: "fileID2"}
+			require.NoError(d.Trash(db, now.Add(-time.Hour)))
+			d = Document{GoogleFileID: "fileID3"}
+			require.NoError(d.Trash(db, now.Add(-48*time.Hour)))
+
+			got := Document{GoogleFileID: "fileID1"}
+			require.NoError(got.Get(db))
+			require.NotNil(got.TrashedAt)
+			assert.WithinDuration(now.Add(-48*time.Hour), *got.TrashedAt,
+				time.Second)
+		})
+
+		t.Run("Get trashed documents", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			docs, err := GetTrashedDocuments(db, "a@a.com", now)
+			require.NoError(err)
+			require.Len(docs, 2)
+			assert.Equal("fileID2", docs[0].GoogleFileID)
+			assert.Equal("fileID1", docs[1].GoogleFileID)
+
+			docs, err = GetTrashedDocuments(db, "", now.Add(-24*time.Hour))
+			require.NoError(err)
+			require.Len(docs, 2)
+		})
+
+		t.Run("Restore a document", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			d := Document{GoogleFileID: "fileID2"}
+			require.NoError(d.Restore(db))
+
+			got := Document{GoogleFileID: "fileID2"}
+			require.NoError(got.Get(db))
+			assert.Nil(got.TrashedAt)
+
+			docs, err := GetTrashedDocuments(db, "a@a.com", now)
+			require.NoError(err)
+			require.Len(docs, 1)
+			assert.Equal("fileID1", docs[0].GoogleFileID)
+		})
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		now := time.Now()
+		t.Run("Create documents", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			dt := DocumentType{
+				Name:     "DT1",
+				LongName: "DocumentType1",
+			}
+			require.NoError(dt.FirstOrCreate(db))
+			p := Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			require.NoError(p.FirstOrCreate(db))
+			for i, id := range []string{"fileID1", "fileID2", "fileID3"} {
+				d := Document{
+					GoogleFileID:       id,
+					DocumentModifiedAt: now.Add(-time.Duration(3-i) * time.Hour),
+					DocumentType:       DocumentType{Name: "DT1"},
+					Owner:              &User{EmailAddress: "a@a.com"},
+					Product:            Product{Name: "Product1"},
+					Status:             ObsoleteDocumentStatus,
+				}
+				require.NoError(d.Create(db))
+			}
+		})
+
+		t.Run("Archive, flag, and opt out documents", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			d := Document{GoogleFileID: "fileID1"}
+			require.NoError(d.Archive(db, now))
+			d = Document{GoogleFileID: "fileID2"}
+			require.NoError(d.FlagForRetention(db, now))
+			d = Document{GoogleFileID: "fileID3"}
+			require.NoError(d.SetRetentionOptOut(db, true))
+
+			got := Document{GoogleFileID: "fileID1"}
+			require.NoError(got.Get(db))
+			assert.NotNil(got.ArchivedAt)
+			got = Document{GoogleFileID: "fileID2"}
+			require.NoError(got.Get(db))
+			assert.NotNil(got.RetentionFlaggedAt)
+			got = Document{GoogleFileID: "fileID3"}
+			require.NoError(got.Get(db))
+			assert.True(got.RetentionOptOut)
+		})
+
+		t.Run("Upsert doesn't change retention state", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			d := Document{GoogleFileID: "fileID1"}
+			require.NoError(d.Get(db))
+			upd := Document{
+				GoogleFileID:       "fileID1",
+				DocumentModifiedAt: d.DocumentModifiedAt,
+				DocumentType:       DocumentType{Name: "DT1"},
+				Owner:              &User{EmailAddress: "a@a.com"},
+				Product:            Product{Name: "Product1"},
+				Status:             ObsoleteDocumentStatus,
+				Title:              "new title",
+			}
+			require.NoError(upd.Upsert(db))
+			assert.Equal("new title", upd.Title)
+			assert.NotNil(upd.ArchivedAt)
+		})
+
+		t.Run("Get retention candidates", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			docs, err := GetRetentionCandidates(db, now)
+			require.NoError(err)
+			require.Len(docs, 1)
+			assert.Equal("fileID2", docs[0].GoogleFileID)
+
+			docs, err = GetRetentionCandidates(db, now.Add(-3*time.Hour))
+			require.NoError(err)
+			assert.Empty(docs)
+		})
+
+		t.Run("Upsert and get retention notices", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			d := Document{GoogleFileID: "fileID2"}
+			require.NoError(d.Get(db))
+
+			n := DocumentRetentionNotice{
+				DocumentID: d.ID,
+				Policy:     "policy1",
+				ActionAt:   now.Add(time.Hour),
+			}
+			require.NoError(n.Upsert(db))
+			n.ActionAt = now.Add(2 * time.Hour)
+			require.NoError(n.Upsert(db))
+
+			got := DocumentRetentionNotice{
+				DocumentID: d.ID,
+				Policy:     "policy1",
+			}
+			require.NoError(got.Get(db))
+			assert.WithinDuration(now.Add(2*time.Hour), got.ActionAt, time.Second)
+
+			ns, err := GetDocumentRetentionNotices(db, d.ID)
+			require.NoError(err)
+			assert.Len(ns, 1)
+		})
+	})
+
+	t.Run("Stale drafts", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		now := time.Now()
+		t.Run("Create documents", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			dt := DocumentType{
+				Name:     "DT1",
+				LongName: "DocumentType1",
+			}
+			require.NoError(dt.FirstOrCreate(db))
+			p := Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			require.NoError(p.FirstOrCreate(db))
+			for _, c := range []struct {
+				id, owner string
+				age       time.Duration
+				status    DocumentStatus
+			}{
+				{"fileID1", "a@a.com", 72 * time.Hour, WIPDocumentStatus},
+				{"fileID2", "a@a.com", 48 * time.Hour, WIPDocumentStatus},
+				{"fileID3", "b@b.com", 48 * time.Hour, WIPDocumentStatus},
+				{"fileID4", "a@a.com", time.Hour, WIPDocumentStatus},
+				{"fileID5", "a@a.com", 72 * time.Hour, InReviewDocumentStatus},
+			} {
+				d := Document{
+					GoogleFileID:       c.id,
+					DocumentModifiedAt: now.Add(-c.age),
+					DocumentType:       DocumentType{Name: "DT1"},
+					Owner:              &User{EmailAddress: c.owner},
+					Product:            Product{Name: "Product1"},
+					Status:             c.status,
+				}
+				require.NoError(d.Create(db))
+			}
+		})
+
+		t.Run("Get stale drafts", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			docs, err := GetStaleDrafts(db, "a@a.com", now.Add(-24*time.Hour))
+			require.NoError(err)
+			require.Len(docs, 2)
+			assert.Equal("fileID1", docs[0].GoogleFileID)
+			assert.Equal("fileID2", docs[1].GoogleFileID)
+
+			docs, err = GetStaleDrafts(db, "", now.Add(-24*time.Hour))
+			require.NoError(err)
+			assert.Len(docs, 3)
+		})
+
+		t.Run("Keep a stale draft", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			d := Document{GoogleFileID: "fileID1"}
+			require.NoError(d.KeepStaleDraft(db, now))
+
+			docs, err := GetStaleDrafts(db, "a@a.com", now.Add(-24*time.Hour))
+			require.NoError(err)
+			require.Len(docs, 1)
+			assert.Equal("fileID2", docs[0].GoogleFileID)
+		})
+	})
+
+	t.Run("Get document by number", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		dt := DocumentType{
+			Name:     "DT1",
+			LongName: "DocumentType1",
+		}
+		require.NoError(dt.FirstOrCreate(db))
+		for _, p := range []Product{
+			{Name: "Product1", Abbreviation: "P1"},
+			{Name: "Product2", Abbreviation: "P2"},
+		} {
+			require.NoError(p.FirstOrCreate(db))
+		}
+		for _, c := range []struct {
+			id, product string
+			number      int
+		}{
+			{"fileID1", "Product1", 1},
+			{"fileID2", "Product1", 2},
+			{"fileID3", "Product2", 1},
+		} {
+			d := Document{
+				GoogleFileID:   c.id,
+				DocumentNumber: c.number,
+				DocumentType:   DocumentType{Name: "DT1"},
+				Product:        Product{Name: c.product},
+			}
+			require.NoError(d.Create(db))
+		}
+
+		d, err := GetDocumentByNumber(db, "DT1", "Product1", 2)
+		require.NoError(err)
+		assert.Equal("fileID2", d.GoogleFileID)
+		assert.Equal("Product1", d.Product.Name)
+
+		d, err = GetDocumentByNumber(db, "DT1", "Product2", 1)
+		require.NoError(err)
+		assert.Equal("fileID3", d.GoogleFileID)
+
+		_, err = GetDocumentByNumber(db, "DT1", "Product2", 2)
+		assert.ErrorIs(err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("Get documents", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		for _, dt := range []DocumentType{
+			{Name: "DT1", LongName: "DocumentType1"},
+			{Name: "DT2", LongName: "DocumentType2"},
+		} {
+			require.NoError(dt.FirstOrCreate(db))
+		}
+		for _, p := range []Product{
+			{Name: "Product1", Abbreviation: "P1"},
+			{Name: "Product2", Abbreviation: "P2"},
+		} {
+			require.NoError(p.FirstOrCreate(db))
+		}
+		for _, c := range []struct {
+			id, docType, product string
+			number               int
+			status               DocumentStatus
+		}{
+			{"fileID1", "DT1", "Product2", 1, ApprovedDocumentStatus},
+			{"fileID2", "DT1", "Product1", 2, InReviewDocumentStatus},
+			{"fileID3", "DT1", "Product1", 1, ApprovedDocumentStatus},
+			{"fileID4", "DT2", "Product1", 1, ObsoleteDocumentStatus},
+			{"fileID5", "DT1", "Product1", 0, WIPDocumentStatus},
+		} {
+			d := Document{
+				GoogleFileID:   c.id,
+				DocumentNumber: c.number,
+				DocumentType:   DocumentType{Name: c.docType},
+				Product:        Product{Name: c.product},
+				Status:         c.status,
+			}
+			require.NoError(d.Create(db))
+		}
+		d := Document{GoogleFileID: "fileID5"}
+		require.NoError(d.Trash(db, time.Now()))
+
+		ids := func(docs []Document) []string {
+			var res []string
+			for _, d := range docs {
+				res = append(res, d.GoogleFileID)
+			}
+			return res
+		}
+
+		docs, err := GetDocuments(db, DocumentFilter{})
+		require.NoError(err)
+		assert.Equal(
+			[]string{"fileID3", "fileID2", "fileID4", "fileID1"}, ids(docs))
+
+		docs, err = GetDocuments(db, DocumentFilter{
+			DocumentTypes: []string{"DT1"},
+			Products:      []string{"Product1"},
+			Statuses:      []DocumentStatus{ApprovedDocumentStatus},
+		})
+		require.NoError(err)
+		assert.Equal([]string{"fileID3"}, ids(docs))
+	})
+
+	t.Run("Get latest document number in a sequence", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		for _, dt := range []DocumentType{
+			{Name: "DT1", LongName: "DocumentType1"},
+			{Name: "DT2", LongName: "DocumentType2"},
+		} {
+			require.NoError(dt.FirstOrCreate(db))
+		}
+		for _, p := range []Product{
+			{Name: "Product1", Abbreviation: "P1"},
+			{Name: "Product2", Abbreviation: "P2"},
+		} {
+			require.NoError(p.FirstOrCreate(db))
+		}
+		for _, c := range []struct {
+			id, docType, product string
+			number, year         int
+		}{
+			{"fileID1", "DT1", "Product1", 3, 2025},
+			{"fileID2", "DT1", "Product1", 1, 2026},
+			{"fileID3", "DT2", "Product1", 5, 2025},
+			{"fileID4", "DT1", "Product2", 9, 2026},
+		} {
+			d := Document{
+				GoogleFileID:       c.id,
+				DocumentNumber:     c.number,
+				DocumentNumberYear: c.year,
+				DocumentType:       DocumentType{Name: c.docType},
+				Product:            Product{Name: c.product},
+			}
+			require.NoError(d.Create(db))
+		}
+
+		n, err := GetLatestDocumentNumber(db, "Product1", []string{"DT1"}, 0)
+		require.NoError(err)
+		assert.Equal(3, n)
+
+		n, err = GetLatestDocumentNumber(
+			db, "Product1", []string{"DT1", "DT2"}, 0)
+		require.NoError(err)
+		assert.Equal(5, n)
+
+		n, err = GetLatestDocumentNumber(db, "Product1", []string{"DT1"}, 2026)
+		require.NoError(err)
+		assert.Equal(1, n)
+
+		n, err = GetLatestDocumentNumber(db, "Product2", []string{"DT2"}, 0)
+		require.NoError(err)
+		assert.Equal(0, n)
+
+		d, err := GetDocumentBySequenceNumber(
+			db, "Product1", []string{"DT1", "DT2"}, 0, 5)
+		require.NoError(err)
+		assert.Equal("fileID3", d.GoogleFileID)
+
+		d, err = GetDocumentBySequenceNumber(
+			db, "Product1", []string{"DT1"}, 2026, 1)
+		require.NoError(err)
+		assert.Equal("fileID2", d.GoogleFileID)
+
+		_, err = GetDocumentBySequenceNumber(
+			db, "Product1", []string{"DT1"}, 2025, 1)
+		assert.ErrorIs(err, gorm.ErrRecordNotFound)
+		_, err = GetDocumentBySequenceNumber(
+			db, "Product1", []string{"DT1"}, 0, 5)
+		assert.ErrorIs(err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestDocumentDocNumber(t *testing.T) {
+	cases := map[string]struct {
+		doc  Document
+		want string
+	}{
+		"draft": {
+			doc: Document{
+				Product: Product{Abbreviation: "TF"},
+			},
+			want: "TF-???",
+		},
+		"default format": {
+			doc: Document{
+				DocumentNumber: 7,
+				Product:        Product{Abbreviation: "TF"},
+			},
+			want: "TF-007",
+		},
+		"formatted": {
+			doc: Document{
+				DocumentNumber:          7,
+				FormattedDocumentNumber: "TF-2026-007",
+				Product:                 Product{Abbreviation: "TF"},
+			},
+			want: "TF-2026-007",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, c.doc.DocNumber())
+		})
+	}
 }
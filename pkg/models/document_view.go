@@ -0,0 +1,203 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentView is a model for a document view event.
+type DocumentView struct {
+	gorm.Model
+
+	// Document is the viewed document.
+	Document   Document
+	DocumentID uint `gorm:"index:document_views_document_user_viewed_at;uniqueIndex:document_views_document_user_window;not null"`
+
+	// User is the user that viewed the document.
+	User   User
+	UserID uint `gorm:"index:document_views_document_user_viewed_at;uniqueIndex:document_views_document_user_window;not null"`
+
+	// ViewedAt is the time of the document view.
+	ViewedAt time.Time `gorm:"index:document_views_document_user_viewed_at;index;not null"`
+
+	// WindowStart is the start of the deduplication window that contains the
+	// view. A user's views of a document are recorded once per window. It is
+	// null for views recorded before windows were tracked.
+	WindowStart *time.Time `gorm:"uniqueIndex:document_views_document_user_window"`
+}
+
+// DocumentViewStats contains aggregate view statistics.
+type DocumentViewStats struct {
+	// Views is the total number of (deduplicated) views.
+	Views int64
+
+	// UniqueViewers is the number of distinct users that viewed.
+	UniqueViewers int64
+}
+
+// DocumentViewCount contains aggregate view statistics for a document.
+type DocumentViewCount struct {
+	// DocumentID is the database ID of the document.
+	DocumentID uint
+
+	// Views is the total number of (deduplicated) views.
+	Views int64
+
+	// UniqueViewers is the number of distinct users that viewed the document.
+	UniqueViewers int64
+}
+
+// Record records a document view in database db, unless the same user has
+// already viewed the same document in the same deduplication window (in which
+// case false is returned). Deduplication windows are consecutive periods of
+// length dedupWindow. Required fields in the receiver:
+//   - Document ID or Google File ID
+//   - User email address
+func (v *DocumentView) Record(
+	db *gorm.DB, dedupWindow time.Duration) (bool, error) {
+	// Validate required fields.
+	if err := validation.ValidateStruct(&v.User,
+		validation.Field(&v.User.EmailAddress, validation.Required),
+	); err != nil {
+		return false, err
+	}
+
+	if v.ViewedAt.IsZero() {
+		v.ViewedAt = time.Now().UTC()
+	}
+	windowStart := v.ViewedAt.Truncate(dedupWindow)
+	v.WindowStart = &windowStart
+
+	recorded := false
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		// Get document.
+		if v.DocumentID == 0 {
+			if err := v.Document.Get(tx); err != nil {
+				return fmt.Errorf("error getting document: %w", err)
+			}
+			v.DocumentID = v.Document.ID
+		}
+
+		// Find or create user.
+		if err := v.User.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error finding or creating user: %w", err)
+		}
+		v.UserID = v.User.ID
+
+		// Don't record the view if there is already a view in the deduplication
+		// window. The unique index makes this safe for concurrent requests.
+		res := tx.
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Omit("Document", "User").
+			Create(v)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		recorded = true
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	return recorded, nil
+}
+
+// GetDocumentViewStats gets view statistics for the document with database ID
+// documentID for views since the provided time.
+func GetDocumentViewStats(
+	db *gorm.DB, documentID uint, since time.Time) (DocumentViewStats, error) {
+	var stats DocumentViewStats
+	if err := validation.Validate(documentID, validation.Required); err != nil {
+		return stats, err
+	}
+
+	err := db.
+		Model(&DocumentView{}).
+		Select("COUNT(*) AS views, COUNT(DISTINCT user_id) AS unique_viewers").
+		Where("document_id = ? AND viewed_at >= ?", documentID, since).
+		Scan(&stats).
+		Error
+
+	return stats, err
+}
+
+// GetDocumentViewCount gets the total number of views (for all time) of the
+// document with database ID documentID.
+func GetDocumentViewCount(db *gorm.DB, documentID uint) (int64, error) {
+	stats, err := GetDocumentViewStats(db, documentID, time.Unix(0, 0))
+	if err != nil {
+		return 0, err
+	}
+	return stats.Views, nil
+}
+
+// GetProductViewStats gets view statistics for all documents in the product
+// with database ID productID for views since the provided time.
+func GetProductViewStats(
+	db *gorm.DB, productID uint, since time.Time) (DocumentViewStats, error) {
+	var stats DocumentViewStats
+	if err := validation.Validate(productID, validation.Required); err != nil {
+		return stats, err
+	}
+
+	err := db.
+		Model(&DocumentView{}).
+		Select("COUNT(*) AS views, COUNT(DISTINCT document_views.user_id) AS unique_viewers").
+		Joins("JOIN documents ON documents.id = document_views.document_id").
+		Where("documents.product_id = ? AND document_views.viewed_at >= ?",
+			productID, since).
+		Scan(&stats).
+		Error
+
+	return stats, err
+}
+
+// GetMostViewedDocuments gets up to limit documents with the most views since
+// the provided time, ordered by number of views (descending).
+func GetMostViewedDocuments(
+	db *gorm.DB, since time.Time, limit int) ([]DocumentViewCount, error) {
+	var counts []DocumentViewCount
+	err := db.
+		Model(&DocumentView{}).
+		Select("document_views.document_id, COUNT(*) AS views, "+
+			"COUNT(DISTINCT document_views.user_id) AS unique_viewers").
+		Joins("JOIN documents ON documents.id = document_views.document_id").
+		Where("document_views.viewed_at >= ?", since).
+		Where("documents.deleted_at IS NULL").
+		Group("document_views.document_id").
+		Order("views DESC").
+		Limit(limit).
+		Scan(&counts).
+		Error
+
+	return counts, err
+}
+
+// GetDocumentViewCountsSince gets total view counts (for all time) for every
+// document that has been viewed since the provided time.
+func GetDocumentViewCountsSince(
+	db *gorm.DB, since time.Time) ([]DocumentViewCount, error) {
+	var counts []DocumentViewCount
+	err := db.
+		Model(&DocumentView{}).
+		Select("document_id, COUNT(*) AS views, "+
+			"COUNT(DISTINCT user_id) AS unique_viewers").
+		Where("document_id IN (?)",
+			db.Model(&DocumentView{}).
+				Select("document_id").
+				Where("viewed_at >= ?", since),
+		).
+		Group("document_id").
+		Scan(&counts).
+		Error
+
+	return counts, err
+}
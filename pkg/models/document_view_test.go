@@ -0,0 +1,160 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentViewModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	t.Run("Record and get stats", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		t.Run("Create a document type", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			dt := DocumentType{
+				Name:     "DT1",
+				LongName: "DocumentType1",
+			}
+			err := dt.FirstOrCreate(db)
+			require.NoError(err)
+		})
+
+		var p Product
+		t.Run("Create a product", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			p = Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			err := p.FirstOrCreate(db)
+			require.NoError(err)
+		})
+
+		var d1, d2 Document
+		t.Run("Create documents", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			d1 = Document{
+				GoogleFileID: "fileID1",
+				DocumentType: DocumentType{Name: "DT1"},
+				Product:      Product{Name: "Product1"},
+			}
+			err := d1.Create(db)
+			require.NoError(err)
+			d2 = Document{
+				GoogleFileID: "fileID2",
+				DocumentType: DocumentType{Name: "DT1"},
+				Product:      Product{Name: "Product1"},
+			}
+			err = d2.Create(db)
+			require.NoError(err)
+		})
+
+		window := 30 * time.Minute
+		now := time.Now().UTC().Truncate(window)
+
+		t.Run("Record a view", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			v := DocumentView{
+				Document: Document{GoogleFileID: "fileID1"},
+				User:     User{EmailAddress: "a@example.com"},
+				ViewedAt: now,
+			}
+			recorded, err := v.Record(db, window)
+			require.NoError(err)
+			assert.True(recorded)
+			assert.Equal(d1.ID, v.DocumentID)
+		})
+
+		t.Run("Record a duplicate view inside the window", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			v := DocumentView{
+				Document: Document{GoogleFileID: "fileID1"},
+				User:     User{EmailAddress: "a@example.com"},
+				ViewedAt: now.Add(10 * time.Minute),
+			}
+			recorded, err := v.Record(db, window)
+			require.NoError(err)
+			assert.False(recorded)
+		})
+
+		t.Run("Record a view outside the window", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			v := DocumentView{
+				Document: Document{GoogleFileID: "fileID1"},
+				User:     User{EmailAddress: "a@example.com"},
+				ViewedAt: now.Add(time.Hour),
+			}
+			recorded, err := v.Record(db, window)
+			require.NoError(err)
+			assert.True(recorded)
+		})
+
+		t.Run("Record views by other users", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			v := DocumentView{
+				Document: Document{GoogleFileID: "fileID1"},
+				User:     User{EmailAddress: "b@example.com"},
+				ViewedAt: now,
+			}
+			recorded, err := v.Record(db, window)
+			require.NoError(err)
+			assert.True(recorded)
+
+			v = DocumentView{
+				Document: Document{GoogleFileID: "fileID2"},
+				User:     User{EmailAddress: "b@example.com"},
+				ViewedAt: now,
+			}
+			recorded, err = v.Record(db, window)
+			require.NoError(err)
+			assert.True(recorded)
+		})
+
+		t.Run("Get document view stats", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			stats, err := GetDocumentViewStats(db, d1.ID, now.Add(-time.Hour))
+			require.NoError(err)
+			assert.EqualValues(3, stats.Views)
+			assert.EqualValues(2, stats.UniqueViewers)
+		})
+
+		t.Run("Get product view stats", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			stats, err := GetProductViewStats(db, p.ID, now.Add(-time.Hour))
+			require.NoError(err)
+			assert.EqualValues(4, stats.Views)
+			assert.EqualValues(2, stats.UniqueViewers)
+		})
+
+		t.Run("Get most viewed documents", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			counts, err := GetMostViewedDocuments(db, now.Add(-time.Hour), 10)
+			require.NoError(err)
+			require.Len(counts, 2)
+			assert.Equal(d1.ID, counts[0].DocumentID)
+			assert.EqualValues(3, counts[0].Views)
+			assert.Equal(d2.ID, counts[1].DocumentID)
+			assert.EqualValues(1, counts[1].Views)
+		})
+
+		t.Run("Get document view counts since a time", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			counts, err := GetDocumentViewCountsSince(
+				db, now.Add(30*time.Minute))
+			require.NoError(err)
+			require.Len(counts, 1)
+			assert.Equal(d1.ID, counts[0].DocumentID)
+			assert.EqualValues(3, counts[0].Views)
+		})
+	})
+}
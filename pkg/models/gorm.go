@@ -2,16 +2,20 @@ package models
 
 func ModelsToAutoMigrate() []interface{} {
 	return []interface{}{
+		&APIToken{},
 		&DocumentType{},
 		&Document{},
 		&DocumentCustomField{},
 		&DocumentFileRevision{},
+		&DocumentNumberSequence{},
 		DocumentGroupReview{},
 		&DocumentRelatedResource{},
 		&DocumentRelatedResourceExternalLink{},
 		&DocumentRelatedResourceHermesDocument{},
+		&DocumentRetentionNotice{},
 		&DocumentReview{},
 		&DocumentTypeCustomField{},
+		&DocumentView{},
 		&Group{},
 		&IndexerFolder{},
 		&IndexerMetadata{},
@@ -21,6 +25,12 @@ func ModelsToAutoMigrate() []interface{} {
 		&ProjectRelatedResource{},
 		&ProjectRelatedResourceExternalLink{},
 		&ProjectRelatedResourceHermesDocument{},
+		&RateLimitCounter{},
+		&SavedSearch{},
+		&SavedSearchMatch{},
+		&ShortLink{},
+		&StaleDraftNudge{},
+		&Tag{},
 		&User{},
 	}
 }
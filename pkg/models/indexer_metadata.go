@@ -15,6 +15,10 @@ type IndexerMetadata struct {
 
 	// LastFullIndexAt is the time that the indexer last completed a full index.
 	LastFullIndexAt time.Time
+
+	// LastStaleDraftsReportAt is the time that the indexer last sent a report of
+	// stale drafts to admins.
+	LastStaleDraftsReportAt time.Time
 }
 
 // Get gets the indexer metadata and assigns it to the receiver.
@@ -36,6 +36,9 @@ type Project struct {
 	// Status is the status of the document.
 	Status ProjectStatus `gorm:"default:null;not null"`
 
+	// Tags are the tags of the project. They are managed using ReplaceTags.
+	Tags []*Tag `gorm:"many2many:project_tags;"`
+
 	// Title is the title of the project.
 	Title string `gorm:"default:null;not null"`
 }
@@ -234,6 +237,34 @@ func (p *Project) ReplaceRelatedResources(
 	return nil
 }
 
+// ReplaceTags replaces the tags of project p with the tags with the provided
+// names, creating tags that don't exist. Names should be normalized using
+// NormalizeTagNames.
+func (p *Project) ReplaceTags(db *gorm.DB, names []string) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(p,
+		validation.Field(&p.ID, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		tags, err := findOrCreateTags(tx, names)
+		if err != nil {
+			return err
+		}
+		p.Tags = make([]*Tag, len(tags))
+		for i := range tags {
+			p.Tags[i] = &tags[i]
+		}
+
+		return tx.
+			Model(&p).
+			Association("Tags").
+			Replace(p.Tags)
+	})
+}
+
 // Update updates a project. The resulting project is saved back to the
 // receiver.
 func (p *Project) Update(db *gorm.DB) error {
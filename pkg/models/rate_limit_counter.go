@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RateLimitCounter is a model for a rate limit counter, which counts the
+// requests for a key (e.g., a user and route) in a fixed time window. It is used
+// to share rate limits across server replicas.
+type RateLimitCounter struct {
+	// Key is the rate limit key.
+	Key string `gorm:"primaryKey"`
+
+	// WindowStart is the start time of the window.
+	WindowStart time.Time `gorm:"primaryKey"`
+
+	// Count is the number of requests in the window.
+	Count int `gorm:"not null"`
+
+	// ExpiresAt is the time after which the counter is no longer needed.
+	ExpiresAt time.Time `gorm:"index;not null"`
+}
+
+// Increment increments the counter identified by the receiver's Key and
+// WindowStart, creating it if it doesn't exist, and assigns the new count to
+// the receiver.
+func (c *RateLimitCounter) Increment(db *gorm.DB) error {
+	c.Count = 1
+	return db.
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "key"}, {Name: "window_start"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"count": gorm.Expr("rate_limit_counters.count + 1"),
+				}),
+			},
+			clause.Returning{Columns: []clause.Column{{Name: "count"}}},
+		).
+		Create(c).
+		Error
+}
+
+// DeleteExpiredRateLimitCounters deletes rate limit counters that expired
+// before a time.
+func DeleteExpiredRateLimitCounters(db *gorm.DB, before time.Time) error {
+	return db.
+		Where("expires_at < ?", before).
+		Delete(&RateLimitCounter{}).
+		Error
+}
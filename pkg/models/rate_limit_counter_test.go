@@ -0,0 +1,50 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitCounterModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	t.Run("Increment and delete expired", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		windowStart := time.Now().Truncate(time.Minute)
+		for i := 1; i <= 3; i++ {
+			c := RateLimitCounter{
+				Key:         "a@a.com /api/v2/people",
+				WindowStart: windowStart,
+				ExpiresAt:   windowStart.Add(time.Minute),
+			}
+			require.NoError(c.Increment(db))
+			assert.Equal(i, c.Count)
+		}
+
+		// A different key has its own counter.
+		c := RateLimitCounter{
+			Key:         "b@b.com /api/v2/people",
+			WindowStart: windowStart,
+			ExpiresAt:   windowStart.Add(time.Minute),
+		}
+		require.NoError(c.Increment(db))
+		assert.Equal(1, c.Count)
+
+		// Delete expired counters.
+		require.NoError(DeleteExpiredRateLimitCounters(
+			db, windowStart.Add(2*time.Minute)))
+		var count int64
+		require.NoError(db.Model(&RateLimitCounter{}).Count(&count).Error)
+		assert.Zero(count)
+	})
+}
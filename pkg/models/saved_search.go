@@ -0,0 +1,384 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SavedSearch is a model for a user's saved search of published documents.
+// Users can be alerted when documents start matching a saved search, which
+// generalizes subscribing to a product.
+type SavedSearch struct {
+	gorm.Model
+
+	// User is the user that saved the search.
+	User   User
+	UserID uint `gorm:"index;not null"`
+
+	// Name is a name to identify the saved search.
+	Name string `gorm:"default:null;not null"`
+
+	// Query is the search text.
+	Query string
+
+	// Filters are the search filters.
+	Filters datatypes.JSONType[SavedSearchFilters]
+
+	// AlertsEnabled enables alerting the user when documents start matching the
+	// saved search.
+	AlertsEnabled bool
+}
+
+// SavedSearchFilters are the filters of a saved search. Documents match a
+// filter if they match any of its values.
+type SavedSearchFilters struct {
+	// DocTypes filters by document type (e.g., "RFC").
+	DocTypes []string `json:"docTypes,omitempty"`
+
+	// Owners filters by owner email address.
+	Owners []string `json:"owners,omitempty"`
+
+	// Products filters by product.
+	Products []string `json:"products,omitempty"`
+
+	// Statuses filters by document status (e.g., "In-Review").
+	Statuses []string `json:"statuses,omitempty"`
+}
+
+// SavedSearchDocument is the document data that saved searches are matched
+// against.
+type SavedSearchDocument struct {
+	DocType string
+	Owners  []string
+	Product string
+	Status  string
+
+	// Text is the searchable text of the document (e.g., its title, document
+	// number, summary, product, tags, and content).
+	Text []string
+}
+
+// SavedSearchMatch is a model for whether a document matched a saved search
+// when it was last evaluated, which is used to alert users when a document
+// starts matching. Documents that were already published when alerts were
+// enabled for the saved search are recorded with whether they matched then, so
+// only documents published since then, or that start matching later, alert
+// users.
+type SavedSearchMatch struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	SavedSearch   SavedSearch
+	SavedSearchID uint `gorm:"primaryKey"`
+	Document      Document
+	DocumentID    uint `gorm:"primaryKey"`
+
+	// Matched is true if the document matched the saved search.
+	Matched bool
+}
+
+// Create creates a saved search in database db. Required fields in the
+// receiver:
+//   - Name
+//   - User email address
+func (s *SavedSearch) Create(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(s,
+		validation.Field(&s.Name, validation.Required),
+	); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(&s.User,
+		validation.Field(&s.User.EmailAddress, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Find or create user.
+		if err := s.User.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error finding or creating user: %w", err)
+		}
+		s.UserID = s.User.ID
+
+		if err := tx.
+			Omit("User").
+			Create(&s).
+			Error; err != nil {
+			return err
+		}
+
+		return s.recordBaselineMatches(tx)
+	})
+}
+
+// Get gets the saved search with the ID in the receiver, if it was saved by
+// the user with the email address in the receiver, and assigns it to the
+// receiver. It returns gorm.ErrRecordNotFound if no such saved search exists.
+func (s *SavedSearch) Get(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(s,
+		validation.Field(&s.ID, validation.Required),
+	); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(&s.User,
+		validation.Field(&s.User.EmailAddress, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.
+		Joins("JOIN users ON users.id = saved_searches.user_id").
+		Where("users.email_address = ?", s.User.EmailAddress).
+		Preload("User").
+		First(&s, s.ID).
+		Error
+}
+
+// Update updates the name, query, filters, and alerts setting of the saved
+// search with the ID in the receiver.
+func (s *SavedSearch) Update(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(s,
+		validation.Field(&s.ID, validation.Required),
+		validation.Field(&s.Name, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Model(&s).
+			Select("Name", "Query", "Filters", "AlertsEnabled").
+			Updates(s).
+			Error; err != nil {
+			return err
+		}
+
+		// Matches are no longer valid if the search changed.
+		if err := tx.
+			Where("saved_search_id = ?", s.ID).
+			Delete(&SavedSearchMatch{}).
+			Error; err != nil {
+			return err
+		}
+
+		return s.recordBaselineMatches(tx)
+	})
+}
+
+// recordBaselineMatches records whether the documents that are published
+// match the saved search in the receiver, if alerts are enabled, so users
+// aren't alerted about documents that already matched before they saved the
+// search or enabled alerts. Drafts aren't recorded, so they alert users once
+// they are published and match. Document content isn't stored in the database,
+// so documents that only match the query by their content are recorded as not
+// matching, and alert users when they are next evaluated.
+func (s *SavedSearch) recordBaselineMatches(db *gorm.DB) error {
+	if !s.AlertsEnabled {
+		return nil
+	}
+
+	var docs []Document
+	if err := db.
+		Where("status <> ?", WIPDocumentStatus).
+		Preload("DocumentType").
+		Preload("Owner").
+		Preload("Product").
+		Preload("Tags").
+		FindInBatches(&docs, 1000, func(tx *gorm.DB, batch int) error {
+			matches := make([]SavedSearchMatch, 0, len(docs))
+			for _, d := range docs {
+				matches = append(matches, SavedSearchMatch{
+					SavedSearchID: s.ID,
+					DocumentID:    d.ID,
+					Matched:       s.Matches(d.savedSearchDocument()),
+				})
+			}
+			if err := db.
+				Omit(clause.Associations).
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&matches).
+				Error; err != nil {
+				return fmt.Errorf("error creating saved search matches: %w", err)
+			}
+			return nil
+		}).
+		Error; err != nil {
+		return fmt.Errorf("error recording baseline matches: %w", err)
+	}
+
+	return nil
+}
+
+// savedSearchDocument returns the saved search document data of the document
+// in the receiver, which must have its document type, owner, product, and tags
+// loaded.
+func (d Document) savedSearchDocument() SavedSearchDocument {
+	doc := SavedSearchDocument{
+		DocType: d.DocumentType.Name,
+		Product: d.Product.Name,
+		Status:  d.Status.String(),
+		Text:    []string{d.Title, d.DocNumber(), d.Product.Name},
+	}
+	if d.Owner != nil {
+		doc.Owners = []string{d.Owner.EmailAddress}
+	}
+	if d.Summary != nil {
+		doc.Text = append(doc.Text, *d.Summary)
+	}
+	for _, t := range d.Tags {
+		doc.Text = append(doc.Text, t.Name)
+	}
+	return doc
+}
+
+// Matches returns true if a document matches the saved search in the receiver.
+// The document must match at least one value of each filter, and contain every
+// word of the query in its text (case-insensitively).
+func (s SavedSearch) Matches(doc SavedSearchDocument) bool {
+	f := s.Filters.Data
+	if !matchesAny(f.DocTypes, doc.DocType) ||
+		!matchesAny(f.Products, doc.Product) ||
+		!matchesAny(f.Statuses, doc.Status) ||
+		!matchesAny(f.Owners, doc.Owners...) {
+		return false
+	}
+
+	text := strings.ToLower(strings.Join(doc.Text, " "))
+	for _, w := range strings.Fields(strings.ToLower(s.Query)) {
+		if !strings.Contains(text, w) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesAny returns true if filter is empty or any of values are in filter
+// (case-insensitively).
+func matchesAny(filter []string, values ...string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		for _, v := range values {
+			if strings.EqualFold(f, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Delete deletes the saved search with the ID in the receiver and its
+// matches.
+func (s *SavedSearch) Delete(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(s,
+		validation.Field(&s.ID, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("saved_search_id = ?", s.ID).
+			Delete(&SavedSearchMatch{}).
+			Error; err != nil {
+			return err
+		}
+
+		return tx.
+			Delete(&SavedSearch{}, s.ID).
+			Error
+	})
+}
+
+// GetUserSavedSearches gets all saved searches of the user with email address
+// userEmail, ordered by name.
+func GetUserSavedSearches(db *gorm.DB, userEmail string) ([]SavedSearch, error) {
+	if err := validation.Validate(userEmail, validation.Required); err != nil {
+		return nil, err
+	}
+
+	var searches []SavedSearch
+	err := db.
+		Joins("JOIN users ON users.id = saved_searches.user_id").
+		Where("users.email_address = ?", userEmail).
+		Preload("User").
+		Order("saved_searches.name").
+		Find(&searches).
+		Error
+	return searches, err
+}
+
+// GetAlertingSavedSearches gets all saved searches that have alerts enabled.
+func GetAlertingSavedSearches(db *gorm.DB) ([]SavedSearch, error) {
+	var searches []SavedSearch
+	err := db.
+		Where("alerts_enabled = ?", true).
+		Preload("User").
+		Find(&searches).
+		Error
+	return searches, err
+}
+
+// UpdateMatch records whether the document with ID docID matches the saved
+// search with the ID in the receiver, and returns the previous match, or nil
+// if the document hasn't been evaluated before.
+func (s *SavedSearch) UpdateMatch(
+	db *gorm.DB, docID uint, matched bool) (*SavedSearchMatch, error) {
+	// Validate required fields.
+	if err := validation.ValidateStruct(s,
+		validation.Field(&s.ID, validation.Required),
+	); err != nil {
+		return nil, err
+	}
+	if err := validation.Validate(docID, validation.Required); err != nil {
+		return nil, err
+	}
+
+	var prev *SavedSearchMatch
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var m SavedSearchMatch
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(SavedSearchMatch{
+				SavedSearchID: s.ID,
+				DocumentID:    docID,
+			}).
+			Omit(clause.Associations).
+			First(&m).
+			Error
+		switch {
+		case err == nil:
+			prev = &SavedSearchMatch{
+				CreatedAt:     m.CreatedAt,
+				UpdatedAt:     m.UpdatedAt,
+				SavedSearchID: m.SavedSearchID,
+				DocumentID:    m.DocumentID,
+				Matched:       m.Matched,
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		m.SavedSearchID = s.ID
+		m.DocumentID = docID
+		m.Matched = matched
+		return tx.
+			Omit(clause.Associations).
+			Save(&m).
+			Error
+	})
+	return prev, err
+}
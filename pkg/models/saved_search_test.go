@@ -0,0 +1,269 @@
+package models
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func TestSavedSearchModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	t.Run("Create, get, update, match, and delete", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		var s SavedSearch
+		t.Run("Create a saved search", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			s = SavedSearch{
+				Name:  "In-review RFCs",
+				Query: "terraform",
+				Filters: datatypes.JSONType[SavedSearchFilters]{
+					Data: SavedSearchFilters{
+						DocTypes: []string{"RFC"},
+						Statuses: []string{"In-Review"},
+					},
+				},
+				AlertsEnabled: true,
+				User: User{
+					EmailAddress: "a@a.com",
+				},
+			}
+			require.NoError(s.Create(db))
+			require.NotZero(s.ID)
+		})
+
+		t.Run("Create a saved search without a name", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			bad := SavedSearch{
+				User: User{
+					EmailAddress: "a@a.com",
+				},
+			}
+			require.Error(bad.Create(db))
+		})
+
+		t.Run("Get the saved search", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got := SavedSearch{
+				Model: gorm.Model{ID: s.ID},
+				User:  User{EmailAddress: "a@a.com"},
+			}
+			require.NoError(got.Get(db))
+			assert.Equal("In-review RFCs", got.Name)
+			assert.Equal([]string{"RFC"}, got.Filters.Data.DocTypes)
+			assert.Equal("a@a.com", got.User.EmailAddress)
+		})
+
+		t.Run("Get the saved search as another user", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			got := SavedSearch{
+				Model: gorm.Model{ID: s.ID},
+				User:  User{EmailAddress: "b@b.com"},
+			}
+			require.ErrorIs(got.Get(db), gorm.ErrRecordNotFound)
+		})
+
+		t.Run("Get user and alerting saved searches", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			searches, err := GetUserSavedSearches(db, "a@a.com")
+			require.NoError(err)
+			require.Len(searches, 1)
+			assert.Equal(s.ID, searches[0].ID)
+
+			searches, err = GetAlertingSavedSearches(db)
+			require.NoError(err)
+			require.Len(searches, 1)
+			assert.Equal("a@a.com", searches[0].User.EmailAddress)
+		})
+
+		var d Document
+		t.Run("Create a document", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			dt := DocumentType{
+				Name:     "RFC",
+				LongName: "Request for Comments",
+			}
+			require.NoError(dt.FirstOrCreate(db))
+			p := Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			require.NoError(p.FirstOrCreate(db))
+			d = Document{
+				GoogleFileID: "fileID1",
+				DocumentType: DocumentType{Name: "RFC"},
+				Product:      Product{Name: "Product1"},
+			}
+			require.NoError(d.Create(db))
+		})
+
+		t.Run("Update matches", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			prev, err := s.UpdateMatch(db, d.ID, false)
+			require.NoError(err)
+			assert.Nil(prev)
+
+			prev, err = s.UpdateMatch(db, d.ID, true)
+			require.NoError(err)
+			require.NotNil(prev)
+			assert.False(prev.Matched)
+
+			prev, err = s.UpdateMatch(db, d.ID, true)
+			require.NoError(err)
+			require.NotNil(prev)
+			assert.True(prev.Matched)
+		})
+
+		t.Run("Update the saved search", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s.Name = "RFCs"
+			s.AlertsEnabled = false
+			require.NoError(s.Update(db))
+
+			got := SavedSearch{
+				Model: gorm.Model{ID: s.ID},
+				User:  User{EmailAddress: "a@a.com"},
+			}
+			require.NoError(got.Get(db))
+			assert.Equal("RFCs", got.Name)
+			assert.False(got.AlertsEnabled)
+
+			// Matches are reset.
+			prev, err := s.UpdateMatch(db, d.ID, true)
+			require.NoError(err)
+			assert.Nil(prev)
+
+			searches, err := GetAlertingSavedSearches(db)
+			require.NoError(err)
+			assert.Empty(searches)
+		})
+
+		t.Run("Delete the saved search", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			require.NoError(s.Delete(db))
+			searches, err := GetUserSavedSearches(db, "a@a.com")
+			require.NoError(err)
+			require.Empty(searches)
+		})
+	})
+
+	t.Run("Record baseline matches", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		var published, draft Document
+		t.Run("Create a published document and a draft", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			dt := DocumentType{
+				Name:     "RFC",
+				LongName: "Request for Comments",
+			}
+			require.NoError(dt.FirstOrCreate(db))
+			p := Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			require.NoError(p.FirstOrCreate(db))
+			published = Document{
+				GoogleFileID:   "fileID1",
+				DocumentNumber: 1,
+				DocumentType:   DocumentType{Name: "RFC"},
+				Product:        Product{Name: "Product1"},
+				Status:         InReviewDocumentStatus,
+			}
+			require.NoError(published.Create(db))
+			draft = Document{
+				GoogleFileID: "fileID2",
+				DocumentType: DocumentType{Name: "RFC"},
+				Product:      Product{Name: "Product1"},
+				Status:       WIPDocumentStatus,
+			}
+			require.NoError(draft.Create(db))
+		})
+
+		var s SavedSearch
+		t.Run("Create a saved search with alerts", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			s = SavedSearch{
+				Name:          "RFCs",
+				AlertsEnabled: true,
+				User: User{
+					EmailAddress: "a@a.com",
+				},
+			}
+			require.NoError(s.Create(db))
+		})
+
+		t.Run("Published document is recorded as matching", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			prev, err := s.UpdateMatch(db, published.ID, true)
+			require.NoError(err)
+			require.NotNil(prev)
+			assert.True(prev.Matched)
+		})
+
+		t.Run("Published document that doesn't match is recorded as not matching",
+			func(t *testing.T) {
+				assert, require := assert.New(t), require.New(t)
+				approved := SavedSearch{
+					Name: "Approved RFCs",
+					Filters: datatypes.JSONType[SavedSearchFilters]{
+						Data: SavedSearchFilters{
+							Statuses: []string{"Approved"},
+						},
+					},
+					AlertsEnabled: true,
+					User: User{
+						EmailAddress: "a@a.com",
+					},
+				}
+				require.NoError(approved.Create(db))
+
+				// The document starts matching when it's approved, so the previous
+				// match must show that it didn't match before.
+				prev, err := approved.UpdateMatch(db, published.ID, true)
+				require.NoError(err)
+				require.NotNil(prev)
+				assert.False(prev.Matched)
+			})
+
+		t.Run("Draft published after the search was saved isn't recorded",
+			func(t *testing.T) {
+				assert, require := assert.New(t), require.New(t)
+				draft.Status = InReviewDocumentStatus
+				draft.DocumentNumber = 2
+				require.NoError(draft.Upsert(db))
+
+				prev, err := s.UpdateMatch(db, draft.ID, true)
+				require.NoError(err)
+				assert.Nil(prev)
+			})
+
+		t.Run("Enabling alerts records baseline matches", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s.AlertsEnabled = false
+			require.NoError(s.Update(db))
+			prev, err := s.UpdateMatch(db, published.ID, false)
+			require.NoError(err)
+			assert.Nil(prev)
+
+			s.AlertsEnabled = true
+			require.NoError(s.Update(db))
+			for _, id := range []uint{published.ID, draft.ID} {
+				prev, err := s.UpdateMatch(db, id, true)
+				require.NoError(err)
+				require.NotNil(prev)
+				assert.True(prev.Matched)
+			}
+		})
+	})
+}
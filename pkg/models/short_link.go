@@ -0,0 +1,285 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ShortLink is a model for a short link (served under "/l") that redirects to
+// a document. Short links for document numbers (e.g., "/rfc/lab-001") are kept
+// when a document's product or number changes, so old links keep working.
+// Aliases are short links defined by users (e.g., "/vault-ha").
+type ShortLink struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Path is the lowercase path of the short link without the "/l" prefix
+	// (e.g., "/rfc/lab-001").
+	Path string `gorm:"default:null;not null;uniqueIndex"`
+
+	// Document is the document that the short link redirects to.
+	Document   Document
+	DocumentID uint `gorm:"index;not null"`
+
+	// Alias is true if the short link was defined by a user.
+	Alias bool
+
+	// CreatedBy is the user that created an alias.
+	CreatedBy   *User
+	CreatedByID *uint
+
+	// HitCount is the number of times the short link has been followed.
+	HitCount int64 `gorm:"default:0;not null"`
+
+	// LastHitAt is the time the short link was last followed.
+	LastHitAt *time.Time
+}
+
+// ShortLinks is a slice of short links.
+type ShortLinks []ShortLink
+
+const (
+	// MaxShortLinkAliasLength is the maximum length of a short link alias.
+	MaxShortLinkAliasLength = 64
+)
+
+// ErrShortLinkExists is returned when creating a short link with the path of
+// another short link.
+var ErrShortLinkExists = errors.New("short link already exists")
+
+var shortLinkAliasRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// NormalizeShortLinkAlias returns the normalized form of a short link alias
+// (lowercase, without slashes), or an error if the alias is invalid. Valid
+// aliases contain only lowercase letters, numbers, and single hyphens between
+// them, and are at most MaxShortLinkAliasLength characters long.
+func NormalizeShortLinkAlias(alias string) (string, error) {
+	alias = strings.ToLower(strings.Trim(strings.TrimSpace(alias), "/"))
+	if alias == "" {
+		return "", errors.New("alias is required")
+	}
+	if len(alias) > MaxShortLinkAliasLength {
+		return "", fmt.Errorf("alias %q is longer than %d characters",
+			alias, MaxShortLinkAliasLength)
+	}
+	if !shortLinkAliasRegexp.MatchString(alias) {
+		return "", fmt.Errorf(
+			"alias %q can only contain lowercase letters, numbers, and hyphens",
+			alias)
+	}
+	return alias, nil
+}
+
+// Create creates an alias short link in database db. Required fields in the
+// receiver:
+//   - Path
+//   - Document Google file ID
+//   - CreatedBy email address
+//
+// It returns ErrShortLinkExists if a short link with the path already exists.
+func (l *ShortLink) Create(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(l,
+		validation.Field(&l.Path, validation.Required),
+		validation.Field(&l.CreatedBy, validation.Required),
+	); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(&l.Document,
+		validation.Field(&l.Document.GoogleFileID, validation.Required),
+	); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(l.CreatedBy,
+		validation.Field(&l.CreatedBy.EmailAddress, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Get document.
+		if err := tx.
+			Where(Document{GoogleFileID: l.Document.GoogleFileID}).
+			First(&l.Document).
+			Error; err != nil {
+			return fmt.Errorf("error getting document: %w", err)
+		}
+		l.DocumentID = l.Document.ID
+
+		// Find or create user.
+		if err := l.CreatedBy.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error finding or creating user: %w", err)
+		}
+		l.CreatedByID = &l.CreatedBy.ID
+
+		l.Alias = true
+		res := tx.
+			Omit(clause.Associations).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&l)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrShortLinkExists
+		}
+
+		return nil
+	})
+}
+
+// Delete deletes the alias short link with the path and document ID in the
+// receiver. It returns gorm.ErrRecordNotFound if no such alias exists.
+func (l *ShortLink) Delete(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(l,
+		validation.Field(&l.Path, validation.Required),
+		validation.Field(&l.DocumentID, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	res := db.
+		Where("path = ? AND document_id = ? AND alias", l.Path, l.DocumentID).
+		Delete(&ShortLink{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Get gets the short link with the path in the receiver from database db, and
+// assigns it back to the receiver. The short link's document is preloaded, and
+// is empty if the document was deleted.
+func (l *ShortLink) Get(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(l,
+		validation.Field(&l.Path, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.
+		Where(ShortLink{Path: l.Path}).
+		Preload("Document").
+		Preload("CreatedBy").
+		First(&l).
+		Error
+}
+
+// RecordHit increments the hit count of the short link with the ID in the
+// receiver, and sets the time it was last followed to now.
+func (l *ShortLink) RecordHit(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(l,
+		validation.Field(&l.ID, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.
+		Model(&ShortLink{}).
+		Where("id = ?", l.ID).
+		UpdateColumns(map[string]any{
+			"hit_count":   gorm.Expr("hit_count + 1"),
+			"last_hit_at": time.Now(),
+		}).
+		Error
+}
+
+// Upsert saves a short link for a document number with the path in the
+// receiver, redirecting to the document with the Google file ID in the
+// receiver. If the path already exists, it is updated to redirect to the
+// document.
+func (l *ShortLink) Upsert(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(l,
+		validation.Field(&l.Path, validation.Required),
+	); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(&l.Document,
+		validation.Field(&l.Document.GoogleFileID, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Get document.
+		if err := tx.
+			Where(Document{GoogleFileID: l.Document.GoogleFileID}).
+			First(&l.Document).
+			Error; err != nil {
+			return fmt.Errorf("error getting document: %w", err)
+		}
+		l.DocumentID = l.Document.ID
+		l.Alias = false
+		l.CreatedByID = nil
+
+		if err := tx.
+			Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "path"}},
+				DoUpdates: clause.AssignmentColumns(
+					[]string{"document_id", "alias", "created_by_id", "updated_at"}),
+			}).
+			Create(&l).
+			Error; err != nil {
+			return err
+		}
+
+		// Get the short link in case it already existed.
+		return tx.
+			Where(ShortLink{Path: l.Path}).
+			First(&l).
+			Error
+	})
+}
+
+// DeleteDocumentNumberShortLink deletes the short link for a document number
+// with path p, if it redirects to the document with Google file ID
+// googleFileID.
+func DeleteDocumentNumberShortLink(
+	db *gorm.DB, googleFileID, p string) error {
+	if err := validation.Validate(googleFileID, validation.Required); err != nil {
+		return err
+	}
+	if err := validation.Validate(p, validation.Required); err != nil {
+		return err
+	}
+
+	return db.
+		Where("path = ? AND NOT alias", p).
+		Where("document_id = (?)", db.
+			Model(&Document{}).
+			Select("id").
+			Where("google_file_id = ?", googleFileID)).
+		Delete(&ShortLink{}).
+		Error
+}
+
+// FindByDocument finds all short links that redirect to the document with ID
+// documentID in database db, oldest first, and assigns them to the receiver.
+func (ls *ShortLinks) FindByDocument(db *gorm.DB, documentID uint) error {
+	if err := validation.Validate(documentID, validation.Required); err != nil {
+		return err
+	}
+
+	return db.
+		Where(ShortLink{DocumentID: documentID}).
+		Preload("CreatedBy").
+		Order("created_at, id").
+		Find(&ls).
+		Error
+}
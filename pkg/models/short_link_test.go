@@ -0,0 +1,185 @@
+package models
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestNormalizeShortLinkAlias(t *testing.T) {
+	cases := map[string]struct {
+		alias   string
+		want    string
+		wantErr bool
+	}{
+		"valid": {
+			alias: "vault-ha",
+			want:  "vault-ha",
+		},
+		"uppercase with slashes": {
+			alias: " /Vault-HA/ ",
+			want:  "vault-ha",
+		},
+		"empty": {
+			alias:   "/",
+			wantErr: true,
+		},
+		"invalid characters": {
+			alias:   "vault/ha",
+			wantErr: true,
+		},
+		"double hyphen": {
+			alias:   "vault--ha",
+			wantErr: true,
+		},
+		"too long": {
+			alias:   string(make([]byte, MaxShortLinkAliasLength+1)),
+			wantErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, err := NormalizeShortLinkAlias(c.alias)
+			if c.wantErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+				assert.Equal(c.want, got)
+			}
+		})
+	}
+}
+
+func TestShortLinkModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	t.Run("Upsert, create, get, hit, and delete", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		var d1, d2 Document
+		t.Run("Create documents", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			dt := DocumentType{
+				Name:     "RFC",
+				LongName: "Request for Comments",
+			}
+			require.NoError(dt.FirstOrCreate(db))
+			p := Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			require.NoError(p.FirstOrCreate(db))
+			d1 = Document{
+				GoogleFileID: "fileID1",
+				DocumentType: DocumentType{Name: "RFC"},
+				Product:      Product{Name: "Product1"},
+			}
+			require.NoError(d1.Create(db))
+			d2 = Document{
+				GoogleFileID: "fileID2",
+				DocumentType: DocumentType{Name: "RFC"},
+				Product:      Product{Name: "Product1"},
+			}
+			require.NoError(d2.Create(db))
+		})
+
+		t.Run("Upsert document number short links", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			l := ShortLink{
+				Path:     "/rfc/p1-001",
+				Document: Document{GoogleFileID: "fileID1"},
+			}
+			require.NoError(l.Upsert(db))
+			assert.NotZero(l.ID)
+			assert.Equal(d1.ID, l.DocumentID)
+
+			// The document's number changed, so it has another short link.
+			l = ShortLink{
+				Path:     "/rfc/p1-002",
+				Document: Document{GoogleFileID: "fileID1"},
+			}
+			require.NoError(l.Upsert(db))
+
+			// Upserting an existing path redirects it to the new document.
+			l = ShortLink{
+				Path:     "/rfc/p1-002",
+				Document: Document{GoogleFileID: "fileID2"},
+			}
+			require.NoError(l.Upsert(db))
+			got := ShortLink{Path: "/rfc/p1-002"}
+			require.NoError(got.Get(db))
+			assert.Equal(d2.ID, got.DocumentID)
+			assert.Equal("fileID2", got.Document.GoogleFileID)
+		})
+
+		t.Run("Create an alias", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			l := ShortLink{
+				Path:      "/vault-ha",
+				Document:  Document{GoogleFileID: "fileID1"},
+				CreatedBy: &User{EmailAddress: "a@a.com"},
+			}
+			require.NoError(l.Create(db))
+			assert.True(l.Alias)
+
+			dup := ShortLink{
+				Path:      "/vault-ha",
+				Document:  Document{GoogleFileID: "fileID2"},
+				CreatedBy: &User{EmailAddress: "b@b.com"},
+			}
+			require.ErrorIs(dup.Create(db), ErrShortLinkExists)
+		})
+
+		t.Run("Record hits", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			l := ShortLink{Path: "/vault-ha"}
+			require.NoError(l.Get(db))
+			require.NoError(l.RecordHit(db))
+			require.NoError(l.RecordHit(db))
+
+			got := ShortLink{Path: "/vault-ha"}
+			require.NoError(got.Get(db))
+			assert.Equal(int64(2), got.HitCount)
+			assert.NotNil(got.LastHitAt)
+			require.NotNil(got.CreatedBy)
+			assert.Equal("a@a.com", got.CreatedBy.EmailAddress)
+		})
+
+		t.Run("Find short links by document", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			var ls ShortLinks
+			require.NoError(ls.FindByDocument(db, d1.ID))
+			require.Len(ls, 2)
+			assert.Equal("/rfc/p1-001", ls[0].Path)
+			assert.Equal("/vault-ha", ls[1].Path)
+		})
+
+		t.Run("Delete short links", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+
+			// Document number short links aren't deleted as aliases.
+			l := ShortLink{Path: "/rfc/p1-001", DocumentID: d1.ID}
+			require.ErrorIs(l.Delete(db), gorm.ErrRecordNotFound)
+
+			l = ShortLink{Path: "/vault-ha", DocumentID: d1.ID}
+			require.NoError(l.Delete(db))
+			got := ShortLink{Path: "/vault-ha"}
+			require.ErrorIs(got.Get(db), gorm.ErrRecordNotFound)
+
+			require.NoError(
+				DeleteDocumentNumberShortLink(db, "fileID1", "/rfc/p1-001"))
+			got = ShortLink{Path: "/rfc/p1-001"}
+			require.ErrorIs(got.Get(db), gorm.ErrRecordNotFound)
+		})
+	})
+}
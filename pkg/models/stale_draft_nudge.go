@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StaleDraftNudge is a model for an email sent to the owner of a stale draft,
+// asking them to keep, publish, or delete it.
+type StaleDraftNudge struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Document   Document
+	DocumentID uint `gorm:"primaryKey"`
+
+	// Token authorizes the actions in the email. It is cleared once it is used.
+	Token string `gorm:"not null"`
+
+	// SentAt is the time the email was last sent.
+	SentAt time.Time `gorm:"not null"`
+}
+
+// Get gets the stale draft nudge for the document ID in the receiver from
+// database db, and assigns it back to the receiver.
+func (n *StaleDraftNudge) Get(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(n,
+		validation.Field(&n.DocumentID, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.
+		Where(StaleDraftNudge{DocumentID: n.DocumentID}).
+		Omit(clause.Associations).
+		First(&n).
+		Error
+}
+
+// UseToken clears the token of the stale draft nudge for the document ID in the
+// receiver in database db, if it is token, and returns true if it was cleared.
+// Tokens can only be used once.
+func (n *StaleDraftNudge) UseToken(db *gorm.DB, token string) (bool, error) {
+	// Validate required fields.
+	if err := validation.ValidateStruct(n,
+		validation.Field(&n.DocumentID, validation.Required),
+	); err != nil {
+		return false, err
+	}
+	if token == "" {
+		return false, nil
+	}
+
+	tx := db.
+		Model(&StaleDraftNudge{}).
+		Where("document_id = ? AND token = ?", n.DocumentID, token).
+		Update("token", "")
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected == 1, nil
+}
+
+// Upsert updates or inserts the stale draft nudge in the receiver into
+// database db.
+func (n *StaleDraftNudge) Upsert(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.ValidateStruct(n,
+		validation.Field(&n.DocumentID, validation.Required),
+		validation.Field(&n.Token, validation.Required),
+		validation.Field(&n.SentAt, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "document_id"}},
+			DoUpdates: clause.AssignmentColumns(
+				[]string{"token", "sent_at", "updated_at"}),
+		}).
+		Create(&n).
+		Error
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestStaleDraftNudgeModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	t.Run("Upsert and get", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		var d Document
+		t.Run("Create a document", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			dt := DocumentType{
+				Name:     "DT1",
+				LongName: "DocumentType1",
+			}
+			require.NoError(dt.FirstOrCreate(db))
+			p := Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			require.NoError(p.FirstOrCreate(db))
+			d = Document{
+				GoogleFileID: "fileID1",
+				DocumentType: DocumentType{Name: "DT1"},
+				Product:      Product{Name: "Product1"},
+				Status:       WIPDocumentStatus,
+			}
+			require.NoError(d.Create(db))
+		})
+
+		t.Run("Get a nudge that doesn't exist", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			n := StaleDraftNudge{DocumentID: d.ID}
+			require.ErrorIs(n.Get(db), gorm.ErrRecordNotFound)
+		})
+
+		t.Run("Upsert nudges", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			now := time.Now()
+			n := StaleDraftNudge{
+				DocumentID: d.ID,
+				Token:      "token1",
+				SentAt:     now.Add(-time.Hour),
+			}
+			require.NoError(n.Upsert(db))
+
+			n = StaleDraftNudge{
+				DocumentID: d.ID,
+				Token:      "token2",
+				SentAt:     now,
+			}
+			require.NoError(n.Upsert(db))
+
+			got := StaleDraftNudge{DocumentID: d.ID}
+			require.NoError(got.Get(db))
+			assert.Equal("token2", got.Token)
+			assert.WithinDuration(now, got.SentAt, time.Second)
+		})
+
+		t.Run("Use a token", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			n := StaleDraftNudge{DocumentID: d.ID}
+			ok, err := n.UseToken(db, "token1")
+			require.NoError(err)
+			assert.False(ok)
+
+			ok, err = n.UseToken(db, "token2")
+			require.NoError(err)
+			assert.True(ok)
+
+			// Tokens can only be used once.
+			ok, err = n.UseToken(db, "token2")
+			require.NoError(err)
+			assert.False(ok)
+			ok, err = n.UseToken(db, "")
+			require.NoError(err)
+			assert.False(ok)
+
+			got := StaleDraftNudge{DocumentID: d.ID}
+			require.NoError(got.Get(db))
+			assert.Empty(got.Token)
+		})
+
+		t.Run("Upsert a nudge without a token", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			n := StaleDraftNudge{
+				DocumentID: d.ID,
+				SentAt:     time.Now(),
+			}
+			require.Error(n.Upsert(db))
+		})
+	})
+}
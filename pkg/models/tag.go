@@ -0,0 +1,279 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Tag is a model for a tag used to help users discover documents and projects.
+type Tag struct {
+	gorm.Model
+
+	// Name is the name of the tag. Names are normalized using NormalizeTagName.
+	Name string `gorm:"default:null;index;not null;type:citext;unique"`
+
+	// Documents are the documents that have the tag.
+	Documents []*Document `gorm:"many2many:document_tags;"`
+
+	// Projects are the projects that have the tag.
+	Projects []*Project `gorm:"many2many:project_tags;"`
+
+	// UserSubscribers are the users that subscribed to this tag.
+	UserSubscribers []User `gorm:"many2many:user_tag_subscriptions;"`
+}
+
+// TagUsage is a tag and the number of documents and projects that have it.
+type TagUsage struct {
+	Name  string
+	Count int
+}
+
+const (
+	// MaxTagNameLength is the maximum length of a tag name.
+	MaxTagNameLength = 32
+)
+
+// ErrTagExists is returned when renaming a tag to the name of another tag.
+var ErrTagExists = errors.New("tag already exists")
+
+var tagNameRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// NormalizeTagName returns the normalized form of a tag name (lowercase, with
+// whitespace replaced by hyphens), or an error if the name is invalid. Valid
+// names contain only lowercase letters, numbers, and single hyphens between
+// them, and are at most MaxTagNameLength characters long.
+func NormalizeTagName(name string) (string, error) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), "-"))
+	if name == "" {
+		return "", errors.New("tag name is required")
+	}
+	if len(name) > MaxTagNameLength {
+		return "", fmt.Errorf("tag %q is longer than %d characters",
+			name, MaxTagNameLength)
+	}
+	if !tagNameRegexp.MatchString(name) {
+		return "", fmt.Errorf(
+			"tag %q can only contain lowercase letters, numbers, and hyphens", name)
+	}
+	return name, nil
+}
+
+// NormalizeTagNames normalizes tag names using NormalizeTagName and removes
+// duplicates, preserving order.
+func NormalizeTagNames(names []string) ([]string, error) {
+	res := []string{}
+	seen := map[string]bool{}
+	for _, n := range names {
+		n, err := NormalizeTagName(n)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[n] {
+			seen[n] = true
+			res = append(res, n)
+		}
+	}
+	return res, nil
+}
+
+// FirstOrCreate finds the first tag by name or creates a record if it does not
+// exist in database db. The result is saved back to the receiver.
+func (t *Tag) FirstOrCreate(db *gorm.DB) error {
+	if err := validation.ValidateStruct(t,
+		validation.Field(&t.Name, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	if err := db.
+		Where(Tag{Name: t.Name}).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		FirstOrCreate(&t).
+		Error; err != nil {
+		return err
+	}
+
+	// The tag may have been created concurrently, in which case nothing was
+	// created above.
+	if t.ID == 0 {
+		return db.
+			Where(Tag{Name: t.Name}).
+			First(&t).
+			Error
+	}
+
+	return nil
+}
+
+// Get gets a tag from database db by name, and assigns it back to the
+// receiver. Only user subscribers are preloaded.
+func (t *Tag) Get(db *gorm.DB) error {
+	if err := validation.ValidateStruct(t,
+		validation.Field(&t.Name, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.
+		Where(Tag{Name: t.Name}).
+		Preload("UserSubscribers").
+		First(&t).
+		Error
+}
+
+// GetDocuments gets all documents that have the tag, which must have been
+// found using Get.
+func (t *Tag) GetDocuments(db *gorm.DB) ([]Document, error) {
+	var docs []Document
+	if err := db.
+		Joins("JOIN document_tags ON document_tags.document_id = documents.id").
+		Where("document_tags.tag_id = ?", t.ID).
+		Preload(clause.Associations).
+		Find(&docs).
+		Error; err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// GetProjects gets all projects that have the tag, which must have been found
+// using Get.
+func (t *Tag) GetProjects(db *gorm.DB) ([]Project, error) {
+	var projs []Project
+	if err := db.
+		Joins("JOIN project_tags ON project_tags.project_id = projects.id").
+		Where("project_tags.tag_id = ?", t.ID).
+		Preload(clause.Associations).
+		Find(&projs).
+		Error; err != nil {
+		return nil, err
+	}
+	return projs, nil
+}
+
+// MergeInto moves the tag's documents, projects, and subscribers to tag dst
+// (which is created if it doesn't exist) and deletes the receiver tag. The
+// receiver must have been found using Get.
+func (t *Tag) MergeInto(db *gorm.DB, dst *Tag) error {
+	if err := validation.ValidateStruct(t,
+		validation.Field(&t.ID, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := dst.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error finding or creating destination tag: %w", err)
+		}
+		if dst.ID == t.ID {
+			return errors.New("cannot merge a tag into itself")
+		}
+
+		for _, jt := range []struct {
+			table, column string
+		}{
+			{"document_tags", "document_id"},
+			{"project_tags", "project_id"},
+			{"user_tag_subscriptions", "user_id"},
+		} {
+			if err := tx.Exec(fmt.Sprintf(`
+				INSERT INTO %[1]s (%[2]s, tag_id)
+				SELECT %[2]s, ? FROM %[1]s WHERE tag_id = ?
+				ON CONFLICT DO NOTHING`, jt.table, jt.column),
+				dst.ID, t.ID,
+			).Error; err != nil {
+				return fmt.Errorf("error moving %s: %w", jt.table, err)
+			}
+			if err := tx.Exec(
+				fmt.Sprintf("DELETE FROM %s WHERE tag_id = ?", jt.table), t.ID,
+			).Error; err != nil {
+				return fmt.Errorf("error deleting %s: %w", jt.table, err)
+			}
+		}
+
+		// Hard delete so the tag name can be reused.
+		return tx.
+			Unscoped().
+			Delete(&Tag{}, t.ID).
+			Error
+	})
+}
+
+// Rename renames the tag, which must have been found using Get. It returns
+// ErrTagExists if another tag already has the new name.
+func (t *Tag) Rename(db *gorm.DB, name string) error {
+	if err := validation.ValidateStruct(t,
+		validation.Field(&t.ID, validation.Required),
+	); err != nil {
+		return err
+	}
+	if err := validation.Validate(name, validation.Required); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var n int64
+		if err := tx.
+			Unscoped().
+			Model(&Tag{}).
+			Where("name = ? AND id != ?", name, t.ID).
+			Count(&n).
+			Error; err != nil {
+			return err
+		}
+		if n > 0 {
+			return ErrTagExists
+		}
+
+		if err := tx.
+			Model(&t).
+			Update("name", name).
+			Error; err != nil {
+			return err
+		}
+		t.Name = name
+
+		return nil
+	})
+}
+
+// SearchTags returns up to limit tags whose name starts with prefix, ordered
+// by the number of documents and projects that have them.
+func SearchTags(db *gorm.DB, prefix string, limit int) ([]TagUsage, error) {
+	prefix = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+
+	var res []TagUsage
+	if err := db.
+		Model(&Tag{}).
+		Select(`tags.name AS name,
+			(SELECT COUNT(*) FROM document_tags WHERE tag_id = tags.id) +
+			(SELECT COUNT(*) FROM project_tags WHERE tag_id = tags.id) AS count`).
+		Where("tags.name LIKE ?", prefix+"%").
+		Order("count DESC, tags.name ASC").
+		Limit(limit).
+		Scan(&res).
+		Error; err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// findOrCreateTags finds or creates tags by name.
+func findOrCreateTags(db *gorm.DB, names []string) ([]Tag, error) {
+	tags := []Tag{}
+	for _, n := range names {
+		t := Tag{Name: n}
+		if err := t.FirstOrCreate(db); err != nil {
+			return nil, fmt.Errorf("error finding or creating tag %q: %w", n, err)
+		}
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
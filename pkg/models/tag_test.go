@@ -0,0 +1,211 @@
+package models
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTagName(t *testing.T) {
+	cases := map[string]struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		"already normalized": {
+			name: "terraform",
+			want: "terraform",
+		},
+		"uppercase and whitespace": {
+			name: "  Cloud   Platform ",
+			want: "cloud-platform",
+		},
+		"numbers and hyphens": {
+			name: "k8s-1-28",
+			want: "k8s-1-28",
+		},
+		"empty": {
+			name:    "  ",
+			wantErr: true,
+		},
+		"invalid characters": {
+			name:    "c++",
+			wantErr: true,
+		},
+		"leading hyphen": {
+			name:    "-terraform",
+			wantErr: true,
+		},
+		"repeated hyphens": {
+			name:    "cloud--platform",
+			wantErr: true,
+		},
+		"too long": {
+			name:    strings.Repeat("a", MaxTagNameLength+1),
+			wantErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, err := NormalizeTagName(c.name)
+			if c.wantErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(c.want, got)
+		})
+	}
+}
+
+func TestNormalizeTagNames(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	got, err := NormalizeTagNames([]string{"Vault", "cloud platform", "vault"})
+	require.NoError(err)
+	assert.Equal([]string{"vault", "cloud-platform"}, got)
+
+	_, err = NormalizeTagNames([]string{"vault", "c++"})
+	assert.Error(err)
+}
+
+func TestTagModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	t.Run("Tag documents, projects, and subscribers", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		t.Run("Create a document type", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			dt := DocumentType{
+				Name:     "DT1",
+				LongName: "DocumentType1",
+			}
+			require.NoError(dt.FirstOrCreate(db))
+		})
+
+		t.Run("Create a product", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			p := Product{
+				Name:         "Product1",
+				Abbreviation: "P1",
+			}
+			require.NoError(p.FirstOrCreate(db))
+		})
+
+		var d Document
+		t.Run("Create a document with tags", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			d = Document{
+				GoogleFileID: "fileID1",
+				DocumentType: DocumentType{Name: "DT1"},
+				Product:      Product{Name: "Product1"},
+			}
+			require.NoError(d.Create(db))
+			require.NoError(d.ReplaceTags(db, []string{"vault", "secrets"}))
+
+			got := Document{GoogleFileID: "fileID1"}
+			require.NoError(got.Get(db))
+			var names []string
+			for _, t := range got.Tags {
+				names = append(names, t.Name)
+			}
+			assert.ElementsMatch([]string{"vault", "secrets"}, names)
+		})
+
+		t.Run("Upserting a document keeps its tags", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			up := Document{GoogleFileID: "fileID1"}
+			require.NoError(up.Get(db))
+			up.Title = "Title"
+			up.Tags = nil
+			require.NoError(up.Upsert(db))
+			assert.Len(up.Tags, 2)
+		})
+
+		var p Project
+		t.Run("Create a project with tags", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			p = Project{
+				Creator: User{EmailAddress: "a@a.com"},
+				Title:   "Project1",
+			}
+			require.NoError(p.Create(db))
+			require.NoError(p.ReplaceTags(db, []string{"vault"}))
+		})
+
+		t.Run("Subscribe to tags", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			u := User{EmailAddress: "b@b.com"}
+			require.NoError(u.ReplaceTagSubscriptions(db, []string{"vault"}))
+
+			tag := Tag{Name: "vault"}
+			require.NoError(tag.Get(db))
+			require.Len(tag.UserSubscribers, 1)
+			assert.Equal("b@b.com", tag.UserSubscribers[0].EmailAddress)
+		})
+
+		t.Run("Search tags", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			tags, err := SearchTags(db, "", 10)
+			require.NoError(err)
+			assert.Equal([]TagUsage{
+				{Name: "vault", Count: 2},
+				{Name: "secrets", Count: 1},
+			}, tags)
+
+			tags, err = SearchTags(db, "sec", 10)
+			require.NoError(err)
+			assert.Equal([]TagUsage{{Name: "secrets", Count: 1}}, tags)
+		})
+
+		t.Run("Rename a tag to an existing tag", func(t *testing.T) {
+			_, require := assert.New(t), require.New(t)
+			tag := Tag{Name: "secrets"}
+			require.NoError(tag.Get(db))
+			require.ErrorIs(tag.Rename(db, "vault"), ErrTagExists)
+		})
+
+		t.Run("Rename a tag", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			tag := Tag{Name: "secrets"}
+			require.NoError(tag.Get(db))
+			require.NoError(tag.Rename(db, "secret-management"))
+			assert.Equal("secret-management", tag.Name)
+
+			docs, err := tag.GetDocuments(db)
+			require.NoError(err)
+			require.Len(docs, 1)
+			assert.Equal("fileID1", docs[0].GoogleFileID)
+		})
+
+		t.Run("Merge a tag", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			tag := Tag{Name: "vault"}
+			require.NoError(tag.Get(db))
+			dst := Tag{Name: "secret-management"}
+			require.NoError(tag.MergeInto(db, &dst))
+
+			require.Error(tag.Get(db))
+
+			docs, err := dst.GetDocuments(db)
+			require.NoError(err)
+			assert.Len(docs, 1)
+			projs, err := dst.GetProjects(db)
+			require.NoError(err)
+			assert.Len(projs, 1)
+			require.NoError(dst.Get(db))
+			assert.Len(dst.UserSubscribers, 1)
+		})
+	})
+}
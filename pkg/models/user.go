@@ -20,6 +20,10 @@ type User struct {
 	// user.
 	ProductSubscriptions []Product `gorm:"many2many:user_product_subscriptions;"`
 
+	// TagSubscriptions are the tags that have been subscribed to by the user.
+	// They are managed using ReplaceTagSubscriptions.
+	TagSubscriptions []Tag `gorm:"many2many:user_tag_subscriptions;"`
+
 	// RecentlyViewedDocs are the documents recently viewed by the user.
 	RecentlyViewedDocs []Document `gorm:"many2many:recently_viewed_docs;"`
 
@@ -127,6 +131,30 @@ func (u *User) Upsert(db *gorm.DB) error {
 	})
 }
 
+// ReplaceTagSubscriptions replaces the tag subscriptions of the user with the
+// tags with the provided names, creating tags that don't exist. Names should be
+// normalized using NormalizeTagNames. The user is created if it does not
+// exist.
+func (u *User) ReplaceTagSubscriptions(db *gorm.DB, names []string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := u.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error finding or creating user: %w", err)
+		}
+
+		tags, err := findOrCreateTags(tx, names)
+		if err != nil {
+			return err
+		}
+		u.TagSubscriptions = tags
+
+		return tx.
+			Session(&gorm.Session{SkipHooks: true}).
+			Model(&u).
+			Association("TagSubscriptions").
+			Replace(u.TagSubscriptions)
+	})
+}
+
 // getAssociations gets required associations, creating them where appropriate.
 func (u *User) getAssociations(tx *gorm.DB) error {
 	// Get product subscriptions.
@@ -0,0 +1,58 @@
+import Controller from "@ember/controller";
+import RouterService from "@ember/routing/router-service";
+import { inject as service } from "@ember/service";
+import { task } from "ember-concurrency";
+import AuthenticatedStaleDraftRoute from "hermes/routes/authenticated/stale-draft";
+import ConfigService from "hermes/services/config";
+import FetchService from "hermes/services/fetch";
+import HermesFlashMessagesService from "hermes/services/flash-messages";
+import { ModelFrom } from "hermes/types/route-models";
+
+export default class AuthenticatedStaleDraftController extends Controller {
+  @service("fetch") declare fetchSvc: FetchService;
+  @service("config") declare configSvc: ConfigService;
+  @service declare router: RouterService;
+  @service declare flashMessages: HermesFlashMessagesService;
+
+  declare model: ModelFrom<AuthenticatedStaleDraftRoute>;
+
+  queryParams = ["token"];
+  token: string | null = null;
+
+  /**
+   * The task to confirm the action from a stale draft email. The token from
+   * the email link can only be used once.
+   */
+  confirm = task(async () => {
+    const { docID, action } = this.model;
+
+    try {
+      await this.fetchSvc.fetch(
+        `/api/${this.configSvc.config.api_version}/me/stale-drafts/${docID}/${action}`,
+        {
+          method: "POST",
+          headers: { "Content-Type": "application/json" },
+          body: JSON.stringify({ token: this.token }),
+        },
+      );
+
+      if (action === "delete") {
+        this.router.transitionTo("authenticated.my.documents");
+      } else {
+        this.router.transitionTo("authenticated.document", docID, {
+          queryParams: { draft: true },
+        });
+      }
+    } catch (e) {
+      this.flashMessages.critical((e as any).message, {
+        title: "Error updating draft",
+      });
+    }
+  });
+}
+
+declare module "@ember/controller" {
+  interface Registry {
+    "authenticated.stale-draft": AuthenticatedStaleDraftController;
+  }
+}
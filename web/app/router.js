@@ -20,6 +20,9 @@ Router.map(function () {
     });
     this.route("results");
     this.route("settings");
+    this.route("stale-draft", {
+      path: "/stale-drafts/:document_id/:action",
+    });
     this.route("new", function () {
       this.route("doc");
       this.route("project");
@@ -0,0 +1,36 @@
+import Route from "@ember/routing/route";
+import RouterService from "@ember/routing/router-service";
+import { inject as service } from "@ember/service";
+
+export type StaleDraftAction = "keep" | "publish" | "delete";
+
+const STALE_DRAFT_ACTIONS: string[] = ["keep", "publish", "delete"];
+
+interface StaleDraftRouteParams {
+  document_id: string;
+  action: string;
+}
+
+export interface StaleDraftRouteModel {
+  docID: string;
+  action: StaleDraftAction;
+}
+
+export default class AuthenticatedStaleDraftRoute extends Route {
+  @service declare router: RouterService;
+
+  model(params: StaleDraftRouteParams): StaleDraftRouteModel | void {
+    /**
+     * Links in stale draft emails only have these actions.
+     */
+    if (!STALE_DRAFT_ACTIONS.includes(params.action)) {
+      this.router.transitionTo("404", "stale-drafts");
+      return;
+    }
+
+    return {
+      docID: params.document_id,
+      action: params.action as StaleDraftAction,
+    };
+  }
+}
@@ -959,6 +959,14 @@ export default function (mirageConfig) {
         return new Response(200, {});
       });
 
+      /**
+       * Used by the StaleDraft route to confirm an action from a stale draft
+       * email.
+       */
+      this.post("/me/stale-drafts/:document_id/:action", () => {
+        return new Response(200, {});
+      });
+
       /**
        *  Used by the RecentlyViewedDocsService to log a viewed doc.
        */
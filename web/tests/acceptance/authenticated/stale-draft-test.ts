@@ -0,0 +1,47 @@
+import { click, currentURL, visit } from "@ember/test-helpers";
+import { setupApplicationTest } from "ember-qunit";
+import { module, test } from "qunit";
+import { authenticateSession } from "ember-simple-auth/test-support";
+import { MirageTestContext, setupMirage } from "ember-cli-mirage/test-support";
+import { getPageTitle } from "ember-page-title/test-support";
+import { Response } from "miragejs";
+
+const CONFIRM_BUTTON = "[data-test-stale-draft-confirm]";
+
+interface AuthenticatedStaleDraftRouteTestContext extends MirageTestContext {}
+
+module("Acceptance | authenticated/stale-draft", function (hooks) {
+  setupApplicationTest(hooks);
+  setupMirage(hooks);
+
+  hooks.beforeEach(async function () {
+    await authenticateSession({});
+  });
+
+  test("the page title is correct", async function (this: AuthenticatedStaleDraftRouteTestContext, assert) {
+    await visit("/stale-drafts/1/keep?token=abc");
+    assert.equal(getPageTitle(), "Stale draft | Hermes");
+  });
+
+  test("it doesn't act on the draft until the action is confirmed", async function (this: AuthenticatedStaleDraftRouteTestContext, assert) {
+    let requestBody: string | null = null;
+
+    this.server.post("/me/stale-drafts/:document_id/:action", (_, request) => {
+      requestBody = request.requestBody;
+      return new Response(200, {});
+    });
+
+    await visit("/stale-drafts/1/delete?token=abc");
+
+    assert.equal(requestBody, null, "no request before confirming");
+
+    await click(CONFIRM_BUTTON);
+
+    assert.deepEqual(
+      JSON.parse(requestBody as unknown as string),
+      { token: "abc" },
+      "the token is posted",
+    );
+    assert.equal(currentURL(), "/my/documents");
+  });
+});
@@ -108,9 +108,11 @@ func ConfigHandler(
 			shortLinkBaseURL = strings.TrimSuffix(cfg.BaseURL, "/") + "/l"
 		}
 
-		// Skip Google auth if Okta is not disabled in the config.
+		// Skip Google auth if Okta is not disabled or OIDC is enabled in the
+		// config.
 		skipGoogleAuth := false
-		if cfg.Okta == nil || (cfg.Okta != nil && !cfg.Okta.Disabled) {
+		if cfg.Okta == nil || (cfg.Okta != nil && !cfg.Okta.Disabled) ||
+			(cfg.OIDC != nil && !cfg.OIDC.Disabled) {
 			skipGoogleAuth = true
 		}
 